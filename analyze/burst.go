@@ -0,0 +1,55 @@
+package analyze
+
+import (
+	"sort"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// Episode is a run of consecutive intervals whose burst score met a threshold.
+type Episode struct {
+	Start     time.Time         `json:"start"`     // ts of the first point
+	End       time.Time         `json:"end"`       // exclusive: last point's ts plus one interval
+	Intervals int               `json:"intervals"` // number of points in the episode
+	Peak      types.Probability `json:"peak"`      // highest burst score
+	PeakAt    time.Time         `json:"peak_at"`   // ts of the first point reaching Peak
+	Volume    int               `json:"volume"`    // summed volume across the episode
+}
+
+// BurstEpisodes merges consecutive points with burst_score >= threshold
+// into episodes, in time order. Points are consecutive when their ts differ
+// by exactly one interval; a missing bucket ends the episode. s.Points need
+// not be sorted.
+func BurstEpisodes(s *types.Series, threshold float64) []Episode {
+	step := s.Interval.Duration()
+	if step <= 0 {
+		step = time.Minute
+	}
+	pts := append([]types.Point(nil), s.Points...)
+	sort.SliceStable(pts, func(i, j int) bool { return pts[i].TS.Before(pts[j].TS) })
+
+	var out []Episode
+	var cur *Episode
+	for _, p := range pts {
+		score := p.CoordinationSignals.BurstScore
+		if float64(score) < threshold {
+			cur = nil
+			continue
+		}
+		if cur != nil && !p.TS.Equal(cur.End) {
+			cur = nil
+		}
+		if cur == nil {
+			out = append(out, Episode{Start: p.TS, PeakAt: p.TS, Peak: score})
+			cur = &out[len(out)-1]
+		}
+		cur.End = p.TS.Add(step)
+		cur.Intervals++
+		cur.Volume += p.Volume
+		if score > cur.Peak {
+			cur.Peak, cur.PeakAt = score, p.TS
+		}
+	}
+	return out
+}
@@ -0,0 +1,187 @@
+package analyze
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// TimedTag is a ProvenanceTag with the time its post was observed.
+type TimedTag struct {
+	TS  time.Time
+	Tag types.ProvenanceTag
+}
+
+// ClientBucket counts tags by client family over one interval.
+type ClientBucket struct {
+	TS     time.Time                  `json:"ts"`     // interval start, UTC
+	Total  int                        `json:"total"`  // tags in the interval
+	Counts map[types.ClientFamily]int `json:"counts"` // tags per client family
+}
+
+// Share returns the fraction of the bucket's tags from client c, or 0 for
+// an empty bucket.
+func (b ClientBucket) Share(c types.ClientFamily) float64 {
+	if b.Total == 0 {
+		return 0
+	}
+	return float64(b.Counts[c]) / float64(b.Total)
+}
+
+// MaxClientBuckets bounds the number of buckets ClientTrends returns.
+const MaxClientBuckets = 1 << 20
+
+// ErrTooManyBuckets is returned by ClientTrends when the tags span more
+// than MaxClientBuckets intervals.
+var ErrTooManyBuckets = errors.New("analyze: tags span too many intervals")
+
+// ClientTrends buckets tags into intervals of length step aligned to the
+// Unix epoch in UTC and counts client families in each. The result is in
+// time order and regular: intervals without tags between the first and the
+// last are present with a zero Total. tags need not be sorted.
+//
+// Because the result is regular, its size depends on the time span of
+// tags rather than their number: one bucket per step from the first tag to
+// the last. A span of more than MaxClientBuckets steps returns
+// ErrTooManyBuckets rather than allocating them.
+func ClientTrends(tags []TimedTag, step time.Duration) ([]ClientBucket, error) {
+	if len(tags) == 0 || step <= 0 {
+		return nil, nil
+	}
+	first, last := tags[0].TS.UTC().Truncate(step), tags[0].TS.UTC().Truncate(step)
+	for _, t := range tags[1:] {
+		k := t.TS.UTC().Truncate(step)
+		if k.Before(first) {
+			first = k
+		}
+		if k.After(last) {
+			last = k
+		}
+	}
+	// Compare in whole steps so spans beyond time.Duration's range, where
+	// Sub saturates, are still caught.
+	if last.Sub(first)/step >= MaxClientBuckets || last.Sub(first) == math.MaxInt64 {
+		return nil, ErrTooManyBuckets
+	}
+	byTS := map[time.Time]*ClientBucket{}
+	for _, t := range tags {
+		k := t.TS.UTC().Truncate(step)
+		b := byTS[k]
+		if b == nil {
+			b = &ClientBucket{TS: k, Counts: map[types.ClientFamily]int{}}
+			byTS[k] = b
+		}
+		b.Total++
+		b.Counts[t.Tag.ClientFamily]++
+	}
+	out := make([]ClientBucket, 0, int(last.Sub(first)/step)+1)
+	for k := first; !k.After(last); k = k.Add(step) {
+		if b := byTS[k]; b != nil {
+			out = append(out, *b)
+		} else {
+			out = append(out, ClientBucket{TS: k, Counts: map[types.ClientFamily]int{}})
+		}
+	}
+	return out, nil
+}
+
+// ClientSpike is an interval whose share of one client family jumped above
+// its recent baseline.
+type ClientSpike struct {
+	TS       time.Time `json:"ts"`
+	Share    float64   `json:"share"`    // client share in the interval
+	Baseline float64   `json:"baseline"` // mean share over the trailing window
+	Z        float64   `json:"z"`        // standard scores above the baseline
+	Total    int       `json:"total"`    // tags in the interval
+}
+
+// SpikeOptions tunes ClientSpikes. Zero fields select the defaults.
+type SpikeOptions struct {
+	// Client is the family watched. Default types.ClientThirdParty, whose
+	// sudden rise is a known astroturfing signal.
+	Client types.ClientFamily
+	// Window is the number of trailing qualifying buckets forming the
+	// baseline. Default 30.
+	Window int
+	// MinZ is the standard score a share must reach. Default 3.
+	MinZ float64
+	// MinDelta is the absolute share increase over the baseline a spike
+	// must also reach, so tiny-variance baselines don't flag noise.
+	// Default 0.1.
+	MinDelta float64
+	// MinTotal skips buckets with fewer tags, whose shares are too noisy to
+	// judge or to learn from. Default 20.
+	MinTotal int
+}
+
+func (o SpikeOptions) withDefaults() SpikeOptions {
+	if o.Client == "" {
+		o.Client = types.ClientThirdParty
+	}
+	if o.Window <= 0 {
+		o.Window = 30
+	}
+	if o.MinZ == 0 {
+		o.MinZ = 3
+	}
+	if o.MinDelta == 0 {
+		o.MinDelta = 0.1
+	}
+	if o.MinTotal == 0 {
+		o.MinTotal = 20
+	}
+	return o
+}
+
+// minBaseline is the fewest baseline buckets ClientSpikes judges against.
+const minBaseline = 5
+
+// minStddev keeps the standard score finite for flat baselines.
+const minStddev = 0.01
+
+// ClientSpikes scans buckets (as returned by ClientTrends) in time order
+// and reports those whose opts.Client share exceeds the mean of the
+// trailing window by both opts.MinZ standard deviations and opts.MinDelta.
+// Flagged buckets are kept out of the baseline, so a sustained campaign is
+// reported for as long as it lasts rather than becoming the new normal.
+func ClientSpikes(buckets []ClientBucket, opts SpikeOptions) []ClientSpike {
+	opts = opts.withDefaults()
+	bs := append([]ClientBucket(nil), buckets...)
+	sort.SliceStable(bs, func(i, j int) bool { return bs[i].TS.Before(bs[j].TS) })
+
+	var out []ClientSpike
+	var window []float64
+	for _, b := range bs {
+		if b.Total < opts.MinTotal {
+			continue
+		}
+		share := b.Share(opts.Client)
+		if len(window) >= minBaseline {
+			mean, sd := meanStddev(window)
+			z := (share - mean) / math.Max(sd, minStddev)
+			if z >= opts.MinZ && share-mean >= opts.MinDelta {
+				out = append(out, ClientSpike{TS: b.TS, Share: share, Baseline: mean, Z: z, Total: b.Total})
+				continue
+			}
+		}
+		window = append(window, share)
+		if len(window) > opts.Window {
+			window = window[1:]
+		}
+	}
+	return out
+}
+
+func meanStddev(xs []float64) (mean, sd float64) {
+	for _, x := range xs {
+		mean += x
+	}
+	mean /= float64(len(xs))
+	for _, x := range xs {
+		sd += (x - mean) * (x - mean)
+	}
+	return mean, math.Sqrt(sd / float64(len(xs)))
+}
@@ -0,0 +1,28 @@
+package analyze
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClientTrendsBounded(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		span, step time.Duration
+		err        error
+	}{
+		{(MaxClientBuckets - 1) * time.Second, time.Second, nil},
+		{MaxClientBuckets * time.Second, time.Second, ErrTooManyBuckets},
+		{200 * 365 * 24 * time.Hour, time.Nanosecond, ErrTooManyBuckets},
+	} {
+		tags := []TimedTag{{TS: t0}, {TS: t0.Add(tc.span)}}
+		got, err := ClientTrends(tags, tc.step)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("span %v step %v: err = %v, want %v", tc.span, tc.step, err, tc.err)
+		}
+		if err == nil && len(got) != int(tc.span/tc.step)+1 {
+			t.Errorf("span %v step %v: %d buckets", tc.span, tc.step, len(got))
+		}
+	}
+}
@@ -0,0 +1,9 @@
+// analyze/doc.go
+// Package analyze derives research-oriented structures from Civic
+// Transparency data, such as coordination cluster graphs, burst episodes,
+// and per-client-family trends with spike detection, and computes the
+// synchrony_index signal from raw event times so every collector derives
+// the same number. DistributionShift compares the categorical composition
+// of two windows of tags. NormalizeBurst rescales burst scores robustly
+// within a series so topics of very different activity can be compared.
+package analyze
@@ -0,0 +1,86 @@
+package analyze_test
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/analyze"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func ExampleClusterGraph() {
+	tags := []types.ProvenanceTag{
+		{AcctType: types.AcctTypePerson, AcctAgeBucket: types.AcctAge_0_7d, AutomationFlag: types.AutomationScheduled, DedupHash: "aaaaaaaa"},
+		{AcctType: types.AcctTypePerson, AcctAgeBucket: types.AcctAge_0_7d, AutomationFlag: types.AutomationScheduled, DedupHash: "aaaaaaaa"},
+		{AcctType: types.AcctTypeOrg, AcctAgeBucket: types.AcctAge_24mPlus, AutomationFlag: types.AutomationManual, DedupHash: "aaaaaaaa"},
+		{AcctType: types.AcctTypeOrg, AcctAgeBucket: types.AcctAge_24mPlus, AutomationFlag: types.AutomationManual, DedupHash: "bbbbbbbb"},
+	}
+	_ = analyze.ClusterGraph(tags, 2).WriteDOT(os.Stdout)
+	// Output:
+	// graph coordination {
+	//   "b:org/24m+/manual" [label="org 24m+ manual", kind="account_bucket", count=1, shape=ellipse];
+	//   "b:person/0-7d/scheduled" [label="person 0-7d scheduled", kind="account_bucket", count=2, shape=ellipse];
+	//   "c:aaaaaaaa" [label="aaaaaaaa", kind="content_cluster", count=3, shape=box];
+	//   "b:org/24m+/manual" -- "c:aaaaaaaa" [weight=1];
+	//   "b:person/0-7d/scheduled" -- "c:aaaaaaaa" [weight=2];
+	// }
+}
+
+func ExampleBurstEpisodes() {
+	t0 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	scores := []types.Probability{0.1, 0.8, 0.9, 0.7, 0.2, 0.95}
+	s := &types.Series{Interval: types.IntervalMinute}
+	for i, b := range scores {
+		s.Points = append(s.Points, types.Point{
+			TS:                  t0.Add(time.Duration(i) * time.Minute),
+			Volume:              10 * (i + 1),
+			CoordinationSignals: types.CoordinationSignals{BurstScore: b},
+		})
+	}
+	for _, e := range analyze.BurstEpisodes(s, 0.7) {
+		fmt.Println(e.Start.Format("15:04"), e.End.Format("15:04"), e.Peak, e.PeakAt.Format("15:04"), e.Volume)
+	}
+	// Output:
+	// 12:01 12:04 0.9 12:02 90
+	// 12:05 12:06 0.95 12:05 60
+}
+
+func ExampleClientSpikes() {
+	t0 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	var tags []analyze.TimedTag
+	for m := 0; m < 10; m++ {
+		thirdParty := 4 + m%2 // 10–12% normally
+		if m == 8 {
+			thirdParty = 20
+		}
+		for i := 0; i < 40; i++ {
+			c := types.ClientWeb
+			if i < thirdParty {
+				c = types.ClientThirdParty
+			}
+			tags = append(tags, analyze.TimedTag{
+				TS:  t0.Add(time.Duration(m)*time.Minute + time.Duration(i)*time.Second),
+				Tag: types.ProvenanceTag{ClientFamily: c},
+			})
+		}
+	}
+	trends, err := analyze.ClientTrends(tags, time.Minute)
+	fmt.Println(err, len(trends), trends[8].Share(types.ClientThirdParty))
+	for _, s := range analyze.ClientSpikes(trends, analyze.SpikeOptions{}) {
+		fmt.Printf("%s share=%.2f baseline=%.3f\n", s.TS.Format("15:04"), s.Share, s.Baseline)
+	}
+	// Output:
+	// <nil> 10 0.5
+	// 12:08 share=0.50 baseline=0.113
+}
+
+func ExampleSynchronyIndex() {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var events []time.Time
+	for _, s := range []float64{0, 20, 21, 21.5, 40} {
+		events = append(events, t0.Add(time.Duration(s*float64(time.Second))))
+	}
+	fmt.Println(analyze.SynchronyIndex(events, 2*time.Second, 500*time.Millisecond))
+	// Output: 0.3
+}
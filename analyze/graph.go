@@ -0,0 +1,183 @@
+package analyze
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// NodeKind distinguishes the two sides of a ClusterGraph.
+type NodeKind string
+
+const (
+	// NodeAccountBucket is an (acct_type, acct_age_bucket, automation_flag) cohort.
+	NodeAccountBucket NodeKind = "account_bucket"
+	// NodeContentCluster is a dedup_hash content cluster.
+	NodeContentCluster NodeKind = "content_cluster"
+)
+
+// Node is a vertex of a ClusterGraph.
+type Node struct {
+	ID    string
+	Kind  NodeKind
+	Label string
+	Count int // tags incident to this node
+}
+
+// Edge links an account bucket to a content cluster. Weight is the number
+// of tags from that bucket carrying that dedup_hash.
+type Edge struct {
+	Source, Target string
+	Weight         int
+}
+
+// Graph is a bipartite account-bucket / content-cluster graph. Nodes and
+// Edges are sorted by ID for deterministic output.
+type Graph struct {
+	Nodes []Node
+	Edges []Edge
+}
+
+// ClusterGraph builds the bipartite graph of account cohorts and the content
+// clusters (dedup hashes) they posted. When minClusterSize > 1, clusters
+// reached by fewer distinct account buckets are dropped, which keeps the
+// graph focused on content shared across cohorts.
+func ClusterGraph(tags []types.ProvenanceTag, minClusterSize int) *Graph {
+	nodes := map[string]*Node{}
+	edges := map[[2]string]int{}
+	for i := range tags {
+		t := &tags[i]
+		bID := "b:" + string(t.AcctType) + "/" + string(t.AcctAgeBucket) + "/" + string(t.AutomationFlag)
+		cID := "c:" + string(t.DedupHash)
+		if _, ok := nodes[bID]; !ok {
+			nodes[bID] = &Node{ID: bID, Kind: NodeAccountBucket,
+				Label: string(t.AcctType) + " " + string(t.AcctAgeBucket) + " " + string(t.AutomationFlag)}
+		}
+		if _, ok := nodes[cID]; !ok {
+			nodes[cID] = &Node{ID: cID, Kind: NodeContentCluster, Label: string(t.DedupHash)}
+		}
+		nodes[bID].Count++
+		nodes[cID].Count++
+		edges[[2]string{bID, cID}]++
+	}
+
+	if minClusterSize > 1 {
+		degree := map[string]int{}
+		for k := range edges {
+			degree[k[1]]++
+		}
+		for k, w := range edges {
+			if degree[k[1]] < minClusterSize {
+				delete(edges, k)
+				nodes[k[0]].Count -= w
+				delete(nodes, k[1])
+			}
+		}
+		for id, n := range nodes {
+			if n.Kind == NodeAccountBucket && n.Count == 0 {
+				delete(nodes, id)
+			}
+		}
+	}
+
+	g := &Graph{}
+	for _, n := range nodes {
+		g.Nodes = append(g.Nodes, *n)
+	}
+	for k, w := range edges {
+		g.Edges = append(g.Edges, Edge{Source: k[0], Target: k[1], Weight: w})
+	}
+	sort.Slice(g.Nodes, func(i, j int) bool { return g.Nodes[i].ID < g.Nodes[j].ID })
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].Source != g.Edges[j].Source {
+			return g.Edges[i].Source < g.Edges[j].Source
+		}
+		return g.Edges[i].Target < g.Edges[j].Target
+	})
+	return g
+}
+
+// WriteDOT writes g as an undirected Graphviz DOT graph.
+func (g *Graph) WriteDOT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("graph coordination {\n")
+	for _, n := range g.Nodes {
+		shape := "ellipse"
+		if n.Kind == NodeContentCluster {
+			shape = "box"
+		}
+		fmt.Fprintf(&b, "  %q [label=%q, kind=%q, count=%d, shape=%s];\n", n.ID, n.Label, n.Kind, n.Count, shape)
+	}
+	for _, e := range g.Edges {
+		fmt.Fprintf(&b, "  %q -- %q [weight=%d];\n", e.Source, e.Target, e.Weight)
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteGraphML writes g as GraphML, loadable by Gephi and similar tools.
+func (g *Graph) WriteGraphML(w io.Writer) error {
+	type data struct {
+		Key   string `xml:"key,attr"`
+		Value string `xml:",chardata"`
+	}
+	type node struct {
+		ID   string `xml:"id,attr"`
+		Data []data `xml:"data"`
+	}
+	type edge struct {
+		Source string `xml:"source,attr"`
+		Target string `xml:"target,attr"`
+		Data   []data `xml:"data"`
+	}
+	type key struct {
+		ID   string `xml:"id,attr"`
+		For  string `xml:"for,attr"`
+		Name string `xml:"attr.name,attr"`
+		Type string `xml:"attr.type,attr"`
+	}
+	type graph struct {
+		EdgeDefault string `xml:"edgedefault,attr"`
+		Nodes       []node `xml:"node"`
+		Edges       []edge `xml:"edge"`
+	}
+	doc := struct {
+		XMLName xml.Name `xml:"graphml"`
+		NS      string   `xml:"xmlns,attr"`
+		Keys    []key    `xml:"key"`
+		Graph   graph    `xml:"graph"`
+	}{
+		NS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []key{
+			{"label", "node", "label", "string"},
+			{"kind", "node", "kind", "string"},
+			{"count", "node", "count", "int"},
+			{"weight", "edge", "weight", "int"},
+		},
+		Graph: graph{EdgeDefault: "undirected"},
+	}
+	for _, n := range g.Nodes {
+		doc.Graph.Nodes = append(doc.Graph.Nodes, node{ID: n.ID, Data: []data{
+			{"label", n.Label}, {"kind", string(n.Kind)}, {"count", fmt.Sprint(n.Count)},
+		}})
+	}
+	for _, e := range g.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, edge{Source: e.Source, Target: e.Target,
+			Data: []data{{"weight", fmt.Sprint(e.Weight)}}})
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
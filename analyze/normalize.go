@@ -0,0 +1,125 @@
+package analyze
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// BurstNorm selects how NormalizeBurst rescales burst scores.
+type BurstNorm string
+
+const (
+	// BurstMAD maps each score x to Φ((x − median) / (1.4826·MAD)), the
+	// standard normal CDF of its robust z-score: the series' typical score
+	// becomes 0.5 and a score k robust standard deviations above it becomes
+	// Φ(k). Unlike a mean and standard deviation, the median and median
+	// absolute deviation barely move when one viral event adds a few
+	// extreme scores. When more than half the scores are equal (MAD 0),
+	// scores above the median map to 1 and below it to 0.
+	BurstMAD BurstNorm = "mad"
+	// BurstRank maps each score to its mid-rank percentile, (rank − 0.5)/n
+	// with tied scores sharing their average rank, so the result is
+	// uniform on (0, 1) whatever the original distribution.
+	BurstRank BurstNorm = "rank"
+)
+
+// NormalizeBurst returns a copy of s whose burst scores are rescaled
+// within the series by method, so series of different topics can be
+// compared: a score of 0.9 means "unusually bursty for this topic" in
+// every normalized series, where raw scores of a busy topic would dwarf a
+// quiet one's. s is not modified; the copy has its own Points slice (the
+// breakdown maps are shared with s) and a methodology caveat recording
+// the normalization.
+func NormalizeBurst(s *types.Series, method BurstNorm) (*types.Series, error) {
+	xs := make([]float64, len(s.Points))
+	for i, p := range s.Points {
+		xs[i] = float64(p.CoordinationSignals.BurstScore)
+	}
+	var norm []float64
+	switch method {
+	case BurstMAD:
+		norm = robustZ(xs)
+	case BurstRank:
+		norm = midRanks(xs)
+	default:
+		return nil, fmt.Errorf("analyze: unknown burst normalization %q", method)
+	}
+
+	out := *s
+	out.Points = append([]types.Point(nil), s.Points...)
+	for i := range out.Points {
+		out.Points[i].CoordinationSignals.BurstScore = types.Probability(norm[i])
+	}
+	m := types.Methodology{}
+	if s.Methodology != nil {
+		m = *s.Methodology
+	}
+	m.Caveats = append(append([]string(nil), m.Caveats...),
+		fmt.Sprintf("burst_score normalized within the series (%s); not comparable to raw scores", method))
+	out.Methodology = &m
+	return &out, nil
+}
+
+// robustZ returns Φ of each value's robust z-score.
+func robustZ(xs []float64) []float64 {
+	med := median(xs)
+	dev := make([]float64, len(xs))
+	for i, x := range xs {
+		dev[i] = math.Abs(x - med)
+	}
+	scale := 1.4826 * median(dev) // consistent with σ for normal data
+	out := make([]float64, len(xs))
+	for i, x := range xs {
+		switch {
+		case scale > 0:
+			out[i] = 0.5 * math.Erfc(-(x-med)/scale/math.Sqrt2)
+		case x > med:
+			out[i] = 1
+		case x < med:
+			out[i] = 0
+		default:
+			out[i] = 0.5
+		}
+	}
+	return out
+}
+
+func median(xs []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	s := append([]float64(nil), xs...)
+	sort.Float64s(s)
+	n := len(s)
+	if n%2 == 1 {
+		return s[n/2]
+	}
+	return (s[n/2-1] + s[n/2]) / 2
+}
+
+// midRanks returns the mid-rank percentile of each value.
+func midRanks(xs []float64) []float64 {
+	idx := make([]int, len(xs))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.SliceStable(idx, func(a, b int) bool { return xs[idx[a]] < xs[idx[b]] })
+	out := make([]float64, len(xs))
+	n := float64(len(xs))
+	for lo := 0; lo < len(idx); {
+		hi := lo
+		for hi < len(idx) && xs[idx[hi]] == xs[idx[lo]] {
+			hi++
+		}
+		// Ranks lo+1..hi share their average, (lo+1+hi)/2.
+		p := (float64(lo+1+hi)/2 - 0.5) / n
+		for _, i := range idx[lo:hi] {
+			out[i] = p
+		}
+		lo = hi
+	}
+	return out
+}
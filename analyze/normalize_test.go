@@ -0,0 +1,79 @@
+package analyze
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func burstSeries(scores ...float64) *types.Series {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &types.Series{Topic: "#t", Interval: types.IntervalMinute}
+	for i, x := range scores {
+		s.Points = append(s.Points, types.Point{TS: t0.Add(time.Duration(i) * time.Minute),
+			CoordinationSignals: types.CoordinationSignals{BurstScore: types.Probability(x)}})
+	}
+	return s
+}
+
+func bursts(s *types.Series) []float64 {
+	out := make([]float64, len(s.Points))
+	for i, p := range s.Points {
+		out[i] = float64(p.CoordinationSignals.BurstScore)
+	}
+	return out
+}
+
+func TestNormalizeBurstMAD(t *testing.T) {
+	quiet := burstSeries(0.1, 0.2, 0.3, 0.2, 0.1)
+	viral := burstSeries(0.1, 0.2, 0.3, 0.2, 1)
+	q, err := NormalizeBurst(quiet, BurstMAD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, _ := NormalizeBurst(viral, BurstMAD)
+	// One extreme score leaves the others' normalized values untouched.
+	for i := 0; i < 4; i++ {
+		if math.Abs(bursts(q)[i]-bursts(v)[i]) > 1e-12 {
+			t.Errorf("point %d: quiet %g, viral %g", i, bursts(q)[i], bursts(v)[i])
+		}
+	}
+	if got := bursts(q)[1]; got != 0.5 {
+		t.Errorf("median maps to %g, want 0.5", got)
+	}
+	if got := bursts(v)[4]; got < 0.999 {
+		t.Errorf("viral point = %g, want ≈1", got)
+	}
+	if bursts(viral)[4] != 1 || quiet.Methodology != nil {
+		t.Error("input modified")
+	}
+	if q.Methodology == nil || len(q.Methodology.Caveats) != 1 {
+		t.Errorf("methodology = %+v", q.Methodology)
+	}
+
+	flat, _ := NormalizeBurst(burstSeries(0.2, 0.2, 0.2, 0.9, 0.1), BurstMAD)
+	want := []float64{0.5, 0.5, 0.5, 1, 0}
+	for i, got := range bursts(flat) {
+		if got != want[i] {
+			t.Errorf("MAD 0: point %d = %g, want %g", i, got, want[i])
+		}
+	}
+}
+
+func TestNormalizeBurstRank(t *testing.T) {
+	r, err := NormalizeBurst(burstSeries(0.4, 0.1, 0.4, 0.9), BurstRank)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []float64{0.5, 0.125, 0.5, 0.875}
+	for i, got := range bursts(r) {
+		if got != want[i] {
+			t.Errorf("point %d = %g, want %g", i, got, want[i])
+		}
+	}
+	if _, err := NormalizeBurst(burstSeries(0.1), "zscore"); err == nil {
+		t.Error("unknown method accepted")
+	}
+}
@@ -0,0 +1,207 @@
+package analyze
+
+import (
+	"math"
+	"sort"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// TagBreakdown counts the categorical fields of a set of tags: for each
+// JSON field name, the number of tags with each value. Unset values are
+// not counted, so a field's counts may sum to less than Total.
+type TagBreakdown struct {
+	Total  int                       `json:"total"`
+	Counts map[string]map[string]int `json:"counts"` // field → value → tags
+}
+
+// BreakdownOf counts acct_age_bucket, acct_type, automation_flag,
+// post_kind, client_family, and media_provenance over tags.
+func BreakdownOf(tags []types.ProvenanceTag) TagBreakdown {
+	b := TagBreakdown{Counts: map[string]map[string]int{}}
+	for _, t := range tags {
+		b.Add(t)
+	}
+	return b
+}
+
+// Add counts one more tag.
+func (b *TagBreakdown) Add(t types.ProvenanceTag) {
+	if b.Counts == nil {
+		b.Counts = map[string]map[string]int{}
+	}
+	b.Total++
+	for _, f := range [...]struct{ name, value string }{
+		{"acct_age_bucket", string(t.AcctAgeBucket)},
+		{"acct_type", string(t.AcctType)},
+		{"automation_flag", string(t.AutomationFlag)},
+		{"post_kind", string(t.PostKind)},
+		{"client_family", string(t.ClientFamily)},
+		{"media_provenance", string(t.MediaProvenance)},
+	} {
+		if f.value == "" {
+			continue
+		}
+		m := b.Counts[f.name]
+		if m == nil {
+			m = map[string]int{}
+			b.Counts[f.name] = m
+		}
+		m[f.value]++
+	}
+}
+
+// ShiftOptions tunes DistributionShiftWith. Zero fields select the
+// defaults.
+type ShiftOptions struct {
+	// MinJSD is the Jensen-Shannon divergence (base 2, 0–1) at which a
+	// field's distribution counts as changed. Default 0.05.
+	MinJSD float64
+	// MinDelta is the absolute share change that flags a category within
+	// a changed field. Default 0.1.
+	MinDelta float64
+	// MinSamples is the fewest counted tags each window needs in a field
+	// for the field to be judged; smaller samples are reported but never
+	// flagged. Default 30.
+	MinSamples int
+}
+
+func (o ShiftOptions) withDefaults() ShiftOptions {
+	if o.MinJSD == 0 {
+		o.MinJSD = 0.05
+	}
+	if o.MinDelta == 0 {
+		o.MinDelta = 0.1
+	}
+	if o.MinSamples == 0 {
+		o.MinSamples = 30
+	}
+	return o
+}
+
+// CategoryDelta is the change in one value's share of a field.
+type CategoryDelta struct {
+	Value   string  `json:"value"`
+	Before  float64 `json:"before"` // share in the earlier window
+	After   float64 `json:"after"`  // share in the later window
+	Delta   float64 `json:"delta"`  // After - Before
+	Flagged bool    `json:"flagged"`
+}
+
+// FieldShift compares one field's distribution between two windows.
+type FieldShift struct {
+	Field       string          `json:"field"`
+	JSD         float64         `json:"jsd"`
+	BeforeCount int             `json:"before_count"` // counted tags in the earlier window
+	AfterCount  int             `json:"after_count"`  // counted tags in the later window
+	Deltas      []CategoryDelta `json:"deltas"`       // by |Delta| descending, then Value
+	Significant bool            `json:"significant"`
+}
+
+// ShiftReport is the result of DistributionShift.
+type ShiftReport struct {
+	Fields      []FieldShift `json:"fields"`      // sorted by Field
+	Significant bool         `json:"significant"` // any field significant
+}
+
+// Flagged returns the flagged categories of significant fields, for
+// alerts such as a sudden surge of one account type.
+func (r ShiftReport) Flagged() []CategoryDelta {
+	var out []CategoryDelta
+	for _, f := range r.Fields {
+		for _, d := range f.Deltas {
+			if d.Flagged {
+				out = append(out, d)
+			}
+		}
+	}
+	return out
+}
+
+// DistributionShift is DistributionShiftWith using the default options.
+func DistributionShift(before, after TagBreakdown) ShiftReport {
+	return DistributionShiftWith(before, after, ShiftOptions{})
+}
+
+// DistributionShiftWith compares the composition of two windows field by
+// field. Each field present in either window gets its Jensen-Shannon
+// divergence and per-value share deltas. A field is significant when both
+// windows have opts.MinSamples counted tags and its divergence reaches
+// opts.MinJSD; its values whose share moved by opts.MinDelta are flagged.
+func DistributionShiftWith(before, after TagBreakdown, opts ShiftOptions) ShiftReport {
+	opts = opts.withDefaults()
+	names := map[string]bool{}
+	for f := range before.Counts {
+		names[f] = true
+	}
+	for f := range after.Counts {
+		names[f] = true
+	}
+	var r ShiftReport
+	for f := range names {
+		fs := fieldShift(f, before.Counts[f], after.Counts[f], opts)
+		r.Significant = r.Significant || fs.Significant
+		r.Fields = append(r.Fields, fs)
+	}
+	sort.Slice(r.Fields, func(i, j int) bool { return r.Fields[i].Field < r.Fields[j].Field })
+	return r
+}
+
+func fieldShift(field string, before, after map[string]int, opts ShiftOptions) FieldShift {
+	fs := FieldShift{Field: field, BeforeCount: sum(before), AfterCount: sum(after)}
+	values := map[string]bool{}
+	for v := range before {
+		values[v] = true
+	}
+	for v := range after {
+		values[v] = true
+	}
+	for v := range values {
+		d := CategoryDelta{Value: v, Before: share(before[v], fs.BeforeCount), After: share(after[v], fs.AfterCount)}
+		d.Delta = d.After - d.Before
+		fs.Deltas = append(fs.Deltas, d)
+	}
+	sort.Slice(fs.Deltas, func(i, j int) bool {
+		a, b := math.Abs(fs.Deltas[i].Delta), math.Abs(fs.Deltas[j].Delta)
+		if a != b {
+			return a > b
+		}
+		return fs.Deltas[i].Value < fs.Deltas[j].Value
+	})
+	if fs.BeforeCount > 0 && fs.AfterCount > 0 {
+		for _, d := range fs.Deltas {
+			m := (d.Before + d.After) / 2
+			fs.JSD += (kl(d.Before, m) + kl(d.After, m)) / 2
+		}
+	}
+	fs.Significant = fs.BeforeCount >= opts.MinSamples && fs.AfterCount >= opts.MinSamples && fs.JSD >= opts.MinJSD
+	if fs.Significant {
+		for i := range fs.Deltas {
+			fs.Deltas[i].Flagged = math.Abs(fs.Deltas[i].Delta) >= opts.MinDelta
+		}
+	}
+	return fs
+}
+
+// kl is one term of the Kullback-Leibler divergence, in bits.
+func kl(p, q float64) float64 {
+	if p == 0 {
+		return 0
+	}
+	return p * math.Log2(p/q)
+}
+
+func sum(m map[string]int) int {
+	n := 0
+	for _, c := range m {
+		n += c
+	}
+	return n
+}
+
+func share(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(n) / float64(total)
+}
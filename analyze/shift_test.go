@@ -0,0 +1,49 @@
+package analyze
+
+import (
+	"math"
+	"testing"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func tagsOf(n int, acct types.AcctType) []types.ProvenanceTag {
+	out := make([]types.ProvenanceTag, n)
+	for i := range out {
+		out[i] = types.ProvenanceTag{AcctType: acct, PostKind: types.PostKindOriginal}
+	}
+	return out
+}
+
+func TestDistributionShiftFlagsSurge(t *testing.T) {
+	before := BreakdownOf(append(tagsOf(90, types.AcctTypePerson), tagsOf(10, types.AcctTypeUnverified)...))
+	after := BreakdownOf(append(tagsOf(50, types.AcctTypePerson), tagsOf(50, types.AcctTypeUnverified)...))
+	r := DistributionShift(before, after)
+	if !r.Significant || len(r.Fields) != 2 || r.Fields[0].Field != "acct_type" || r.Fields[1].Field != "post_kind" {
+		t.Fatalf("report = %+v", r)
+	}
+	acct := r.Fields[0]
+	// JSD of (0.9, 0.1) vs (0.5, 0.5), base 2.
+	m := []float64{0.7, 0.3}
+	want := (0.9*math.Log2(0.9/m[0])+0.1*math.Log2(0.1/m[1]))/2 + (0.5*math.Log2(0.5/m[0])+0.5*math.Log2(0.5/m[1]))/2
+	if math.Abs(acct.JSD-want) > 1e-12 {
+		t.Errorf("JSD = %v, want %v", acct.JSD, want)
+	}
+	flagged := r.Flagged()
+	if len(flagged) != 2 || flagged[0].Value != "person" || flagged[1].Value != "unverified" || math.Abs(flagged[1].Delta-0.4) > 1e-12 {
+		t.Errorf("flagged = %+v", flagged)
+	}
+	if r.Fields[1].Significant || r.Fields[1].JSD != 0 {
+		t.Errorf("post_kind = %+v", r.Fields[1])
+	}
+}
+
+func TestDistributionShiftSmallSamples(t *testing.T) {
+	r := DistributionShift(BreakdownOf(tagsOf(5, types.AcctTypePerson)), BreakdownOf(tagsOf(5, types.AcctTypeUnverified)))
+	if r.Significant || r.Fields[0].JSD != 1 {
+		t.Errorf("report = %+v", r)
+	}
+	if got := DistributionShift(TagBreakdown{}, TagBreakdown{}); got.Significant || len(got.Fields) != 0 {
+		t.Errorf("empty = %+v", got)
+	}
+}
@@ -0,0 +1,113 @@
+package analyze
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// SynchronyIndex computes the synchrony_index coordination signal for the
+// events of one interval: the share of event pairs that occur within
+// window of each other.
+//
+// Each event time is first floored to a multiple of resolution since the
+// Unix epoch (resolution ≤ 0 keeps nanoseconds), so implementations agree
+// regardless of clock precision. With q the quantized times of N events,
+//
+//	synchrony_index = |{(i, j) : i < j, |q_i − q_j| < window}| / (N(N−1)/2)
+//
+// and 0 when N < 2. It is 1 when every event falls within window of every
+// other (a coordinated burst) and approaches 2·window/T for N events spread
+// uniformly over a span T much longer than window. events need not be
+// sorted and are not modified.
+func SynchronyIndex(events []time.Time, window, resolution time.Duration) types.Probability {
+	q := make([]int64, len(events))
+	for i, t := range events {
+		q[i] = quantize(t, resolution)
+	}
+	sort.Slice(q, func(i, j int) bool { return q[i] < q[j] })
+	var sc SynchronyCounter
+	sc.Window, sc.Resolution = window, resolution
+	for _, v := range q {
+		sc.add(v)
+	}
+	return sc.Index()
+}
+
+// ErrOutOfOrder is returned by SynchronyCounter.Add for an event earlier
+// than the previous one after quantization.
+var ErrOutOfOrder = errors.New("analyze: event out of order")
+
+// SynchronyCounter computes SynchronyIndex over a stream of events in time
+// order, holding only the events of the trailing window. Set Window and
+// Resolution before the first Add; Reset starts the next interval.
+type SynchronyCounter struct {
+	Window     time.Duration
+	Resolution time.Duration
+
+	recent []int64 // quantized times within Window of the latest event
+	n      int64   // events added
+	pairs  int64   // pairs within Window
+}
+
+// Add records an event. Events must arrive in nondecreasing order after
+// quantization; an earlier event is rejected with ErrOutOfOrder and not
+// counted.
+func (c *SynchronyCounter) Add(t time.Time) error {
+	q := quantize(t, c.Resolution)
+	if len(c.recent) > 0 && q < c.recent[len(c.recent)-1] {
+		return ErrOutOfOrder
+	}
+	c.add(q)
+	return nil
+}
+
+func (c *SynchronyCounter) add(q int64) {
+	w := int64(c.Window)
+	drop := 0
+	for drop < len(c.recent) && q-c.recent[drop] >= w {
+		drop++
+	}
+	// Reuse the backing array once the dropped prefix dominates.
+	if drop > 0 && drop >= len(c.recent)/2 {
+		c.recent = append(c.recent[:0], c.recent[drop:]...)
+	} else {
+		c.recent = c.recent[drop:]
+	}
+	c.pairs += int64(len(c.recent))
+	c.recent = append(c.recent, q)
+	c.n++
+}
+
+// Len reports how many events have been added.
+func (c *SynchronyCounter) Len() int { return int(c.n) }
+
+// Index returns the synchrony index of the events added so far.
+func (c *SynchronyCounter) Index() types.Probability {
+	if c.n < 2 {
+		return 0
+	}
+	return types.Probability(float64(c.pairs) / float64(c.n*(c.n-1)/2))
+}
+
+// Reset clears the counter, keeping Window and Resolution.
+func (c *SynchronyCounter) Reset() {
+	c.recent, c.n, c.pairs = c.recent[:0], 0, 0
+}
+
+// quantize returns t in nanoseconds since the Unix epoch, floored to a
+// multiple of res.
+func quantize(t time.Time, res time.Duration) int64 {
+	ns := t.UnixNano()
+	if res <= 0 {
+		return ns
+	}
+	r := int64(res)
+	m := ns % r
+	if m < 0 {
+		m += r
+	}
+	return ns - m
+}
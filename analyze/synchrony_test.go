@@ -0,0 +1,94 @@
+package analyze
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+type synchronyVector struct {
+	Name       string            `json:"name"`
+	Events     []time.Time       `json:"events"`
+	Window     types.ISODuration `json:"window"`
+	Resolution types.ISODuration `json:"resolution"`
+	Want       float64           `json:"want"`
+}
+
+func TestSynchronyIndexVectors(t *testing.T) {
+	b, err := os.ReadFile("testdata/synchrony_vectors.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var vs []synchronyVector
+	if err := json.Unmarshal(b, &vs); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range vs {
+		window, res := time.Duration(v.Window), time.Duration(v.Resolution)
+		if got := SynchronyIndex(v.Events, window, res); math.Abs(float64(got)-v.Want) > 1e-12 {
+			t.Errorf("%s: SynchronyIndex = %g, want %g", v.Name, got, v.Want)
+		}
+
+		sorted := append([]time.Time(nil), v.Events...)
+		sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+		c := SynchronyCounter{Window: window, Resolution: res}
+		for _, e := range sorted {
+			if err := c.Add(e); err != nil {
+				t.Fatalf("%s: Add: %v", v.Name, err)
+			}
+		}
+		if got := c.Index(); math.Abs(float64(got)-v.Want) > 1e-12 {
+			t.Errorf("%s: SynchronyCounter.Index = %g, want %g", v.Name, got, v.Want)
+		}
+	}
+}
+
+func TestSynchronyCounterOrderAndReset(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := SynchronyCounter{Window: time.Second, Resolution: time.Second}
+	c.Add(t0.Add(1500 * time.Millisecond))
+	if err := c.Add(t0.Add(1200 * time.Millisecond)); err != nil {
+		t.Errorf("same quantized second rejected: %v", err)
+	}
+	if err := c.Add(t0); !errors.Is(err, ErrOutOfOrder) {
+		t.Errorf("Add(earlier) = %v, want ErrOutOfOrder", err)
+	}
+	if c.Len() != 2 || c.Index() != 1 {
+		t.Errorf("Len, Index = %d, %g", c.Len(), c.Index())
+	}
+	c.Reset()
+	if c.Len() != 0 || c.Index() != 0 || c.Add(t0) != nil {
+		t.Error("Reset did not clear the counter")
+	}
+}
+
+func TestSynchronyIndexMatchesBruteForce(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var events []time.Time
+	for i := 0; i < 300; i++ {
+		events = append(events, t0.Add(time.Duration(i*i%7919)*time.Millisecond*7))
+	}
+	window, res := 3*time.Second, 100*time.Millisecond
+	var pairs int
+	for i := range events {
+		for j := i + 1; j < len(events); j++ {
+			d := quantize(events[i], res) - quantize(events[j], res)
+			if d < 0 {
+				d = -d
+			}
+			if d < int64(window) {
+				pairs++
+			}
+		}
+	}
+	want := float64(pairs) / float64(len(events)*(len(events)-1)/2)
+	if got := SynchronyIndex(events, window, res); math.Abs(float64(got)-want) > 1e-12 {
+		t.Errorf("SynchronyIndex = %g, brute force %g", got, want)
+	}
+}
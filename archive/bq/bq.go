@@ -0,0 +1,296 @@
+package bq
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// Field is one column of a BigQuery schema, in the JSON form accepted by
+// bq load --schema and the tables API.
+type Field struct {
+	Name   string  `json:"name"`
+	Type   string  `json:"type"` // STRING, INTEGER, FLOAT, BOOLEAN, TIMESTAMP, DATETIME, JSON, or RECORD
+	Mode   string  `json:"mode"` // REQUIRED, NULLABLE, or REPEATED
+	Fields []Field `json:"fields,omitempty"`
+}
+
+// Options tunes schemas and rows; both must be produced with the same
+// Options.
+type Options struct {
+	// DateTime writes times as DATETIME columns holding the UTC wall
+	// clock instead of TIMESTAMP, for partners whose tooling mishandles
+	// zoned timestamps.
+	DateTime bool
+}
+
+// Civil formats. BigQuery accepts both with a space or a "T" between date
+// and time; the space form is what it prints.
+const (
+	timestampLayout = "2006-01-02 15:04:05.000000 UTC"
+	dateTimeLayout  = "2006-01-02 15:04:05.000000"
+	dateLayout      = "2006-01-02"
+)
+
+// Timestamp formats t as a BigQuery TIMESTAMP literal in UTC, truncated to
+// the microsecond.
+func Timestamp(t time.Time) string {
+	return t.UTC().Truncate(time.Microsecond).Format(timestampLayout)
+}
+
+// DateTime formats t as a BigQuery DATETIME literal holding its UTC wall
+// clock, truncated to the microsecond.
+func DateTime(t time.Time) string {
+	return t.UTC().Truncate(time.Microsecond).Format(dateTimeLayout)
+}
+
+// Date formats t's UTC calendar date as a BigQuery DATE literal, e.g. for
+// a partition filter.
+func Date(t time.Time) string { return t.UTC().Format(dateLayout) }
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	durationType   = reflect.TypeOf(types.ISODuration(0))
+	extensionsType = reflect.TypeOf(types.Extensions(nil))
+	rawType        = reflect.TypeOf(json.RawMessage(nil))
+)
+
+// SchemaFor returns the schema of a table whose rows are values of v's
+// type, a struct or pointer to struct. It fails for field types with no
+// BigQuery equivalent.
+func SchemaFor(v any, opts Options) ([]Field, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("bq: %T is not a struct", v)
+	}
+	return structFields("", t, opts)
+}
+
+// TagSchema returns the schema for a table of ProvenanceTag rows.
+func TagSchema(opts Options) []Field { return mustSchema(types.ProvenanceTag{}, opts) }
+
+// SeriesSchema returns the schema for a table with one row per Series and
+// its points in a REPEATED RECORD column.
+func SeriesSchema(opts Options) []Field { return mustSchema(types.Series{}, opts) }
+
+func mustSchema(v any, opts Options) []Field {
+	fs, err := SchemaFor(v, opts)
+	if err != nil {
+		panic(err) // the package types are covered by tests
+	}
+	return fs
+}
+
+// column is a struct field as it appears in JSON.
+type column struct {
+	name      string
+	index     int
+	omitempty bool
+}
+
+func columns(t reflect.Type) []column {
+	var cs []column
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, rest, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = f.Name
+		}
+		cs = append(cs, column{name: name, index: i, omitempty: strings.Contains(","+rest+",", ",omitempty,")})
+	}
+	return cs
+}
+
+// structFields returns the columns of struct type t; path is the dotted
+// column path of t itself, for errors.
+func structFields(path string, t reflect.Type, opts Options) ([]Field, error) {
+	var out []Field
+	for _, c := range columns(t) {
+		mode := "REQUIRED"
+		if c.omitempty {
+			mode = "NULLABLE"
+		}
+		f, err := field(strings.TrimPrefix(path+"."+c.name, "."), c.name, t.Field(c.index).Type, mode, opts)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, f)
+	}
+	return out, nil
+}
+
+func field(path, name string, t reflect.Type, mode string, opts Options) (Field, error) {
+	f := Field{Name: name, Mode: mode}
+	switch {
+	case t == timeType:
+		f.Type = "TIMESTAMP"
+		if opts.DateTime {
+			f.Type = "DATETIME"
+		}
+		return f, nil
+	case t == durationType:
+		f.Type = "STRING"
+		return f, nil
+	case t == extensionsType || t == rawType:
+		f.Type, f.Mode = "JSON", "NULLABLE"
+		return f, nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		f.Type = "STRING"
+	case reflect.Bool:
+		f.Type = "BOOLEAN"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		f.Type = "INTEGER"
+	case reflect.Float32, reflect.Float64:
+		f.Type = "FLOAT"
+	case reflect.Pointer:
+		return field(path, name, t.Elem(), "NULLABLE", opts)
+	case reflect.Slice:
+		if k := t.Elem().Kind(); k == reflect.Slice || k == reflect.Map {
+			return Field{}, fmt.Errorf("bq: %s: nested repeated %s has no BigQuery type", path, t)
+		}
+		elem, err := field(path, name, t.Elem(), "REPEATED", opts)
+		elem.Mode = "REPEATED"
+		return elem, err
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return Field{}, fmt.Errorf("bq: %s: map key %s is not a string", path, t.Key())
+		}
+		value, err := field(path+".value", "value", t.Elem(), "NULLABLE", opts)
+		if err != nil {
+			return Field{}, err
+		}
+		f.Type, f.Mode = "RECORD", "REPEATED"
+		f.Fields = []Field{{Name: "key", Type: "STRING", Mode: "REQUIRED"}, value}
+	case reflect.Struct:
+		sub, err := structFields(path, t, opts)
+		if err != nil {
+			return Field{}, err
+		}
+		f.Type, f.Fields = "RECORD", sub
+	default:
+		return Field{}, fmt.Errorf("bq: %s: %s has no BigQuery type", path, t)
+	}
+	return f, nil
+}
+
+// Row converts v, a struct or pointer to struct, to a row matching
+// SchemaFor(v, opts): times in civil form, maps as sorted key/value
+// records, and omitted fields left out. Its JSON encoding is one line of
+// a newline-delimited JSON load file.
+func Row(v any, opts Options) (map[string]any, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("bq: %T is not a struct", v)
+	}
+	if _, err := structFields("", rv.Type(), opts); err != nil {
+		return nil, err
+	}
+	return structRow(rv, opts), nil
+}
+
+func structRow(v reflect.Value, opts Options) map[string]any {
+	row := map[string]any{}
+	for _, c := range columns(v.Type()) {
+		fv := v.Field(c.index)
+		if (c.omitempty && fv.IsZero()) || (fv.Kind() == reflect.Pointer && fv.IsNil()) {
+			continue
+		}
+		row[c.name] = value(fv, opts)
+	}
+	return row
+}
+
+func value(v reflect.Value, opts Options) any {
+	switch t := v.Type(); {
+	case t == timeType:
+		if opts.DateTime {
+			return DateTime(v.Interface().(time.Time))
+		}
+		return Timestamp(v.Interface().(time.Time))
+	case t == durationType:
+		return types.FormatISODuration(time.Duration(v.Int()))
+	case t == extensionsType:
+		if v.Len() == 0 {
+			return nil
+		}
+		return v.Interface()
+	case t == rawType:
+		return v.Interface()
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Pointer:
+		return value(v.Elem(), opts)
+	case reflect.Slice:
+		out := make([]any, v.Len())
+		for i := range out {
+			out[i] = value(v.Index(i), opts)
+		}
+		return out
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return keys[i].String() < keys[j].String() })
+		out := make([]any, len(keys))
+		for i, k := range keys {
+			out[i] = map[string]any{"key": k.String(), "value": value(v.MapIndex(k), opts)}
+		}
+		return out
+	case reflect.Struct:
+		return structRow(v, opts)
+	}
+	return v.Interface()
+}
+
+// Encoder writes rows as newline-delimited JSON.
+type Encoder struct {
+	w    *bufio.Writer
+	opts Options
+}
+
+// NewEncoder returns an Encoder writing to w. Call Flush when done.
+func NewEncoder(w io.Writer, opts Options) *Encoder {
+	return &Encoder{w: bufio.NewWriter(w), opts: opts}
+}
+
+// Encode writes Row(v) as one line.
+func (e *Encoder) Encode(v any) error {
+	row, err := Row(v, e.opts)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(row)
+	if err != nil {
+		return fmt.Errorf("bq: %w", err)
+	}
+	if _, err := e.w.Write(append(b, '\n')); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Flush writes any buffered rows.
+func (e *Encoder) Flush() error { return e.w.Flush() }
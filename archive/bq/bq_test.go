@@ -0,0 +1,111 @@
+package bq
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func find(fs []Field, path string) (Field, bool) {
+	name, rest, nested := strings.Cut(path, ".")
+	for _, f := range fs {
+		if f.Name == name {
+			if nested {
+				return find(f.Fields, rest)
+			}
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+func TestSeriesSchema(t *testing.T) {
+	fs := SeriesSchema(Options{})
+	for path, want := range map[string]string{
+		"topic":                     "STRING REQUIRED",
+		"generated_at":              "TIMESTAMP REQUIRED",
+		"points":                    "RECORD REPEATED",
+		"points.volume":             "INTEGER REQUIRED",
+		"points.acct_age_mix":       "RECORD REPEATED",
+		"points.acct_age_mix.key":   "STRING REQUIRED",
+		"points.acct_age_mix.value": "FLOAT NULLABLE",
+		"points.coordination_signals.synchrony_index": "FLOAT REQUIRED",
+		"points.backfilled":                           "BOOLEAN NULLABLE",
+		"retraction":                                  "RECORD NULLABLE",
+		"extensions":                                  "JSON NULLABLE",
+		"methodology.collection_lag":                  "STRING NULLABLE",
+		"methodology.caveats":                         "STRING REPEATED",
+	} {
+		f, ok := find(fs, path)
+		if !ok {
+			t.Errorf("%s: missing", path)
+			continue
+		}
+		if got := f.Type + " " + f.Mode; got != want {
+			t.Errorf("%s = %s, want %s", path, got, want)
+		}
+	}
+	if f, _ := find(SeriesSchema(Options{DateTime: true}), "points.ts"); f.Type != "DATETIME" {
+		t.Errorf("DateTime points.ts = %s", f.Type)
+	}
+	if _, err := SchemaFor(struct{ M map[int]string }{}, Options{}); err == nil || !strings.Contains(err.Error(), "M") {
+		t.Errorf("int-keyed map: %v", err)
+	}
+}
+
+func TestEncoder(t *testing.T) {
+	ts := time.Date(2025, 1, 1, 12, 0, 0, 123456789, time.FixedZone("", 3600))
+	s := types.Series{
+		Topic: "#vote", GeneratedAt: ts, Interval: types.IntervalMinute,
+		Points: []types.Point{{
+			TS: ts, Volume: 4,
+			AcctAgeMix: map[string]types.Probability{"8-30d": 0.25, "0-7d": 0.75},
+		}},
+		Methodology: &types.Methodology{CollectionLag: types.ISODuration(90 * time.Second)},
+	}
+	s.Extensions.Set("x-test-note", "hi")
+	var buf bytes.Buffer
+	e := NewEncoder(&buf, Options{})
+	if err := e.Encode(&s); err != nil {
+		t.Fatal(err)
+	}
+	e.Flush()
+	var row map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &row); err != nil {
+		t.Fatal(err)
+	}
+	if got := row["generated_at"]; got != "2025-01-01 11:00:00.123456 UTC" {
+		t.Errorf("generated_at = %v", got)
+	}
+	p := row["points"].([]any)[0].(map[string]any)
+	mix, _ := json.Marshal(p["acct_age_mix"])
+	if string(mix) != `[{"key":"0-7d","value":0.75},{"key":"8-30d","value":0.25}]` {
+		t.Errorf("acct_age_mix = %s", mix)
+	}
+	if _, ok := p["backfilled"]; ok {
+		t.Error("omitempty field written")
+	}
+	if _, ok := row["retraction"]; ok {
+		t.Error("nil pointer written")
+	}
+	if got := row["methodology"].(map[string]any)["collection_lag"]; got != "PT1M30S" {
+		t.Errorf("collection_lag = %v", got)
+	}
+	if got := row["extensions"].(map[string]any)["x-test-note"]; got != "hi" {
+		t.Errorf("extensions = %v", row["extensions"])
+	}
+}
+
+func TestCivil(t *testing.T) {
+	ts := time.Date(2024, 12, 31, 23, 59, 59, 999999999, time.FixedZone("", -3600))
+	if got := DateTime(ts); got != "2025-01-01 00:59:59.999999" {
+		t.Errorf("DateTime = %s", got)
+	}
+	if got := Date(ts); got != "2025-01-01" {
+		t.Errorf("Date = %s", got)
+	}
+}
@@ -0,0 +1,22 @@
+// archive/bq/doc.go
+// Package bq exports tags and series for loading into BigQuery: table
+// schemas in BigQuery's JSON schema-file format, and rows as newline-
+// delimited JSON in the matching shape.
+//
+// Schemas are derived from the Go types by reflection, so they follow the
+// schema as it grows instead of drifting from hand-maintained copies:
+//
+//	bq.SeriesSchema(bq.Options{}) // bq load --schema=series.json ...
+//
+// Column types: strings and enums are STRING, counts INTEGER, ratios
+// FLOAT, booleans BOOLEAN, times TIMESTAMP (or DATETIME in UTC with
+// Options.DateTime), durations STRING in ISO 8601 form, and extensions
+// JSON. Nested structs are RECORD columns. BigQuery has no map type, so
+// breakdown maps become REPEATED RECORD columns of key/value pairs, sorted
+// by key. Fields that the JSON encoding never omits are REQUIRED; the
+// rest are NULLABLE.
+//
+// BigQuery stores microseconds, so times are truncated (not rounded) to
+// the microsecond and written in its civil formats; Timestamp, DateTime,
+// and Date expose the conversions for query parameters.
+package bq
@@ -0,0 +1,40 @@
+package bq_test
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/archive/bq"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func ExampleTagSchema() {
+	for _, f := range bq.TagSchema(bq.Options{}) {
+		fmt.Printf("%-16s %-6s %s\n", f.Name, f.Type, f.Mode)
+	}
+	// Output:
+	// acct_age_bucket  STRING REQUIRED
+	// acct_type        STRING REQUIRED
+	// automation_flag  STRING REQUIRED
+	// post_kind        STRING REQUIRED
+	// client_family    STRING REQUIRED
+	// media_provenance STRING REQUIRED
+	// dedup_hash       STRING REQUIRED
+	// origin_hint      STRING NULLABLE
+	// dedup_hash_alg   STRING NULLABLE
+	// extensions       JSON   NULLABLE
+}
+
+func ExampleEncoder() {
+	e := bq.NewEncoder(os.Stdout, bq.Options{})
+	_ = e.Encode(types.Point{
+		TS:         time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Volume:     12,
+		ClientMix:  map[string]types.Probability{"web": 0.5, "mobile": 0.5},
+		AcctAgeMix: map[string]types.Probability{},
+	})
+	_ = e.Flush()
+	// Output:
+	// {"acct_age_mix":[],"automation_mix":[],"client_mix":[{"key":"mobile","value":0.5},{"key":"web","value":0.5}],"coordination_signals":{"burst_score":0,"duplication_clusters":0,"synchrony_index":0},"recycled_content_rate":0,"reshare_ratio":0,"ts":"2025-01-01 00:00:00.000000 UTC","volume":12}
+}
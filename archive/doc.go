@@ -0,0 +1,4 @@
+// archive/doc.go
+// Package archive holds export and archival formats for Civic Transparency
+// data. Format-specific encoders live in subpackages.
+package archive
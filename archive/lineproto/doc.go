@@ -0,0 +1,14 @@
+// archive/lineproto/doc.go
+// Package lineproto exports Series points as InfluxDB line protocol.
+//
+// Each point becomes one line: the measurement is the series topic, the
+// interval and raw topic are tags, ratios and coordination signals are
+// fields, and the timestamp is the point's ts. Line protocol treats lines
+// starting with "#" as comments, so leading "#" characters are dropped from
+// the measurement before any prefix is added; the topic tag keeps the
+// original spelling. Write rejects a series it cannot encode faithfully: a
+// topic that is nothing but "#" with no prefix, a line break in the topic or
+// a tag, or a NaN or infinite ratio.
+//
+//	vote,interval=minute,topic=#vote volume=12i,reshare_ratio=0.25,... 1735689600000000000
+package lineproto
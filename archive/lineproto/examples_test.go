@@ -0,0 +1,29 @@
+package lineproto_test
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/archive/lineproto"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func ExampleWrite() {
+	s := &types.Series{
+		Topic:    "#vote 2024",
+		Interval: types.IntervalMinute,
+		Points: []types.Point{{
+			TS:           time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			Volume:       12,
+			ReshareRatio: 0.25,
+		}},
+	}
+	_ = lineproto.Write(os.Stdout, s, lineproto.Options{Precision: lineproto.Second, Tags: map[string]string{"publisher": "pub_1"}})
+
+	s.Topic = "#"
+	fmt.Println(lineproto.Write(os.Stdout, s, lineproto.Options{}))
+	// Output:
+	// vote\ 2024,interval=minute,publisher=pub_1,topic=#vote\ 2024 volume=12i,reshare_ratio=0.25,recycled_content_rate=0,burst_score=0,synchrony_index=0,duplication_clusters=0i,backfilled=false 1735689600
+	// lineproto: empty measurement name
+}
@@ -0,0 +1,134 @@
+package lineproto
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// Precision is the timestamp unit written at the end of each line. It must
+// match the precision parameter used when writing to InfluxDB.
+type Precision time.Duration
+
+const (
+	Nanosecond  = Precision(time.Nanosecond)
+	Microsecond = Precision(time.Microsecond)
+	Millisecond = Precision(time.Millisecond)
+	Second      = Precision(time.Second)
+)
+
+// Options configures Write.
+type Options struct {
+	// Precision of timestamps. Zero selects Nanosecond.
+	Precision Precision
+	// MeasurementPrefix is prepended to the topic, after its leading "#"
+	// characters are dropped, to form the measurement, e.g. "ct_" turns
+	// "#vote" into "ct_vote".
+	MeasurementPrefix string
+	// Tags are extra tags added to every line (e.g., publisher).
+	Tags map[string]string
+}
+
+var (
+	// ErrEmptyMeasurement is returned by Write when the prefix and topic
+	// leave no measurement name, e.g. for the topic "#" without a prefix.
+	ErrEmptyMeasurement = errors.New("lineproto: empty measurement name")
+	// ErrNewline is returned by Write when the measurement or a tag
+	// contains a line break, which line protocol cannot escape.
+	ErrNewline = errors.New("lineproto: line break in measurement or tag")
+	// ErrNotFinite is returned by Write for a NaN or infinite field value,
+	// which line protocol cannot represent.
+	ErrNotFinite = errors.New("lineproto: field value is not finite")
+)
+
+var (
+	measurementEscaper = strings.NewReplacer(`,`, `\,`, ` `, `\ `)
+	tagEscaper         = strings.NewReplacer(`,`, `\,`, `=`, `\=`, ` `, `\ `)
+)
+
+// Write writes one line per point of s to w. It checks the whole series
+// before writing, so an error leaves w untouched.
+func Write(w io.Writer, s *types.Series, opts Options) error {
+	measurement := opts.MeasurementPrefix + strings.TrimLeft(s.Topic, "#")
+	if measurement == "" {
+		return ErrEmptyMeasurement
+	}
+	for i, p := range s.Points {
+		for _, f := range []struct {
+			name string
+			v    types.Probability
+		}{
+			{"reshare_ratio", p.ReshareRatio},
+			{"recycled_content_rate", p.RecycledContentRate},
+			{"burst_score", p.CoordinationSignals.BurstScore},
+			{"synchrony_index", p.CoordinationSignals.SynchronyIndex},
+		} {
+			if math.IsNaN(float64(f.v)) || math.IsInf(float64(f.v), 0) {
+				return fmt.Errorf("%w: points[%d].%s", ErrNotFinite, i, f.name)
+			}
+		}
+	}
+	bw := bufio.NewWriter(w)
+	prec := time.Duration(opts.Precision)
+	if prec <= 0 {
+		prec = time.Nanosecond
+	}
+
+	all := map[string]string{"interval": string(s.Interval), "topic": s.Topic}
+	for k, v := range opts.Tags {
+		all[k] = v
+	}
+	keys := make([]string, 0, len(all))
+	for k := range all {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	if hasNewline(measurement) {
+		return fmt.Errorf("%w: measurement %q", ErrNewline, measurement)
+	}
+	for _, k := range keys {
+		if hasNewline(k) || hasNewline(all[k]) {
+			return fmt.Errorf("%w: tag %q", ErrNewline, k)
+		}
+	}
+	var b strings.Builder
+	b.WriteString(measurementEscaper.Replace(measurement))
+	for _, k := range keys {
+		if all[k] == "" {
+			continue // line protocol forbids empty tag values
+		}
+		b.WriteString("," + tagEscaper.Replace(k) + "=" + tagEscaper.Replace(all[k]))
+	}
+	b.WriteByte(' ')
+	prefix := b.String()
+
+	for _, p := range s.Points {
+		line := prefix +
+			"volume=" + strconv.Itoa(p.Volume) + "i" +
+			",reshare_ratio=" + ftoa(p.ReshareRatio) +
+			",recycled_content_rate=" + ftoa(p.RecycledContentRate) +
+			",burst_score=" + ftoa(p.CoordinationSignals.BurstScore) +
+			",synchrony_index=" + ftoa(p.CoordinationSignals.SynchronyIndex) +
+			",duplication_clusters=" + strconv.Itoa(p.CoordinationSignals.DuplicationClusters) + "i" +
+			",backfilled=" + strconv.FormatBool(p.Backfilled) +
+			" " + strconv.FormatInt(p.TS.UnixNano()/int64(prec), 10) + "\n"
+		if _, err := bw.WriteString(line); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+func hasNewline(s string) bool { return strings.ContainsAny(s, "\r\n") }
+
+func ftoa(p types.Probability) string {
+	return strconv.FormatFloat(float64(p), 'g', -1, 64)
+}
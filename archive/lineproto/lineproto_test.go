@@ -0,0 +1,66 @@
+package lineproto
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestWriteRejects(t *testing.T) {
+	series := func() *types.Series {
+		return &types.Series{Topic: "#vote", Interval: types.IntervalMinute, Points: []types.Point{
+			{TS: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Volume: 1},
+			{TS: time.Date(2025, 1, 1, 0, 1, 0, 0, time.UTC), Volume: 2},
+		}}
+	}
+	tests := []struct {
+		name string
+		edit func(*types.Series, *Options)
+		want error
+	}{
+		{"newline in topic", func(s *types.Series, _ *Options) { s.Topic = "#vote\nfake,topic=x volume=1i" }, ErrNewline},
+		{"carriage return in tag", func(_ *types.Series, o *Options) { o.Tags = map[string]string{"publisher": "a\rb"} }, ErrNewline},
+		{"newline in tag key", func(_ *types.Series, o *Options) { o.Tags = map[string]string{"a\nb": "x"} }, ErrNewline},
+		{"NaN", func(s *types.Series, _ *Options) { s.Points[1].ReshareRatio = types.Probability(math.NaN()) }, ErrNotFinite},
+		{"Inf", func(s *types.Series, _ *Options) {
+			s.Points[1].CoordinationSignals.BurstScore = types.Probability(math.Inf(-1))
+		}, ErrNotFinite},
+		{"empty measurement", func(s *types.Series, _ *Options) { s.Topic = "##" }, ErrEmptyMeasurement},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, opts := series(), Options{}
+			tt.edit(s, &opts)
+			var buf bytes.Buffer
+			if err := Write(&buf, s, opts); !errors.Is(err, tt.want) {
+				t.Fatalf("Write = %v, want %v", err, tt.want)
+			}
+			if buf.Len() != 0 {
+				t.Errorf("wrote %q before failing", buf.String())
+			}
+		})
+	}
+}
+
+func TestMeasurementPrefix(t *testing.T) {
+	s := &types.Series{Topic: "##vote", Interval: types.IntervalMinute,
+		Points: []types.Point{{TS: time.Unix(60, 0)}}}
+	var buf bytes.Buffer
+	if err := Write(&buf, s, Options{MeasurementPrefix: "ct_"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := buf.String(); !strings.HasPrefix(got, "ct_vote,interval=minute,topic=##vote ") {
+		t.Errorf("line = %q", got)
+	}
+
+	s.Topic = "#"
+	buf.Reset()
+	if err := Write(&buf, s, Options{MeasurementPrefix: "ct_"}); err != nil || !strings.HasPrefix(buf.String(), "ct_,") {
+		t.Errorf("bare # with prefix = %q, %v", buf.String(), err)
+	}
+}
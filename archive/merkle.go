@@ -0,0 +1,203 @@
+package archive
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+)
+
+// ManifestVersion is the current Manifest format version.
+const ManifestVersion = 1
+
+// ManifestFile records one archived file.
+type ManifestFile struct {
+	Name   string `json:"name"`   // slash-separated path within the archive
+	Size   int64  `json:"size"`   // bytes
+	SHA256 string `json:"sha256"` // hex digest of the file contents
+}
+
+// Manifest lists the files of an archive and commits to them with a Merkle
+// root, so a third party holding only Root can detect modified, missing,
+// reordered, or truncated files.
+//
+// The tree follows RFC 6962 section 2.1: leaves are hashed with a 0x00
+// prefix and interior nodes with 0x01, and a tree of n leaves splits at the
+// largest power of two below n. Each leaf covers a file's name, size, and
+// content digest. Files are ordered by Name.
+type Manifest struct {
+	Version int            `json:"version"`
+	Files   []ManifestFile `json:"files"`
+	Root    string         `json:"root"` // hex Merkle root
+}
+
+// Proof is an inclusion proof for one file of a Manifest.
+type Proof struct {
+	Index    int      `json:"index"`     // leaf position
+	TreeSize int      `json:"tree_size"` // number of leaves
+	Path     []string `json:"path"`      // hex sibling hashes, leaf to root
+}
+
+// ErrNotInManifest is returned by Manifest.Proof for unknown file names.
+var ErrNotInManifest = errors.New("archive: file not in manifest")
+
+// BuildManifest hashes the named files in fsys and returns their Manifest.
+func BuildManifest(fsys fs.FS, names ...string) (*Manifest, error) {
+	sorted := append([]string(nil), names...)
+	sort.Strings(sorted)
+	m := &Manifest{Version: ManifestVersion}
+	for i, name := range sorted {
+		if i > 0 && sorted[i-1] == name {
+			return nil, fmt.Errorf("archive: duplicate file %q", name)
+		}
+		f, err := fsys.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		h := sha256.New()
+		n, err := io.Copy(h, f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("archive: hash %s: %w", name, err)
+		}
+		m.Files = append(m.Files, ManifestFile{Name: name, Size: n, SHA256: hex.EncodeToString(h.Sum(nil))})
+	}
+	m.Root = hex.EncodeToString(merkleRoot(m.leaves()))
+	return m, nil
+}
+
+// Verify re-hashes every listed file in fsys and checks the manifest root.
+func (m *Manifest) Verify(fsys fs.FS) error {
+	names := make([]string, len(m.Files))
+	for i, f := range m.Files {
+		names[i] = f.Name
+	}
+	got, err := BuildManifest(fsys, names...)
+	if err != nil {
+		return err
+	}
+	for i := range got.Files {
+		if got.Files[i] != m.Files[i] {
+			return fmt.Errorf("archive: %s does not match manifest", m.Files[i].Name)
+		}
+	}
+	if got.Root != m.Root {
+		return errors.New("archive: merkle root mismatch")
+	}
+	return nil
+}
+
+// Proof returns an inclusion proof for the named file.
+func (m *Manifest) Proof(name string) (Proof, error) {
+	idx := sort.Search(len(m.Files), func(i int) bool { return m.Files[i].Name >= name })
+	if idx == len(m.Files) || m.Files[idx].Name != name {
+		return Proof{}, ErrNotInManifest
+	}
+	var path []string
+	for _, h := range auditPath(idx, m.leaves()) {
+		path = append(path, hex.EncodeToString(h))
+	}
+	return Proof{Index: idx, TreeSize: len(m.Files), Path: path}, nil
+}
+
+// LeafHash returns the Merkle leaf hash committed for f.
+func LeafHash(f ManifestFile) []byte {
+	digest, _ := hex.DecodeString(f.SHA256)
+	var size [8]byte
+	binary.BigEndian.PutUint64(size[:], uint64(f.Size))
+	h := sha256.New()
+	h.Write([]byte{0x00})
+	h.Write([]byte(f.Name))
+	h.Write([]byte{0x00})
+	h.Write(size[:])
+	h.Write(digest)
+	return h.Sum(nil)
+}
+
+// VerifyProof reports whether f is included at p.Index in the tree with
+// the given hex root.
+func VerifyProof(root string, f ManifestFile, p Proof) bool {
+	want, err := hex.DecodeString(root)
+	if err != nil || p.Index < 0 || p.Index >= p.TreeSize {
+		return false
+	}
+	path := make([][]byte, len(p.Path))
+	for i, s := range p.Path {
+		if path[i], err = hex.DecodeString(s); err != nil {
+			return false
+		}
+	}
+	// RFC 9162 section 2.1.3.2 verification algorithm.
+	fn, sn := p.Index, p.TreeSize-1
+	r := LeafHash(f)
+	for _, c := range path {
+		if sn == 0 {
+			return false
+		}
+		if fn&1 == 1 || fn == sn {
+			r = nodeHash(c, r)
+			for fn&1 == 0 && fn != 0 {
+				fn >>= 1
+				sn >>= 1
+			}
+		} else {
+			r = nodeHash(r, c)
+		}
+		fn >>= 1
+		sn >>= 1
+	}
+	return sn == 0 && bytes.Equal(r, want)
+}
+
+func (m *Manifest) leaves() [][]byte {
+	out := make([][]byte, len(m.Files))
+	for i, f := range m.Files {
+		out[i] = LeafHash(f)
+	}
+	return out
+}
+
+func nodeHash(l, r []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte{0x01})
+	h.Write(l)
+	h.Write(r)
+	return h.Sum(nil)
+}
+
+// split returns the largest power of two strictly less than n (n > 1).
+func split(n int) int {
+	k := 1
+	for k<<1 < n {
+		k <<= 1
+	}
+	return k
+}
+
+func merkleRoot(leaves [][]byte) []byte {
+	switch len(leaves) {
+	case 0:
+		h := sha256.Sum256(nil)
+		return h[:]
+	case 1:
+		return leaves[0]
+	}
+	k := split(len(leaves))
+	return nodeHash(merkleRoot(leaves[:k]), merkleRoot(leaves[k:]))
+}
+
+func auditPath(m int, leaves [][]byte) [][]byte {
+	if len(leaves) <= 1 {
+		return nil
+	}
+	k := split(len(leaves))
+	if m < k {
+		return append(auditPath(m, leaves[:k]), merkleRoot(leaves[k:]))
+	}
+	return append(auditPath(m-k, leaves[k:]), merkleRoot(leaves[:k]))
+}
@@ -0,0 +1,44 @@
+package archive
+
+import (
+	"fmt"
+	"testing"
+	"testing/fstest"
+)
+
+func TestManifestProofs(t *testing.T) {
+	for n := 1; n <= 9; n++ {
+		fsys := fstest.MapFS{}
+		var names []string
+		for i := 0; i < n; i++ {
+			name := fmt.Sprintf("2025/01/%02d.json", i+1)
+			fsys[name] = &fstest.MapFile{Data: []byte(fmt.Sprintf(`{"day":%d}`, i))}
+			names = append(names, name)
+		}
+		m, err := BuildManifest(fsys, names...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := m.Verify(fsys); err != nil {
+			t.Fatalf("n=%d: Verify: %v", n, err)
+		}
+		for i, f := range m.Files {
+			p, err := m.Proof(f.Name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !VerifyProof(m.Root, f, p) {
+				t.Errorf("n=%d: proof for leaf %d does not verify", n, i)
+			}
+			f.Size++
+			if VerifyProof(m.Root, f, p) {
+				t.Errorf("n=%d: tampered leaf %d verifies", n, i)
+			}
+		}
+
+		fsys[names[0]].Data = append(fsys[names[0]].Data, ' ')
+		if m.Verify(fsys) == nil {
+			t.Errorf("n=%d: Verify accepted modified file", n)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+// archive/promwrite/doc.go
+// Package promwrite exports Series points to Prometheus through the
+// remote-write protocol (version 1), so transparency metrics can be graphed
+// and alerted on with existing tooling.
+//
+// Each point metric becomes its own time series labelled with the topic and
+// interval: volume, ratios, and coordination signals are plain gauges, and
+// each breakdown becomes one series per bucket, e.g.
+//
+//	civic_transparency_volume{interval="minute",topic="#vote"}
+//	civic_transparency_acct_age_share{bucket="24m+",interval="minute",topic="#vote"}
+//
+// The WriteRequest protobuf and its snappy block framing are encoded by hand
+// to keep the module free of dependencies. The snappy encoder emits literal
+// blocks only, which every snappy decoder accepts but which are not smaller
+// than the input.
+package promwrite
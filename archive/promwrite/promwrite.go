@@ -0,0 +1,178 @@
+package promwrite
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// DefaultPrefix is prepended to every metric name when Options.Prefix is
+// empty.
+const DefaultPrefix = "civic_transparency_"
+
+// Options configures Convert.
+type Options struct {
+	// Prefix for metric names. Empty selects DefaultPrefix.
+	Prefix string
+	// Labels are extra labels added to every series (e.g., publisher).
+	Labels map[string]string
+}
+
+// Label is a name/value pair identifying a time series.
+type Label struct {
+	Name, Value string
+}
+
+// Sample is one value at a Unix millisecond timestamp.
+type Sample struct {
+	Value     float64
+	Timestamp int64
+}
+
+// TimeSeries is a labelled sequence of samples. Labels are sorted by name
+// and include "__name__".
+type TimeSeries struct {
+	Labels  []Label
+	Samples []Sample
+}
+
+// Convert turns the points of ss into time series, one per metric and label
+// set, with samples in time order.
+func Convert(opts Options, ss ...*types.Series) []TimeSeries {
+	prefix := opts.Prefix
+	if prefix == "" {
+		prefix = DefaultPrefix
+	}
+	byKey := map[string]*TimeSeries{}
+	var keys []string
+	add := func(s *types.Series, metric string, extra []Label, v float64, ts int64) {
+		labels := []Label{{"__name__", prefix + metric}, {"interval", string(s.Interval)}, {"topic", s.Topic}}
+		for k, v := range opts.Labels {
+			labels = append(labels, Label{k, v})
+		}
+		labels = append(labels, extra...)
+		sort.Slice(labels, func(i, j int) bool { return labels[i].Name < labels[j].Name })
+		var kb bytes.Buffer
+		for _, l := range labels {
+			kb.WriteString(l.Name + "\x00" + l.Value + "\x00")
+		}
+		k := kb.String()
+		t, ok := byKey[k]
+		if !ok {
+			t = &TimeSeries{Labels: labels}
+			byKey[k] = t
+			keys = append(keys, k)
+		}
+		t.Samples = append(t.Samples, Sample{Value: v, Timestamp: ts})
+	}
+	for _, s := range ss {
+		for _, p := range s.Points {
+			ts := p.TS.UnixMilli()
+			add(s, "volume", nil, float64(p.Volume), ts)
+			add(s, "reshare_ratio", nil, float64(p.ReshareRatio), ts)
+			add(s, "recycled_content_rate", nil, float64(p.RecycledContentRate), ts)
+			add(s, "burst_score", nil, float64(p.CoordinationSignals.BurstScore), ts)
+			add(s, "synchrony_index", nil, float64(p.CoordinationSignals.SynchronyIndex), ts)
+			add(s, "duplication_clusters", nil, float64(p.CoordinationSignals.DuplicationClusters), ts)
+			for b, v := range p.AcctAgeMix {
+				add(s, "acct_age_share", []Label{{"bucket", b}}, float64(v), ts)
+			}
+			for f, v := range p.AutomationMix {
+				add(s, "automation_share", []Label{{"flag", f}}, float64(v), ts)
+			}
+			for c, v := range p.ClientMix {
+				add(s, "client_share", []Label{{"family", c}}, float64(v), ts)
+			}
+			for a, v := range p.AcctTypeShares {
+				add(s, "acct_type_share", []Label{{"acct_type", string(a)}}, float64(v), ts)
+			}
+		}
+	}
+	sort.Strings(keys)
+	out := make([]TimeSeries, len(keys))
+	for i, k := range keys {
+		t := byKey[k]
+		sort.SliceStable(t.Samples, func(a, b int) bool { return t.Samples[a].Timestamp < t.Samples[b].Timestamp })
+		out[i] = *t
+	}
+	return out
+}
+
+// Marshal encodes ts as a prometheus.WriteRequest protobuf message.
+func Marshal(ts []TimeSeries) []byte {
+	var req []byte
+	for _, t := range ts {
+		var tb []byte
+		for _, l := range t.Labels {
+			var lb []byte
+			lb = appendString(lb, 1, l.Name)
+			lb = appendString(lb, 2, l.Value)
+			tb = appendBytes(tb, 1, lb)
+		}
+		for _, s := range t.Samples {
+			var sb []byte
+			sb = appendDouble(sb, 1, s.Value)
+			sb = appendVarintField(sb, 2, uint64(s.Timestamp))
+			tb = appendBytes(tb, 2, sb)
+		}
+		req = appendBytes(req, 1, tb)
+	}
+	return req
+}
+
+// Encode returns the remote-write request body for ss: a snappy-framed
+// WriteRequest.
+func Encode(opts Options, ss ...*types.Series) []byte {
+	return snappyEncode(Marshal(Convert(opts, ss...)))
+}
+
+// Client pushes Series to a remote-write endpoint.
+type Client struct {
+	URL        string
+	HTTPClient *http.Client // nil uses http.DefaultClient
+	Header     http.Header  // extra headers (e.g., Authorization)
+	Options    Options
+}
+
+// StatusError is a non-2xx response from the endpoint.
+type StatusError struct {
+	Code int
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("promwrite: HTTP %d: %s", e.Code, e.Body)
+}
+
+// Push sends the points of ss in one request.
+func (c *Client) Push(ctx context.Context, ss ...*types.Series) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.URL, bytes.NewReader(Encode(c.Options, ss...)))
+	if err != nil {
+		return err
+	}
+	for k, v := range c.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return &StatusError{Code: resp.StatusCode, Body: string(b)}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package promwrite
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// snappyDecode decodes the literal-only blocks produced by snappyEncode.
+func snappyDecode(t *testing.T, b []byte) []byte {
+	t.Helper()
+	n, k := binary.Uvarint(b)
+	b = b[k:]
+	var out []byte
+	for len(b) > 0 {
+		tag := b[0]
+		if tag&3 != 0 {
+			t.Fatalf("non-literal tag %#x", tag)
+		}
+		m, hdr := int(tag>>2), 1
+		switch m {
+		case 60:
+			m, hdr = int(b[1]), 2
+		case 61:
+			m, hdr = int(b[1])|int(b[2])<<8, 3
+		}
+		out = append(out, b[hdr:hdr+m+1]...)
+		b = b[hdr+m+1:]
+	}
+	if uint64(len(out)) != n {
+		t.Fatalf("decoded %d bytes, header says %d", len(out), n)
+	}
+	return out
+}
+
+func TestSnappyLiteralFraming(t *testing.T) {
+	for _, n := range []int{0, 1, 60, 61, 256, 257, 70000} {
+		src := bytes.Repeat([]byte{'x'}, n)
+		if got := snappyDecode(t, snappyEncode(src)); !bytes.Equal(got, src) {
+			t.Fatalf("n=%d: round trip mismatch", n)
+		}
+	}
+}
+
+func TestMarshal(t *testing.T) {
+	got := hex.EncodeToString(Marshal([]TimeSeries{{
+		Labels:  []Label{{"a", "b"}},
+		Samples: []Sample{{Value: 1, Timestamp: 2}},
+	}}))
+	const want = "0a15" + "0a06" + "0a0161" + "120162" + "120b" + "09000000000000f03f" + "1002"
+	if got != want {
+		t.Fatalf("Marshal = %s, want %s", got, want)
+	}
+}
+
+func TestPush(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &types.Series{
+		Topic: "#vote", Interval: types.IntervalMinute,
+		Points: []types.Point{
+			{TS: t0.Add(time.Minute), Volume: 5, AcctAgeMix: map[string]types.Probability{"24m+": 1}},
+			{TS: t0, Volume: 3, AcctAgeMix: map[string]types.Probability{"24m+": 0.5, "0-7d": 0.5}},
+		},
+	}
+	ts := Convert(Options{Labels: map[string]string{"publisher": "pub_1"}}, s)
+	if len(ts) != 8 { // 6 gauges + 2 acct_age buckets
+		t.Fatalf("Convert returned %d series, want 8", len(ts))
+	}
+	for _, x := range ts {
+		if x.Labels[0].Name != "__name__" || x.Labels[0].Value != "civic_transparency_volume" {
+			continue
+		}
+		if len(x.Samples) != 2 || x.Samples[0] != (Sample{3, t0.UnixMilli()}) || x.Labels[2] != (Label{"publisher", "pub_1"}) {
+			t.Fatalf("volume series = %+v", x)
+		}
+	}
+
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Encoding") != "snappy" || r.Header.Get("Content-Type") != "application/x-protobuf" ||
+			r.Header.Get("Authorization") != "Bearer t" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	c := &Client{URL: srv.URL, Header: http.Header{"Authorization": {"Bearer t"}},
+		Options: Options{Labels: map[string]string{"publisher": "pub_1"}}}
+	if err := c.Push(context.Background(), s); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(snappyDecode(t, body), Marshal(ts)) {
+		t.Fatal("pushed body does not match Marshal(Convert(...))")
+	}
+}
@@ -0,0 +1,66 @@
+package promwrite
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// Protobuf wire types used by WriteRequest.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+)
+
+func appendVarint(b []byte, v uint64) []byte {
+	return binary.AppendUvarint(b, v)
+}
+
+func appendTag(b []byte, field, wire int) []byte {
+	return appendVarint(b, uint64(field<<3|wire))
+}
+
+func appendBytes(b []byte, field int, v []byte) []byte {
+	b = appendTag(b, field, wireBytes)
+	b = appendVarint(b, uint64(len(v)))
+	return append(b, v...)
+}
+
+func appendString(b []byte, field int, v string) []byte {
+	return appendBytes(b, field, []byte(v))
+}
+
+func appendDouble(b []byte, field int, v float64) []byte {
+	b = appendTag(b, field, wireFixed64)
+	return binary.LittleEndian.AppendUint64(b, math.Float64bits(v))
+}
+
+func appendVarintField(b []byte, field int, v uint64) []byte {
+	b = appendTag(b, field, wireVarint)
+	return appendVarint(b, v)
+}
+
+// maxLiteral is the longest literal a two-byte snappy length can describe.
+const maxLiteral = 1 << 16
+
+// snappyEncode frames src as a snappy block of literal elements: the
+// uncompressed length as a varint, then for each chunk a literal tag
+// (lengths up to 60 inline, longer ones in a trailing 1- or 2-byte length)
+// followed by the bytes.
+func snappyEncode(src []byte) []byte {
+	dst := appendVarint(make([]byte, 0, len(src)+len(src)/maxLiteral*3+16), uint64(len(src)))
+	for len(src) > 0 {
+		n := min(len(src), maxLiteral)
+		switch m := n - 1; {
+		case m < 60:
+			dst = append(dst, byte(m)<<2)
+		case m < 1<<8:
+			dst = append(dst, 60<<2, byte(m))
+		default:
+			dst = append(dst, 61<<2, byte(m), byte(m>>8))
+		}
+		dst = append(dst, src[:n]...)
+		src = src[n:]
+	}
+	return dst
+}
@@ -0,0 +1,29 @@
+// archive/seekbundle/doc.go
+// Package seekbundle reads and writes seekable, compressed archives of
+// Series with one independently compressed frame per UTC day, so a single
+// day can be extracted from a month-long archive without decompressing the
+// rest.
+//
+// Layout:
+//
+//	magic "CTSEEK01"
+//	frame 0 .. frame n-1   compressed NDJSON, one Series per line
+//	index                  JSON Index: codec and, per frame, its day,
+//	                       offset, lengths, topics, and SHA-256
+//	trailer                index length (uint64 LE), index SHA-256 (32 bytes),
+//	                       magic "CTSEEK01"
+//
+// A Reader loads only the trailer and index on open. Each frame's SHA-256
+// covers its uncompressed bytes and is checked whenever the frame is read,
+// and the index is checked against the trailer, so truncation or tampering
+// is reported rather than silently decoded.
+//
+// Compression is pluggable: "deflate" (the default) and "none" are
+// registered. The standard library has no zstd, so to write or read zstd
+// archives register an implementation (for example over
+// github.com/klauspost/compress/zstd) under the name "zstd" in writers and
+// readers:
+//
+//	seekbundle.RegisterCodec(zstdCodec{})
+//	w, err := seekbundle.NewWriter(f, seekbundle.Options{Codec: "zstd"})
+package seekbundle
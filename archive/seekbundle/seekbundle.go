@@ -0,0 +1,317 @@
+package seekbundle
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+const (
+	magic       = "CTSEEK01"
+	trailerSize = 8 + sha256.Size + 8 // index length, index digest, magic
+	// IndexVersion is the current Index format version.
+	IndexVersion = 1
+	dayLayout    = "2006-01-02"
+)
+
+var (
+	// ErrFormat is returned for data that is not a seekbundle archive or is
+	// truncated.
+	ErrFormat = errors.New("seekbundle: not a seekbundle archive")
+	// ErrChecksum is returned when a frame or the index fails its SHA-256.
+	ErrChecksum = errors.New("seekbundle: checksum mismatch")
+	// ErrOutOfOrder is returned by Writer.Add for a day before the current one.
+	ErrOutOfOrder = errors.New("seekbundle: series added out of day order")
+	// ErrNoDay is returned by Reader.Day when the archive has no such frame.
+	ErrNoDay = errors.New("seekbundle: day not in archive")
+	// ErrNoFrame is returned by Reader.Frame for an index outside the
+	// archive's frames.
+	ErrNoFrame = errors.New("seekbundle: frame index out of range")
+)
+
+// Frame describes one compressed day.
+type Frame struct {
+	Day       string   `json:"day"`    // UTC date, YYYY-MM-DD
+	Offset    int64    `json:"offset"` // from the start of the archive
+	Length    int64    `json:"length"` // compressed bytes
+	RawLength int64    `json:"raw_length"`
+	Series    int      `json:"series"`
+	Topics    []string `json:"topics"` // sorted, distinct
+	SHA256    string   `json:"sha256"` // of the uncompressed frame
+}
+
+// Index is the archive's table of contents.
+type Index struct {
+	Version int     `json:"version"`
+	Codec   string  `json:"codec"`
+	Frames  []Frame `json:"frames"`
+}
+
+// DayOf returns the UTC day s is filed under: that of its first point, or
+// of GeneratedAt when it has none.
+func DayOf(s *types.Series) time.Time {
+	t := s.GeneratedAt
+	if len(s.Points) > 0 {
+		t = s.Points[0].TS
+	}
+	y, m, d := t.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// Options configures a Writer.
+type Options struct {
+	// Codec names a registered Codec: "deflate" (the default when empty),
+	// "none", or one added with RegisterCodec, such as "zstd".
+	Codec string
+}
+
+// Writer writes an archive. Series must be added in non-decreasing day
+// order; only the current day is held in memory.
+type Writer struct {
+	w      io.Writer
+	codec  Codec
+	off    int64
+	index  Index
+	day    time.Time
+	buf    bytes.Buffer
+	count  int
+	topics map[string]bool
+	err    error
+}
+
+// NewWriter writes the archive header to w and returns a Writer.
+func NewWriter(w io.Writer, opts Options) (*Writer, error) {
+	name := opts.Codec
+	if name == "" {
+		name = "deflate"
+	}
+	c, err := lookupCodec(name)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(w, magic); err != nil {
+		return nil, err
+	}
+	return &Writer{w: w, codec: c, off: int64(len(magic)),
+		index: Index{Version: IndexVersion, Codec: name}}, nil
+}
+
+// Add appends s to the frame for DayOf(s), closing the previous frame when
+// the day advances.
+func (w *Writer) Add(s *types.Series) error {
+	if w.err != nil {
+		return w.err
+	}
+	day := DayOf(s)
+	switch {
+	case w.count > 0 && day.Before(w.day):
+		return fmt.Errorf("%w: %s after %s", ErrOutOfOrder, day.Format(dayLayout), w.day.Format(dayLayout))
+	case w.count > 0 && day.After(w.day):
+		if w.err = w.flush(); w.err != nil {
+			return w.err
+		}
+	}
+	if w.count == 0 {
+		w.day, w.topics = day, map[string]bool{}
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	w.buf.Write(b)
+	w.buf.WriteByte('\n')
+	w.count++
+	w.topics[s.Topic] = true
+	return nil
+}
+
+func (w *Writer) flush() error {
+	raw := w.buf.Bytes()
+	sum := sha256.Sum256(raw)
+	cw := &countWriter{w: w.w}
+	zw, err := w.codec.NewWriter(cw)
+	if err != nil {
+		return err
+	}
+	if _, err := zw.Write(raw); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	f := Frame{
+		Day: w.day.Format(dayLayout), Offset: w.off, Length: cw.n, RawLength: int64(len(raw)),
+		Series: w.count, SHA256: hex.EncodeToString(sum[:]),
+	}
+	for t := range w.topics {
+		f.Topics = append(f.Topics, t)
+	}
+	sort.Strings(f.Topics)
+	w.index.Frames = append(w.index.Frames, f)
+	w.off += cw.n
+	w.buf.Reset()
+	w.count = 0
+	return nil
+}
+
+// Close writes the last frame, the index, and the trailer. It does not
+// close the underlying writer.
+func (w *Writer) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+	if w.count > 0 {
+		if w.err = w.flush(); w.err != nil {
+			return w.err
+		}
+	}
+	idx, err := json.Marshal(w.index)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(idx)
+	trailer := binary.LittleEndian.AppendUint64(nil, uint64(len(idx)))
+	trailer = append(append(trailer, sum[:]...), magic...)
+	if _, err := w.w.Write(append(idx, trailer...)); err != nil {
+		return err
+	}
+	w.err = errors.New("seekbundle: writer closed")
+	return nil
+}
+
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// Reader gives random access to the frames of an archive.
+type Reader struct {
+	ra    io.ReaderAt
+	codec Codec
+	index Index
+	byDay map[string]int
+}
+
+// NewReader reads and verifies the trailer and index of the size-byte
+// archive in ra.
+func NewReader(ra io.ReaderAt, size int64) (*Reader, error) {
+	if size < int64(len(magic)+trailerSize) {
+		return nil, ErrFormat
+	}
+	head := make([]byte, len(magic))
+	if _, err := ra.ReadAt(head, 0); err != nil {
+		return nil, err
+	}
+	tr := make([]byte, trailerSize)
+	if _, err := ra.ReadAt(tr, size-trailerSize); err != nil {
+		return nil, err
+	}
+	if string(head) != magic || string(tr[8+sha256.Size:]) != magic {
+		return nil, ErrFormat
+	}
+	n := int64(binary.LittleEndian.Uint64(tr))
+	if n <= 0 || n > size-int64(len(magic)+trailerSize) {
+		return nil, ErrFormat
+	}
+	idx := make([]byte, n)
+	if _, err := ra.ReadAt(idx, size-trailerSize-n); err != nil {
+		return nil, err
+	}
+	if sum := sha256.Sum256(idx); !bytes.Equal(sum[:], tr[8:8+sha256.Size]) {
+		return nil, fmt.Errorf("%w: index", ErrChecksum)
+	}
+	r := &Reader{ra: ra, byDay: map[string]int{}}
+	if err := json.Unmarshal(idx, &r.index); err != nil {
+		return nil, fmt.Errorf("seekbundle: decode index: %w", err)
+	}
+	if r.index.Version != IndexVersion {
+		return nil, fmt.Errorf("seekbundle: unsupported index version %d", r.index.Version)
+	}
+	c, err := lookupCodec(r.index.Codec)
+	if err != nil {
+		return nil, err
+	}
+	r.codec = c
+	for i, f := range r.index.Frames {
+		r.byDay[f.Day] = i
+	}
+	return r, nil
+}
+
+// Index returns the archive's index. The caller must not modify it.
+func (r *Reader) Index() Index { return r.index }
+
+// Day returns the series filed under the UTC day containing t.
+func (r *Reader) Day(t time.Time) ([]types.Series, error) {
+	i, ok := r.byDay[t.UTC().Format(dayLayout)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoDay, t.UTC().Format(dayLayout))
+	}
+	return r.Frame(i)
+}
+
+// Frame decompresses, verifies, and decodes frame i.
+func (r *Reader) Frame(i int) ([]types.Series, error) {
+	raw, err := r.raw(i)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]types.Series, 0, r.index.Frames[i].Series)
+	sc := bufio.NewScanner(bytes.NewReader(raw))
+	sc.Buffer(nil, len(raw)+1)
+	for sc.Scan() {
+		var s types.Series
+		if err := json.Unmarshal(sc.Bytes(), &s); err != nil {
+			return nil, fmt.Errorf("seekbundle: frame %s: %w", r.index.Frames[i].Day, err)
+		}
+		out = append(out, s)
+	}
+	return out, sc.Err()
+}
+
+func (r *Reader) raw(i int) ([]byte, error) {
+	if i < 0 || i >= len(r.index.Frames) {
+		return nil, fmt.Errorf("%w: %d of %d", ErrNoFrame, i, len(r.index.Frames))
+	}
+	f := r.index.Frames[i]
+	zr, err := r.codec.NewReader(io.NewSectionReader(r.ra, f.Offset, f.Length))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	raw, err := io.ReadAll(io.LimitReader(zr, f.RawLength+1))
+	if err != nil {
+		return nil, fmt.Errorf("seekbundle: frame %s: %w", f.Day, err)
+	}
+	sum := sha256.Sum256(raw)
+	if int64(len(raw)) != f.RawLength || hex.EncodeToString(sum[:]) != f.SHA256 {
+		return nil, fmt.Errorf("%w: frame %s", ErrChecksum, f.Day)
+	}
+	return raw, nil
+}
+
+// Verify decompresses every frame and checks its checksum.
+func (r *Reader) Verify() error {
+	for i := range r.index.Frames {
+		if _, err := r.raw(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,89 @@
+package seekbundle
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestRoundTrip(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	series := func(day, hour int, topic string) *types.Series {
+		ts := t0.AddDate(0, 0, day).Add(time.Duration(hour) * time.Hour)
+		return &types.Series{Topic: topic, GeneratedAt: ts.Add(time.Hour), Interval: types.IntervalMinute,
+			Points: []types.Point{{TS: ts, Volume: day*100 + hour}}}
+	}
+
+	for _, codec := range []string{"", "deflate", "none"} {
+		var buf bytes.Buffer
+		w, err := NewWriter(&buf, Options{Codec: codec})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for day := 0; day < 30; day++ {
+			for _, topic := range []string{"#vote", "#ballot"} {
+				if err := w.Add(series(day, day%24, topic)); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+		if err := w.Add(series(3, 0, "#late")); !errors.Is(err, ErrOutOfOrder) {
+			t.Fatalf("out-of-order Add: %v", err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		data := buf.Bytes()
+		r, err := NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if idx := r.Index(); len(idx.Frames) != 30 || idx.Frames[0].Topics[0] != "#ballot" {
+			t.Fatalf("index = %+v", idx)
+		}
+		got, err := r.Day(t0.AddDate(0, 0, 17).Add(13 * time.Hour))
+		if err != nil || len(got) != 2 || got[1].Topic != "#ballot" || got[0].Points[0].Volume != 1717 {
+			t.Fatalf("Day(17) = %+v, %v", got, err)
+		}
+		if _, err := r.Day(t0.AddDate(0, 1, 0)); !errors.Is(err, ErrNoDay) {
+			t.Fatalf("missing day: %v", err)
+		}
+		if err := r.Verify(); err != nil {
+			t.Fatal(err)
+		}
+
+		// Corrupt one byte inside frame 5.
+		f := r.Index().Frames[5]
+		bad := append([]byte(nil), data...)
+		bad[f.Offset+f.Length/2] ^= 0xff
+		rb, err := NewReader(bytes.NewReader(bad), int64(len(bad)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := rb.Frame(4); err != nil {
+			t.Fatalf("undamaged frame: %v", err)
+		}
+		for _, i := range []int{-1, 30} {
+			if _, err := rb.Frame(i); !errors.Is(err, ErrNoFrame) {
+				t.Fatalf("Frame(%d): %v", i, err)
+			}
+		}
+		if err := rb.Verify(); err == nil {
+			t.Fatalf("codec %q: corrupted frame verified", codec)
+		}
+
+		if _, err := NewReader(bytes.NewReader(data[:len(data)-1]), int64(len(data)-1)); !errors.Is(err, ErrFormat) {
+			t.Fatalf("truncated archive: %v", err)
+		}
+	}
+}
+
+func TestUnregisteredCodec(t *testing.T) {
+	if _, err := NewWriter(&bytes.Buffer{}, Options{Codec: "zstd"}); err == nil {
+		t.Fatal("NewWriter accepted an unregistered codec")
+	}
+}
@@ -0,0 +1,5 @@
+// audit/doc.go
+// Package audit records ingestion decisions in an append-only, hash-chained
+// log. Each entry commits to its predecessor, so altering, dropping, or
+// reordering any entry breaks verification of every later one.
+package audit
@@ -0,0 +1,215 @@
+package audit
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+	"github.com/civic-interconnect/civic-transparency-go-types/validate"
+)
+
+// Decision is the outcome recorded for a payload.
+type Decision string
+
+const (
+	Accepted Decision = "accepted"
+	Rejected Decision = "rejected"
+)
+
+// GenesisHash is the PrevHash of the first entry in a log.
+const GenesisHash = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// Entry is one line of the log.
+type Entry struct {
+	Seq         uint64    `json:"seq"`
+	Time        time.Time `json:"time"`
+	ContentHash string    `json:"content_hash"` // hex SHA-256 of the payload bytes
+	// SubmissionID is the submission the payload arrived in, if known.
+	// Omitted when empty, so entries written before it existed still verify.
+	SubmissionID types.SubmissionID `json:"submission_id,omitempty"`
+	// Source is the payload metadata attached to the validation error (see
+	// validate.Options.Source). Omitted when unknown, like SubmissionID.
+	Source     *validate.Source `json:"source,omitempty"`
+	Decision   Decision         `json:"decision"`
+	ErrorCodes []string         `json:"error_codes,omitempty"`
+	PrevHash   string           `json:"prev_hash"`
+	Hash       string           `json:"hash"` // hex SHA-256 of this entry with Hash empty
+}
+
+// computeHash returns the chain hash of e: SHA-256 over its JSON encoding
+// with Hash cleared.
+func (e Entry) computeHash() string {
+	e.Hash = ""
+	b, _ := json.Marshal(e)
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// Log appends entries as JSON lines to an io.Writer. It is safe for
+// concurrent use.
+type Log struct {
+	mu   sync.Mutex
+	w    io.Writer
+	seq  uint64
+	prev string
+	now  func() time.Time
+}
+
+// NewLog starts a new chain writing to w.
+func NewLog(w io.Writer) *Log {
+	return &Log{w: w, prev: GenesisHash, now: time.Now}
+}
+
+// Resume continues the chain after last, the final entry of an existing log
+// (typically obtained from Verify). The zero Entry, which Verify returns for
+// an empty log, starts a new chain as NewLog does.
+func Resume(w io.Writer, last Entry) *Log {
+	if last.Hash == "" {
+		return NewLog(w)
+	}
+	return &Log{w: w, seq: last.Seq + 1, prev: last.Hash, now: time.Now}
+}
+
+// Record appends a decision for payload. A nil err records Accepted;
+// otherwise Rejected with codes taken from err (see ErrorCodes) and the
+// Source the errors carry.
+func (l *Log) Record(payload []byte, err error) (Entry, error) {
+	return l.RecordSubmission("", payload, err)
+}
+
+// RecordSubmission is Record for a payload that arrived as submission id.
+func (l *Log) RecordSubmission(id types.SubmissionID, payload []byte, err error) (Entry, error) {
+	d := Accepted
+	if err != nil {
+		d = Rejected
+	}
+	var src *validate.Source
+	if s, ok := validate.SourceOf(err); ok {
+		src = &s
+	}
+	return l.append(id, src, payload, d, ErrorCodes(err))
+}
+
+// RecordSource is Record with explicit source metadata. Use it for accepted
+// payloads, whose nil error carries no Source.
+func (l *Log) RecordSource(src validate.Source, payload []byte, err error) (Entry, error) {
+	d := Accepted
+	if err != nil {
+		d = Rejected
+	}
+	var sp *validate.Source
+	if !src.IsZero() {
+		sp = &src
+	}
+	return l.append("", sp, payload, d, ErrorCodes(err))
+}
+
+// Append writes an entry with an explicit decision and error codes.
+func (l *Log) Append(payload []byte, d Decision, codes []string) (Entry, error) {
+	return l.append("", nil, payload, d, codes)
+}
+
+func (l *Log) append(id types.SubmissionID, src *validate.Source, payload []byte, d Decision, codes []string) (Entry, error) {
+	sum := sha256.Sum256(payload)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e := Entry{
+		Seq:          l.seq,
+		Time:         l.now().UTC(),
+		ContentHash:  hex.EncodeToString(sum[:]),
+		SubmissionID: id,
+		Source:       src,
+		Decision:     d,
+		ErrorCodes:   codes,
+		PrevHash:     l.prev,
+	}
+	e.Hash = e.computeHash()
+	b, err := json.Marshal(e)
+	if err != nil {
+		return Entry{}, err
+	}
+	if _, err := l.w.Write(append(b, '\n')); err != nil {
+		return Entry{}, err
+	}
+	l.seq++
+	l.prev = e.Hash
+	return e, nil
+}
+
+// ErrorCodes lists the failing field paths of a validation error, or the
+// error text for errors without field information.
+func ErrorCodes(err error) []string {
+	if err == nil {
+		return nil
+	}
+	errs := []error{err}
+	var me *validate.MultiError
+	if errors.As(err, &me) {
+		errs = me.Errors()
+	}
+	var out []string
+	for _, e := range errs {
+		var fe *validate.FieldError
+		if errors.As(e, &fe) {
+			out = append(out, fe.Field)
+		} else {
+			out = append(out, e.Error())
+		}
+	}
+	return out
+}
+
+// Verify reads a log from r and checks sequence numbers and the hash chain.
+// It returns the last entry, which can be passed to Resume. An empty log
+// verifies with a zero Entry.
+func Verify(r io.Reader) (Entry, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 16<<20)
+	var last Entry
+	prev := GenesisHash
+	var n uint64
+	for sc.Scan() {
+		var e Entry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return last, fmt.Errorf("audit: line %d: %w", n+1, err)
+		}
+		switch {
+		case e.Seq != n:
+			return last, fmt.Errorf("audit: line %d: seq %d, want %d", n+1, e.Seq, n)
+		case e.PrevHash != prev:
+			return last, fmt.Errorf("audit: line %d: chain broken", n+1)
+		case e.computeHash() != e.Hash:
+			return last, fmt.Errorf("audit: line %d: entry hash mismatch", n+1)
+		}
+		last, prev = e, e.Hash
+		n++
+	}
+	return last, sc.Err()
+}
+
+// Find returns the entries whose ContentHash matches payload, for resolving
+// disputes about whether a payload was received.
+func Find(r io.Reader, payload []byte) ([]Entry, error) {
+	sum := sha256.Sum256(payload)
+	want := hex.EncodeToString(sum[:])
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 16<<20)
+	var out []Entry
+	for sc.Scan() {
+		var e Entry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return out, err
+		}
+		if e.ContentHash == want {
+			out = append(out, e)
+		}
+	}
+	return out, sc.Err()
+}
@@ -0,0 +1,114 @@
+package audit
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+	"github.com/civic-interconnect/civic-transparency-go-types/validate"
+)
+
+func TestChainVerifyAndTamper(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLog(&buf)
+	l.now = func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+	if _, err := l.Record([]byte(`{"topic":"#a"}`), nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := l.Record([]byte(`{"topic":""}`), errors.New("topic must be non-empty")); err != nil {
+		t.Fatal(err)
+	}
+
+	last, err := Verify(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	if last.Seq != 1 || last.Decision != Rejected {
+		t.Errorf("last = %+v", last)
+	}
+
+	l2 := Resume(&buf, last)
+	if _, err := l2.Record([]byte(`{"topic":"#b"}`), nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Verify(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("Verify after resume: %v", err)
+	}
+
+	tampered := strings.Replace(buf.String(), `"rejected"`, `"accepted"`, 1)
+	if _, err := Verify(strings.NewReader(tampered)); err == nil {
+		t.Error("Verify accepted a tampered log")
+	}
+	lines := strings.SplitAfter(buf.String(), "\n")
+	if _, err := Verify(strings.NewReader(lines[0] + lines[2])); err == nil {
+		t.Error("Verify accepted a log with a dropped entry")
+	}
+
+	found, err := Find(bytes.NewReader(buf.Bytes()), []byte(`{"topic":"#b"}`))
+	if err != nil || len(found) != 1 || found[0].Seq != 2 {
+		t.Errorf("Find = %v, %v", found, err)
+	}
+}
+
+func TestResumeEmptyLog(t *testing.T) {
+	var buf bytes.Buffer
+	last, err := Verify(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := Resume(&buf, last)
+	for _, p := range []string{`{"topic":"#a"}`, `{"topic":"#b"}`} {
+		if _, err := l.Record([]byte(p), nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if last, err = Verify(bytes.NewReader(buf.Bytes())); err != nil || last.Seq != 1 {
+		t.Errorf("Verify after resuming an empty log = %+v, %v", last, err)
+	}
+}
+
+func TestRecordSubmission(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLog(&buf)
+	const id = types.SubmissionID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	e, err := l.RecordSubmission(id, []byte(`{"topic":"#a"}`), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"submission_id":"`+string(id)+`"`) {
+		t.Errorf("log line = %s", buf.String())
+	}
+	if last, err := Verify(bytes.NewReader(buf.Bytes())); err != nil || last.SubmissionID != id || last.Hash != e.Hash {
+		t.Errorf("Verify = %+v, %v", last, err)
+	}
+	tampered := strings.Replace(buf.String(), "FAV", "FAW", 1)
+	if _, err := Verify(strings.NewReader(tampered)); err == nil {
+		t.Error("Verify accepted a changed submission_id")
+	}
+}
+
+func TestRecordCarriesSource(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewLog(&buf)
+	src := validate.Source{Publisher: "p1", Batch: "b-7"}
+	verr := validate.ValidateSeriesWith(&types.Series{}, validate.Options{Source: src})
+	e, err := l.Record([]byte(`{}`), verr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Source == nil || *e.Source != src {
+		t.Errorf("rejected entry source = %v", e.Source)
+	}
+	if e, _ = l.RecordSource(src, []byte(`{"topic":"#a"}`), nil); e.Decision != Accepted || e.Source == nil || e.Source.Batch != "b-7" {
+		t.Errorf("accepted entry = %+v", e)
+	}
+	if e, _ = l.Record([]byte(`{"topic":"#b"}`), nil); e.Source != nil {
+		t.Errorf("entry without source = %+v", e)
+	}
+	if _, err := Verify(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+}
@@ -0,0 +1,292 @@
+package classify
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Level is a field's sensitivity. Higher levels are more sensitive.
+type Level int
+
+const (
+	// Public fields may be published as-is.
+	Public Level = iota
+	// AggregateOnly fields may leave a service only as part of an
+	// aggregate, never per event or per account.
+	AggregateOnly
+	// Restricted fields must not leave the service that produced them.
+	Restricted
+)
+
+var levelNames = [...]string{"public", "aggregate-only", "restricted"}
+
+func (l Level) String() string {
+	if l >= 0 && int(l) < len(levelNames) {
+		return levelNames[l]
+	}
+	return fmt.Sprintf("Level(%d)", int(l))
+}
+
+// ParseLevel parses a level name as written by String.
+func ParseLevel(s string) (Level, error) {
+	for i, n := range levelNames {
+		if s == n {
+			return Level(i), nil
+		}
+	}
+	return 0, fmt.Errorf("classify: unknown level %q", s)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (l Level) MarshalText() ([]byte, error) { return []byte(l.String()), nil }
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (l *Level) UnmarshalText(b []byte) error {
+	v, err := ParseLevel(string(b))
+	*l = v
+	return err
+}
+
+// TagKey is the struct tag consulted for fields missing from a Registry.
+const TagKey = "classify"
+
+// Registry maps struct fields to levels. The zero value is an empty
+// registry ready for use; it is safe for concurrent use.
+type Registry struct {
+	mu sync.RWMutex
+	m  map[reflect.Type]map[string]Level
+}
+
+// Register sets the level of the named field of the struct type of v
+// (a struct value or pointer to one). It panics if there is no such field.
+func (r *Registry) Register(v any, field string, l Level) {
+	t := structType(reflect.TypeOf(v))
+	if t == nil {
+		panic(fmt.Sprintf("classify: %T is not a struct", v))
+	}
+	if _, ok := t.FieldByName(field); !ok {
+		panic(fmt.Sprintf("classify: %s has no field %s", t, field))
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.m == nil {
+		r.m = map[reflect.Type]map[string]Level{}
+	}
+	if r.m[t] == nil {
+		r.m[t] = map[string]Level{}
+	}
+	r.m[t][field] = l
+}
+
+// lookup returns the declared level of f in t, from the registry or its
+// struct tag.
+func (r *Registry) lookup(t reflect.Type, f reflect.StructField) (Level, bool, error) {
+	r.mu.RLock()
+	l, ok := r.m[t][f.Name]
+	r.mu.RUnlock()
+	if ok {
+		return l, true, nil
+	}
+	if tag, ok := f.Tag.Lookup(TagKey); ok {
+		l, err := ParseLevel(tag)
+		if err != nil {
+			return 0, false, fmt.Errorf("%s.%s: %w", t, f.Name, err)
+		}
+		return l, true, nil
+	}
+	return 0, false, nil
+}
+
+// LevelOf returns the level of the named field of v's struct type, or
+// Public if it is not classified.
+func (r *Registry) LevelOf(v any, field string) Level {
+	t := structType(reflect.TypeOf(v))
+	if t == nil {
+		return Public
+	}
+	f, ok := t.FieldByName(field)
+	if !ok {
+		return Public
+	}
+	l, _, _ := r.lookup(t, f)
+	return l
+}
+
+// Filter zeroes every field of *v whose level exceeds max, descending
+// through nested structs, pointers, interfaces, slices, and arrays. v must
+// be a non-nil pointer to a struct. Map values are not descended into.
+//
+// Only *v itself is modified: nested data that needs redacting is copied
+// first, so filtering a shallow copy (tag := *in) never alters the
+// original through a shared pointer or slice.
+func (r *Registry) Filter(v any, max Level) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("classify: Filter needs a non-nil pointer to a struct, got %T", v)
+	}
+	out, changed, err := r.filter(rv.Elem(), Public, max)
+	if err != nil || !changed {
+		return err
+	}
+	rv.Elem().Set(out)
+	return nil
+}
+
+// filter returns v with fields above max zeroed and whether that differs
+// from v. v is never modified; changed containers are copied.
+func (r *Registry) filter(v reflect.Value, inherited, max Level) (reflect.Value, bool, error) {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			break
+		}
+		elem, changed, err := r.filter(v.Elem(), inherited, max)
+		if !changed || err != nil {
+			return v, false, err
+		}
+		p := reflect.New(elem.Type())
+		p.Elem().Set(elem)
+		return p, true, nil
+	case reflect.Interface:
+		if v.IsNil() {
+			break
+		}
+		elem, changed, err := r.filter(v.Elem(), inherited, max)
+		if !changed || err != nil {
+			return v, false, err
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(elem)
+		return out, true, nil
+	case reflect.Slice, reflect.Array:
+		var out reflect.Value
+		for i := 0; i < v.Len(); i++ {
+			elem, changed, err := r.filter(v.Index(i), inherited, max)
+			if err != nil {
+				return v, false, err
+			}
+			if !changed {
+				continue
+			}
+			if !out.IsValid() {
+				out = copyOf(v)
+			}
+			out.Index(i).Set(elem)
+		}
+		if out.IsValid() {
+			return out, true, nil
+		}
+	case reflect.Struct:
+		t := v.Type()
+		var out reflect.Value
+		set := func(i int, x reflect.Value) {
+			if !out.IsValid() {
+				out = copyOf(v)
+			}
+			out.Field(i).Set(x)
+		}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			l, ok, err := r.lookup(t, f)
+			if err != nil {
+				return v, false, err
+			}
+			if !ok {
+				l = inherited
+			}
+			fv := v.Field(i)
+			if l > max {
+				if !fv.IsZero() {
+					set(i, reflect.Zero(f.Type))
+				}
+				continue
+			}
+			x, changed, err := r.filter(fv, l, max)
+			if err != nil {
+				return v, false, err
+			}
+			if changed {
+				set(i, x)
+			}
+		}
+		if out.IsValid() {
+			return out, true, nil
+		}
+	}
+	return v, false, nil
+}
+
+// copyOf returns a settable shallow copy of v, with its own backing array
+// if v is a slice.
+func copyOf(v reflect.Value) reflect.Value {
+	if v.Kind() == reflect.Slice {
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		reflect.Copy(out, v)
+		return out
+	}
+	out := reflect.New(v.Type()).Elem()
+	out.Set(v)
+	return out
+}
+
+// Unclassified returns the exported fields reachable from v's struct type
+// that have no registry entry or tag and no classified ancestor field to
+// inherit from, as "Type.Field" paths in field order. A test asserting it
+// is empty keeps new fields from being published unreviewed.
+func (r *Registry) Unclassified(v any) []string {
+	var out []string
+	seen := map[reflect.Type]bool{}
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		t = structType(t)
+		if t == nil || seen[t] {
+			return
+		}
+		seen[t] = true
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			if _, ok, _ := r.lookup(t, f); !ok {
+				out = append(out, t.Name()+"."+f.Name)
+				walk(f.Type)
+			}
+		}
+	}
+	walk(reflect.TypeOf(v))
+	return out
+}
+
+// structType unwraps pointers, slices, and arrays to a struct type, or
+// returns nil.
+func structType(t reflect.Type) reflect.Type {
+	for t != nil {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array:
+			t = t.Elem()
+		case reflect.Struct:
+			return t
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// Default is the registry used by the package-level functions. It is
+// preloaded with the classification of package types (see defaults.go).
+var Default = &Registry{}
+
+// Filter calls Default.Filter.
+func Filter(v any, max Level) error { return Default.Filter(v, max) }
+
+// LevelOf calls Default.LevelOf.
+func LevelOf(v any, field string) Level { return Default.LevelOf(v, field) }
+
+// Unclassified calls Default.Unclassified.
+func Unclassified(v any) []string { return Default.Unclassified(v) }
@@ -0,0 +1,89 @@
+package classify
+
+import (
+	"testing"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// TestDefaultsComplete fails when a field is added to a classified type
+// without a classification decision.
+func TestDefaultsComplete(t *testing.T) {
+	for _, v := range []any{types.Series{}, types.ProvenanceTag{}, types.CollectorConfig{}, types.DeadLetter{}} {
+		if u := Unclassified(v); len(u) > 0 {
+			t.Errorf("%T: unclassified fields %v", v, u)
+		}
+	}
+}
+
+func TestFilterNested(t *testing.T) {
+	type inner struct {
+		Secret string `classify:"restricted"`
+		Count  int
+	}
+	type outer struct {
+		Name   string
+		Items  []inner
+		Ptr    *inner
+		Hidden inner `classify:"aggregate-only"`
+	}
+	v := outer{Name: "a", Items: []inner{{"s", 1}}, Ptr: &inner{"s", 2}, Hidden: inner{"s", 3}}
+	if err := Filter(&v, AggregateOnly); err != nil {
+		t.Fatal(err)
+	}
+	if v.Name != "a" || v.Items[0] != (inner{Count: 1}) || *v.Ptr != (inner{Count: 2}) || v.Hidden != (inner{Count: 3}) {
+		t.Errorf("aggregate-only: %+v", v)
+	}
+	if err := Filter(&v, Public); err != nil {
+		t.Fatal(err)
+	}
+	if v.Hidden != (inner{}) || v.Items[0].Count != 1 {
+		t.Errorf("public: %+v", v)
+	}
+	if err := Filter(v, Public); err == nil {
+		t.Error("non-pointer accepted")
+	}
+	if got := Unclassified(outer{}); len(got) != 4 || got[0] != "outer.Name" || got[2] != "inner.Count" {
+		t.Errorf("Unclassified = %v", got)
+	}
+}
+
+func TestFilterInterface(t *testing.T) {
+	type inner struct {
+		Secret string `classify:"restricted"`
+		Count  int
+	}
+	type outer struct {
+		Any  any
+		List []any
+	}
+	v := outer{Any: inner{"s", 1}, List: []any{&inner{"s", 2}, "x"}}
+	if err := Filter(&v, AggregateOnly); err != nil {
+		t.Fatal(err)
+	}
+	if v.Any != (inner{Count: 1}) || *v.List[0].(*inner) != (inner{Count: 2}) || v.List[1] != "x" {
+		t.Errorf("interface-held structs: %+v", v)
+	}
+}
+
+func TestFilterShallowCopy(t *testing.T) {
+	type inner struct {
+		Secret string `classify:"restricted"`
+	}
+	type outer struct {
+		Items []inner
+		Ptr   *inner
+		Arr   [1]inner
+	}
+	in := &outer{Items: []inner{{"s"}}, Ptr: &inner{"s"}, Arr: [1]inner{{"s"}}}
+	cp := *in
+	if err := Filter(&cp, Public); err != nil {
+		t.Fatal(err)
+	}
+	if cp.Items[0].Secret != "" || cp.Ptr.Secret != "" || cp.Arr[0].Secret != "" {
+		t.Errorf("copy not redacted: %+v", cp)
+	}
+	if in.Items[0].Secret != "s" || in.Ptr.Secret != "s" || in.Arr[0].Secret != "s" {
+		t.Errorf("original redacted through the copy: %+v %+v", in.Items, *in.Ptr)
+	}
+}
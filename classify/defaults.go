@@ -0,0 +1,34 @@
+package classify
+
+import "github.com/civic-interconnect/civic-transparency-go-types/types"
+
+// The classification of package types. Series and everything in them are
+// aggregates by construction and public. Individual ProvenanceTags describe
+// one post: their enumerations may only be released aggregated, and the
+// dedup hash and origin hint, which can link or locate a post, stay inside
+// the collecting service. Secrets, raw payloads, and reviewer notes are
+// restricted.
+func init() {
+	reg := func(v any, l Level, fields ...string) {
+		for _, f := range fields {
+			Default.Register(v, f, l)
+		}
+	}
+
+	reg(types.Series{}, Public, "Topic", "GeneratedAt", "Interval", "Points", "Annotations",
+		"Retraction", "Extensions", "Tenant", "Jurisdiction", "Methodology")
+
+	reg(types.ProvenanceTag{}, AggregateOnly, "AcctAgeBucket", "AcctType", "AutomationFlag",
+		"PostKind", "ClientFamily", "MediaProvenance", "Extensions")
+	reg(types.ProvenanceTag{}, Restricted, "DedupHash", "OriginHint")
+	reg(types.ProvenanceTag{}, Public, "DedupHashAlg")
+
+	reg(types.CollectorConfig{}, Public, "Interval", "Topics", "DedupHashAlg", "SaltRotation", "Privacy")
+	reg(types.CollectorConfig{}, Restricted, "DedupSalt")
+
+	reg(types.DeadLetter{}, AggregateOnly, "Key", "SubmissionID", "Source", "Stage", "Codes", "Attempts", "FirstSeen", "LastSeen")
+	reg(types.DeadLetter{}, Restricted, "Payload", "Errors")
+
+	reg(types.QuarantineRecord{}, AggregateOnly, "Key", "SubmissionID", "Source", "Reasons", "State", "QuarantinedAt", "DecidedAt")
+	reg(types.QuarantineRecord{}, Restricted, "Payload", "Reviewer", "Note")
+}
@@ -0,0 +1,14 @@
+// classify/doc.go
+// Package classify labels struct fields with a sensitivity level and strips
+// fields above a level before data leaves a trust boundary, so privacy
+// review decisions live in code:
+//
+//	tag := *in
+//	if err := classify.Filter(&tag, classify.AggregateOnly); err != nil { ... }
+//
+// Levels come from a Registry (Default covers package types) or, for
+// fields the registry does not list, from a `classify:"restricted"` struct
+// tag. Fields with neither inherit the level of the field containing them;
+// top-level fields default to Public. Unclassified lists fields that rely
+// on that default, for use in tests.
+package classify
@@ -0,0 +1,30 @@
+package classify_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/classify"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func ExampleFilter() {
+	tag := types.ProvenanceTag{
+		AcctAgeBucket:   types.AcctAge_1_6m,
+		AcctType:        types.AcctTypePerson,
+		AutomationFlag:  types.AutomationManual,
+		PostKind:        types.PostKindReshare,
+		ClientFamily:    types.ClientMobile,
+		MediaProvenance: types.MediaProvNone,
+		DedupHash:       "deadbeef",
+		OriginHint:      "US-CA",
+	}
+	fmt.Println(classify.LevelOf(tag, "OriginHint"))
+
+	_ = classify.Filter(&tag, classify.AggregateOnly)
+	b, _ := json.Marshal(tag)
+	fmt.Println(string(b))
+	// Output:
+	// restricted
+	// {"acct_age_bucket":"1-6m","acct_type":"person","automation_flag":"manual","post_kind":"reshare","client_family":"mobile","media_provenance":"none","dedup_hash":""}
+}
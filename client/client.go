@@ -0,0 +1,318 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// DefaultMaxChunkBytes bounds the encoded size of a chunk when
+// Client.MaxChunkBytes is zero.
+const DefaultMaxChunkBytes = 4 << 20
+
+// ErrChecksumMismatch is returned when the server acknowledges a chunk with
+// a digest that does not match what was sent.
+var ErrChecksumMismatch = errors.New("client: server checksum mismatch")
+
+// Client uploads and fetches Series. Set BaseURL to upload; FetchSeries
+// takes absolute URLs and works with the zero value.
+type Client struct {
+	BaseURL       string       // e.g. "https://ingest.example.org/v1"
+	HTTPClient    *http.Client // nil selects http.DefaultClient
+	MaxChunkBytes int          // zero selects DefaultMaxChunkBytes
+	Retry         RetryPolicy  // zero value selects DefaultRetryPolicy
+	// Header is added to every request (e.g., Authorization).
+	Header http.Header
+	// Cache, if set, keeps fetched documents for conditional requests.
+	Cache FetchCache
+	// Digest, if set, returns the expected hex SHA-256 of the document at
+	// a URL; see ManifestDigests. URLs it does not know are not checked.
+	Digest func(url string) (string, bool)
+}
+
+// RetryPolicy controls exponential backoff for transient failures
+// (network errors, 429, and 5xx responses).
+type RetryPolicy struct {
+	MaxAttempts int           // per request, including the first
+	BaseDelay   time.Duration // delay before the second attempt
+	MaxDelay    time.Duration // cap on any single delay
+}
+
+// DefaultRetryPolicy is used when Client.Retry is the zero value.
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 6, BaseDelay: 500 * time.Millisecond, MaxDelay: 30 * time.Second}
+
+// Manifest is sent on completion so the server can check it holds every chunk.
+type Manifest struct {
+	UploadID string   `json:"upload_id"`
+	Topic    string   `json:"topic"`
+	Chunks   int      `json:"chunks"`
+	SHA256   []string `json:"sha256"` // per-chunk body digests, in order
+}
+
+// ResumeToken identifies an upload so it can be resumed later.
+type ResumeToken struct {
+	UploadID string `json:"upload_id"`
+	Next     int    `json:"next"` // first chunk index not yet acknowledged
+}
+
+// UploadID returns the deterministic upload ID for s.
+func UploadID(s *types.Series) string {
+	h := sha256.Sum256([]byte(s.Topic + "\x00" + s.GeneratedAt.UTC().Format(time.RFC3339Nano)))
+	return hex.EncodeToString(h[:16])
+}
+
+// Chunks splits s into Series whose JSON encoding is at most maxBytes,
+// each carrying the parent's series-level fields and a contiguous run of
+// points. A single point larger than maxBytes still gets its own chunk.
+func Chunks(s *types.Series, maxBytes int) ([][]byte, error) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxChunkBytes
+	}
+	head := *s
+	head.Points = nil
+	empty, err := json.Marshal(head)
+	if err != nil {
+		return nil, err
+	}
+	var out [][]byte
+	start, size := 0, len(empty)
+	flush := func(end int) error {
+		c := head
+		c.Points = s.Points[start:end]
+		b, err := json.Marshal(c)
+		if err != nil {
+			return err
+		}
+		out = append(out, b)
+		start, size = end, len(empty)
+		return nil
+	}
+	for i := range s.Points {
+		pb, err := json.Marshal(s.Points[i])
+		if err != nil {
+			return nil, err
+		}
+		if i > start && size+len(pb)+1 > maxBytes {
+			if err := flush(i); err != nil {
+				return nil, err
+			}
+		}
+		size += len(pb) + 1
+	}
+	if start < len(s.Points) || len(out) == 0 {
+		if err := flush(len(s.Points)); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// Upload sends s, skipping chunks the server already acknowledged. On error
+// the returned token records progress; Upload can simply be called again
+// with the same series to resume.
+func (c *Client) Upload(ctx context.Context, s *types.Series) (ResumeToken, error) {
+	tok := ResumeToken{UploadID: UploadID(s)}
+	chunks, err := Chunks(s, c.MaxChunkBytes)
+	if err != nil {
+		return tok, err
+	}
+	received, err := c.received(ctx, tok.UploadID)
+	if err != nil {
+		return tok, err
+	}
+
+	m := Manifest{UploadID: tok.UploadID, Topic: s.Topic, Chunks: len(chunks)}
+	for i, body := range chunks {
+		sum := sha256.Sum256(body)
+		m.SHA256 = append(m.SHA256, hex.EncodeToString(sum[:]))
+		if received[i] {
+			if tok.Next == i {
+				tok.Next = i + 1
+			}
+			continue
+		}
+		if err := c.putChunk(ctx, tok.UploadID, i, body, sum[:]); err != nil {
+			return tok, fmt.Errorf("client: chunk %d/%d: %w", i+1, len(chunks), err)
+		}
+		if tok.Next == i {
+			tok.Next = i + 1
+		}
+	}
+
+	mb, err := json.Marshal(m)
+	if err != nil {
+		return tok, err
+	}
+	_, err = c.do(ctx, http.MethodPost, c.path("uploads", tok.UploadID, "complete"), mb, nil)
+	return tok, err
+}
+
+func (c *Client) received(ctx context.Context, id string) (map[int]bool, error) {
+	var st struct {
+		Received []int `json:"received"`
+	}
+	resp, err := c.do(ctx, http.MethodGet, c.path("uploads", id), nil, nil)
+	var se *StatusError
+	if errors.As(err, &se) && se.Code == http.StatusNotFound {
+		return map[int]bool{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(resp, &st); err != nil {
+		return nil, fmt.Errorf("client: decode upload status: %w", err)
+	}
+	out := make(map[int]bool, len(st.Received))
+	for _, n := range st.Received {
+		out[n] = true
+	}
+	return out, nil
+}
+
+func (c *Client) putChunk(ctx context.Context, id string, n int, body, sum []byte) error {
+	h := http.Header{}
+	h.Set("Content-Digest", "sha-256=:"+base64.StdEncoding.EncodeToString(sum)+":")
+	resp, err := c.do(ctx, http.MethodPut, c.path("uploads", id, "chunks", strconv.Itoa(n)), body, h)
+	if err != nil {
+		return err
+	}
+	var ack struct {
+		SHA256 string `json:"sha256"`
+	}
+	if err := json.Unmarshal(resp, &ack); err != nil {
+		return fmt.Errorf("decode ack: %w", err)
+	}
+	if ack.SHA256 != hex.EncodeToString(sum) {
+		return ErrChecksumMismatch
+	}
+	return nil
+}
+
+func (c *Client) path(parts ...string) string {
+	u := c.BaseURL
+	for _, p := range parts {
+		u += "/" + url.PathEscape(p)
+	}
+	return u
+}
+
+// StatusError is a non-2xx response that was not retried or exhausted retries.
+type StatusError struct {
+	Code int
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("client: HTTP %d: %s", e.Code, e.Body)
+}
+
+// do performs one logical request with retries and returns the body.
+func (c *Client) do(ctx context.Context, method, u string, body []byte, h http.Header) ([]byte, error) {
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	rp := c.Retry
+	if rp == (RetryPolicy{}) {
+		rp = DefaultRetryPolicy
+	}
+	if rp.MaxAttempts < 1 {
+		rp.MaxAttempts = 1
+	}
+
+	var lastErr error
+	for attempt := 0; attempt < rp.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			d := backoff(rp, attempt, lastErr)
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(d):
+			}
+		}
+		req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		for k, vs := range c.Header {
+			req.Header[k] = vs
+		}
+		for k, vs := range h {
+			req.Header[k] = vs
+		}
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		resp, err := hc.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			lastErr = err
+			continue
+		}
+		rb, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if resp.StatusCode/100 == 2 {
+			return rb, nil
+		}
+		se := &StatusError{Code: resp.StatusCode, Body: string(rb)}
+		if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+			return nil, se
+		}
+		lastErr = &retryAfterError{se, parseRetryAfter(resp.Header.Get("Retry-After"))}
+	}
+	var ra *retryAfterError
+	if errors.As(lastErr, &ra) {
+		return nil, ra.StatusError
+	}
+	return nil, lastErr
+}
+
+type retryAfterError struct {
+	*StatusError
+	after time.Duration
+}
+
+func backoff(rp RetryPolicy, attempt int, last error) time.Duration {
+	var ra *retryAfterError
+	if errors.As(last, &ra) && ra.after > 0 {
+		return ra.after
+	}
+	d := rp.BaseDelay << (attempt - 1)
+	if d <= 0 || (rp.MaxDelay > 0 && d > rp.MaxDelay) {
+		d = rp.MaxDelay
+	}
+	// Full jitter keeps retrying publishers from synchronizing.
+	return time.Duration(rand.Int63n(int64(d) + 1))
+}
+
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+		return time.Duration(n) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
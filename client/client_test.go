@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestUploadRetriesAndResumes(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		chunks   = map[int]bool{}
+		puts     int
+		complete bool
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodGet:
+			got := []int{}
+			for n := range chunks {
+				got = append(got, n)
+			}
+			_ = json.NewEncoder(w).Encode(map[string][]int{"received": got})
+		case r.Method == http.MethodPut:
+			puts++
+			if puts == 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			var n int
+			fmt.Sscanf(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:], "%d", &n)
+			body, _ := io.ReadAll(r.Body)
+			sum := sha256.Sum256(body)
+			chunks[n] = true
+			_ = json.NewEncoder(w).Encode(map[string]string{"sha256": hex.EncodeToString(sum[:])})
+		case strings.HasSuffix(r.URL.Path, "/complete"):
+			complete = true
+		}
+	}))
+	defer srv.Close()
+
+	s := &types.Series{Topic: "#vote", Interval: types.IntervalMinute}
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 20; i++ {
+		s.Points = append(s.Points, types.Point{TS: t0.Add(time.Duration(i) * time.Minute), Volume: i})
+	}
+	c := &Client{BaseURL: srv.URL, MaxChunkBytes: 600, Retry: RetryPolicy{MaxAttempts: 3}}
+
+	chunks[0] = true // pretend an earlier run delivered chunk 0
+	tok, err := c.Upload(context.Background(), s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts, _ := Chunks(s, 600)
+	if len(parts) < 3 {
+		t.Fatalf("want several chunks, got %d", len(parts))
+	}
+	if tok.Next != len(parts) || !complete {
+		t.Errorf("tok.Next = %d, complete = %v; want %d, true", tok.Next, complete, len(parts))
+	}
+	if want := len(parts); puts != want {
+		t.Errorf("puts = %d, want %d (one retry, chunk 0 skipped)", puts, want)
+	}
+}
@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+	"github.com/civic-interconnect/civic-transparency-go-types/validate"
+)
+
+// DiscoveryURL returns the URL of the discovery document for a publisher
+// given as a host name ("example.org") or an origin
+// ("https://example.org:8443"). Bare host names use https.
+func DiscoveryURL(publisher string) (string, error) {
+	if !strings.Contains(publisher, "://") {
+		publisher = "https://" + publisher
+	}
+	u, err := url.Parse(publisher)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return "", fmt.Errorf("client: %q is not a publisher host or origin", publisher)
+	}
+	return u.Scheme + "://" + u.Host + types.DiscoveryPath, nil
+}
+
+// FetchDiscovery fetches a publisher's discovery document with a zero
+// Client.
+func FetchDiscovery(ctx context.Context, publisher string) (*types.Discovery, error) {
+	return (&Client{}).FetchDiscovery(ctx, publisher)
+}
+
+// FetchDiscovery downloads the discovery document of publisher (see
+// DiscoveryURL) with the same retries, caching, and digest checks as
+// FetchSeries, and validates it with validate.ValidateDiscovery. An
+// invalid document is returned together with the validation error, so
+// callers can log what the publisher served.
+func (c *Client) FetchDiscovery(ctx context.Context, publisher string) (*types.Discovery, error) {
+	u, err := DiscoveryURL(publisher)
+	if err != nil {
+		return nil, err
+	}
+	body, err := c.fetch(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	var d types.Discovery
+	if err := json.Unmarshal(body, &d); err != nil {
+		return nil, fmt.Errorf("client: decode %s: %w", u, err)
+	}
+	if err := validate.ValidateDiscovery(&d); err != nil {
+		return &d, fmt.Errorf("client: %s: %w", u, err)
+	}
+	return &d, nil
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+	"github.com/civic-interconnect/civic-transparency-go-types/validate"
+)
+
+func TestFetchDiscovery(t *testing.T) {
+	pub, _, _ := ed25519.GenerateKey(nil)
+	d := types.Discovery{
+		Publisher: types.PublisherRef{ID: "p1"},
+		Versions:  []string{types.SpecVersion},
+		Endpoints: []types.Endpoint{{Rel: types.RelSeries, URL: "https://data.example.org/series"}},
+		Keys:      []types.PublicKey{{ID: "k1", Alg: types.KeyAlgEd25519, Key: base64.StdEncoding.EncodeToString(pub)}},
+		UpdatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != types.DiscoveryPath {
+			http.NotFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(d)
+	}))
+	defer srv.Close()
+
+	got, err := FetchDiscovery(context.Background(), srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u, ok := got.Endpoint(types.RelSeries); !ok || u != "https://data.example.org/series" {
+		t.Errorf("series endpoint = %q, %v", u, ok)
+	}
+	if k, ok := got.Key("k1", d.UpdatedAt); !ok {
+		t.Error("key k1 missing")
+	} else if key, err := k.Ed25519(); err != nil || !key.Equal(pub) {
+		t.Errorf("key k1 = %v, %v", key, err)
+	}
+
+	d.Endpoints[0].URL = "/series"
+	got, err = FetchDiscovery(context.Background(), srv.URL)
+	if validate.CodeOf(err) != validate.CodeURLInvalid || got == nil {
+		t.Errorf("relative endpoint: %v", err)
+	}
+}
+
+func TestDiscoveryURL(t *testing.T) {
+	for in, want := range map[string]string{
+		"example.org":                   "https://example.org/.well-known/civic-transparency",
+		"http://localhost:8080/ignored": "http://localhost:8080/.well-known/civic-transparency",
+	} {
+		if got, err := DiscoveryURL(in); err != nil || got != want {
+			t.Errorf("DiscoveryURL(%q) = %q, %v", in, got, err)
+		}
+	}
+	if _, err := DiscoveryURL("ftp://example.org"); err == nil {
+		t.Error("ftp accepted")
+	}
+}
@@ -0,0 +1,23 @@
+// client/doc.go
+// Package client uploads Series to a transparency endpoint in size-bounded
+// chunks, resuming interrupted uploads and retrying transient failures.
+//
+// Wire protocol, relative to Client.BaseURL:
+//
+//	GET  /uploads/{id}              -> {"received":[0,1,...]}  (404 if unknown)
+//	PUT  /uploads/{id}/chunks/{n}   body: chunk Series JSON
+//	                                 -> {"sha256":"<hex of body>"}
+//	POST /uploads/{id}/complete     body: Manifest JSON
+//
+// Each chunk is a complete Series holding a contiguous run of points. The
+// upload ID is derived from the series topic and generated_at, so a crashed
+// publisher can restart the same upload and skip chunks the server already
+// holds. Chunk requests carry a Content-Digest header (RFC 9530) and the
+// server's reported digest is checked before the chunk counts as sent.
+//
+// FetchSeries is the matching download path for mirrors: it retries,
+// resumes broken transfers with Range requests, revalidates cached copies
+// with ETags, verifies digests from an archive manifest, and decompresses
+// gzip content. FetchDiscovery reads a publisher's well-known discovery
+// document, so its endpoints need not be registered by hand.
+package client
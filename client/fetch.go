@@ -0,0 +1,309 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/archive"
+	"github.com/civic-interconnect/civic-transparency-go-types/codec"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// MaxFetchBytes bounds a fetched document, both as transferred and after
+// decompression.
+const MaxFetchBytes = 256 << 20
+
+// ErrContentMismatch is returned by FetchSeries when a document's SHA-256
+// differs from the digest Client.Digest expects for its URL.
+var ErrContentMismatch = errors.New("client: content does not match expected digest")
+
+// FetchCache stores fetched documents by URL so FetchSeries can make
+// conditional requests. Implementations must be safe for concurrent use.
+type FetchCache interface {
+	// Get returns the entity tag and body stored for url.
+	Get(url string) (etag string, body []byte, ok bool)
+	// Put stores body, which carried etag, for url.
+	Put(url, etag string, body []byte)
+}
+
+// MemoryCache is an in-process FetchCache. The zero value is ready to use.
+type MemoryCache struct {
+	mu sync.RWMutex
+	m  map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	etag string
+	body []byte
+}
+
+// Get implements FetchCache.
+func (c *MemoryCache) Get(url string) (string, []byte, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	e, ok := c.m[url]
+	return e.etag, e.body, ok
+}
+
+// Put implements FetchCache.
+func (c *MemoryCache) Put(url, etag string, body []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.m == nil {
+		c.m = map[string]cacheEntry{}
+	}
+	c.m[url] = cacheEntry{etag, body}
+}
+
+// ManifestDigests returns a Client.Digest function expecting the digests of
+// an archive manifest, with each file published at baseURL + "/" + name.
+func ManifestDigests(m *archive.Manifest, baseURL string) func(url string) (string, bool) {
+	want := make(map[string]string, len(m.Files))
+	for _, f := range m.Files {
+		want[strings.TrimSuffix(baseURL, "/")+"/"+f.Name] = f.SHA256
+	}
+	return func(url string) (string, bool) {
+		d, ok := want[url]
+		return d, ok
+	}
+}
+
+// FetchSeries fetches a published Series with a zero Client: no cache, no
+// digest checks, and DefaultRetryPolicy.
+func FetchSeries(ctx context.Context, url string) (*types.Series, error) {
+	return (&Client{}).FetchSeries(ctx, url)
+}
+
+// FetchSeries downloads and decodes the Series at url, in either codec
+// form. BaseURL is not used.
+//
+// Transient failures are retried per c.Retry. A transfer that breaks
+// mid-body resumes with a Range request validated by If-Range, so large
+// series are not downloaded twice. With c.Cache set, a cached copy is
+// revalidated with If-None-Match and reused on 304 Not Modified. With
+// c.Digest set, the document (after removing any Content-Encoding, before
+// decompressing a gzip file) must match the expected SHA-256. Gzip content,
+// whether a Content-Encoding or a .gz file, is decompressed.
+func (c *Client) FetchSeries(ctx context.Context, url string) (*types.Series, error) {
+	body, err := c.fetch(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	var s types.Series
+	if err := codec.Unmarshal(body, &s); err != nil {
+		return nil, fmt.Errorf("client: decode %s: %w", url, err)
+	}
+	return &s, nil
+}
+
+// partialBody is the part of a response received before a transfer broke.
+type partialBody struct {
+	data     []byte
+	etag     string // strong entity tag, required to resume
+	encoding string // Content-Encoding the bytes are in
+}
+
+func (c *Client) fetch(ctx context.Context, url string) ([]byte, error) {
+	hc := c.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	rp := c.Retry
+	if rp == (RetryPolicy{}) {
+		rp = DefaultRetryPolicy
+	}
+	if rp.MaxAttempts < 1 {
+		rp.MaxAttempts = 1
+	}
+	var (
+		cachedTag string
+		cached    []byte
+		hasCache  bool
+		part      partialBody
+		lastErr   error
+	)
+	if c.Cache != nil {
+		cachedTag, cached, hasCache = c.Cache.Get(url)
+	}
+
+	for attempt := 0; attempt < rp.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(backoff(rp, attempt, lastErr)):
+			}
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		for k, vs := range c.Header {
+			req.Header[k] = vs
+		}
+		// Asking explicitly stops the transport from decoding gzip itself,
+		// which would make byte ranges ambiguous.
+		req.Header.Set("Accept-Encoding", "gzip")
+		resuming := len(part.data) > 0 && part.etag != ""
+		if resuming {
+			req.Header.Set("Range", fmt.Sprintf("bytes=%d-", len(part.data)))
+			req.Header.Set("If-Range", part.etag)
+		} else if hasCache && cachedTag != "" {
+			req.Header.Set("If-None-Match", cachedTag)
+		}
+
+		resp, err := hc.Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			lastErr = err
+			continue
+		}
+		enc := resp.Header.Get("Content-Encoding")
+		switch {
+		case resp.StatusCode == http.StatusNotModified && hasCache:
+			resp.Body.Close()
+			return cached, nil
+		case resp.StatusCode == http.StatusPartialContent:
+			if !resuming || rangeStart(resp.Header.Get("Content-Range")) != len(part.data) || enc != part.encoding {
+				resp.Body.Close()
+				part = partialBody{}
+				lastErr = fmt.Errorf("client: unusable partial response for %s", url)
+				continue
+			}
+		case resp.StatusCode/100 == 2:
+			part = partialBody{etag: strongETag(resp.Header.Get("ETag")), encoding: enc}
+		default:
+			rb, _ := io.ReadAll(io.LimitReader(resp.Body, 4<<10))
+			resp.Body.Close()
+			se := &StatusError{Code: resp.StatusCode, Body: string(rb)}
+			if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+				return nil, se
+			}
+			lastErr = &retryAfterError{se, parseRetryAfter(resp.Header.Get("Retry-After"))}
+			continue
+		}
+		etag := resp.Header.Get("ETag")
+		part.data, err = appendBody(part.data, resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if err == errTooLarge {
+				return nil, err
+			}
+			lastErr = err
+			continue
+		}
+		body, err := c.finish(url, part)
+		if err != nil {
+			return nil, err
+		}
+		if c.Cache != nil && etag != "" {
+			c.Cache.Put(url, etag, body)
+		}
+		return body, nil
+	}
+	var ra *retryAfterError
+	if errors.As(lastErr, &ra) {
+		return nil, ra.StatusError
+	}
+	return nil, lastErr
+}
+
+// finish removes the content encoding, checks the digest, and decompresses
+// a gzip document.
+func (c *Client) finish(url string, part partialBody) ([]byte, error) {
+	doc := part.data
+	var err error
+	switch strings.ToLower(part.encoding) {
+	case "", "identity":
+	case "gzip", "x-gzip":
+		if doc, err = gunzip(doc); err != nil {
+			return nil, fmt.Errorf("client: %s: %w", url, err)
+		}
+	default:
+		return nil, fmt.Errorf("client: %s: unsupported Content-Encoding %q", url, part.encoding)
+	}
+	if c.Digest != nil {
+		if want, ok := c.Digest(url); ok {
+			sum := sha256.Sum256(doc)
+			if got := hex.EncodeToString(sum[:]); !strings.EqualFold(got, want) {
+				return nil, fmt.Errorf("%w: %s has sha256 %s, want %s", ErrContentMismatch, url, got, want)
+			}
+		}
+	}
+	if bytes.HasPrefix(doc, []byte{0x1f, 0x8b}) {
+		if doc, err = gunzip(doc); err != nil {
+			return nil, fmt.Errorf("client: %s: %w", url, err)
+		}
+	}
+	return doc, nil
+}
+
+var errTooLarge = fmt.Errorf("client: document exceeds %d bytes", MaxFetchBytes)
+
+// appendBody appends r to b, failing with errTooLarge once the total
+// exceeds MaxFetchBytes. On a read error the bytes read so far are kept
+// for resumption.
+func appendBody(b []byte, r io.Reader) ([]byte, error) {
+	buf := bytes.NewBuffer(b)
+	_, err := buf.ReadFrom(io.LimitReader(r, MaxFetchBytes+1-int64(len(b))))
+	b = buf.Bytes()
+	if err == nil && int64(len(b)) > MaxFetchBytes {
+		return nil, errTooLarge
+	}
+	return b, err
+}
+
+func gunzip(b []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	out, err := io.ReadAll(io.LimitReader(zr, MaxFetchBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(out) > MaxFetchBytes {
+		return nil, fmt.Errorf("decompressed document exceeds %d bytes", MaxFetchBytes)
+	}
+	return out, nil
+}
+
+// strongETag returns v if it is a strong entity tag, which If-Range
+// requires, and "" otherwise.
+func strongETag(v string) string {
+	if strings.HasPrefix(v, "W/") {
+		return ""
+	}
+	return v
+}
+
+// rangeStart parses the first byte position of a Content-Range header, or
+// returns -1.
+func rangeStart(v string) int {
+	v, ok := strings.CutPrefix(v, "bytes ")
+	if !ok {
+		return -1
+	}
+	first, _, _ := strings.Cut(v, "-")
+	n, err := strconv.Atoi(first)
+	if err != nil {
+		return -1
+	}
+	return n
+}
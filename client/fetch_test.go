@@ -0,0 +1,110 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/archive"
+)
+
+func TestFetchSeriesResumesAndRevalidates(t *testing.T) {
+	var doc bytes.Buffer
+	zw := gzip.NewWriter(&doc)
+	fmt.Fprint(zw, `{"topic":"#t","generated_at":"2025-01-01T01:00:00+01:00","interval":"PT1M","points":[`)
+	for i := 0; i < 500; i++ {
+		if i > 0 {
+			fmt.Fprint(zw, ",")
+		}
+		fmt.Fprintf(zw, `{"ts":"2025-01-01T00:%02d:00Z","volume":%d}`, i%60, i)
+	}
+	fmt.Fprint(zw, `]}`)
+	zw.Close()
+	body := doc.Bytes()
+	sum := sha256.Sum256(body)
+
+	var (
+		mu     sync.Mutex
+		ranges []string
+		gets   int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		gets++
+		n := gets
+		ranges = append(ranges, r.Header.Get("Range"))
+		mu.Unlock()
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if rg := r.Header.Get("Range"); rg != "" && r.Header.Get("If-Range") == `"v1"` {
+			start, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rg, "bytes="), "-"))
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(body)-1, len(body)))
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write(body[start:])
+			return
+		}
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		if n == 1 {
+			w.Write(body[:len(body)/2])
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler) // drop the connection mid-body
+		}
+		w.Write(body)
+	}))
+	defer srv.Close()
+
+	m := &archive.Manifest{Files: []archive.ManifestFile{{Name: "t.json.gz", SHA256: hex.EncodeToString(sum[:])}}}
+	c := &Client{
+		Retry:  RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond},
+		Cache:  &MemoryCache{},
+		Digest: ManifestDigests(m, srv.URL+"/"),
+	}
+	url := srv.URL + "/t.json.gz"
+	s, err := c.FetchSeries(context.Background(), url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Points) != 500 || s.GeneratedAt != time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) {
+		t.Errorf("series = %d points, generated_at %v", len(s.Points), s.GeneratedAt)
+	}
+	if want := fmt.Sprintf("bytes=%d-", len(body)/2); len(ranges) != 2 || ranges[1] != want {
+		t.Errorf("ranges = %q, want second %q", ranges, want)
+	}
+
+	if s, err = c.FetchSeries(context.Background(), url); err != nil || len(s.Points) != 500 {
+		t.Fatalf("revalidated fetch: %v", err)
+	}
+	if gets != 3 {
+		t.Errorf("gets = %d, want 3", gets)
+	}
+
+	m.Files[0].SHA256 = strings.Repeat("0", 64)
+	c.Cache = nil
+	c.Digest = ManifestDigests(m, srv.URL)
+	if _, err := c.FetchSeries(context.Background(), url); !errors.Is(err, ErrContentMismatch) {
+		t.Errorf("err = %v, want ErrContentMismatch", err)
+	}
+}
+
+func TestFetchSeriesStatus(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	defer srv.Close()
+	var se *StatusError
+	if _, err := FetchSeries(context.Background(), srv.URL); !errors.As(err, &se) || se.Code != http.StatusNotFound {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -0,0 +1,96 @@
+// Command ctfmt prints Civic Transparency documents as aligned plain text
+// (see types.Format) for reading and diffing, e.g. in code review or when
+// comparing two versions of a series during an incident.
+//
+// Usage:
+//
+//	ctfmt [-decimals 3] [-nomixes] [file ...]
+//
+// Each file (or standard input) holds a Series in any encoding package
+// codec reads, a CompositeSeries, a ProvenanceTag, or an NDJSON stream of
+// ProvenanceTags. To compare two versions of a series:
+//
+//	diff <(ctfmt old.json) <(ctfmt new.json)
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/codec"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func main() {
+	var opts types.FormatOptions
+	flag.IntVar(&opts.Decimals, "decimals", 3, "fraction digits for ratios")
+	flag.BoolVar(&opts.NoMixes, "nomixes", false, "omit breakdown maps from point lines")
+	flag.Parse()
+
+	names := flag.Args()
+	if len(names) == 0 {
+		names = []string{"-"}
+	}
+	status := 0
+	for _, name := range names {
+		if err := run(os.Stdout, name, opts); err != nil {
+			fmt.Fprintln(os.Stderr, "ctfmt:", err)
+			status = 1
+		}
+	}
+	os.Exit(status)
+}
+
+func run(w io.Writer, name string, opts types.FormatOptions) error {
+	var data []byte
+	var err error
+	if name == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(name)
+	}
+	if err != nil {
+		return err
+	}
+	v, err := decode(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	_, err = io.WriteString(w, types.Format(v, opts))
+	return err
+}
+
+// decode recognizes the document by its top-level members.
+func decode(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	var probe map[string]json.RawMessage
+	if err := dec.Decode(&probe); err != nil {
+		return nil, err
+	}
+	switch {
+	case probe["levels"] != nil:
+		var c types.CompositeSeries
+		err := json.Unmarshal(data, &c)
+		return &c, err
+	case probe["points"] != nil || probe["retraction"] != nil:
+		var s types.Series
+		err := codec.Unmarshal(data, &s)
+		return &s, err
+	case probe["acct_age_bucket"] != nil:
+		var tags []types.ProvenanceTag
+		dec := json.NewDecoder(bytes.NewReader(data))
+		for dec.More() {
+			var t types.ProvenanceTag
+			if err := dec.Decode(&t); err != nil {
+				return nil, fmt.Errorf("tag %d: %w", len(tags), err)
+			}
+			tags = append(tags, t)
+		}
+		return tags, nil
+	}
+	return nil, fmt.Errorf("not a series, composite series, or provenance tag")
+}
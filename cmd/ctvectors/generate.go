@@ -0,0 +1,201 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+	"github.com/civic-interconnect/civic-transparency-go-types/validate"
+)
+
+// FormatVersion is the layout version of manifest.json.
+const FormatVersion = 1
+
+// Manifest indexes a generated vector tree.
+type Manifest struct {
+	FormatVersion int      `json:"format_version"`
+	SpecVersion   string   `json:"spec_version"`
+	Seed          int64    `json:"seed"`
+	Count         int      `json:"count"`
+	Vectors       []Vector `json:"vectors"`
+}
+
+// Vector is one generated document and its expected results.
+type Vector struct {
+	File   string   `json:"file"` // slash-separated, relative to the manifest
+	Kind   string   `json:"kind"` // "series" or "provenance_tag"
+	SHA256 string   `json:"sha256"`
+	Valid  bool     `json:"valid"`
+	Errors []string `json:"errors,omitempty"` // failing field paths, in validator order
+	Codes  []string `json:"codes,omitempty"`  // validate.ErrorCode of each entry in Errors
+}
+
+// Generate writes n vectors of each kind for seed under dir and returns the
+// manifest it wrote.
+func Generate(dir string, seed int64, n int) (*Manifest, error) {
+	r := rand.New(rand.NewSource(seed))
+	m := &Manifest{FormatVersion: FormatVersion, SpecVersion: types.SpecVersion, Seed: seed, Count: n}
+	for i := 1; i <= n; i++ {
+		s := genSeries(r)
+		if err := m.add(dir, "series", i, s, validate.ValidateSeries(s)); err != nil {
+			return nil, err
+		}
+	}
+	for i := 1; i <= n; i++ {
+		t := genTag(r)
+		if err := m.add(dir, "provenance_tag", i, t, validate.ValidateProvenanceTag(t)); err != nil {
+			return nil, err
+		}
+	}
+	if err := writeJSON(filepath.Join(dir, "error_codes.json"), validate.Catalog()); err != nil {
+		return nil, err
+	}
+	return m, writeJSON(filepath.Join(dir, "manifest.json"), m)
+}
+
+func writeJSON(path string, v any) error {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(b, '\n'), 0o644)
+}
+
+func (m *Manifest) add(dir, kind string, i int, v any, verr error) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s/%04d.json", kind, i)
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, b, 0o644); err != nil {
+		return err
+	}
+	sum := sha256.Sum256(b)
+	vec := Vector{File: name, Kind: kind, SHA256: hex.EncodeToString(sum[:]), Valid: verr == nil}
+	var me *validate.MultiError
+	if errors.As(verr, &me) {
+		for _, e := range me.Errors() {
+			var fe *validate.FieldError
+			if errors.As(e, &fe) {
+				vec.Errors = append(vec.Errors, fe.Field)
+				vec.Codes = append(vec.Codes, string(fe.Code))
+			}
+		}
+	}
+	m.Vectors = append(m.Vectors, vec)
+	return nil
+}
+
+var epoch = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// prob returns a probability with three decimals, so documents carry short
+// decimal literals that every JSON library round-trips exactly.
+func prob(r *rand.Rand) types.Probability {
+	return types.Probability(math.Round(r.Float64()*1000) / 1000)
+}
+
+// split returns k shares over keys summing to exactly 1 at three decimals.
+func split[K ~string](r *rand.Rand, keys []K, k int) map[K]types.Probability {
+	out := make(map[K]types.Probability, k)
+	left := 1000
+	for i, j := range r.Perm(len(keys))[:k] {
+		v := left
+		if i < k-1 {
+			v = r.Intn(left + 1)
+		}
+		out[keys[j]] = types.Probability(float64(v) / 1000)
+		left -= v
+	}
+	return out
+}
+
+func pick[T any](r *rand.Rand, vs []T) T { return vs[r.Intn(len(vs))] }
+
+// faulty reports whether the next document gets an injected fault.
+func faulty(r *rand.Rand) bool { return r.Intn(3) == 0 }
+
+func genSeries(r *rand.Rand) *types.Series {
+	start := epoch.Add(time.Duration(r.Intn(60*24*30)) * time.Minute)
+	n := 1 + r.Intn(5)
+	s := &types.Series{
+		Topic:       pick(r, []string{"#vote", "#election2025", "#débat", "#ballot_access"}),
+		GeneratedAt: start.Add(time.Duration(n+r.Intn(60)) * time.Minute),
+		Interval:    types.IntervalMinute,
+	}
+	ages := []string{"0-7d", "8-30d", "1-6m", "6-24m", "24m+"}
+	for i := 0; i < n; i++ {
+		vol := r.Intn(500)
+		p := types.Point{
+			TS:                  start.Add(time.Duration(i) * time.Minute),
+			Volume:              vol,
+			ReshareRatio:        prob(r),
+			RecycledContentRate: prob(r),
+			AcctAgeMix:          split(r, ages, 1+r.Intn(len(ages))),
+			AcctTypeShares:      split(r, types.AcctTypeValues(), 1+r.Intn(3)),
+			CoordinationSignals: types.CoordinationSignals{
+				BurstScore:          prob(r),
+				SynchronyIndex:      prob(r),
+				DuplicationClusters: r.Intn(vol + 1),
+			},
+		}
+		s.Points = append(s.Points, p)
+	}
+	if faulty(r) {
+		p := &s.Points[r.Intn(n)]
+		switch r.Intn(6) {
+		case 0:
+			p.Volume = -1 - r.Intn(10)
+		case 1:
+			p.ReshareRatio = 1 + prob(r) + 0.001
+		case 2:
+			p.CoordinationSignals.BurstScore = -prob(r) - 0.001
+		case 3:
+			p.AcctAgeMix["24m+"] += 0.25
+		case 4:
+			s.Topic = ""
+		case 5:
+			p.CoordinationSignals.DuplicationClusters = p.Volume + 1
+		}
+	}
+	return s
+}
+
+func genTag(r *rand.Rand) *types.ProvenanceTag {
+	t := &types.ProvenanceTag{
+		AcctAgeBucket:   pick(r, types.AcctAgeValues()),
+		AcctType:        pick(r, types.AcctTypeValues()),
+		AutomationFlag:  pick(r, types.AutomationFlagValues()),
+		PostKind:        pick(r, types.PostKindValues()),
+		ClientFamily:    pick(r, types.ClientFamilyValues()),
+		MediaProvenance: pick(r, types.MediaProvenanceValues()),
+		DedupHash:       types.HexHash8(fmt.Sprintf("%08x", r.Uint32())),
+	}
+	if r.Intn(2) == 0 {
+		t.OriginHint = pick(r, []string{"US", "US-CA", "DE", "BR-SP"})
+	}
+	if faulty(r) {
+		switch r.Intn(4) {
+		case 0:
+			t.AcctType = "bot"
+		case 1:
+			t.DedupHash = types.HexHash8(fmt.Sprintf("%08X", r.Uint32()|0xA0000000))
+		case 2:
+			t.OriginHint = "usa"
+		case 3:
+			t.PostKind = ""
+		}
+	}
+	return t
+}
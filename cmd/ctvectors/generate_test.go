@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestCheckedInVectors regenerates the checked-in tree and requires it to be
+// byte-identical, so a change to the types or validators that alters any
+// vector must be accompanied by regenerated vectors:
+//
+//	go run ./cmd/ctvectors
+func TestCheckedInVectors(t *testing.T) {
+	want := filepath.Join("..", "..", "testdata", "vectors")
+	b, err := os.ReadFile(filepath.Join(want, "manifest.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m Manifest
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatal(err)
+	}
+
+	got := t.TempDir()
+	if _, err := Generate(got, m.Seed, m.Count); err != nil {
+		t.Fatal(err)
+	}
+	files := []string{"manifest.json", "error_codes.json"}
+	for _, v := range m.Vectors {
+		files = append(files, filepath.FromSlash(v.File))
+	}
+	for _, f := range files {
+		a, err := os.ReadFile(filepath.Join(want, f))
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := os.ReadFile(filepath.Join(got, f))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(a, b) {
+			t.Errorf("%s differs from a fresh run; regenerate with go run ./cmd/ctvectors", f)
+		}
+	}
+}
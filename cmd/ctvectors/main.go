@@ -0,0 +1,43 @@
+// Command ctvectors writes seed-reproducible JSON test vectors for the
+// Civic Transparency types, with each document's canonical SHA-256 and the
+// Go validators' verdict, so sibling implementations (Python, TypeScript)
+// can check that they parse, re-encode, and validate identically.
+//
+// Usage:
+//
+//	ctvectors [-seed 1] [-n 24] [-out testdata/vectors]
+//
+// Layout written under -out:
+//
+//	manifest.json              generator inputs and one entry per vector
+//	error_codes.json           the validate.ErrorCode catalog
+//	series/NNNN.json           Series documents
+//	provenance_tag/NNNN.json   ProvenanceTag documents
+//
+// Every document is canonical JSON: the encoding/json output of the Go type
+// (schema field order, map keys sorted, no insignificant whitespace). A
+// conforming implementation decodes each file, re-encodes it canonically,
+// and must reproduce the manifest's sha256 byte for byte, and must accept
+// exactly the vectors marked valid, reporting the listed error fields and
+// codes for the rest. The same seed and count always produce the same tree.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	seed := flag.Int64("seed", 1, "random seed")
+	n := flag.Int("n", 24, "number of vectors of each kind")
+	out := flag.String("out", "testdata/vectors", "output directory")
+	flag.Parse()
+
+	m, err := Generate(*out, *seed, *n)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "ctvectors:", err)
+		os.Exit(1)
+	}
+	fmt.Printf("wrote %d vectors to %s\n", len(m.Vectors), *out)
+}
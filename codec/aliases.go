@@ -0,0 +1,151 @@
+package codec
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// Aliases maps legacy enum spellings to schema values, per field. The outer
+// key is the schema field name (e.g., "automation_flag"), the inner map goes
+// from legacy value to canonical value. Lookups are exact first, then
+// case-insensitive.
+type Aliases map[string]map[string]string
+
+// DefaultAliases covers spellings seen in legacy platform exports.
+func DefaultAliases() Aliases {
+	return Aliases{
+		"acct_type": {
+			"gov":          string(types.AcctTypePublicOfficial),
+			"government":   string(types.AcctTypePublicOfficial),
+			"official":     string(types.AcctTypePublicOfficial),
+			"bot":          string(types.AcctTypeDeclaredAutomation),
+			"individual":   string(types.AcctTypePerson),
+			"organization": string(types.AcctTypeOrg),
+		},
+		"automation_flag": {
+			"apiclient": string(types.AutomationAPICLIENT),
+			"api":       string(types.AutomationAPICLIENT),
+			"bot":       string(types.AutomationDeclaredBot),
+		},
+		"client_family": {
+			"third_party": string(types.ClientThirdParty),
+			"api":         string(types.ClientThirdParty),
+			"app":         string(types.ClientMobile),
+		},
+		"post_kind": {
+			"retweet": string(types.PostKindReshare),
+			"repost":  string(types.PostKindReshare),
+			"share":   string(types.PostKindReshare),
+		},
+		"media_provenance": {
+			"c2pa": string(types.MediaProvC2PA),
+			"hash": string(types.MediaProvHash),
+		},
+	}
+}
+
+// Resolve returns the canonical value for v in field, or v unchanged.
+func (a Aliases) Resolve(field, v string) string {
+	m := a[field]
+	if c, ok := m[v]; ok {
+		return c
+	}
+	for k, c := range m {
+		if strings.EqualFold(k, v) {
+			return c
+		}
+	}
+	return v
+}
+
+// mixFields maps Point breakdown members to the enum field their keys use.
+var mixFields = map[string]string{
+	"acct_age_mix":   "acct_age_bucket",
+	"automation_mix": "automation_flag",
+	"client_mix":     "client_family",
+}
+
+// TagDecoder reads ProvenanceTag values, rewriting aliased enum values.
+type TagDecoder struct {
+	dec     *json.Decoder
+	aliases Aliases
+}
+
+// NewTagDecoder returns a TagDecoder reading from r with the given aliases.
+func NewTagDecoder(r io.Reader, aliases Aliases) *TagDecoder {
+	return &TagDecoder{dec: json.NewDecoder(r), aliases: aliases}
+}
+
+// Decode reads the next JSON value into t.
+func (d *TagDecoder) Decode(t *types.ProvenanceTag) error {
+	var raw map[string]json.RawMessage
+	if err := d.dec.Decode(&raw); err != nil {
+		return err
+	}
+	for field := range d.aliases {
+		if err := d.rewrite(raw, field); err != nil {
+			return err
+		}
+	}
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, t)
+}
+
+func (d *TagDecoder) rewrite(raw map[string]json.RawMessage, field string) error {
+	v, ok := raw[field]
+	if !ok {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(v, &s); err != nil {
+		return nil // leave non-strings for the typed decode to report
+	}
+	b, err := json.Marshal(d.aliases.Resolve(field, s))
+	raw[field] = b
+	return err
+}
+
+// SeriesDecoder reads Series values, rewriting aliased keys in the
+// per-point breakdowns. Shares whose keys collapse onto the same canonical
+// value are summed.
+type SeriesDecoder struct {
+	dec     *json.Decoder
+	aliases Aliases
+}
+
+// NewSeriesDecoder returns a SeriesDecoder reading from r with the given aliases.
+func NewSeriesDecoder(r io.Reader, aliases Aliases) *SeriesDecoder {
+	return &SeriesDecoder{dec: json.NewDecoder(r), aliases: aliases}
+}
+
+// Decode reads the next JSON value into s.
+func (d *SeriesDecoder) Decode(s *types.Series) error {
+	if err := d.dec.Decode(s); err != nil {
+		return err
+	}
+	for i := range s.Points {
+		p := &s.Points[i]
+		p.AcctAgeMix = d.remap("acct_age_mix", p.AcctAgeMix)
+		p.AutomationMix = d.remap("automation_mix", p.AutomationMix)
+		p.ClientMix = d.remap("client_mix", p.ClientMix)
+	}
+	return nil
+}
+
+func (d *SeriesDecoder) remap(mix string, m map[string]types.Probability) map[string]types.Probability {
+	field := mixFields[mix]
+	if len(m) == 0 || len(d.aliases[field]) == 0 {
+		return m
+	}
+	out := make(map[string]types.Probability, len(m))
+	for k, v := range m {
+		out[d.aliases.Resolve(field, k)] += v
+	}
+	return out
+}
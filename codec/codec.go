@@ -0,0 +1,318 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// Format selects a Series encoding. Verbose or Compact may be combined
+// with the Quantized flag.
+type Format int
+
+const (
+	// Verbose is the schema's object-per-point form.
+	Verbose Format = iota
+	// Compact encodes points as positional arrays under a header.
+	Compact
+)
+
+// CompactEncoding is the value of the "encoding" member in compact documents.
+const CompactEncoding = "compact"
+
+// CompactFields is the column header written by the compact encoder.
+var CompactFields = []string{
+	"ts", "volume", "reshare_ratio", "recycled_content_rate",
+	"acct_age_mix", "automation_mix", "client_mix",
+	"burst_score", "synchrony_index", "duplication_clusters", "backfilled", "acct_type_shares",
+	"post_kind_mix",
+}
+
+type compactSeries struct {
+	Encoding     string             `json:"encoding"`
+	Topic        string             `json:"topic"`
+	GeneratedAt  time.Time          `json:"generated_at"`
+	Interval     types.Interval     `json:"interval"`
+	Fields       []string           `json:"fields"`
+	Points       [][]any            `json:"points"`
+	Annotations  []types.Annotation `json:"annotations,omitempty"`
+	Retraction   *types.Retraction  `json:"retraction,omitempty"`
+	Extensions   types.Extensions   `json:"extensions,omitempty"`
+	Tenant       string             `json:"tenant,omitempty"`
+	Jurisdiction string             `json:"jurisdiction,omitempty"`
+	Methodology  *types.Methodology `json:"methodology,omitempty"`
+}
+
+// Encoder writes Series to an io.Writer in the selected Format.
+type Encoder struct {
+	enc    *json.Encoder
+	format Format
+	floats *FloatFormat
+}
+
+// NewEncoder returns an Encoder writing format to w.
+func NewEncoder(w io.Writer, format Format) *Encoder {
+	return &Encoder{enc: json.NewEncoder(w), format: format}
+}
+
+// SetIndent configures indentation as for json.Encoder.SetIndent.
+func (e *Encoder) SetIndent(prefix, indent string) { e.enc.SetIndent(prefix, indent) }
+
+// Encode writes s followed by a newline.
+func (e *Encoder) Encode(s *types.Series) error {
+	if e.format&ProtoJSON != 0 {
+		b, err := Marshal(s, e.format)
+		if err != nil {
+			return err
+		}
+		if e.floats != nil {
+			if b, err = formatFloats(b, reflect.Value{}, *e.floats); err != nil {
+				return err
+			}
+		}
+		return e.enc.Encode(json.RawMessage(b))
+	}
+	if e.floats != nil {
+		var v any = s
+		if e.format&Compact != 0 {
+			v = toCompact(s)
+		}
+		b, err := marshalFormat(v, e.format)
+		if err != nil {
+			return err
+		}
+		rv := reflect.ValueOf(v)
+		if e.format&Quantized != 0 {
+			rv = reflect.Value{} // re-encoded from a tree; format untyped
+		}
+		if b, err = formatFloats(b, rv, *e.floats); err != nil {
+			return err
+		}
+		return e.enc.Encode(json.RawMessage(b))
+	}
+	if e.format&Quantized == 0 {
+		if e.format&Compact != 0 {
+			return e.enc.Encode(toCompact(s))
+		}
+		return e.enc.Encode(s)
+	}
+	b, err := Marshal(s, e.format)
+	if err != nil {
+		return err
+	}
+	return e.enc.Encode(json.RawMessage(b))
+}
+
+// Marshal returns the encoding of s in format.
+func Marshal(s *types.Series, format Format) ([]byte, error) {
+	if format&ProtoJSON != 0 {
+		return marshalProtoJSON(s, format)
+	}
+	if format&Compact != 0 {
+		return marshalFormat(toCompact(s), format)
+	}
+	return marshalFormat(s, format)
+}
+
+// ErrNonUTC is returned by UnmarshalWith, when DecodeOptions.RejectNonUTC
+// is set, for a document with a timestamp not in UTC.
+var ErrNonUTC = errors.New("codec: timestamp not in UTC")
+
+// DecodeOptions tunes UnmarshalWith. The zero value is what Unmarshal uses.
+type DecodeOptions struct {
+	// RejectNonUTC fails decoding when any timestamp has a non-zero UTC
+	// offset (e.g. "2025-01-01T02:00:00+02:00"), instead of converting it.
+	// Use it on ingestion paths that should push publishers to fix their
+	// clocks rather than silently repair them.
+	RejectNonUTC bool
+	// ProtoJSON decodes documents written with the ProtoJSON format,
+	// converting them to the canonical form first (see FromProtoJSON).
+	ProtoJSON bool
+	// DisallowUnknownFields rejects members Series lacks, as
+	// json.Decoder.DisallowUnknownFields does, and compact columns not in
+	// CompactFields. Quantized "_bp" members are recognized either way.
+	DisallowUnknownFields bool
+}
+
+// Unmarshal decodes either form into s, detecting the compact form by its
+// "encoding" member. Compact columns are matched by header name, so unknown
+// columns are ignored and reordered headers are accepted. Quantized ratios
+// are converted back to Probability in either form. Timestamps are
+// converted to UTC (see types.Series.NormalizeUTC).
+func Unmarshal(data []byte, s *types.Series) error {
+	return UnmarshalWith(data, s, DecodeOptions{})
+}
+
+// UnmarshalWith is Unmarshal with caller-supplied DecodeOptions.
+func UnmarshalWith(data []byte, s *types.Series, opts DecodeOptions) error {
+	if opts.ProtoJSON {
+		var err error
+		if data, err = FromProtoJSON(data, s); err != nil {
+			return err
+		}
+	}
+	if err := unmarshal(data, s, opts.DisallowUnknownFields); err != nil {
+		return err
+	}
+	if opts.RejectNonUTC {
+		if f := s.NonUTCField(); f != "" {
+			return fmt.Errorf("%w: %s", ErrNonUTC, f)
+		}
+	}
+	s.NormalizeUTC()
+	return nil
+}
+
+func unmarshal(data []byte, s *types.Series, strict bool) error {
+	data, err := dequantize(data)
+	if err != nil {
+		return err
+	}
+	var probe struct {
+		Encoding string `json:"encoding"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return err
+	}
+	if probe.Encoding == "" {
+		return decodeJSON(data, s, strict)
+	}
+	if probe.Encoding != CompactEncoding {
+		return fmt.Errorf("codec: unknown encoding %q", probe.Encoding)
+	}
+
+	var c struct {
+		Encoding     string              `json:"encoding"`
+		Topic        string              `json:"topic"`
+		GeneratedAt  time.Time           `json:"generated_at"`
+		Interval     types.Interval      `json:"interval"`
+		Fields       []string            `json:"fields"`
+		Points       [][]json.RawMessage `json:"points"`
+		Annotations  []types.Annotation  `json:"annotations"`
+		Retraction   *types.Retraction   `json:"retraction"`
+		Extensions   types.Extensions    `json:"extensions"`
+		Tenant       string              `json:"tenant"`
+		Jurisdiction string              `json:"jurisdiction"`
+		Methodology  *types.Methodology  `json:"methodology"`
+	}
+	if err := decodeJSON(data, &c, strict); err != nil {
+		return err
+	}
+	*s = types.Series{
+		Topic:        c.Topic,
+		GeneratedAt:  c.GeneratedAt,
+		Interval:     c.Interval,
+		Points:       make([]types.Point, len(c.Points)),
+		Annotations:  c.Annotations,
+		Retraction:   c.Retraction,
+		Extensions:   c.Extensions,
+		Tenant:       c.Tenant,
+		Jurisdiction: c.Jurisdiction,
+		Methodology:  c.Methodology,
+	}
+	for i, row := range c.Points {
+		if len(row) != len(c.Fields) {
+			return fmt.Errorf("codec: points[%d] has %d columns, header has %d", i, len(row), len(c.Fields))
+		}
+		p := &s.Points[i]
+		for j, name := range c.Fields {
+			dst := column(p, name)
+			if dst == nil {
+				if strict {
+					return fmt.Errorf("codec: unknown compact column %q", name)
+				}
+				continue
+			}
+			if err := json.Unmarshal(row[j], dst); err != nil {
+				return fmt.Errorf("codec: points[%d].%s: %w", i, name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// decodeJSON is json.Unmarshal, rejecting unknown members if strict.
+func decodeJSON(data []byte, v any, strict bool) error {
+	if !strict {
+		return json.Unmarshal(data, v)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("codec: invalid data after top-level value")
+	}
+	return nil
+}
+
+func toCompact(s *types.Series) compactSeries {
+	c := compactSeries{
+		Encoding:     CompactEncoding,
+		Topic:        s.Topic,
+		GeneratedAt:  s.GeneratedAt,
+		Interval:     s.Interval,
+		Fields:       CompactFields,
+		Points:       make([][]any, len(s.Points)),
+		Annotations:  s.Annotations,
+		Retraction:   s.Retraction,
+		Extensions:   s.Extensions,
+		Tenant:       s.Tenant,
+		Jurisdiction: s.Jurisdiction,
+		Methodology:  s.Methodology,
+	}
+	for i := range s.Points {
+		c.Points[i] = compactRow(&s.Points[i])
+	}
+	return c
+}
+
+// compactRow returns the compact columns of p, in CompactFields order.
+func compactRow(p *types.Point) []any {
+	row := make([]any, len(CompactFields))
+	for j, name := range CompactFields {
+		row[j] = column(p, name)
+	}
+	return row
+}
+
+// column returns a pointer to the Point member named by a compact header
+// entry, or nil if the name is unknown.
+func column(p *types.Point, name string) any {
+	switch name {
+	case "ts":
+		return &p.TS
+	case "volume":
+		return &p.Volume
+	case "reshare_ratio":
+		return &p.ReshareRatio
+	case "recycled_content_rate":
+		return &p.RecycledContentRate
+	case "acct_age_mix":
+		return &p.AcctAgeMix
+	case "automation_mix":
+		return &p.AutomationMix
+	case "client_mix":
+		return &p.ClientMix
+	case "burst_score":
+		return &p.CoordinationSignals.BurstScore
+	case "synchrony_index":
+		return &p.CoordinationSignals.SynchronyIndex
+	case "duplication_clusters":
+		return &p.CoordinationSignals.DuplicationClusters
+	case "backfilled":
+		return &p.Backfilled
+	case "acct_type_shares":
+		return &p.AcctTypeShares
+	case "post_kind_mix":
+		return &p.PostKindMix
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package codec
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestCompactRetraction(t *testing.T) {
+	s := weekSeries(0)
+	s.Points = []types.Point{}
+	s.Retraction = &types.Retraction{Topic: s.Topic, Reason: types.RetractionPrivacy, EffectiveAt: s.GeneratedAt}
+	for _, format := range []Format{Compact, Compact | Quantized} {
+		b, err := Marshal(s, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got types.Series
+		if err := Unmarshal(b, &got); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(&got, s) {
+			t.Errorf("%s round trip:\ngot  %+v\nwant %+v", formatName(format), got, *s)
+		}
+	}
+}
+
+func TestDisallowUnknownFields(t *testing.T) {
+	strict := DecodeOptions{DisallowUnknownFields: true}
+	s := weekSeries(2)
+	for _, format := range []Format{Verbose, Verbose | Quantized, Compact, Compact | Quantized} {
+		b, err := Marshal(s, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got types.Series
+		if err := UnmarshalWith(b, &got, strict); err != nil {
+			t.Errorf("%s: %v", formatName(format), err)
+		}
+	}
+	for _, doc := range []string{
+		`{"topic":"#vote","interval":"PT1M","points":[],"colour":"red"}`,
+		`{"topic":"#vote","interval":"PT1M","points":[{"ts":"2025-01-01T00:00:00Z","volume":1,"reach":9}]}`,
+		`{"encoding":"compact","topic":"#vote","interval":"PT1M","fields":["ts","reach"],"points":[["2025-01-01T00:00:00Z",9]]}`,
+		`{"topic":"#vote","interval":"PT1M","points":[]} {}`,
+	} {
+		var got types.Series
+		if err := UnmarshalWith([]byte(doc), &got, strict); err == nil {
+			t.Errorf("strict %s: accepted", doc)
+		}
+	}
+}
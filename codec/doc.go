@@ -0,0 +1,25 @@
+// codec/doc.go
+// Package codec encodes Series in either the verbose schema form or a
+// compact form where points are positional arrays described by a header.
+//
+// The compact form is a JSON object with the Series-level fields, an
+// "encoding":"compact" marker, a "fields" header naming each column, and
+// "points" as an array of rows. It is roughly half the size of the verbose
+// form for typical series and is meant for public endpoints; the verbose
+// form remains the canonical, debuggable representation.
+//
+// MarshalParallel and ParallelEncoder marshal chunks of points on several
+// cores and stitch them in order, producing the same bytes as Marshal and
+// Encoder for series too long to encode quickly on one.
+//
+// The ProtoJSON format and ToProtoJSON/FromProtoJSON convert losslessly
+// between the canonical form and protobuf's JSON conventions (camelCase
+// members, SCREAMING_SNAKE enum values, Duration strings).
+//
+// DecodeLegacySeries and DecodeLegacyTag accept documents from publishers
+// still using pre-1.0 member names and report which names they translated.
+//
+// Decoders convert every timestamp to UTC, so series decoded from
+// publishers in different zones compare, hash, and merge consistently;
+// UnmarshalWith can reject non-UTC offsets instead.
+package codec
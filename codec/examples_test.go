@@ -0,0 +1,162 @@
+package codec_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/codec"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func ExampleEncoder() {
+	s := &types.Series{
+		Topic:       "#vote",
+		GeneratedAt: time.Date(2025, 1, 1, 0, 5, 0, 0, time.UTC),
+		Interval:    types.IntervalMinute,
+		Points: []types.Point{{
+			TS:           time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			Volume:       12,
+			ReshareRatio: 0.25,
+			AcctAgeMix:   map[string]types.Probability{"24m+": 1},
+		}},
+	}
+	_ = codec.NewEncoder(os.Stdout, codec.Compact).Encode(s)
+
+	b, _ := codec.Marshal(s, codec.Compact)
+	var back types.Series
+	_ = codec.Unmarshal(b, &back)
+	fmt.Println(back.Points[0].Volume, back.Points[0].ReshareRatio)
+	// Output:
+	// {"encoding":"compact","topic":"#vote","generated_at":"2025-01-01T00:05:00Z","interval":"minute","fields":["ts","volume","reshare_ratio","recycled_content_rate","acct_age_mix","automation_mix","client_mix","burst_score","synchrony_index","duplication_clusters","backfilled","acct_type_shares","post_kind_mix"],"points":[["2025-01-01T00:00:00Z",12,0.25,0,{"24m+":1},null,null,0,0,0,false,null,null]]}
+	// 12 0.25
+}
+
+func ExampleTagDecoder() {
+	in := strings.NewReader(`{"acct_type":"gov","automation_flag":"APIClient","post_kind":"retweet","dedup_hash":"deadbeef"}`)
+	var tag types.ProvenanceTag
+	_ = codec.NewTagDecoder(in, codec.DefaultAliases()).Decode(&tag)
+	fmt.Println(tag.AcctType, tag.AutomationFlag, tag.PostKind)
+	// Output: public_official api_client reshare
+}
+
+func ExampleQuantized() {
+	s := &types.Series{
+		Topic:       "#vote",
+		GeneratedAt: time.Date(2025, 1, 1, 0, 5, 0, 0, time.UTC),
+		Interval:    types.IntervalMinute,
+		Points: []types.Point{{
+			TS:           time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			Volume:       12,
+			ReshareRatio: 0.25,
+			AcctAgeMix:   map[string]types.Probability{"24m+": 0.6, "1-6m": 0.4},
+		}},
+	}
+	b, _ := codec.Marshal(s, codec.Verbose|codec.Quantized)
+	fmt.Println(strings.Contains(string(b), `"reshare_ratio_bp":2500`))
+	fmt.Println(strings.Contains(string(b), `"acct_age_mix_bp":{"1-6m":4000,"24m+":6000}`))
+
+	var back types.Series
+	_ = codec.Unmarshal(b, &back)
+	fmt.Println(back.Points[0].ReshareRatio, back.Points[0].AcctAgeMix["1-6m"])
+
+	b, _ = codec.Marshal(s, codec.Compact|codec.Quantized)
+	back = types.Series{}
+	_ = codec.Unmarshal(b, &back)
+	fmt.Println(back.Points[0].ReshareRatio, back.Points[0].AcctAgeMix["24m+"])
+	// Output:
+	// true
+	// true
+	// 0.25 0.4
+	// 0.25 0.6
+}
+
+func ExampleDecodeLegacySeries() {
+	in := []byte(`{"topic":"#vote","generatedAt":"2025-01-01T00:05:00Z","interval":"minute","points":[
+		{"ts":"2025-01-01T00:00:00Z","volume":4,"reshareRate":0.5,"coordinationSignals":{"burstScore":0.2}},
+		{"ts":"2025-01-01T00:01:00Z","volume":6,"reshareRate":0.1,"reshare_ratio":0.25}]}`)
+	var s types.Series
+	rep, err := codec.DecodeLegacySeries(in, &s, codec.DefaultLegacyFields())
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(s.GeneratedAt.Format(time.Kitchen), s.Points[0].ReshareRatio, s.Points[0].CoordinationSignals.BurstScore, s.Points[1].ReshareRatio)
+	for _, t := range rep.Translations {
+		fmt.Printf("%s -> %s ×%d\n", t.From, t.To, t.Count)
+	}
+	for _, t := range rep.Conflicts {
+		fmt.Printf("dropped %s ×%d\n", t.From, t.Count)
+	}
+	// Output:
+	// 12:05AM 0.5 0.2 0.25
+	// burstScore -> burst_score ×1
+	// coordinationSignals -> coordination_signals ×1
+	// generatedAt -> generated_at ×1
+	// reshareRate -> reshare_ratio ×1
+	// dropped reshareRate ×1
+}
+
+func ExampleDecodeLegacyTag() {
+	in := []byte(`{"acctAge":"0-7d","acctType":"person","postKind":"original","dedupHash":"deadbeef"}`)
+	var tag types.ProvenanceTag
+	rep, _ := codec.DecodeLegacyTag(in, &tag, codec.DefaultLegacyFields())
+	fmt.Println(tag.AcctAgeBucket, tag.AcctType, tag.PostKind, tag.DedupHash, len(rep.Translations))
+	// Output: 0-7d person original deadbeef 4
+}
+
+func ExampleEncoder_SetFloatFormat() {
+	third := 1.0 / 3
+	s := &types.Series{
+		Topic:       "#vote",
+		GeneratedAt: time.Date(2025, 1, 1, 0, 5, 0, 0, time.UTC),
+		Interval:    types.IntervalMinute,
+		Points: []types.Point{{
+			TS:           time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			Volume:       3,
+			ReshareRatio: types.Probability(third),
+			AcctAgeMix:   map[string]types.Probability{"24m+": 1},
+		}},
+	}
+	enc := codec.NewEncoder(os.Stdout, codec.Compact)
+	enc.SetFloatFormat(codec.FloatFormat{Decimals: 6, Zeros: codec.OneDecimal})
+	_ = enc.Encode(s)
+	// Output:
+	// {"encoding":"compact","topic":"#vote","generated_at":"2025-01-01T00:05:00Z","interval":"minute","fields":["ts","volume","reshare_ratio","recycled_content_rate","acct_age_mix","automation_mix","client_mix","burst_score","synchrony_index","duplication_clusters","backfilled","acct_type_shares","post_kind_mix"],"points":[["2025-01-01T00:00:00Z",3,0.333333,0.0,{"24m+":1.0},null,null,0.0,0.0,0,false,null,null]]}
+}
+
+func ExampleUnmarshalWith() {
+	doc := []byte(`{"topic":"#t","generated_at":"2025-06-01T14:05:00+02:00","interval":"PT1M",
+		"points":[{"ts":"2025-06-01T12:00:00Z","volume":3}]}`)
+
+	var s types.Series
+	err := codec.UnmarshalWith(doc, &s, codec.DecodeOptions{RejectNonUTC: true})
+	fmt.Println(err)
+
+	_ = codec.Unmarshal(doc, &s)
+	fmt.Println(s.GeneratedAt)
+	// Output:
+	// codec: timestamp not in UTC: generated_at
+	// 2025-06-01 12:05:00 +0000 UTC
+}
+
+func ExampleProtoJSON() {
+	tag := types.ProvenanceTag{
+		AcctAgeBucket: types.AcctAge_0_7d, AcctType: types.AcctTypePublicOfficial,
+		AutomationFlag: types.AutomationManual, PostKind: types.PostKindReply,
+		ClientFamily: types.ClientMobile, MediaProvenance: types.MediaProvNone,
+		DedupHash: "deadbeef",
+	}
+	b, _ := json.Marshal(tag)
+	p, _ := codec.ToProtoJSON(b, tag)
+	fmt.Println(string(p))
+
+	back, _ := codec.FromProtoJSON(p, tag)
+	var got types.ProvenanceTag
+	_ = json.Unmarshal(back, &got)
+	fmt.Println(got.AcctAgeBucket, got.AcctType)
+	// Output:
+	// {"acctAgeBucket":"ACCT_AGE_BUCKET_0_7D","acctType":"ACCT_TYPE_PUBLIC_OFFICIAL","automationFlag":"AUTOMATION_FLAG_MANUAL","clientFamily":"CLIENT_FAMILY_MOBILE","dedupHash":"deadbeef","mediaProvenance":"MEDIA_PROVENANCE_NONE","postKind":"POST_KIND_REPLY"}
+	// 0-7d public_official
+}
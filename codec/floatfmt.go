@@ -0,0 +1,286 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// TrailingZeros selects how FloatFormat treats zeros at the end of a
+// fraction.
+type TrailingZeros int
+
+const (
+	// TrimZeros drops trailing zeros and a bare decimal point: 0.5, 1.
+	TrimZeros TrailingZeros = iota
+	// KeepZeros pads to FloatFormat.Decimals: 0.500000, 1.000000. With
+	// shortest-form formatting it behaves like TrimZeros.
+	KeepZeros
+	// OneDecimal trims like TrimZeros but keeps at least one fraction
+	// digit, as Python's float repr does: 0.5, 1.0.
+	OneDecimal
+)
+
+// FloatFormat controls how floating-point members are written, so that
+// canonical encodings (and their hashes) can match other implementations
+// byte for byte. Output never uses exponent form.
+//
+// Whether a member is a float is taken from its Go type, so a Probability
+// of 1 is a float and a Volume of 1 is not. Members without a Go type (raw
+// extension values, quantized trees) count as floats only if they are
+// written with a fraction or exponent.
+type FloatFormat struct {
+	// Decimals rounds every float to this many fraction digits (round half
+	// to even on the exact binary value, as Python's format(x, ".6f")).
+	// Zero selects the shortest digits that round-trip.
+	Decimals int
+	// Zeros is the trailing-zero policy.
+	Zeros TrailingZeros
+}
+
+// Format returns f's text for x.
+func (f FloatFormat) Format(x float64) string {
+	prec := -1
+	if f.Decimals > 0 {
+		prec = f.Decimals
+	}
+	s := strconv.FormatFloat(x, 'f', prec, 64)
+	if (prec < 0 || f.Zeros != KeepZeros) && strings.IndexByte(s, '.') >= 0 {
+		s = strings.TrimSuffix(strings.TrimRight(s, "0"), ".")
+	}
+	if strings.Trim(s, "-0.") == "" {
+		s = strings.TrimPrefix(s, "-") // negative zero
+	}
+	if f.Zeros == OneDecimal && strings.IndexByte(s, '.') < 0 {
+		s += ".0"
+	}
+	return s
+}
+
+// MarshalFloats returns the JSON encoding of v, as json.Marshal, with every
+// float written per f. Member order is unchanged.
+func MarshalFloats(v any, f FloatFormat) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return formatFloats(b, reflect.ValueOf(v), f)
+}
+
+// SetFloatFormat makes e write floats per f. Quantized output has no
+// floats to format apart from untyped extension values.
+func (e *Encoder) SetFloatFormat(f FloatFormat) { e.floats = &f }
+
+// formatFloats rewrites the numbers in b, a json.Marshal encoding of v. An
+// invalid v formats untyped.
+func formatFloats(b []byte, v reflect.Value, f FloatFormat) ([]byte, error) {
+	w := floatWriter{in: b, f: f, out: make([]byte, 0, len(b)+len(b)/8)}
+	if err := w.value(v); err != nil {
+		return nil, err
+	}
+	return w.out, nil
+}
+
+type floatWriter struct {
+	in  []byte
+	pos int
+	out []byte
+	f   FloatFormat
+}
+
+var marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// typed unwraps pointers and interfaces, and returns an invalid Value for
+// types with their own JSON encoding, whose output is formatted untyped.
+func typed(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.Type().Implements(marshalerType) {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	if v.IsValid() && (v.Type().Implements(marshalerType) || reflect.PointerTo(v.Type()).Implements(marshalerType)) {
+		return reflect.Value{}
+	}
+	return v
+}
+
+func (w *floatWriter) value(v reflect.Value) error {
+	v = typed(v)
+	if w.pos >= len(w.in) {
+		return fmt.Errorf("codec: unexpected end of JSON")
+	}
+	switch c := w.in[w.pos]; {
+	case c == '{':
+		return w.object(v)
+	case c == '[':
+		return w.array(v)
+	case c == '"':
+		_, err := w.str()
+		return err
+	case c == '-' || (c >= '0' && c <= '9'):
+		return w.number(v)
+	default: // true, false, null
+		start := w.pos
+		for w.pos < len(w.in) && w.in[w.pos] >= 'a' && w.in[w.pos] <= 'z' {
+			w.pos++
+		}
+		w.out = append(w.out, w.in[start:w.pos]...)
+		return nil
+	}
+}
+
+func (w *floatWriter) object(v reflect.Value) error {
+	var fields map[string][]int
+	if v.IsValid() && v.Kind() == reflect.Struct {
+		fields = jsonFields(v.Type())
+	}
+	w.out = append(w.out, '{')
+	w.pos++
+	for w.in[w.pos] != '}' {
+		if w.in[w.pos] == ',' {
+			w.out = append(w.out, ',')
+			w.pos++
+		}
+		key, err := w.str()
+		if err != nil {
+			return err
+		}
+		w.out = append(w.out, ':')
+		w.pos++
+		var elem reflect.Value
+		switch {
+		case fields != nil:
+			if idx, ok := fields[key]; ok {
+				elem, _ = v.FieldByIndexErr(idx)
+			}
+		case v.IsValid() && v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+			elem = v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key()))
+		}
+		if err := w.value(elem); err != nil {
+			return err
+		}
+	}
+	w.out = append(w.out, '}')
+	w.pos++
+	return nil
+}
+
+func (w *floatWriter) array(v reflect.Value) error {
+	indexable := v.IsValid() && (v.Kind() == reflect.Slice || v.Kind() == reflect.Array)
+	w.out = append(w.out, '[')
+	w.pos++
+	for i := 0; w.in[w.pos] != ']'; i++ {
+		if w.in[w.pos] == ',' {
+			w.out = append(w.out, ',')
+			w.pos++
+		}
+		var elem reflect.Value
+		if indexable && i < v.Len() {
+			elem = v.Index(i)
+		}
+		if err := w.value(elem); err != nil {
+			return err
+		}
+	}
+	w.out = append(w.out, ']')
+	w.pos++
+	return nil
+}
+
+// str copies a string token and returns its decoded value.
+func (w *floatWriter) str() (string, error) {
+	start := w.pos
+	w.pos++
+	for w.pos < len(w.in) && w.in[w.pos] != '"' {
+		if w.in[w.pos] == '\\' {
+			w.pos++
+		}
+		w.pos++
+	}
+	if w.pos >= len(w.in) {
+		return "", fmt.Errorf("codec: unterminated JSON string")
+	}
+	w.pos++
+	tok := w.in[start:w.pos]
+	w.out = append(w.out, tok...)
+	var s string
+	if bytes.IndexByte(tok, '\\') < 0 {
+		return string(tok[1 : len(tok)-1]), nil
+	}
+	err := json.Unmarshal(tok, &s)
+	return s, err
+}
+
+func (w *floatWriter) number(v reflect.Value) error {
+	start := w.pos
+	for w.pos < len(w.in) && bytes.IndexByte([]byte("+-.0123456789eE"), w.in[w.pos]) >= 0 {
+		w.pos++
+	}
+	lit := w.in[start:w.pos]
+	isFloat := bytes.ContainsAny(lit, ".eE")
+	if v.IsValid() {
+		k := v.Kind()
+		isFloat = k == reflect.Float32 || k == reflect.Float64
+	}
+	if !isFloat {
+		w.out = append(w.out, lit...)
+		return nil
+	}
+	x, err := strconv.ParseFloat(string(lit), 64)
+	if err != nil {
+		return err
+	}
+	w.out = append(w.out, w.f.Format(x)...)
+	return nil
+}
+
+var fieldCache sync.Map // reflect.Type → map[string][]int
+
+// jsonFields maps the JSON member names of struct type t to field indexes,
+// following encoding/json's rules for tags and promoted embedded fields
+// closely enough for the types in this module.
+func jsonFields(t reflect.Type) map[string][]int {
+	if m, ok := fieldCache.Load(t); ok {
+		return m.(map[string][]int)
+	}
+	m := map[string][]int{}
+	var walk func(t reflect.Type, index []int)
+	walk = func(t reflect.Type, index []int) {
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			tag := sf.Tag.Get("json")
+			if tag == "-" {
+				continue
+			}
+			name, _, _ := strings.Cut(tag, ",")
+			idx := append(append([]int(nil), index...), i)
+			if sf.Anonymous && name == "" {
+				ft := sf.Type
+				if ft.Kind() == reflect.Pointer {
+					ft = ft.Elem()
+				}
+				if ft.Kind() == reflect.Struct {
+					walk(ft, idx)
+					continue
+				}
+			}
+			if !sf.IsExported() {
+				continue
+			}
+			if name == "" {
+				name = sf.Name
+			}
+			if prev, ok := m[name]; !ok || len(prev) > len(idx) {
+				m[name] = idx
+			}
+		}
+	}
+	walk(t, nil)
+	fieldCache.Store(t, m)
+	return m
+}
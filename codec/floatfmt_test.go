@@ -0,0 +1,75 @@
+package codec
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// pointThree is 0.1+0.2 computed in float64; the constant expression is
+// exactly 0.3.
+var pointOne, pointTwo = 0.1, 0.2
+var pointThree = pointOne + pointTwo
+
+func TestFloatFormat(t *testing.T) {
+	for _, c := range []struct {
+		f    FloatFormat
+		x    float64
+		want string
+	}{
+		{FloatFormat{}, pointThree, "0.30000000000000004"},
+		{FloatFormat{}, 1, "1"},
+		{FloatFormat{}, 1e-7, "0.0000001"},
+		{FloatFormat{}, 1e21, "1000000000000000000000"},
+		{FloatFormat{Zeros: OneDecimal}, 1, "1.0"},
+		{FloatFormat{Decimals: 6}, pointThree, "0.3"},
+		{FloatFormat{Decimals: 6, Zeros: KeepZeros}, pointThree, "0.300000"},
+		{FloatFormat{Decimals: 6, Zeros: OneDecimal}, 2, "2.0"},
+		{FloatFormat{Decimals: 2}, 0.125, "0.12"},   // exact half: to even
+		{FloatFormat{Decimals: 3}, 0.0005, "0.001"}, // 0.0005 is slightly above half in binary
+		{FloatFormat{Decimals: 2}, -0.001, "0"},
+		{FloatFormat{Decimals: 2, Zeros: KeepZeros}, -0.001, "0.00"},
+	} {
+		if got := c.f.Format(c.x); got != c.want {
+			t.Errorf("%+v.Format(%v) = %q, want %q", c.f, c.x, got, c.want)
+		}
+	}
+}
+
+func TestMarshalFloatsTypes(t *testing.T) {
+	s := &types.Series{
+		Topic:       `#"quoted"`,
+		GeneratedAt: time.Date(2025, 1, 1, 0, 5, 0, 0, time.UTC),
+		Interval:    types.IntervalMinute,
+		Points: []types.Point{{
+			TS:           time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			Volume:       3,
+			ReshareRatio: 1,
+			AcctAgeMix:   map[string]types.Probability{"0-7d": types.Probability(pointThree), "24m+": 0.7},
+			CoordinationSignals: types.CoordinationSignals{
+				BurstScore:          0.5,
+				DuplicationClusters: 2,
+			},
+		}},
+		Extensions: types.Extensions{"x-p-n": json.RawMessage(`{"a":2,"b":2.50}`)},
+	}
+	got, err := MarshalFloats(s, FloatFormat{Decimals: 6, Zeros: OneDecimal})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"topic":"#\"quoted\"","generated_at":"2025-01-01T00:05:00Z","interval":"minute","points":[{"ts":"2025-01-01T00:00:00Z","volume":3,` +
+		`"reshare_ratio":1.0,"recycled_content_rate":0.0,"acct_age_mix":{"0-7d":0.3,"24m+":0.7},"automation_mix":null,"client_mix":null,` +
+		`"coordination_signals":{"burst_score":0.5,"synchrony_index":0.0,"duplication_clusters":2}}],"extensions":{"x-p-n":{"a":2,"b":2.5}}}`
+	if string(got) != want {
+		t.Errorf("MarshalFloats =\n%s\nwant\n%s", got, want)
+	}
+
+	s.Extensions = nil // raw values are reformatted too
+	plain, _ := json.Marshal(s)
+	same, _ := MarshalFloats(s, FloatFormat{})
+	if string(same) != string(plain) {
+		t.Errorf("zero FloatFormat changed output:\n%s\n%s", same, plain)
+	}
+}
@@ -0,0 +1,191 @@
+package codec
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// LegacyFields maps pre-1.0 member names to schema names, per object. Only
+// names are translated; values are decoded as usual (see Aliases for
+// legacy enum values).
+type LegacyFields struct {
+	Series  map[string]string // Series members
+	Point   map[string]string // members of each points[i]
+	Signals map[string]string // members of points[i].coordination_signals
+	Tag     map[string]string // ProvenanceTag members
+}
+
+// DefaultLegacyFields covers the camelCase shape emitted by pilot
+// publishers before 1.0.
+func DefaultLegacyFields() LegacyFields {
+	return LegacyFields{
+		Series: map[string]string{
+			"generatedAt": "generated_at",
+		},
+		Point: map[string]string{
+			"timestamp":           "ts",
+			"reshareRate":         "reshare_ratio",
+			"reshareRatio":        "reshare_ratio",
+			"recycledContentRate": "recycled_content_rate",
+			"acctAgeMix":          "acct_age_mix",
+			"automationMix":       "automation_mix",
+			"clientMix":           "client_mix",
+			"acctTypeShares":      "acct_type_shares",
+			"postKindMix":         "post_kind_mix",
+			"coordinationSignals": "coordination_signals",
+		},
+		Signals: map[string]string{
+			"burstScore":          "burst_score",
+			"synchronyIndex":      "synchrony_index",
+			"duplicationClusters": "duplication_clusters",
+		},
+		Tag: map[string]string{
+			"acctAge":         "acct_age_bucket",
+			"acctAgeBucket":   "acct_age_bucket",
+			"acctType":        "acct_type",
+			"automationFlag":  "automation_flag",
+			"postKind":        "post_kind",
+			"clientFamily":    "client_family",
+			"mediaProvenance": "media_provenance",
+			"dedupHash":       "dedup_hash",
+			"dedupHashAlg":    "dedup_hash_alg",
+			"originHint":      "origin_hint",
+		},
+	}
+}
+
+// Translation counts the occurrences of one legacy name in a document.
+type Translation struct {
+	From  string // legacy name
+	To    string // schema name
+	Count int
+}
+
+// LegacyReport lists the translations applied while decoding one document,
+// sorted by From. Conflicts lists legacy names that were dropped because
+// the object already had the schema name, or an earlier legacy spelling of
+// it in sorted order; the schema name always takes precedence.
+type LegacyReport struct {
+	Translations []Translation
+	Conflicts    []Translation
+}
+
+// Empty reports whether the document was already in schema shape.
+func (r LegacyReport) Empty() bool {
+	return len(r.Translations) == 0 && len(r.Conflicts) == 0
+}
+
+// DecodeLegacySeries decodes a verbose Series that may use pre-1.0 member
+// names, translating them with f. Timestamps are converted to UTC, as by
+// Unmarshal.
+func DecodeLegacySeries(data []byte, s *types.Series, f LegacyFields) (LegacyReport, error) {
+	var t legacyTally
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return LegacyReport{}, err
+	}
+	t.rename(doc, f.Series)
+	if raw, ok := doc["points"]; ok && string(raw) != "null" {
+		var pts []map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &pts); err != nil {
+			return LegacyReport{}, fmt.Errorf("codec: points: %w", err)
+		}
+		var err error
+		for i, p := range pts {
+			t.rename(p, f.Point)
+			if raw, ok := p["coordination_signals"]; ok && string(raw) != "null" {
+				var cs map[string]json.RawMessage
+				if err := json.Unmarshal(raw, &cs); err != nil {
+					return LegacyReport{}, fmt.Errorf("codec: points[%d].coordination_signals: %w", i, err)
+				}
+				t.rename(cs, f.Signals)
+				if p["coordination_signals"], err = json.Marshal(cs); err != nil {
+					return LegacyReport{}, err
+				}
+			}
+		}
+		if doc["points"], err = json.Marshal(pts); err != nil {
+			return LegacyReport{}, err
+		}
+	}
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return LegacyReport{}, err
+	}
+	if err := json.Unmarshal(b, s); err != nil {
+		return LegacyReport{}, err
+	}
+	s.NormalizeUTC()
+	return t.report(), nil
+}
+
+// DecodeLegacyTag decodes a ProvenanceTag that may use pre-1.0 member
+// names, translating them with f.
+func DecodeLegacyTag(data []byte, tag *types.ProvenanceTag, f LegacyFields) (LegacyReport, error) {
+	var t legacyTally
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return LegacyReport{}, err
+	}
+	t.rename(doc, f.Tag)
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return LegacyReport{}, err
+	}
+	if err := json.Unmarshal(b, tag); err != nil {
+		return LegacyReport{}, err
+	}
+	return t.report(), nil
+}
+
+type legacyTally struct {
+	renamed, dropped map[[2]string]int
+}
+
+// rename moves legacy members of obj to their schema names in place.
+// Legacy names are visited in sorted order, so when an object has two
+// spellings of one member the result does not depend on map iteration.
+func (t *legacyTally) rename(obj map[string]json.RawMessage, names map[string]string) {
+	froms := make([]string, 0, len(names))
+	for from := range names {
+		froms = append(froms, from)
+	}
+	sort.Strings(froms)
+	for _, from := range froms {
+		to := names[from]
+		v, ok := obj[from]
+		if !ok || from == to {
+			continue
+		}
+		delete(obj, from)
+		k := [2]string{from, to}
+		if _, taken := obj[to]; taken {
+			if t.dropped == nil {
+				t.dropped = map[[2]string]int{}
+			}
+			t.dropped[k]++
+			continue
+		}
+		obj[to] = v
+		if t.renamed == nil {
+			t.renamed = map[[2]string]int{}
+		}
+		t.renamed[k]++
+	}
+}
+
+func (t *legacyTally) report() LegacyReport {
+	return LegacyReport{Translations: sortedTranslations(t.renamed), Conflicts: sortedTranslations(t.dropped)}
+}
+
+func sortedTranslations(m map[[2]string]int) []Translation {
+	var out []Translation
+	for k, n := range m {
+		out = append(out, Translation{From: k[0], To: k[1], Count: n})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].From < out[j].From })
+	return out
+}
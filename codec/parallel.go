@@ -0,0 +1,230 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// DefaultChunkPoints is the number of points per chunk when
+// ParallelOptions.ChunkPoints is zero: large enough that per-chunk
+// overhead is negligible, small enough to spread a week of minutes
+// (10080 points) over several cores.
+const DefaultChunkPoints = 1024
+
+// ParallelOptions tunes MarshalParallel and ParallelEncoder.
+type ParallelOptions struct {
+	// Workers is the number of goroutines marshaling chunks. Zero selects
+	// runtime.GOMAXPROCS(0).
+	Workers int
+	// ChunkPoints is the number of points marshaled per task. Zero selects
+	// DefaultChunkPoints.
+	ChunkPoints int
+}
+
+func (o ParallelOptions) withDefaults() ParallelOptions {
+	if o.Workers <= 0 {
+		o.Workers = runtime.GOMAXPROCS(0)
+	}
+	if o.ChunkPoints <= 0 {
+		o.ChunkPoints = DefaultChunkPoints
+	}
+	return o
+}
+
+// ParallelEncoder writes Series like Encoder, marshaling chunks of points
+// concurrently and writing them in order as they complete, so a series
+// with hundreds of thousands of points is not bound to one core and is
+// never held in memory twice. Its output is byte-for-byte what Encoder
+// writes with no indent or float format.
+type ParallelEncoder struct {
+	w      io.Writer
+	format Format
+	opts   ParallelOptions
+}
+
+// NewParallelEncoder returns a ParallelEncoder writing format to w.
+func NewParallelEncoder(w io.Writer, format Format, opts ParallelOptions) *ParallelEncoder {
+	return &ParallelEncoder{w: w, format: format, opts: opts.withDefaults()}
+}
+
+// Encode writes s followed by a newline.
+func (e *ParallelEncoder) Encode(s *types.Series) error {
+	if err := encodeParallel(e.w, s, e.format, e.opts); err != nil {
+		return err
+	}
+	_, err := io.WriteString(e.w, "\n")
+	return err
+}
+
+// MarshalParallel returns the same bytes as Marshal(s, format), marshaling
+// chunks of points concurrently.
+func MarshalParallel(s *types.Series, format Format, opts ParallelOptions) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := encodeParallel(&buf, s, format, opts.withDefaults()); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+type chunkResult struct {
+	b   []byte
+	err error
+}
+
+func encodeParallel(w io.Writer, s *types.Series, format Format, opts ParallelOptions) error {
+	n := len(s.Points)
+	chunks := (n + opts.ChunkPoints - 1) / opts.ChunkPoints
+	if chunks <= 1 || opts.Workers == 1 || format&ProtoJSON != 0 {
+		b, err := Marshal(s, format)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(b)
+		return err
+	}
+	head, tail, err := splitPoints(s, format)
+	if err != nil {
+		return err
+	}
+
+	// A feeder hands out chunk indexes, keeping at most 2*Workers chunks
+	// marshaled but not yet written; each chunk has its own result slot so
+	// output order does not depend on completion order.
+	results := make([]chan chunkResult, chunks)
+	for i := range results {
+		results[i] = make(chan chunkResult, 1)
+	}
+	jobs := make(chan int)
+	window := make(chan struct{}, 2*opts.Workers)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		defer close(jobs)
+		for i := range results {
+			select {
+			case window <- struct{}{}:
+			case <-done:
+				return
+			}
+			select {
+			case jobs <- i:
+			case <-done:
+				return
+			}
+		}
+	}()
+	for k := 0; k < min(opts.Workers, chunks); k++ {
+		go func() {
+			for i := range jobs {
+				lo := i * opts.ChunkPoints
+				b, err := marshalChunk(s.Points[lo:min(lo+opts.ChunkPoints, n)], format)
+				results[i] <- chunkResult{b, err}
+			}
+		}()
+	}
+
+	if _, err := w.Write(head); err != nil {
+		return err
+	}
+	for i := range results {
+		r := <-results[i]
+		<-window
+		if r.err != nil {
+			return r.err
+		}
+		if i > 0 {
+			if _, err := w.Write([]byte{','}); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(r.b[1 : len(r.b)-1]); err != nil { // drop the chunk's brackets
+			return err
+		}
+	}
+	_, err = w.Write(tail)
+	return err
+}
+
+// splitPoints marshals s without its points and splits the result around
+// the empty points array: head ends with "[" and tail starts with "]".
+func splitPoints(s *types.Series, format Format) (head, tail []byte, err error) {
+	hdr := *s
+	hdr.Points = []types.Point{}
+	b, err := Marshal(&hdr, format)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Walk the top-level members: an extension could also be named
+	// "points", so a byte search is not enough.
+	dec := json.NewDecoder(bytes.NewReader(b))
+	if _, err := dec.Token(); err != nil {
+		return nil, nil, err
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		if key == "points" {
+			off := int(dec.InputOffset())
+			i := off + bytes.IndexByte(b[off:], '[')
+			if i < off || i+1 >= len(b) || b[i+1] != ']' {
+				break
+			}
+			return b[:i+1], b[i+1:], nil
+		}
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return nil, nil, err
+		}
+	}
+	return nil, nil, fmt.Errorf("codec: no points array in %s encoding", formatName(format))
+}
+
+// marshalChunk returns the JSON array of pts as they appear in a document
+// of format.
+func marshalChunk(pts []types.Point, format Format) ([]byte, error) {
+	var v any = pts
+	if format&Compact != 0 {
+		rows := make([][]any, len(pts))
+		for i := range pts {
+			rows[i] = compactRow(&pts[i])
+		}
+		v = rows
+	}
+	if format&Quantized == 0 {
+		return json.Marshal(v)
+	}
+	// Quantize through the same document transform as Marshal, with just
+	// enough of the document around the points for quantizeDoc.
+	doc := map[string]any{"points": v}
+	if format&Compact != 0 {
+		doc["fields"] = CompactFields
+	}
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	tree, err := decodeTree(b)
+	if err != nil {
+		return nil, err
+	}
+	quantizeDoc(tree)
+	return json.Marshal(tree["points"])
+}
+
+func formatName(f Format) string {
+	name := "verbose"
+	if f&Compact != 0 {
+		name = "compact"
+	}
+	if f&Quantized != 0 {
+		name += " quantized"
+	}
+	return name
+}
@@ -0,0 +1,119 @@
+package codec
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// weekSeries returns a minute series of n points with every mix populated,
+// so chunks exercise each quantized member.
+func weekSeries(n int) *types.Series {
+	t0 := time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC)
+	s := &types.Series{
+		Topic: "#budget", GeneratedAt: t0.Add(time.Duration(n) * time.Minute), Interval: types.IntervalMinute,
+		Methodology: &types.Methodology{SuppressionThreshold: 10, Caveats: []string{"sampled"}},
+		Extensions:  types.Extensions{"x-acme-points": []byte(`[1,2]`)},
+		Points:      make([]types.Point, n),
+	}
+	for i := range s.Points {
+		f := types.Probability(float64(i%100) / 100)
+		s.Points[i] = types.Point{
+			TS: t0.Add(time.Duration(i) * time.Minute), Volume: 10 + i%500,
+			ReshareRatio: f, RecycledContentRate: f / 2,
+			AcctAgeMix:     map[string]types.Probability{"0-7d": f, "1y+": 1 - f},
+			AutomationMix:  map[string]types.Probability{"manual": 1},
+			ClientMix:      map[string]types.Probability{"web": 0.25, "mobile": 0.75},
+			AcctTypeShares: map[types.AcctType]types.Probability{types.AcctTypePerson: 1},
+			PostKindMix:    map[types.PostKind]types.Probability{types.PostKindOriginal: 1 - f, types.PostKindReshare: f},
+			CoordinationSignals: types.CoordinationSignals{
+				BurstScore: f, SynchronyIndex: f / 3, DuplicationClusters: i % 7,
+			},
+		}
+	}
+	return s
+}
+
+func TestMarshalParallelMatchesMarshal(t *testing.T) {
+	s := weekSeries(1000)
+	for _, format := range []Format{Verbose, Compact, Verbose | Quantized, Compact | Quantized} {
+		want, err := Marshal(s, format)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, opts := range []ParallelOptions{
+			{},
+			{Workers: 1, ChunkPoints: 7},
+			{Workers: 3, ChunkPoints: 1},
+			{Workers: 4, ChunkPoints: 97},
+			{Workers: 16, ChunkPoints: 999},
+		} {
+			got, err := MarshalParallel(s, format, opts)
+			if err != nil {
+				t.Fatalf("%s %+v: %v", formatName(format), opts, err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("%s %+v: output differs from Marshal", formatName(format), opts)
+			}
+		}
+	}
+}
+
+func TestParallelEncoderMatchesEncoder(t *testing.T) {
+	s := weekSeries(300)
+	for _, format := range []Format{Verbose, Compact | Quantized} {
+		var want, got bytes.Buffer
+		if err := NewEncoder(&want, format).Encode(s); err != nil {
+			t.Fatal(err)
+		}
+		e := NewParallelEncoder(&got, format, ParallelOptions{Workers: 4, ChunkPoints: 50})
+		if err := e.Encode(s); err != nil {
+			t.Fatal(err)
+		}
+		if got.String() != want.String() {
+			t.Errorf("%s: ParallelEncoder output differs from Encoder", formatName(format))
+		}
+	}
+}
+
+func TestMarshalParallelError(t *testing.T) {
+	s := weekSeries(100)
+	s.Points[60].ReshareRatio = types.Probability(math.NaN())
+	if _, err := MarshalParallel(s, Verbose, ParallelOptions{Workers: 2, ChunkPoints: 10}); err == nil {
+		t.Error("MarshalParallel with a NaN ratio: want error")
+	}
+}
+
+// BenchmarkMarshalParallel marshals a week of minute points. On a machine
+// with 8 or more cores, ns/op should fall close to linearly up to workers=8.
+func BenchmarkMarshalParallel(b *testing.B) {
+	s := weekSeries(7 * 24 * 60)
+	for _, format := range []Format{Verbose, Compact | Quantized} {
+		want, err := Marshal(s, format)
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(formatName(format)+"/serial", func(b *testing.B) {
+			b.SetBytes(int64(len(want)))
+			for i := 0; i < b.N; i++ {
+				if _, err := Marshal(s, format); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		for _, workers := range []int{1, 2, 4, 8, 16} {
+			b.Run(fmt.Sprintf("%s/workers=%d", formatName(format), workers), func(b *testing.B) {
+				b.SetBytes(int64(len(want)))
+				for i := 0; i < b.N; i++ {
+					if _, err := MarshalParallel(s, format, ParallelOptions{Workers: workers}); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,360 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// ProtoJSON may be combined with Verbose to write the verbose form with the
+// conventions of protobuf's canonical JSON mapping, for consumers whose
+// stack is protobuf-first:
+//
+//   - members are lowerCamelCase ("generatedAt", "coordinationSignals");
+//   - enum values are SCREAMING_SNAKE_CASE prefixed with the enum name
+//     ("ACCT_TYPE_PUBLIC_OFFICIAL", "ACCT_AGE_BUCKET_24M_PLUS");
+//   - interval and durations such as methodology.collection_lag are
+//     google.protobuf.Duration strings ("60s") instead of ISO 8601.
+//
+// Map keys are left as they are, including the enum keys of mixes such as
+// acct_type_shares, since protobuf maps cannot be keyed by enums; so are
+// extensions. Registered experimental enum values are converted like
+// schema values; any other value passes through unchanged, so the
+// conversion is lossless: decoding with DecodeOptions.ProtoJSON, or
+// FromProtoJSON, restores the canonical form.
+// ProtoJSON cannot be combined with Compact or Quantized.
+const ProtoJSON Format = 1 << 9
+
+// ErrProtoJSONFormat is returned when ProtoJSON is combined with Compact or
+// Quantized.
+var ErrProtoJSONFormat = errors.New("codec: ProtoJSON combines only with Verbose")
+
+// protoEnum describes one enum type in the protojson mapping.
+type protoEnum struct {
+	field  string // schema field name for experimental values, or ""
+	prefix string // protobuf enum value prefix
+	values func() []string
+}
+
+// protoEnums lists every enum type reachable from the schema documents.
+var protoEnums = map[reflect.Type]protoEnum{
+	reflect.TypeOf(types.AcctAge("")):          {"acct_age_bucket", "ACCT_AGE_BUCKET", enumStrings(types.AcctAgeValues)},
+	reflect.TypeOf(types.AcctType("")):         {"acct_type", "ACCT_TYPE", enumStrings(types.AcctTypeValues)},
+	reflect.TypeOf(types.AutomationFlag("")):   {"automation_flag", "AUTOMATION_FLAG", enumStrings(types.AutomationFlagValues)},
+	reflect.TypeOf(types.PostKind("")):         {"post_kind", "POST_KIND", enumStrings(types.PostKindValues)},
+	reflect.TypeOf(types.ClientFamily("")):     {"client_family", "CLIENT_FAMILY", enumStrings(types.ClientFamilyValues)},
+	reflect.TypeOf(types.MediaProvenance("")):  {"media_provenance", "MEDIA_PROVENANCE", enumStrings(types.MediaProvenanceValues)},
+	reflect.TypeOf(types.DedupHashAlg("")):     {"", "DEDUP_HASH_ALG", enumStrings(types.DedupHashAlgValues)},
+	reflect.TypeOf(types.AnnotationKind("")):   {"", "ANNOTATION_KIND", enumStrings(types.AnnotationKindValues)},
+	reflect.TypeOf(types.RetractionReason("")): {"", "RETRACTION_REASON", enumStrings(types.RetractionReasonValues)},
+	reflect.TypeOf(types.HealthStatus("")):     {"", "HEALTH_STATUS", enumStrings(types.HealthStatusValues)},
+	reflect.TypeOf(types.QuarantineState("")):  {"", "QUARANTINE_STATE", enumStrings(types.QuarantineStateValues)},
+	reflect.TypeOf(types.SeriesState("")):      {"", "SERIES_STATE", enumStrings(types.SeriesStateValues)},
+}
+
+func enumStrings[T ~string](values func() []T) func() []string {
+	return func() []string {
+		vs := values()
+		out := make([]string, len(vs))
+		for i, v := range vs {
+			out[i] = string(v)
+		}
+		return out
+	}
+}
+
+var (
+	durationType   = reflect.TypeOf(types.ISODuration(0))
+	intervalType   = reflect.TypeOf(types.Interval(""))
+	timeType       = reflect.TypeOf(time.Time{})
+	extensionsType = reflect.TypeOf(types.Extensions(nil))
+	rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+)
+
+// ProtoEnumName returns the protojson name of the enum value v:
+// prefix, an underscore, and v upper-cased with "+" spelled "_PLUS" and
+// any other character outside [A-Z0-9] replaced by "_".
+func ProtoEnumName(prefix, v string) string {
+	var b strings.Builder
+	b.WriteString(prefix)
+	b.WriteByte('_')
+	for _, r := range v {
+		switch {
+		case r >= 'a' && r <= 'z':
+			b.WriteRune(r - 'a' + 'A')
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '+':
+			b.WriteString("_PLUS")
+		default:
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+// protoNames returns the canonical → protojson mapping of e's values and
+// its inverse. Schema values come first, so an experimental value whose
+// name collides with one keeps its canonical spelling.
+func (e protoEnum) protoNames() (to, from map[string]string) {
+	values := e.values()
+	if e.field != "" {
+		for _, x := range types.ExperimentalValues() {
+			if x.Field == e.field {
+				values = append(values, x.Value)
+			}
+		}
+	}
+	to, from = make(map[string]string, len(values)), make(map[string]string, len(values))
+	for _, v := range values {
+		name := ProtoEnumName(e.prefix, v)
+		if _, taken := from[name]; taken {
+			continue
+		}
+		to[v], from[name] = name, v
+	}
+	return to, from
+}
+
+// camelCase returns the protojson member name of a snake_case schema name.
+func camelCase(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range name {
+		if r == '_' {
+			upper = true
+			continue
+		}
+		if upper && r >= 'a' && r <= 'z' {
+			r -= 'a' - 'A'
+		}
+		upper = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// ToProtoJSON converts a canonical JSON document to the ProtoJSON form.
+// shape is a value or nil pointer of the document's Go type, e.g.
+// (*types.ProvenanceTag)(nil); members not in that type are left as they
+// are.
+func ToProtoJSON(data []byte, shape any) ([]byte, error) {
+	return convertProtoJSON(data, shape, true)
+}
+
+// FromProtoJSON converts a ProtoJSON document back to the canonical form;
+// shape is as for ToProtoJSON. Canonical snake_case member names are
+// accepted too, as protobuf parsers accept original field names.
+func FromProtoJSON(data []byte, shape any) ([]byte, error) {
+	return convertProtoJSON(data, shape, false)
+}
+
+func convertProtoJSON(data []byte, shape any, toProto bool) ([]byte, error) {
+	t := reflect.TypeOf(shape)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("codec: protojson shape %T is not a struct", shape)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	c := protoConverter{toProto: toProto, enums: map[reflect.Type][2]map[string]string{}}
+	return json.Marshal(c.value(v, t))
+}
+
+type protoConverter struct {
+	toProto bool
+	enums   map[reflect.Type][2]map[string]string // to, from; built on first use
+}
+
+// value converts v, decoded from a member of Go type t.
+func (c *protoConverter) value(v any, t reflect.Type) any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType, t == extensionsType, t == rawMessageType:
+		return v
+	case t == durationType, t == intervalType:
+		s, ok := v.(string)
+		if !ok {
+			return v
+		}
+		if t == intervalType {
+			return c.interval(s)
+		}
+		return c.duration(s)
+	}
+	if e, ok := protoEnums[t]; ok {
+		s, ok := v.(string)
+		if !ok {
+			return v
+		}
+		names, built := c.enums[t]
+		if !built {
+			to, from := e.protoNames()
+			names = [2]map[string]string{to, from}
+			c.enums[t] = names
+		}
+		m := names[1]
+		if c.toProto {
+			m = names[0]
+		}
+		if n, ok := m[s]; ok {
+			return n
+		}
+		return s
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if obj, ok := v.(map[string]any); ok {
+			c.object(obj, t)
+		}
+	case reflect.Slice, reflect.Array:
+		if arr, ok := v.([]any); ok {
+			for i := range arr {
+				arr[i] = c.value(arr[i], t.Elem())
+			}
+		}
+	}
+	return v
+}
+
+// object renames and converts the members of obj declared by struct type t.
+func (c *protoConverter) object(obj map[string]any, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			for ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				c.object(obj, ft)
+				continue
+			}
+		}
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		from, to := name, camelCase(name)
+		if !c.toProto {
+			from, to = to, from
+		}
+		v, ok := obj[from]
+		if !ok {
+			// Protobuf parsers accept the original field name as well.
+			if v, ok = obj[to]; !ok {
+				continue
+			}
+		}
+		delete(obj, from)
+		obj[to] = c.value(v, f.Type)
+	}
+}
+
+// duration converts between ISO 8601 and google.protobuf.Duration strings.
+// Strings that do not parse are left as they are.
+func (c *protoConverter) duration(s string) string {
+	if c.toProto {
+		d, err := types.ParseISODuration(s)
+		if err != nil {
+			return s
+		}
+		return formatProtoDuration(d)
+	}
+	d, err := parseProtoDuration(s)
+	if err != nil {
+		return s
+	}
+	return types.FormatISODuration(d)
+}
+
+// interval converts an interval like duration, except that one minute is
+// written as "minute" when converting back.
+func (c *protoConverter) interval(s string) string {
+	if c.toProto {
+		return c.duration(types.Interval(s).ISO())
+	}
+	d, err := parseProtoDuration(s)
+	if err != nil {
+		return s
+	}
+	return string(types.IntervalOf(d))
+}
+
+// formatProtoDuration writes d as protojson does: seconds with 0, 3, 6,
+// or 9 fractional digits and an "s" suffix.
+func formatProtoDuration(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	secs, nanos := int64(d/time.Second), int64(d%time.Second)
+	s := sign + strconv.FormatInt(secs, 10)
+	switch {
+	case nanos == 0:
+	case nanos%1e6 == 0:
+		s += fmt.Sprintf(".%03d", nanos/1e6)
+	case nanos%1e3 == 0:
+		s += fmt.Sprintf(".%06d", nanos/1e3)
+	default:
+		s += fmt.Sprintf(".%09d", nanos)
+	}
+	return s + "s"
+}
+
+func parseProtoDuration(s string) (time.Duration, error) {
+	num, ok := strings.CutSuffix(s, "s")
+	if !ok || num == "" {
+		return 0, fmt.Errorf("codec: invalid protojson duration %q", s)
+	}
+	neg := strings.HasPrefix(num, "-")
+	num = strings.TrimPrefix(num, "-")
+	whole, frac, _ := strings.Cut(num, ".")
+	if len(frac) > 9 {
+		return 0, fmt.Errorf("codec: invalid protojson duration %q", s)
+	}
+	secs, err := strconv.ParseInt(whole, 10, 64)
+	if err != nil || secs > int64(1<<63-1)/int64(time.Second) {
+		return 0, fmt.Errorf("codec: invalid protojson duration %q", s)
+	}
+	var nanos int64
+	if frac != "" {
+		if nanos, err = strconv.ParseInt(frac+strings.Repeat("0", 9-len(frac)), 10, 64); err != nil || nanos < 0 {
+			return 0, fmt.Errorf("codec: invalid protojson duration %q", s)
+		}
+	}
+	d := time.Duration(secs)*time.Second + time.Duration(nanos)
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+func marshalProtoJSON(s *types.Series, format Format) ([]byte, error) {
+	if format&(Compact|Quantized) != 0 {
+		return nil, ErrProtoJSONFormat
+	}
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return ToProtoJSON(b, (*types.Series)(nil))
+}
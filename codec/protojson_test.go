@@ -0,0 +1,142 @@
+package codec
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestProtoJSONRoundTrip(t *testing.T) {
+	s := weekSeries(3)
+	s.Methodology.CollectionLag = types.ISODuration(90*time.Minute + 1500*time.Millisecond)
+	s.Annotations = []types.Annotation{{Start: s.Points[0].TS, Kind: types.AnnotationOutage}}
+	s.Retraction = &types.Retraction{Topic: s.Topic, Reason: types.RetractionDataError, EffectiveAt: s.GeneratedAt}
+
+	b, err := Marshal(s, ProtoJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`"generatedAt":"2025-01-06T00:03:00Z"`, `"interval":"60s"`, `"collectionLag":"5401.500s"`,
+		`"kind":"ANNOTATION_KIND_PLATFORM_OUTAGE"`, `"reason":"RETRACTION_REASON_DATA_ERROR"`,
+		`"coordinationSignals":{`, `"acctTypeShares":{"person":1}`, `"x-acme-points":[1,2]`,
+	} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("ProtoJSON output lacks %s:\n%s", want, b)
+		}
+	}
+	if strings.Contains(string(b), "generated_at") {
+		t.Errorf("ProtoJSON output has snake_case members:\n%s", b)
+	}
+
+	var got types.Series
+	if err := UnmarshalWith(b, &got, DecodeOptions{ProtoJSON: true}); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(&got, s) {
+		t.Errorf("round trip:\ngot  %+v\nwant %+v", got, *s)
+	}
+
+	canonical, err := Marshal(s, Verbose)
+	if err != nil {
+		t.Fatal(err)
+	}
+	back, err := FromProtoJSON(b, (*types.Series)(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !jsonEqual(t, back, canonical) {
+		t.Errorf("FromProtoJSON:\ngot  %s\nwant %s", back, canonical)
+	}
+}
+
+func TestProtoJSONTag(t *testing.T) {
+	tag := types.ProvenanceTag{
+		AcctAgeBucket: types.AcctAge_24mPlus, AcctType: types.AcctTypePublicOfficial,
+		AutomationFlag: types.AutomationAPICLIENT, PostKind: types.PostKindReshare,
+		ClientFamily: types.ClientWeb, MediaProvenance: types.MediaProvC2PA,
+		DedupHash: "a1b2c3d4", DedupHashAlg: types.DedupSipHash24,
+	}
+	canonical, _ := json.Marshal(tag)
+	b, err := ToProtoJSON(canonical, tag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"acctAgeBucket":"ACCT_AGE_BUCKET_24M_PLUS","acctType":"ACCT_TYPE_PUBLIC_OFFICIAL",` +
+		`"automationFlag":"AUTOMATION_FLAG_API_CLIENT","clientFamily":"CLIENT_FAMILY_WEB",` +
+		`"dedupHash":"a1b2c3d4","dedupHashAlg":"DEDUP_HASH_ALG_SIPHASH_2_4",` +
+		`"mediaProvenance":"MEDIA_PROVENANCE_C2PA_PRESENT","postKind":"POST_KIND_RESHARE"}`
+	if string(b) != want {
+		t.Errorf("ToProtoJSON =\n%s\nwant\n%s", b, want)
+	}
+	back, err := FromProtoJSON(b, &tag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !jsonEqual(t, back, canonical) {
+		t.Errorf("FromProtoJSON = %s, want %s", back, canonical)
+	}
+}
+
+func TestFromProtoJSONLenient(t *testing.T) {
+	// Original field names are accepted, and unknown enum values pass
+	// through for validation to reject.
+	in := `{"acct_age_bucket":"ACCT_AGE_BUCKET_0_7D","acctType":"robot","dedupHash":"a1b2c3d4"}`
+	got, err := FromProtoJSON([]byte(in), types.ProvenanceTag{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"acct_age_bucket":"0-7d","acct_type":"robot","dedup_hash":"a1b2c3d4"}`
+	if string(got) != want {
+		t.Errorf("FromProtoJSON = %s, want %s", got, want)
+	}
+}
+
+func TestProtoJSONFormatErrors(t *testing.T) {
+	for _, f := range []Format{Compact | ProtoJSON, ProtoJSON | Quantized} {
+		if _, err := Marshal(weekSeries(1), f); err != ErrProtoJSONFormat {
+			t.Errorf("Marshal(%#x) error = %v, want ErrProtoJSONFormat", f, err)
+		}
+	}
+	if _, err := ToProtoJSON([]byte(`{}`), "series"); err == nil {
+		t.Error("ToProtoJSON with a string shape: want error")
+	}
+}
+
+func TestProtoDuration(t *testing.T) {
+	for _, c := range []struct {
+		d time.Duration
+		s string
+	}{
+		{0, "0s"}, {time.Minute, "60s"}, {1500 * time.Millisecond, "1.500s"},
+		{time.Microsecond, "0.000001s"}, {-time.Nanosecond, "-0.000000001s"},
+	} {
+		if got := formatProtoDuration(c.d); got != c.s {
+			t.Errorf("formatProtoDuration(%v) = %q, want %q", c.d, got, c.s)
+		}
+		if got, err := parseProtoDuration(c.s); err != nil || got != c.d {
+			t.Errorf("parseProtoDuration(%q) = %v, %v; want %v", c.s, got, err, c.d)
+		}
+	}
+	for _, s := range []string{"", "s", "60", "1.0000000001s", "x1s"} {
+		if _, err := parseProtoDuration(s); err == nil {
+			t.Errorf("parseProtoDuration(%q): want error", s)
+		}
+	}
+}
+
+func jsonEqual(t *testing.T, a, b []byte) bool {
+	t.Helper()
+	var x, y any
+	if err := json.Unmarshal(a, &x); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &y); err != nil {
+		t.Fatal(err)
+	}
+	return reflect.DeepEqual(x, y)
+}
@@ -0,0 +1,196 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// Quantized may be combined with Verbose or Compact (e.g., Compact|Quantized)
+// to write every ratio as integer basis points (0–10000) instead of a float.
+// Quantized members carry a "_bp" suffix ("reshare_ratio_bp": 2500), so
+// Unmarshal recognizes and converts them automatically.
+const Quantized Format = 1 << 8
+
+// bpSuffix marks quantized members and compact columns.
+const bpSuffix = "_bp"
+
+// ratioKeys are Point members (at any depth) holding a single Probability.
+var ratioKeys = map[string]bool{
+	"reshare_ratio": true, "recycled_content_rate": true,
+	"burst_score": true, "synchrony_index": true,
+}
+
+// mixKeys are Point members holding a map of Probability values.
+var mixKeys = map[string]bool{
+	"acct_age_mix": true, "automation_mix": true, "client_mix": true,
+	"acct_type_shares": true, "post_kind_mix": true,
+}
+
+func marshalFormat(v any, format Format) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || format&Quantized == 0 {
+		return b, err
+	}
+	tree, err := decodeTree(b)
+	if err != nil {
+		return nil, err
+	}
+	quantizeDoc(tree)
+	return json.Marshal(tree)
+}
+
+func decodeTree(b []byte) (map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var tree map[string]any
+	err := dec.Decode(&tree)
+	return tree, err
+}
+
+func quantizeDoc(doc map[string]any) {
+	if fields, ok := doc["fields"].([]any); ok {
+		rows, _ := doc["points"].([]any)
+		for j, f := range fields {
+			name, _ := f.(string)
+			if !ratioKeys[name] && !mixKeys[name] {
+				continue
+			}
+			fields[j] = name + bpSuffix
+			for _, r := range rows {
+				if row, ok := r.([]any); ok && j < len(row) {
+					row[j] = toBP(row[j])
+				}
+			}
+		}
+		return
+	}
+	pts, _ := doc["points"].([]any)
+	for _, p := range pts {
+		if obj, ok := p.(map[string]any); ok {
+			quantizeObject(obj)
+		}
+	}
+}
+
+func quantizeObject(obj map[string]any) {
+	for k, v := range obj {
+		switch {
+		case ratioKeys[k] || mixKeys[k]:
+			delete(obj, k)
+			obj[k+bpSuffix] = toBP(v)
+		default:
+			if child, ok := v.(map[string]any); ok {
+				quantizeObject(child)
+			}
+		}
+	}
+}
+
+// toBP converts a JSON number, or an object of numbers, to basis points.
+func toBP(v any) any {
+	switch x := v.(type) {
+	case json.Number:
+		f, err := x.Float64()
+		if err != nil {
+			return v
+		}
+		return int(types.Probability(f).BasisPoints())
+	case map[string]any:
+		for k, e := range x {
+			x[k] = toBP(e)
+		}
+	}
+	return v
+}
+
+// dequantize rewrites "_bp" members and compact columns back to floats.
+// It returns data unchanged when it holds no quantized members.
+func dequantize(data []byte) ([]byte, error) {
+	if !bytes.Contains(data, []byte(bpSuffix+`"`)) {
+		return data, nil
+	}
+	tree, err := decodeTree(data)
+	if err != nil {
+		return nil, err
+	}
+	if fields, ok := tree["fields"].([]any); ok {
+		rows, _ := tree["points"].([]any)
+		for j, f := range fields {
+			name, _ := f.(string)
+			base := strings.TrimSuffix(name, bpSuffix)
+			if base == name || (!ratioKeys[base] && !mixKeys[base]) {
+				continue
+			}
+			fields[j] = base
+			for i, r := range rows {
+				if row, ok := r.([]any); ok && j < len(row) {
+					if row[j], err = fromBP(row[j]); err != nil {
+						return nil, fmt.Errorf("codec: points[%d].%s: %w", i, name, err)
+					}
+				}
+			}
+		}
+	} else if pts, ok := tree["points"].([]any); ok {
+		for i, p := range pts {
+			if obj, ok := p.(map[string]any); ok {
+				if err := dequantizeObject(obj); err != nil {
+					return nil, fmt.Errorf("codec: points[%d]: %w", i, err)
+				}
+			}
+		}
+	}
+	return json.Marshal(tree)
+}
+
+func dequantizeObject(obj map[string]any) error {
+	for k, v := range obj {
+		base := strings.TrimSuffix(k, bpSuffix)
+		if base != k && (ratioKeys[base] || mixKeys[base]) {
+			if _, dup := obj[base]; dup {
+				return fmt.Errorf("both %s and %s present", base, k)
+			}
+			f, err := fromBP(v)
+			if err != nil {
+				return fmt.Errorf("%s: %w", k, err)
+			}
+			delete(obj, k)
+			obj[base] = f
+			continue
+		}
+		if child, ok := v.(map[string]any); ok {
+			if err := dequantizeObject(child); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fromBP converts integer basis points (or an object of them) to floats.
+// Out-of-range values are kept so validation can report them.
+func fromBP(v any) (any, error) {
+	switch x := v.(type) {
+	case nil:
+		return nil, nil
+	case json.Number:
+		n, err := x.Int64()
+		if err != nil {
+			return nil, fmt.Errorf("basis points must be an integer, got %s", x)
+		}
+		return float64(types.BasisPoints(n).Probability()), nil
+	case map[string]any:
+		for k, e := range x {
+			f, err := fromBP(e)
+			if err != nil {
+				return nil, err
+			}
+			x[k] = f
+		}
+		return x, nil
+	}
+	return nil, fmt.Errorf("basis points must be an integer, got %T", v)
+}
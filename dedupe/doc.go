@@ -0,0 +1,4 @@
+// dedupe/doc.go
+// Package dedupe computes content dedup hashes and tracks recently seen
+// submission keys so at-least-once pipelines can drop retried deliveries.
+package dedupe
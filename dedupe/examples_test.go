@@ -0,0 +1,29 @@
+package dedupe_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/dedupe"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func ExampleWindow() {
+	tag := &types.ProvenanceTag{PostKind: types.PostKindReshare, DedupHash: "deadbeef"}
+	const first, second types.SubmissionID = "01JGFJJZ000000000000000000", "01JGFJJZ000000000000000001"
+	t0 := time.Date(2025, 1, 1, 12, 0, 5, 0, time.UTC)
+	w := dedupe.NewWindow(10 * time.Minute)
+
+	fmt.Println(w.Seen(types.SubmissionKey(first, tag, t0), t0))
+	retry := t0.Add(30 * time.Second)
+	fmt.Println(w.Seen(types.SubmissionKey(first, tag, retry), retry))
+	// An identical reshare in its own submission is counted.
+	fmt.Println(w.Seen(types.SubmissionKey(second, tag, retry), retry))
+	later := t0.Add(11 * time.Minute)
+	fmt.Println(w.Prune(later), w.Len())
+	// Output:
+	// false
+	// true
+	// false
+	// 2 0
+}
@@ -0,0 +1,174 @@
+package dedupe
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/bits"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// Hash computes the dedup hash of content with algorithm alg.
+//
+// key is the algorithm's secret: the daily salt for sha256-trunc8 (any
+// length), the 16-byte key for siphash-2-4, and for xxhash64 an optional
+// 8-byte little-endian seed (empty means seed 0).
+func Hash(alg types.DedupHashAlg, key, content []byte) (types.HexHash8, error) {
+	switch alg {
+	case "", types.DedupSHA256Trunc8:
+		h := sha256.New()
+		h.Write(key)
+		h.Write(content)
+		return types.HexHash8(hex.EncodeToString(h.Sum(nil)[:4])), nil
+	case types.DedupSipHash24:
+		if len(key) != 16 {
+			return "", fmt.Errorf("dedupe: siphash-2-4 key must be 16 bytes, got %d", len(key))
+		}
+		k0 := binary.LittleEndian.Uint64(key[:8])
+		k1 := binary.LittleEndian.Uint64(key[8:])
+		return hex64(sipHash24(k0, k1, content)), nil
+	case types.DedupXXHash64:
+		var seed uint64
+		switch len(key) {
+		case 0:
+		case 8:
+			seed = binary.LittleEndian.Uint64(key)
+		default:
+			return "", fmt.Errorf("dedupe: xxhash64 seed must be 0 or 8 bytes, got %d", len(key))
+		}
+		return hex64(xxHash64(seed, content)), nil
+	}
+	return "", fmt.Errorf("dedupe: unknown dedup hash algorithm %q", alg)
+}
+
+// Verify reports whether t.DedupHash is the hash of content under
+// t.DedupHashAlg and key.
+func Verify(t *types.ProvenanceTag, key, content []byte) bool {
+	h, err := Hash(t.DedupHashAlg, key, content)
+	return err == nil && h == t.DedupHash
+}
+
+func hex64(v uint64) types.HexHash8 {
+	return types.HexHash8(fmt.Sprintf("%016x", v))
+}
+
+// sipHash24 implements SipHash-2-4 (Aumasson & Bernstein).
+func sipHash24(k0, k1 uint64, m []byte) uint64 {
+	v0 := k0 ^ 0x736f6d6570736575
+	v1 := k1 ^ 0x646f72616e646f6d
+	v2 := k0 ^ 0x6c7967656e657261
+	v3 := k1 ^ 0x7465646279746573
+
+	round := func() {
+		v0 += v1
+		v1 = bits.RotateLeft64(v1, 13)
+		v1 ^= v0
+		v0 = bits.RotateLeft64(v0, 32)
+		v2 += v3
+		v3 = bits.RotateLeft64(v3, 16)
+		v3 ^= v2
+		v0 += v3
+		v3 = bits.RotateLeft64(v3, 21)
+		v3 ^= v0
+		v2 += v1
+		v1 = bits.RotateLeft64(v1, 17)
+		v1 ^= v2
+		v2 = bits.RotateLeft64(v2, 32)
+	}
+
+	n := len(m)
+	for len(m) >= 8 {
+		w := binary.LittleEndian.Uint64(m)
+		v3 ^= w
+		round()
+		round()
+		v0 ^= w
+		m = m[8:]
+	}
+	var last [8]byte
+	copy(last[:], m)
+	last[7] = byte(n)
+	w := binary.LittleEndian.Uint64(last[:])
+	v3 ^= w
+	round()
+	round()
+	v0 ^= w
+
+	v2 ^= 0xff
+	round()
+	round()
+	round()
+	round()
+	return v0 ^ v1 ^ v2 ^ v3
+}
+
+const (
+	xxPrime1 uint64 = 11400714785074694791
+	xxPrime2 uint64 = 14029467366897019727
+	xxPrime3 uint64 = 1609587929392839161
+	xxPrime4 uint64 = 9650029242287828579
+	xxPrime5 uint64 = 2870177450012600261
+)
+
+func xxRound(acc, input uint64) uint64 {
+	acc += input * xxPrime2
+	acc = bits.RotateLeft64(acc, 31)
+	return acc * xxPrime1
+}
+
+func xxMerge(acc, val uint64) uint64 {
+	acc ^= xxRound(0, val)
+	return acc*xxPrime1 + xxPrime4
+}
+
+// xxHash64 implements XXH64 (Yann Collet).
+func xxHash64(seed uint64, b []byte) uint64 {
+	n := len(b)
+	var h uint64
+	if n >= 32 {
+		v1 := seed + xxPrime1 + xxPrime2
+		v2 := seed + xxPrime2
+		v3 := seed
+		v4 := seed - xxPrime1
+		for len(b) >= 32 {
+			v1 = xxRound(v1, binary.LittleEndian.Uint64(b[0:]))
+			v2 = xxRound(v2, binary.LittleEndian.Uint64(b[8:]))
+			v3 = xxRound(v3, binary.LittleEndian.Uint64(b[16:]))
+			v4 = xxRound(v4, binary.LittleEndian.Uint64(b[24:]))
+			b = b[32:]
+		}
+		h = bits.RotateLeft64(v1, 1) + bits.RotateLeft64(v2, 7) +
+			bits.RotateLeft64(v3, 12) + bits.RotateLeft64(v4, 18)
+		h = xxMerge(h, v1)
+		h = xxMerge(h, v2)
+		h = xxMerge(h, v3)
+		h = xxMerge(h, v4)
+	} else {
+		h = seed + xxPrime5
+	}
+	h += uint64(n)
+
+	for len(b) >= 8 {
+		h ^= xxRound(0, binary.LittleEndian.Uint64(b))
+		h = bits.RotateLeft64(h, 27)*xxPrime1 + xxPrime4
+		b = b[8:]
+	}
+	if len(b) >= 4 {
+		h ^= uint64(binary.LittleEndian.Uint32(b)) * xxPrime1
+		h = bits.RotateLeft64(h, 23)*xxPrime2 + xxPrime3
+		b = b[4:]
+	}
+	for _, c := range b {
+		h ^= uint64(c) * xxPrime5
+		h = bits.RotateLeft64(h, 11) * xxPrime1
+	}
+
+	h ^= h >> 33
+	h *= xxPrime2
+	h ^= h >> 29
+	h *= xxPrime3
+	h ^= h >> 32
+	return h
+}
@@ -0,0 +1,42 @@
+package dedupe
+
+import (
+	"testing"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestHashVectors(t *testing.T) {
+	seq := func(n int) []byte {
+		b := make([]byte, n)
+		for i := range b {
+			b[i] = byte(i)
+		}
+		return b
+	}
+	cases := []struct {
+		alg          types.DedupHashAlg
+		key, content []byte
+		want         types.HexHash8
+	}{
+		// Reference vector from the SipHash paper, appendix A.
+		{types.DedupSipHash24, seq(16), seq(15), "a129ca6149be45e5"},
+		// XXH64 reference values.
+		{types.DedupXXHash64, nil, nil, "ef46db3751d8e999"},
+		{types.DedupXXHash64, nil, []byte("a"), "d24ec4f1a98c6e5b"},
+		{types.DedupXXHash64, nil, []byte("Nobody inspects the spammish repetition"), "fbcea83c8a378bf1"},
+		{types.DedupSHA256Trunc8, nil, []byte("abc"), "ba7816bf"},
+	}
+	for _, c := range cases {
+		got, err := Hash(c.alg, c.key, c.content)
+		if err != nil {
+			t.Fatalf("%s: %v", c.alg, err)
+		}
+		if got != c.want {
+			t.Errorf("%s(%q) = %s, want %s", c.alg, c.content, got, c.want)
+		}
+		if !c.alg.MatchesShape(got) {
+			t.Errorf("%s: %s does not match shape", c.alg, got)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package dedupe
+
+import (
+	"sync"
+	"time"
+)
+
+// Window remembers keys for a fixed TTL. It is safe for concurrent use.
+// Keys are typically produced by types.SubmissionKey.
+type Window struct {
+	ttl  time.Duration
+	mu   sync.Mutex
+	seen map[string]time.Time // key -> expiry
+}
+
+// NewWindow returns a Window that forgets keys ttl after they were first seen.
+func NewWindow(ttl time.Duration) *Window {
+	return &Window{ttl: ttl, seen: make(map[string]time.Time)}
+}
+
+// Seen records key at now and reports whether it was already present and
+// unexpired. A true result means the submission is a duplicate.
+func (w *Window) Seen(key string, now time.Time) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if exp, ok := w.seen[key]; ok && now.Before(exp) {
+		return true
+	}
+	w.seen[key] = now.Add(w.ttl)
+	return false
+}
+
+// Contains reports whether key is present and unexpired at now without
+// recording it.
+func (w *Window) Contains(key string, now time.Time) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	exp, ok := w.seen[key]
+	return ok && now.Before(exp)
+}
+
+// Prune drops keys that have expired at now and returns how many were removed.
+func (w *Window) Prune(now time.Time) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	n := 0
+	for k, exp := range w.seen {
+		if !now.Before(exp) {
+			delete(w.seen, k)
+			n++
+		}
+	}
+	return n
+}
+
+// Len returns the number of tracked keys, including any not yet pruned.
+func (w *Window) Len() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.seen)
+}
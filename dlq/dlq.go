@@ -0,0 +1,214 @@
+package dlq
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/codec"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+	"github.com/civic-interconnect/civic-transparency-go-types/validate"
+)
+
+// CodeDecode is the code recorded for payloads that fail to decode. It is
+// not part of the validate catalog, which covers decoded documents only.
+const CodeDecode validate.ErrorCode = "ERR_DECODE"
+
+// Key returns the DeadLetter key of payload.
+func Key(payload []byte) string {
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// New wraps a payload that failed at stage with err. The payload is copied.
+// sub is the submission the payload arrived in, or empty if unknown.
+func New(payload []byte, source string, sub types.SubmissionID, stage types.DeadLetterStage, err error, now time.Time) types.DeadLetter {
+	dl := types.DeadLetter{
+		Key:          Key(payload),
+		SubmissionID: sub,
+		Source:       source,
+		Payload:      append([]byte(nil), payload...),
+		FirstSeen:    now.UTC(),
+	}
+	Record(&dl, stage, err, now)
+	return dl
+}
+
+// Record notes another failed attempt of dl: it bumps Attempts, sets
+// LastSeen and Stage, replaces Errors with those of err, and adds any new
+// codes to Codes.
+func Record(dl *types.DeadLetter, stage types.DeadLetterStage, err error, now time.Time) {
+	dl.Attempts++
+	dl.LastSeen = now.UTC()
+	dl.Stage = stage
+	// Fresh slices: dl may share backing arrays with copies handed out
+	// by Queue.List.
+	dl.Errors = nil
+	dl.Codes = dl.Codes[:len(dl.Codes):len(dl.Codes)]
+	seen := make(map[string]bool, len(dl.Codes))
+	for _, c := range dl.Codes {
+		seen[c] = true
+	}
+	for _, e := range flatten(err) {
+		dl.Errors = append(dl.Errors, e.Error())
+		c := validate.CodeOf(e)
+		if c == "" && stage == types.StageDecode {
+			c = CodeDecode
+		}
+		if c != "" && !seen[string(c)] {
+			seen[string(c)] = true
+			dl.Codes = append(dl.Codes, string(c))
+		}
+	}
+}
+
+// flatten splits a *validate.MultiError into its members.
+func flatten(err error) []error {
+	if err == nil {
+		return nil
+	}
+	var me *validate.MultiError
+	if errors.As(err, &me) {
+		return me.Errors()
+	}
+	return []error{err}
+}
+
+// DecodeSeries decodes payload with codec.Unmarshal, so the compact and
+// quantized forms are accepted and timestamps are converted to UTC, and
+// validates it with opts. On failure it returns a DeadLetter for submission
+// sub instead of the series.
+func DecodeSeries(payload []byte, source string, sub types.SubmissionID, opts validate.Options, now time.Time) (*types.Series, *types.DeadLetter) {
+	var s types.Series
+	if err := codec.Unmarshal(payload, &s); err != nil {
+		dl := New(payload, source, sub, types.StageDecode, err, now)
+		return nil, &dl
+	}
+	if err := validate.ValidateSeriesWith(&s, opts); err != nil {
+		dl := New(payload, source, sub, types.StageValidate, err, now)
+		return nil, &dl
+	}
+	return &s, nil
+}
+
+// DecodeTag is DecodeSeries for a ProvenanceTag.
+func DecodeTag(payload []byte, source string, sub types.SubmissionID, opts validate.Options, now time.Time) (*types.ProvenanceTag, *types.DeadLetter) {
+	var t types.ProvenanceTag
+	if err := json.NewDecoder(bytes.NewReader(payload)).Decode(&t); err != nil {
+		dl := New(payload, source, sub, types.StageDecode, err, now)
+		return nil, &dl
+	}
+	if err := validate.ValidateProvenanceTagWith(&t, opts); err != nil {
+		dl := New(payload, source, sub, types.StageValidate, err, now)
+		return nil, &dl
+	}
+	return &t, nil
+}
+
+// Queue is an in-memory dead-letter store keyed by DeadLetter.Key. It is
+// safe for concurrent use; the zero value is ready to use.
+type Queue struct {
+	mu sync.Mutex
+	m  map[string]types.DeadLetter
+}
+
+// Put adds dl, merging it into an existing record for the same payload:
+// attempts add up, FirstSeen and SubmissionID come from the earlier
+// record, and Stage, Errors, and LastSeen come from the later one.
+func (q *Queue) Put(dl types.DeadLetter) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.m == nil {
+		q.m = map[string]types.DeadLetter{}
+	}
+	prev, ok := q.m[dl.Key]
+	if !ok {
+		q.m[dl.Key] = dl
+		return
+	}
+	merged := prev
+	merged.Attempts += dl.Attempts
+	if merged.SubmissionID == "" || dl.SubmissionID != "" && dl.FirstSeen.Before(merged.FirstSeen) {
+		merged.SubmissionID = dl.SubmissionID
+	}
+	if dl.FirstSeen.Before(merged.FirstSeen) {
+		merged.FirstSeen = dl.FirstSeen
+	}
+	if !dl.LastSeen.Before(prev.LastSeen) {
+		merged.LastSeen, merged.Stage, merged.Errors = dl.LastSeen, dl.Stage, dl.Errors
+	}
+	for _, c := range dl.Codes {
+		if !contains(merged.Codes, c) {
+			merged.Codes = append(merged.Codes, c)
+		}
+	}
+	q.m[dl.Key] = merged
+}
+
+// Len returns the number of records.
+func (q *Queue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.m)
+}
+
+// List returns the records, oldest FirstSeen first.
+func (q *Queue) List() []types.DeadLetter {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	out := make([]types.DeadLetter, 0, len(q.m))
+	for _, dl := range q.m {
+		out = append(out, dl)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].FirstSeen.Equal(out[j].FirstSeen) {
+			return out[i].FirstSeen.Before(out[j].FirstSeen)
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+// Remove deletes the record with key, reporting whether it existed.
+func (q *Queue) Remove(key string) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	_, ok := q.m[key]
+	delete(q.m, key)
+	return ok
+}
+
+// Reprocess retries every record in q with process, oldest first. Records
+// that process accepts (returns nil for) are removed; the rest have the
+// failure recorded at the stage process reports. It returns the number of
+// records accepted.
+func (q *Queue) Reprocess(process func(dl types.DeadLetter) (types.DeadLetterStage, error), now time.Time) int {
+	ok := 0
+	for _, dl := range q.List() {
+		stage, err := process(dl)
+		q.mu.Lock()
+		if err == nil {
+			delete(q.m, dl.Key)
+			ok++
+		} else if cur, present := q.m[dl.Key]; present {
+			Record(&cur, stage, err, now)
+			q.m[dl.Key] = cur
+		}
+		q.mu.Unlock()
+	}
+	return ok
+}
+
+func contains(xs []string, x string) bool {
+	for _, y := range xs {
+		if y == x {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package dlq
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/codec"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+	"github.com/civic-interconnect/civic-transparency-go-types/validate"
+)
+
+func TestDecodeSeriesCodecForms(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &types.Series{
+		Topic: "#vote", GeneratedAt: t0.Add(time.Hour), Interval: types.IntervalMinute,
+		Points: []types.Point{{TS: t0, Volume: 10, ReshareRatio: 0.5}},
+	}
+	for _, f := range []codec.Format{codec.Verbose, codec.Compact, codec.Compact | codec.Quantized} {
+		b, err := codec.Marshal(s, f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, dl := DecodeSeries(b, "p1", "", validate.Options{}, t0)
+		if dl != nil {
+			t.Fatalf("format %d: dead letter %+v", f, dl)
+		}
+		if len(got.Points) != 1 || got.Points[0].Volume != 10 || got.Points[0].ReshareRatio != 0.5 {
+			t.Errorf("format %d: points = %+v", f, got.Points)
+		}
+	}
+	local := []byte(`{"topic":"#vote","generated_at":"2025-01-01T03:00:00+02:00","interval":"PT1M",
+		"points":[{"ts":"2025-01-01T02:00:00+02:00","volume":1}]}`)
+	got, dl := DecodeSeries(local, "p1", "", validate.Options{}, t0)
+	if dl != nil {
+		t.Fatalf("dead letter %+v", dl)
+	}
+	if !got.Points[0].TS.Equal(t0) || got.Points[0].TS.Location() != time.UTC {
+		t.Errorf("ts = %v, want converted to UTC", got.Points[0].TS)
+	}
+}
+
+func TestSubmissionID(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	const first, second types.SubmissionID = "01JGFJJZ000000000000000000", "01JGFJJZ000000000000000001"
+	_, dl := DecodeSeries([]byte(`{`), "p1", first, validate.Options{}, t0)
+	if dl == nil || dl.SubmissionID != first {
+		t.Fatalf("DecodeSeries dead letter = %+v", dl)
+	}
+	var q Queue
+	q.Put(New([]byte(`{`), "p1", "", types.StageDecode, errors.New("x"), t0.Add(-time.Minute)))
+	q.Put(*dl)
+	q.Put(New([]byte(`{`), "p1", second, types.StageDecode, errors.New("x"), t0.Add(time.Minute)))
+	if got := q.List()[0].SubmissionID; got != first {
+		t.Errorf("merged SubmissionID = %q, want earliest known %q", got, first)
+	}
+}
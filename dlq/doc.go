@@ -0,0 +1,12 @@
+// dlq/doc.go
+// Package dlq wraps payloads that ingestion rejected into types.DeadLetter
+// records and helps re-processing pipelines retry them.
+//
+//	s, dl := dlq.DecodeSeries(body, "pub_0f3a", subID, opts, time.Now())
+//	if dl != nil {
+//		q.Put(*dl) // persist and move on
+//	}
+//
+// Repeated failures of the same payload share a Key, so a queue keeps one
+// record per payload with a running attempt count.
+package dlq
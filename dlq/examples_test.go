@@ -0,0 +1,42 @@
+package dlq_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/dlq"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+	"github.com/civic-interconnect/civic-transparency-go-types/validate"
+)
+
+func ExampleQueue() {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var q dlq.Queue
+
+	bad := []byte(`{"topic":"#vote","generated_at":"2025-01-01T00:05:00Z","interval":"PT1M",
+		"points":[{"ts":"2025-01-01T00:00:00Z","volume":3,"reshare_ratio":1.5}]}`)
+	for i := 0; i < 2; i++ {
+		if _, dl := dlq.DecodeSeries(bad, "pub_0f3a", "", validate.Options{}, now.Add(time.Duration(i)*time.Minute)); dl != nil {
+			q.Put(*dl)
+		}
+	}
+	_, dl := dlq.DecodeSeries([]byte(`{"topic":`), "pub_0f3a", "", validate.Options{}, now)
+	q.Put(*dl)
+
+	for _, dl := range q.List() {
+		fmt.Println(dl.Stage, dl.Attempts, dl.Codes, dl.Errors)
+	}
+
+	// After a fix upstream, retry: accept decodable payloads only.
+	accepted := q.Reprocess(func(dl types.DeadLetter) (types.DeadLetterStage, error) {
+		if dl.Stage == types.StageDecode {
+			return types.StageDecode, fmt.Errorf("still truncated")
+		}
+		return "", nil
+	}, now.Add(time.Hour))
+	fmt.Println(accepted, q.Len(), q.List()[0].Attempts)
+	// Output:
+	// validate 2 [ERR_RATIO_RANGE] [points[0].reshare_ratio must be 0–1]
+	// decode 1 [ERR_DECODE] [unexpected end of JSON input]
+	// 1 1 2
+}
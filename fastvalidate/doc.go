@@ -0,0 +1,20 @@
+// fastvalidate/doc.go
+// Package fastvalidate checks large slices of Points against the same
+// per-point rules as validate.ValidateSeriesWith, without building error
+// values.
+//
+// Points are copied into flat per-field columns and each rule runs as a
+// branch-free loop over one column, so the compiler emits compare-and-set
+// code with no per-point interface calls or allocations. The result is only
+// the indices of failing points; callers that need messages re-run the
+// validate package on those points. Series-level rules (topic, interval,
+// annotations, extensions) are not covered.
+//
+// Typical use is a bulk job that validates many series and reports a
+// handful of failures:
+//
+//	bad := fastvalidate.Invalid(s, opts, nil)
+//	if len(bad) > 0 {
+//		err := validate.ValidateSeriesWith(s, opts) // full messages
+//	}
+package fastvalidate
@@ -0,0 +1,190 @@
+package fastvalidate
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+	"github.com/civic-interconnect/civic-transparency-go-types/validate"
+)
+
+// Columns is a struct-of-arrays copy of the scalar fields of a []Point.
+// Reuse one Columns across calls to Load to avoid reallocating.
+type Columns struct {
+	Volume      []int
+	Reshare     []float64
+	Recycled    []float64
+	Burst       []float64
+	Synchrony   []float64
+	DupClusters []int
+
+	flags []uint8
+}
+
+// Load replaces c's contents with the scalar fields of pts.
+func (c *Columns) Load(pts []types.Point) {
+	n := len(pts)
+	c.Volume = resize(c.Volume, n)
+	c.Reshare = resize(c.Reshare, n)
+	c.Recycled = resize(c.Recycled, n)
+	c.Burst = resize(c.Burst, n)
+	c.Synchrony = resize(c.Synchrony, n)
+	c.DupClusters = resize(c.DupClusters, n)
+	for i := range pts {
+		p := &pts[i]
+		c.Volume[i] = p.Volume
+		c.Reshare[i] = float64(p.ReshareRatio)
+		c.Recycled[i] = float64(p.RecycledContentRate)
+		c.Burst[i] = float64(p.CoordinationSignals.BurstScore)
+		c.Synchrony[i] = float64(p.CoordinationSignals.SynchronyIndex)
+		c.DupClusters[i] = p.CoordinationSignals.DuplicationClusters
+	}
+}
+
+// Len returns the number of loaded points.
+func (c *Columns) Len() int { return len(c.Volume) }
+
+// mark runs every scalar rule and leaves a non-zero flag for each failing
+// point. All columns must have equal length.
+func (c *Columns) mark() []uint8 {
+	n := c.Len()
+	c.flags = resize(c.flags, n)
+	f := c.flags[:n]
+	vol, dup := c.Volume[:n], c.DupClusters[:n]
+	for i := range f {
+		v, d := vol[i], dup[i]
+		f[i] = b2u(v < 0) | b2u(d < 0) | b2u(d > v && v >= 0)
+	}
+	outside01(f, c.Reshare[:n])
+	outside01(f, c.Recycled[:n])
+	outside01(f, c.Burst[:n])
+	outside01(f, c.Synchrony[:n])
+	return f
+}
+
+// Invalid appends to dst the indices of points that fail a scalar rule.
+func (c *Columns) Invalid(dst []int) []int {
+	return collect(dst, c.mark())
+}
+
+// outside01 flags values below 0 or above 1. As in package validate, NaN
+// is not flagged.
+func outside01(f []uint8, xs []float64) {
+	xs = xs[:len(f)]
+	for i, x := range xs {
+		f[i] |= b2u(x < 0) | b2u(x > 1)
+	}
+}
+
+var pool = sync.Pool{New: func() any { return new(Columns) }}
+
+// Invalid appends to dst the indices of points in s that
+// validate.ValidateSeriesWith(s, opts) would report, in ascending order.
+// It checks every per-point rule, including UTC timestamps, breakdown sums,
+// the suppression floor, and backfill, under the options validate applies
+// to s (see validate.Options.ForSeries), and allocates only when dst must
+// grow.
+func Invalid(s *types.Series, opts validate.Options, dst []int) []int {
+	opts = opts.ForSeries(s)
+	c := pool.Get().(*Columns)
+	defer pool.Put(c)
+	c.Load(s.Points)
+	f := c.mark()
+
+	eps := opts.SumEpsilon
+	if eps == 0 {
+		eps = validate.DefaultSumEpsilon
+	}
+	reshareTol := opts.ReshareTolerance
+	if reshareTol == 0 {
+		reshareTol = validate.DefaultReshareTolerance
+	}
+	var backfillCutoff time.Time
+	checkBackfill := !s.GeneratedAt.IsZero()
+	if checkBackfill {
+		backfillCutoff = s.GeneratedAt.Add(-opts.BackfillMinAge)
+	}
+	for i := range s.Points {
+		p := &s.Points[i]
+		f[i] |= badShares(p.AcctAgeMix, eps) | badShares(p.AutomationMix, eps) |
+			badShares(p.ClientMix, eps) | badAcctTypeShares(p.AcctTypeShares, eps, opts) |
+			badPostKindMix(p, eps, reshareTol, opts) |
+			b2u(!types.IsUTC(p.TS)) | b2u(p.Volume > 0 && p.Volume < opts.MinVolume)
+		if p.Backfilled && checkBackfill && p.TS.After(backfillCutoff) {
+			f[i] = 1
+		}
+	}
+	return collect(dst, f)
+}
+
+// Valid reports whether Invalid would return no indices.
+func Valid(s *types.Series, opts validate.Options) bool {
+	var none [0]int
+	return len(Invalid(s, opts, none[:0])) == 0
+}
+
+func badShares(m map[string]types.Probability, eps float64) uint8 {
+	if len(m) == 0 {
+		return 0
+	}
+	var bad uint8
+	var sum float64
+	for _, v := range m {
+		bad |= b2u(v < 0) | b2u(v > 1)
+		sum += float64(v)
+	}
+	return bad | b2u(eps >= 0 && math.Abs(sum-1) > eps)
+}
+
+func badAcctTypeShares(m map[types.AcctType]types.Probability, eps float64, opts validate.Options) uint8 {
+	if len(m) == 0 {
+		return 0
+	}
+	var bad uint8
+	var sum float64
+	for k, v := range m {
+		bad |= b2u(!k.Valid() && !opts.EnumAccepted("acct_type", string(k))) | b2u(v < 0) | b2u(v > 1)
+		sum += float64(v)
+	}
+	return bad | b2u(eps >= 0 && math.Abs(sum-1) > eps)
+}
+
+func badPostKindMix(p *types.Point, eps, tol float64, opts validate.Options) uint8 {
+	m := p.PostKindMix
+	if len(m) == 0 {
+		return 0
+	}
+	var bad uint8
+	var sum float64
+	for k, v := range m {
+		bad |= b2u(!k.Valid() && !opts.EnumAccepted("post_kind", string(k))) | b2u(v < 0) | b2u(v > 1)
+		sum += float64(v)
+	}
+	r := float64(p.ReshareRatio - m[types.PostKindReshare])
+	return bad | b2u(eps >= 0 && math.Abs(sum-1) > eps) | b2u(tol >= 0 && math.Abs(r) > tol)
+}
+
+func collect(dst []int, f []uint8) []int {
+	for i, v := range f {
+		if v != 0 {
+			dst = append(dst, i)
+		}
+	}
+	return dst
+}
+
+// b2u compiles to a flag-setting instruction rather than a branch.
+func b2u(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func resize[T any](s []T, n int) []T {
+	if cap(s) < n {
+		return make([]T, n)
+	}
+	return s[:n]
+}
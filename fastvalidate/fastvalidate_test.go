@@ -0,0 +1,147 @@
+package fastvalidate
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+	"github.com/civic-interconnect/civic-transparency-go-types/validate"
+)
+
+// series returns n minute points; about one in every faultEvery points has
+// a random fault (0 disables faults).
+func series(n, faultEvery int, seed int64) *types.Series {
+	r := rand.New(rand.NewSource(seed))
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &types.Series{Topic: "#vote", GeneratedAt: t0.Add(time.Duration(n+60) * time.Minute), Interval: types.IntervalMinute}
+	s.Points = make([]types.Point, n)
+	for i := range s.Points {
+		p := types.Point{
+			TS:                  t0.Add(time.Duration(i) * time.Minute),
+			Volume:              100,
+			ReshareRatio:        0.4,
+			RecycledContentRate: 0.1,
+			AcctAgeMix:          map[string]types.Probability{"0-7d": 0.25, "24m+": 0.75},
+			AcctTypeShares:      map[types.AcctType]types.Probability{types.AcctTypePerson: 1},
+			PostKindMix:         map[types.PostKind]types.Probability{types.PostKindOriginal: 0.6, types.PostKindReshare: 0.4},
+			CoordinationSignals: types.CoordinationSignals{BurstScore: 0.2, SynchronyIndex: 0.3, DuplicationClusters: 4},
+		}
+		if faultEvery > 0 && r.Intn(faultEvery) == 0 {
+			switch r.Intn(10) {
+			case 0:
+				p.Volume = -1
+			case 1:
+				p.ReshareRatio = 1.5
+			case 2:
+				p.CoordinationSignals.SynchronyIndex = -0.1
+			case 3:
+				p.CoordinationSignals.DuplicationClusters = 101
+			case 4:
+				p.AcctAgeMix["0-7d"] = 0.3
+			case 5:
+				p.AcctTypeShares = map[types.AcctType]types.Probability{"bot": 1}
+			case 6:
+				p.Backfilled = true
+				p.TS = s.GeneratedAt
+			case 7:
+				p.PostKindMix = map[types.PostKind]types.Probability{types.PostKindOriginal: 0.7, types.PostKindReshare: 0.3}
+			case 8:
+				p.TS = p.TS.In(time.FixedZone("CET", 3600))
+			case 9:
+				p.Volume = 5
+			}
+		}
+		s.Points[i] = p
+	}
+	return s
+}
+
+// slowInvalid extracts failing point indices from the validate package.
+func slowInvalid(s *types.Series, opts validate.Options) []int {
+	var out []int
+	var me *validate.MultiError
+	if !errors.As(validate.ValidateSeriesWith(s, opts), &me) {
+		return nil
+	}
+	seen := map[int]bool{}
+	for _, err := range me.Errors() {
+		var fe *validate.FieldError
+		var i int
+		if errors.As(err, &fe) {
+			if _, e := fmt.Sscanf(fe.Field, "points[%d]", &i); e == nil && !seen[i] {
+				seen[i] = true
+				out = append(out, i)
+			}
+		}
+	}
+	return out
+}
+
+func TestInvalidMatchesValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		opts        validate.Options
+		methodology *types.Methodology
+	}{
+		{"backfill", validate.Options{BackfillMinAge: time.Hour}, nil},
+		{"min volume", validate.Options{MinVolume: 10}, nil},
+		{"topic override", validate.Options{TopicOverrides: map[string]validate.Options{"#vote": {MinVolume: 10, BackfillMinAge: time.Hour}}}, nil},
+		{"other topic", validate.Options{TopicOverrides: map[string]validate.Options{"#other": {MinVolume: 10}}}, nil},
+		{"methodology", validate.Options{}, &types.Methodology{SuppressionThreshold: 10}},
+	} {
+		for seed := int64(0); seed < 20; seed++ {
+			s := series(500, 25, seed)
+			s.Methodology = tc.methodology
+			got, want := Invalid(s, tc.opts, nil), slowInvalid(s, tc.opts)
+			if !reflect.DeepEqual(got, want) {
+				t.Fatalf("%s, seed %d: Invalid = %v, validate = %v", tc.name, seed, got, want)
+			}
+		}
+	}
+	if !Valid(series(100, 0, 1), validate.DefaultOptions()) {
+		t.Fatal("clean series reported invalid")
+	}
+}
+
+const benchPoints = 7 * 24 * 60 // one week of minutes
+
+func BenchmarkValidateSeries(b *testing.B) {
+	s := series(benchPoints, 0, 1)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := validate.ValidateSeries(s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkInvalid(b *testing.B) {
+	s := series(benchPoints, 0, 1)
+	opts := validate.DefaultOptions()
+	var dst []int
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if dst = Invalid(s, opts, dst[:0]); len(dst) != 0 {
+			b.Fatal(dst)
+		}
+	}
+}
+
+func BenchmarkColumns(b *testing.B) {
+	var c Columns
+	c.Load(series(benchPoints, 0, 1).Points)
+	var dst []int
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if dst = c.Invalid(dst[:0]); len(dst) != 0 {
+			b.Fatal(dst)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+// filter/doc.go
+// Package filter compiles a small expression language into predicates over
+// types.ProvenanceTag, for streaming subscriptions and archival queries.
+//
+// Grammar (keywords are case-insensitive):
+//
+//	expr    = and { "OR" and }
+//	and     = unary { "AND" unary }
+//	unary   = "NOT" unary | "(" expr ")" | cmp
+//	cmp     = field ( "=" | "!=" ) value
+//	        | field [ "NOT" ] "IN" "(" value { "," value } ")"
+//
+// Fields are the ProvenanceTag JSON names. Values may be bare words
+// (media, 24m+, US-CA) or quoted with ' or ". Enumerated fields only accept
+// schema-defined values; dedup_hash values must have the shape of a defined
+// dedup_hash_alg and origin_hint values must match their schema pattern. Violations are reported as *Error at compile time.
+package filter
@@ -0,0 +1,29 @@
+package filter_test
+
+import (
+	"fmt"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/filter"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func ExampleParse() {
+	f := filter.MustParse("acct_type = media AND automation_flag != declared_bot")
+	fmt.Println(f.Match(&types.ProvenanceTag{AcctType: types.AcctTypeMedia, AutomationFlag: types.AutomationManual}))
+	fmt.Println(f.Match(&types.ProvenanceTag{AcctType: types.AcctTypeMedia, AutomationFlag: types.AutomationDeclaredBot}))
+
+	g := filter.MustParse("NOT (acct_age_bucket IN (0-7d, 8-30d) OR origin_hint = 'US-CA')")
+	fmt.Println(g.Match(&types.ProvenanceTag{AcctAgeBucket: types.AcctAge_24mPlus, OriginHint: "US"}))
+
+	h := filter.MustParse("dedup_hash IN (deadbeef, 0123456789abcdef)")
+	fmt.Println(h.Match(&types.ProvenanceTag{DedupHash: "0123456789abcdef", DedupHashAlg: types.DedupXXHash64}))
+
+	_, err := filter.Parse("acct_type = robot")
+	fmt.Println(err)
+	// Output:
+	// true
+	// false
+	// true
+	// true
+	// filter: at offset 12: invalid acct_type value "robot": want one of person, org, media, public_official, unverified, declared_automation
+}
@@ -0,0 +1,249 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// Filter is a compiled predicate over ProvenanceTag. It is immutable and safe
+// for concurrent use.
+type Filter struct {
+	src  string
+	pred func(*types.ProvenanceTag) bool
+}
+
+// Match reports whether t satisfies the filter. A nil tag never matches.
+func (f *Filter) Match(t *types.ProvenanceTag) bool {
+	if t == nil {
+		return false
+	}
+	return f.pred(t)
+}
+
+// String returns the source expression.
+func (f *Filter) String() string { return f.src }
+
+// Parse compiles src. Errors are of type *Error.
+func Parse(src string) (*Filter, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks}
+	pred, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, &Error{t.pos, fmt.Sprintf("unexpected %q", t.text)}
+	}
+	return &Filter{src: src, pred: pred}, nil
+}
+
+// MustParse is like Parse but panics on error.
+func MustParse(src string) *Filter {
+	f, err := Parse(src)
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+// field describes a filterable ProvenanceTag member.
+type field struct {
+	get   func(*types.ProvenanceTag) string
+	check func(string) bool
+	want  string // human description of acceptable values
+}
+
+var fields = map[string]field{
+	"acct_age_bucket": enumField(types.AcctAgeValues(), func(t *types.ProvenanceTag) string { return string(t.AcctAgeBucket) }),
+	"acct_type":       enumField(types.AcctTypeValues(), func(t *types.ProvenanceTag) string { return string(t.AcctType) }),
+	"automation_flag": enumField(types.AutomationFlagValues(), func(t *types.ProvenanceTag) string { return string(t.AutomationFlag) }),
+	"post_kind":       enumField(types.PostKindValues(), func(t *types.ProvenanceTag) string { return string(t.PostKind) }),
+	"client_family":   enumField(types.ClientFamilyValues(), func(t *types.ProvenanceTag) string { return string(t.ClientFamily) }),
+	"media_provenance": enumField(types.MediaProvenanceValues(), func(t *types.ProvenanceTag) string {
+		return string(t.MediaProvenance)
+	}),
+	"dedup_hash": {
+		get: func(t *types.ProvenanceTag) string { return string(t.DedupHash) },
+		check: func(s string) bool {
+			// The algorithm is not known until match time, so accept the
+			// shape of any defined one.
+			for _, a := range types.DedupHashAlgValues() {
+				if a.MatchesShape(types.HexHash8(s)) {
+					return true
+				}
+			}
+			return false
+		},
+		want: "8 or 16 lowercase hex chars",
+	},
+	"origin_hint": {
+		get:   func(t *types.ProvenanceTag) string { return t.OriginHint },
+		check: func(s string) bool { return s == "" || types.ISO3166.MatchString(s) },
+		want:  "an ISO-3166 code or empty string",
+	},
+}
+
+func enumField[T ~string](vals []T, get func(*types.ProvenanceTag) string) field {
+	names := make([]string, len(vals))
+	for i, v := range vals {
+		names[i] = string(v)
+	}
+	return field{
+		get: get,
+		check: func(s string) bool {
+			for _, n := range names {
+				if n == s {
+					return true
+				}
+			}
+			return false
+		},
+		want: "one of " + strings.Join(names, ", "),
+	}
+}
+
+type pred = func(*types.ProvenanceTag) bool
+
+type parser struct {
+	toks []token
+	i    int
+}
+
+func (p *parser) peek() token { return p.toks[p.i] }
+func (p *parser) next() token { t := p.toks[p.i]; p.i++; return t }
+
+func (p *parser) keyword(kw string) bool {
+	t := p.peek()
+	if t.kind == tokWord && strings.EqualFold(t.text, kw) {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expr() (pred, error) {
+	l, err := p.and()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("OR") {
+		r, err := p.and()
+		if err != nil {
+			return nil, err
+		}
+		a, b := l, r
+		l = func(t *types.ProvenanceTag) bool { return a(t) || b(t) }
+	}
+	return l, nil
+}
+
+func (p *parser) and() (pred, error) {
+	l, err := p.unary()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("AND") {
+		r, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		a, b := l, r
+		l = func(t *types.ProvenanceTag) bool { return a(t) && b(t) }
+	}
+	return l, nil
+}
+
+func (p *parser) unary() (pred, error) {
+	if p.keyword("NOT") {
+		inner, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(t *types.ProvenanceTag) bool { return !inner(t) }, nil
+	}
+	if p.peek().kind == tokLParen {
+		p.next()
+		inner, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if t := p.next(); t.kind != tokRParen {
+			return nil, &Error{t.pos, "expected )"}
+		}
+		return inner, nil
+	}
+	return p.cmp()
+}
+
+func (p *parser) cmp() (pred, error) {
+	ft := p.next()
+	if ft.kind != tokWord {
+		return nil, &Error{ft.pos, "expected field name"}
+	}
+	f, ok := fields[ft.text]
+	if !ok {
+		return nil, &Error{ft.pos, fmt.Sprintf("unknown field %q", ft.text)}
+	}
+
+	switch op := p.next(); {
+	case op.kind == tokEq || op.kind == tokNeq:
+		v, err := p.value(ft.text, f)
+		if err != nil {
+			return nil, err
+		}
+		if op.kind == tokEq {
+			return func(t *types.ProvenanceTag) bool { return f.get(t) == v }, nil
+		}
+		return func(t *types.ProvenanceTag) bool { return f.get(t) != v }, nil
+	case op.kind == tokWord && (strings.EqualFold(op.text, "IN") || strings.EqualFold(op.text, "NOT")):
+		negate := strings.EqualFold(op.text, "NOT")
+		if negate && !p.keyword("IN") {
+			return nil, &Error{p.peek().pos, "expected IN after NOT"}
+		}
+		set, err := p.list(ft.text, f)
+		if err != nil {
+			return nil, err
+		}
+		return func(t *types.ProvenanceTag) bool { return set[f.get(t)] != negate }, nil
+	default:
+		return nil, &Error{op.pos, "expected =, !=, IN or NOT IN"}
+	}
+}
+
+func (p *parser) list(name string, f field) (map[string]bool, error) {
+	if t := p.next(); t.kind != tokLParen {
+		return nil, &Error{t.pos, "expected ( after IN"}
+	}
+	set := map[string]bool{}
+	for {
+		v, err := p.value(name, f)
+		if err != nil {
+			return nil, err
+		}
+		set[v] = true
+		switch t := p.next(); t.kind {
+		case tokComma:
+			continue
+		case tokRParen:
+			return set, nil
+		default:
+			return nil, &Error{t.pos, "expected , or )"}
+		}
+	}
+}
+
+func (p *parser) value(name string, f field) (string, error) {
+	t := p.next()
+	if t.kind != tokWord && t.kind != tokString {
+		return "", &Error{t.pos, "expected value"}
+	}
+	if !f.check(t.text) {
+		return "", &Error{t.pos, fmt.Sprintf("invalid %s value %q: want %s", name, t.text, f.want)}
+	}
+	return t.text, nil
+}
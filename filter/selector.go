@@ -0,0 +1,34 @@
+package filter
+
+import "github.com/civic-interconnect/civic-transparency-go-types/types"
+
+// Selector is a declarative, JSON-configurable tag filter. Each non-empty
+// set restricts the corresponding field to its members; empty sets match
+// anything. All restrictions must hold.
+//
+//	{"acct_types":["media","org"],"automation_flags":["manual"]}
+type Selector struct {
+	AcctAgeBuckets   types.AcctAgeSet         `json:"acct_age_buckets,omitempty"`
+	AcctTypes        types.AcctTypeSet        `json:"acct_types,omitempty"`
+	AutomationFlags  types.AutomationFlagSet  `json:"automation_flags,omitempty"`
+	PostKinds        types.PostKindSet        `json:"post_kinds,omitempty"`
+	ClientFamilies   types.ClientFamilySet    `json:"client_families,omitempty"`
+	MediaProvenances types.MediaProvenanceSet `json:"media_provenances,omitempty"`
+}
+
+// Match reports whether t satisfies every restriction in s.
+func (s Selector) Match(t *types.ProvenanceTag) bool {
+	if t == nil {
+		return false
+	}
+	return in(s.AcctAgeBuckets, t.AcctAgeBucket) &&
+		in(s.AcctTypes, t.AcctType) &&
+		in(s.AutomationFlags, t.AutomationFlag) &&
+		in(s.PostKinds, t.PostKind) &&
+		in(s.ClientFamilies, t.ClientFamily) &&
+		in(s.MediaProvenances, t.MediaProvenance)
+}
+
+func in[T types.Enum](set types.EnumSet[T], v T) bool {
+	return set.IsEmpty() || set.Has(v)
+}
@@ -0,0 +1,13 @@
+// geo/doc.go
+// Package geo exports activity aggregated by origin_hint as GeoJSON
+// (RFC 7946) FeatureCollections for choropleth maps.
+//
+//	bs := geo.Aggregate(tags)
+//	fc := geo.ToGeoJSON(bs, geo.Options{Level: geo.Country, MinVolume: 10, Boundaries: shapes})
+//
+// Each Feature's id is the ISO 3166 code, and its properties carry the
+// volume, the share of the volume of published features, and each ratio.
+// This module ships no boundary data: supply geometries through
+// Options.Boundaries, or leave it nil to emit null geometries for frontends
+// that join on id.
+package geo
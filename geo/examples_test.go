@@ -0,0 +1,28 @@
+package geo_test
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/geo"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func ExampleToGeoJSON() {
+	tags := []types.ProvenanceTag{
+		{OriginHint: "US-CA", PostKind: types.PostKindReshare},
+		{OriginHint: "US-CA", PostKind: types.PostKindOriginal},
+		{OriginHint: "US-NY", PostKind: types.PostKindReshare},
+		{OriginHint: "FR", PostKind: types.PostKindOriginal},
+	}
+	fc := geo.ToGeoJSON(geo.Aggregate(tags), geo.Options{Level: geo.Country})
+	for _, f := range fc.Features {
+		fmt.Println(f.ID, f.Properties["volume"], f.Properties[geo.RatioReshare])
+	}
+	b, _ := json.Marshal(fc.Features[0].Geometry)
+	fmt.Println(string(b))
+	// Output:
+	// FR 1 0
+	// US 3 0.6666666666666666
+	// null
+}
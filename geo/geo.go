@@ -0,0 +1,181 @@
+package geo
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// OriginBreakdown is the activity attributed to one origin_hint.
+type OriginBreakdown struct {
+	Origin string // ISO 3166 country ("US") or subdivision ("US-CA")
+	Volume int
+	// Ratios are per-origin fractions of Volume, e.g. "reshare_ratio".
+	Ratios map[string]types.Probability
+}
+
+// Ratio names computed by Aggregate.
+const (
+	RatioReshare    = "reshare_ratio"    // post_kind reshare
+	RatioAutomation = "automation_share" // automation_flag set and not manual
+	RatioC2PA       = "c2pa_share"       // media_provenance c2pa_present
+)
+
+// Aggregate counts tags by origin_hint, sorted by origin. Tags without an
+// origin_hint are skipped.
+func Aggregate(tags []types.ProvenanceTag) []OriginBreakdown {
+	type acc struct{ n, reshare, auto, c2pa int }
+	m := map[string]*acc{}
+	for i := range tags {
+		t := &tags[i]
+		if t.OriginHint == "" {
+			continue
+		}
+		a := m[t.OriginHint]
+		if a == nil {
+			a = &acc{}
+			m[t.OriginHint] = a
+		}
+		a.n++
+		if t.PostKind == types.PostKindReshare {
+			a.reshare++
+		}
+		if t.AutomationFlag != "" && t.AutomationFlag != types.AutomationManual {
+			a.auto++
+		}
+		if t.MediaProvenance == types.MediaProvC2PA {
+			a.c2pa++
+		}
+	}
+	out := make([]OriginBreakdown, 0, len(m))
+	for origin, a := range m {
+		n := float64(a.n)
+		out = append(out, OriginBreakdown{Origin: origin, Volume: a.n, Ratios: map[string]types.Probability{
+			RatioReshare:    types.Probability(float64(a.reshare) / n),
+			RatioAutomation: types.Probability(float64(a.auto) / n),
+			RatioC2PA:       types.Probability(float64(a.c2pa) / n),
+		}})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Origin < out[j].Origin })
+	return out
+}
+
+// Level selects the administrative level of exported features.
+type Level int
+
+const (
+	// AsGiven keeps each breakdown's own level.
+	AsGiven Level = iota
+	// Country rolls subdivisions up into their country.
+	Country
+)
+
+// Boundaries supplies the GeoJSON geometry for an ISO 3166 code.
+type Boundaries interface {
+	Geometry(code string) (json.RawMessage, bool)
+}
+
+// Options configures ToGeoJSON.
+type Options struct {
+	Level Level
+	// MinVolume drops features with fewer events, after any roll-up, so
+	// small regions are not disclosed. Their volume is left out of other
+	// features' share too, so it cannot be recovered from the shares.
+	MinVolume int
+	// Boundaries, if set, fills in geometries. Codes it does not know get
+	// a null geometry.
+	Boundaries Boundaries
+}
+
+// FeatureCollection is a GeoJSON FeatureCollection.
+type FeatureCollection struct {
+	Type     string    `json:"type"` // always "FeatureCollection"
+	Features []Feature `json:"features"`
+}
+
+// Feature is a GeoJSON Feature for one origin. Properties holds:
+//
+//	iso_3166  the origin code (also the feature id)
+//	country   the country part of the code
+//	level     "country" or "subdivision"
+//	volume    events attributed to the origin
+//	share     volume as a fraction of the total across emitted features
+//
+// plus one member per ratio name.
+type Feature struct {
+	Type       string          `json:"type"` // always "Feature"
+	ID         string          `json:"id"`
+	Geometry   json.RawMessage `json:"geometry"` // null when unknown
+	Properties map[string]any  `json:"properties"`
+}
+
+// ToGeoJSON converts breakdowns to a FeatureCollection sorted by code.
+// Breakdowns for the same code (after roll-up) are merged, with ratios
+// averaged by volume.
+func ToGeoJSON(breakdowns []OriginBreakdown, opts Options) FeatureCollection {
+	type acc struct {
+		volume int
+		sums   map[string]float64 // ratio × volume
+	}
+	m := map[string]*acc{}
+	for _, b := range breakdowns {
+		code := b.Origin
+		if opts.Level == Country {
+			code, _, _ = strings.Cut(code, "-")
+		}
+		if code == "" || b.Volume <= 0 {
+			continue
+		}
+		a := m[code]
+		if a == nil {
+			a = &acc{sums: map[string]float64{}}
+			m[code] = a
+		}
+		a.volume += b.Volume
+		for k, r := range b.Ratios {
+			a.sums[k] += float64(r) * float64(b.Volume)
+		}
+	}
+
+	codes := make([]string, 0, len(m))
+	for c := range m {
+		codes = append(codes, c)
+	}
+	sort.Strings(codes)
+	kept, total := codes[:0], 0
+	for _, code := range codes {
+		if v := m[code].volume; v >= opts.MinVolume {
+			kept = append(kept, code)
+			total += v
+		}
+	}
+	fc := FeatureCollection{Type: "FeatureCollection", Features: []Feature{}}
+	for _, code := range kept {
+		a := m[code]
+		country, _, sub := strings.Cut(code, "-")
+		level := "country"
+		if sub {
+			level = "subdivision"
+		}
+		props := map[string]any{
+			"iso_3166": code,
+			"country":  country,
+			"level":    level,
+			"volume":   a.volume,
+			"share":    float64(a.volume) / float64(total),
+		}
+		for k, s := range a.sums {
+			props[k] = s / float64(a.volume)
+		}
+		f := Feature{Type: "Feature", ID: code, Properties: props}
+		if opts.Boundaries != nil {
+			if g, ok := opts.Boundaries.Geometry(code); ok {
+				f.Geometry = g
+			}
+		}
+		fc.Features = append(fc.Features, f)
+	}
+	return fc
+}
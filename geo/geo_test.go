@@ -0,0 +1,97 @@
+package geo
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+type shapes map[string]string
+
+func (s shapes) Geometry(code string) (json.RawMessage, bool) {
+	g, ok := s[code]
+	return json.RawMessage(g), ok
+}
+
+func TestAggregate(t *testing.T) {
+	tags := []types.ProvenanceTag{
+		{OriginHint: "US-CA", PostKind: types.PostKindReshare, AutomationFlag: types.AutomationManual},
+		{OriginHint: "US-CA", PostKind: types.PostKindOriginal},
+		{OriginHint: "DE", MediaProvenance: types.MediaProvC2PA},
+		{PostKind: types.PostKindReshare},
+	}
+	got := Aggregate(tags)
+	if len(got) != 2 || got[0].Origin != "DE" || got[1].Origin != "US-CA" {
+		t.Fatalf("origins = %+v", got)
+	}
+	if got[1].Volume != 2 || got[1].Ratios[RatioReshare] != 0.5 || got[1].Ratios[RatioAutomation] != 0 {
+		t.Errorf("US-CA = %+v", got[1])
+	}
+	if got[0].Ratios[RatioC2PA] != 1 {
+		t.Errorf("DE = %+v", got[0])
+	}
+}
+
+func TestToGeoJSONCountryRollup(t *testing.T) {
+	bs := []OriginBreakdown{
+		{Origin: "US-CA", Volume: 30, Ratios: map[string]types.Probability{RatioReshare: 0.2}},
+		{Origin: "US-TX", Volume: 10, Ratios: map[string]types.Probability{RatioReshare: 0.6}},
+		{Origin: "LU", Volume: 2},
+	}
+	fc := ToGeoJSON(bs, Options{Level: Country, MinVolume: 5, Boundaries: shapes{"US": `{"type":"Point","coordinates":[0,0]}`}})
+	if len(fc.Features) != 1 {
+		t.Fatalf("features = %+v", fc.Features)
+	}
+	f := fc.Features[0]
+	if f.ID != "US" || f.Properties["level"] != "country" || f.Properties["volume"] != 40 {
+		t.Errorf("feature = %+v", f)
+	}
+	if r := f.Properties[RatioReshare].(float64); math.Abs(r-0.3) > 1e-12 {
+		t.Errorf("reshare_ratio = %v, want 0.3", r)
+	}
+	if s := f.Properties["share"].(float64); s != 1 {
+		t.Errorf("share = %v", s)
+	}
+	if string(f.Geometry) == "" {
+		t.Error("geometry not joined")
+	}
+}
+
+func TestToGeoJSONSuppressedNotDerivable(t *testing.T) {
+	// The suppressed region's volume must not be recoverable as
+	// volume/share − Σ volumes.
+	bs := []OriginBreakdown{{Origin: "DE", Volume: 60}, {Origin: "FR", Volume: 40}, {Origin: "LU", Volume: 3}}
+	fc := ToGeoJSON(bs, Options{MinVolume: 5})
+	if len(fc.Features) != 2 {
+		t.Fatalf("features = %+v", fc.Features)
+	}
+	var sum float64
+	for _, f := range fc.Features {
+		share := f.Properties["share"].(float64)
+		if total := float64(f.Properties["volume"].(int)) / share; math.Abs(total-100) > 1e-9 {
+			t.Errorf("%s: implied total %v reveals suppressed volume", f.ID, total)
+		}
+		sum += share
+	}
+	if math.Abs(sum-1) > 1e-12 {
+		t.Errorf("shares sum to %v, want 1", sum)
+	}
+}
+
+func TestToGeoJSONNullGeometry(t *testing.T) {
+	fc := ToGeoJSON([]OriginBreakdown{{Origin: "US-CA", Volume: 1}}, Options{})
+	b, err := json.Marshal(fc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"FeatureCollection","features":[{"type":"Feature","id":"US-CA","geometry":null,` +
+		`"properties":{"country":"US","iso_3166":"US-CA","level":"subdivision","share":1,"volume":1}}]}`
+	if string(b) != want {
+		t.Errorf("got  %s\nwant %s", b, want)
+	}
+	if b, _ := json.Marshal(ToGeoJSON(nil, Options{})); string(b) != `{"type":"FeatureCollection","features":[]}` {
+		t.Errorf("empty = %s", b)
+	}
+}
@@ -0,0 +1,127 @@
+package goldens
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// Cases returns a fixed, fully populated value of every wire type. Values
+// are built fresh on each call, so tests may modify them.
+func Cases() []Case {
+	t0 := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	decided := t0.Add(time.Hour)
+	ext := types.Extensions{
+		"x-example-reach":  json.RawMessage(`{"min":10,"max":250}`),
+		"x-example-region": json.RawMessage(`"emea"`),
+	}
+	series := func() *types.Series {
+		return &types.Series{
+			Topic:        "#Vote2025",
+			GeneratedAt:  t0.Add(5 * time.Minute),
+			Interval:     types.IntervalMinute,
+			Tenant:       "us.fec",
+			Jurisdiction: "US-CA",
+			Methodology: &types.Methodology{
+				SamplingRate: 0.25, SuppressionThreshold: 10, NoiseEpsilon: 1.5,
+				CollectionLag: types.ISODuration(2 * time.Minute), Caveats: []string{"Deleted posts are excluded."},
+			},
+			Points: []types.Point{
+				{
+					TS: t0, Volume: 1200, ReshareRatio: 0.375, RecycledContentRate: 0.1,
+					AcctAgeMix:     map[string]types.Probability{"0-7d": 0.2, "8-30d": 0.1, "1-6m": 0.3, "6-24m": 0.25, "24m+": 0.15},
+					AutomationMix:  map[string]types.Probability{"manual": 0.9, "scheduled": 0.05, "api_client": 0.05},
+					ClientMix:      map[string]types.Probability{"web": 0.4, "mobile": 0.5, "third_party_api": 0.1},
+					AcctTypeShares: map[types.AcctType]types.Probability{types.AcctTypePerson: 0.8, types.AcctTypeUnverified: 0.2},
+					PostKindMix:    map[types.PostKind]types.Probability{types.PostKindOriginal: 0.625, types.PostKindReshare: 0.375},
+					CoordinationSignals: types.CoordinationSignals{
+						BurstScore: 0.6666666666666666, SynchronyIndex: 0.01, DuplicationClusters: 3,
+					},
+				},
+				{TS: t0.Add(time.Minute), Volume: 0},
+				{TS: t0.Add(-24 * time.Hour), Volume: 7, ReshareRatio: 1, Backfilled: true},
+			},
+			Annotations: []types.Annotation{
+				{Kind: types.AnnotationElection, Start: t0.Add(-time.Hour), End: t0.Add(time.Hour), Note: "primary"},
+				{Kind: types.AnnotationOutage, Start: t0.Add(time.Minute)},
+			},
+			Extensions: ext,
+		}
+	}
+	retraction := &types.Retraction{
+		Topic: "#Vote2025", GeneratedAt: t0, Reason: types.RetractionDataError,
+		Note: "duplicated upstream batch", EffectiveAt: t0.Add(time.Hour), Signature: "c2lnbmF0dXJl",
+	}
+	tombstone := series()
+	tombstone.Points = nil
+	tombstone.Retraction = retraction
+
+	return []Case{
+		{"series", series()},
+		{"series_retracted", tombstone},
+		{"provenance_tag", &types.ProvenanceTag{
+			AcctAgeBucket: types.AcctAge_8_30d, AcctType: types.AcctTypeUnverified,
+			AutomationFlag: types.AutomationScheduled, PostKind: types.PostKindQuote,
+			ClientFamily: types.ClientThirdParty, MediaProvenance: types.MediaProvHash,
+			DedupHash: "0123456789abcdef", DedupHashAlg: types.DedupSipHash24,
+			OriginHint: "US-CA", Extensions: ext,
+		}},
+		{"retraction", retraction},
+		{"bundle", &types.SeriesBundle{
+			Manifest: types.BundleManifest{
+				GeneratedAt: t0.Add(time.Hour),
+				Publishers:  []types.PublisherRef{{ID: "p1", Label: "Example"}, {ID: "k9Qx", Pseudonymous: true}},
+				Entries:     []types.BundleEntry{{Topic: "#Vote2025", Publisher: "p1"}},
+			},
+			Series: []types.Series{*series()},
+		}},
+		{"health_report", &types.HealthReport{
+			SpecVersion: types.SpecVersion, GeneratedAt: t0, Status: types.HealthDegraded,
+			Window: types.ISODuration(time.Hour), Message: "ingest lagging",
+			Topics: []types.TopicHealth{{Topic: "#Vote2025", LastPublished: t0.Add(-2 * time.Minute), Submitted: 60, Rejected: 3, FailureRate: 0.05}},
+		}},
+		{"heartbeat", &types.Heartbeat{
+			Publisher: "p1", SentAt: t0, LastFinalized: t0.Add(-2 * time.Minute),
+			Lag: types.ISODuration(45 * time.Second), Interval: types.ISODuration(15 * time.Second),
+		}},
+		{"discovery", &types.Discovery{
+			Publisher: types.PublisherRef{ID: "p1", Label: "Example Platform"},
+			Versions:  []string{types.SpecVersion, "0.2.1"},
+			Endpoints: []types.Endpoint{
+				{Rel: types.RelSeries, URL: "https://data.example.org/v1/series"},
+				{Rel: types.RelHeartbeat, URL: "https://data.example.org/v1/heartbeat"},
+			},
+			Topics:    []string{"#Vote2025"},
+			Keys:      []types.PublicKey{{ID: "2025-01", Alg: types.KeyAlgEd25519, Key: "11qYAYKxCrfVS/7TyWQHOg7hcvPapiMlrwIaaPcHURo="}},
+			UpdatedAt: t0,
+		}},
+		{"coverage_slo", &types.CoverageSLO{
+			TargetPercent: 99.5, Window: types.ISODuration(30 * 24 * time.Hour),
+			Deadline: types.ISODuration(15 * time.Minute), Exclusions: []types.AnnotationKind{types.AnnotationOutage},
+		}},
+		{"dead_letter", &types.DeadLetter{
+			Key: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", SubmissionID: "01JNG7Q8W4Z5S1A2B3C4D5E6F7",
+			Source: "p1", Stage: types.StageValidate, Payload: []byte(`{"topic":""}`),
+			Codes: []string{"ERR_REQUIRED"}, Errors: []string{"topic must be non-empty"},
+			Attempts: 2, FirstSeen: t0, LastSeen: t0.Add(time.Minute),
+		}},
+		{"quarantine_record", &types.QuarantineRecord{
+			Key: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", Source: "p1",
+			Payload: []byte(`{"topic":"#vote"}`), Reasons: []string{"every point has volume 0"},
+			State: types.QuarantineReleased, QuarantinedAt: t0, DecidedAt: &decided,
+			Reviewer: "reviewer-1", Note: "platform outage confirmed",
+		}},
+		{"quarantine_record_pending", &types.QuarantineRecord{
+			Key: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", Source: "p1",
+			Payload: []byte(`{"topic":"#vote"}`), Reasons: []string{"every point has volume 0"},
+			State: types.QuarantinePending, QuarantinedAt: t0,
+		}},
+		{"state_transition", &types.StateTransition{
+			From: types.SeriesFinalized, To: types.SeriesRetracted, Reason: string(types.RetractionDataError),
+		}},
+		{"interval", types.IntervalMinute},
+		{"duration", types.ISODuration(36*time.Hour + 30*time.Minute)},
+		{"extensions", ext},
+	}
+}
@@ -0,0 +1,23 @@
+// goldens/doc.go
+// Package goldens pins the wire formats of this module with committed
+// golden files, so an accidental change to any encoder fails a test before
+// release instead of surprising consumers after it.
+//
+// Run marshals every Case through every Encoding that supports it and
+// compares the bytes, exactly, with dir/<case>.<ext>:
+//
+//	func TestGoldens(t *testing.T) {
+//		goldens.Run(t, "testdata", goldens.Cases(), goldens.Encodings())
+//	}
+//
+// After an intentional format change, regenerate the files and review the
+// diff:
+//
+//	go test ./goldens -update
+//
+// Encodings covers every encoder in the module: encoding/json, the codec
+// compact, quantized, and fixed-float forms, BSON values, InfluxDB line
+// protocol, Prometheus remote write, and the binary recordio and seekbundle
+// framings. A new encoder is pinned by adding
+// an Encoding here.
+package goldens
@@ -0,0 +1,103 @@
+package goldens
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/archive/lineproto"
+	"github.com/civic-interconnect/civic-transparency-go-types/archive/promwrite"
+	"github.com/civic-interconnect/civic-transparency-go-types/archive/seekbundle"
+	"github.com/civic-interconnect/civic-transparency-go-types/codec"
+	"github.com/civic-interconnect/civic-transparency-go-types/recordio"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// bsonValuer is the mongo-driver interface the types package implements.
+type bsonValuer interface {
+	MarshalBSONValue() (byte, []byte, error)
+}
+
+// Encodings returns every encoding in the module:
+//
+//	json            encoding/json
+//	floats.json     codec.MarshalFloats with 6 decimals, one kept
+//	compact.json    codec Compact, for *types.Series
+//	quantized.json  codec Verbose|Quantized, for *types.Series
+//	protojson.json  codec.ToProtoJSON, for structs
+//	bson            BSON type byte then value, for types with MarshalBSONValue
+//	line            InfluxDB line protocol, for *types.Series
+//	promwrite       Prometheus remote-write body, for *types.Series
+func Encodings() []Encoding {
+	return []Encoding{
+		{"json", json.Marshal},
+		{"floats.json", func(v any) ([]byte, error) {
+			return codec.MarshalFloats(v, codec.FloatFormat{Decimals: 6, Zeros: codec.OneDecimal})
+		}},
+		{"compact.json", series(func(s *types.Series) ([]byte, error) { return codec.Marshal(s, codec.Compact) })},
+		{"quantized.json", series(func(s *types.Series) ([]byte, error) { return codec.Marshal(s, codec.Verbose|codec.Quantized) })},
+		{"protojson.json", func(v any) ([]byte, error) {
+			t := reflect.TypeOf(v)
+			if t.Kind() == reflect.Pointer {
+				t = t.Elem()
+			}
+			if t.Kind() != reflect.Struct {
+				return nil, errors.ErrUnsupported
+			}
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			return codec.ToProtoJSON(b, v)
+		}},
+		{"bson", func(v any) ([]byte, error) {
+			bv, ok := v.(bsonValuer)
+			if !ok {
+				return nil, errors.ErrUnsupported
+			}
+			typ, data, err := bv.MarshalBSONValue()
+			return append([]byte{typ}, data...), err
+		}},
+		{"line", series(func(s *types.Series) ([]byte, error) {
+			var b bytes.Buffer
+			err := lineproto.Write(&b, s, lineproto.Options{})
+			return b.Bytes(), err
+		})},
+		{"promwrite", series(func(s *types.Series) ([]byte, error) {
+			return promwrite.Encode(promwrite.Options{}, s), nil
+		})},
+		{"recordio", func(v any) ([]byte, error) {
+			rec, err := json.Marshal(v)
+			if err != nil {
+				return nil, err
+			}
+			var b bytes.Buffer
+			err = recordio.NewWriter(&b).Append(rec)
+			return b.Bytes(), err
+		}},
+		{"seekbundle", series(func(s *types.Series) ([]byte, error) {
+			var b bytes.Buffer
+			w, err := seekbundle.NewWriter(&b, seekbundle.Options{Codec: "none"})
+			if err != nil {
+				return nil, err
+			}
+			if err := w.Add(s); err != nil {
+				return nil, err
+			}
+			err = w.Close()
+			return b.Bytes(), err
+		})},
+	}
+}
+
+// series adapts a Series-only encoder.
+func series(f func(*types.Series) ([]byte, error)) func(any) ([]byte, error) {
+	return func(v any) ([]byte, error) {
+		s, ok := v.(*types.Series)
+		if !ok {
+			return nil, errors.ErrUnsupported
+		}
+		return f(s)
+	}
+}
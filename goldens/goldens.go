@@ -0,0 +1,135 @@
+package goldens
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files instead of comparing against them")
+
+// Case is a named value to encode. Name becomes the golden file's base
+// name, so it must be unique within a Run.
+type Case struct {
+	Name  string
+	Value any
+}
+
+// Encoding is one wire format. Marshal returns errors.ErrUnsupported for
+// values it cannot encode, which Run skips.
+type Encoding struct {
+	Ext     string // golden file extension, e.g. "json" or "compact.json"
+	Marshal func(v any) ([]byte, error)
+}
+
+// Run checks the encoding of every case in every supporting encoding
+// against the golden files in dir. Files in dir that no case produces are
+// reported as stale, or removed with -update.
+func Run(t *testing.T, dir string, cases []Case, encs []Encoding) {
+	t.Helper()
+	want := map[string]bool{}
+	for _, c := range cases {
+		for _, e := range encs {
+			got, err := e.Marshal(c.Value)
+			if errors.Is(err, errors.ErrUnsupported) {
+				continue
+			}
+			name := c.Name + "." + e.Ext
+			if err != nil {
+				t.Errorf("%s: %v", name, err)
+				continue
+			}
+			want[name] = true
+			Check(t, filepath.Join(dir, name), got)
+		}
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if !*update {
+			t.Errorf("goldens: %v", err)
+		}
+		return
+	}
+	for _, ent := range entries {
+		if ent.IsDir() || want[ent.Name()] {
+			continue
+		}
+		path := filepath.Join(dir, ent.Name())
+		if *update {
+			if err := os.Remove(path); err != nil {
+				t.Error(err)
+			}
+			continue
+		}
+		t.Errorf("%s: stale golden file; no case produces it (run go test -update to remove)", path)
+	}
+}
+
+// Check compares got with the golden file at path, or writes it there when
+// the test binary runs with -update.
+func Check(t testing.TB, path string, got []byte) {
+	t.Helper()
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Errorf("%v (run go test -update to create it)", err)
+		return
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("%s: encoding changed: %s\nIf the change is intended, run go test -update and review the diff.", path, Diff(want, got))
+	}
+}
+
+// Diff describes the first difference between two encodings: the line
+// for text, or the byte offset with surrounding hex for binary data.
+func Diff(want, got []byte) string {
+	i := 0
+	for i < len(want) && i < len(got) && want[i] == got[i] {
+		i++
+	}
+	if isText(want) && isText(got) {
+		line := bytes.Count(want[:i], []byte("\n")) + 1
+		return fmt.Sprintf("line %d\n  want: %s\n  got:  %s", line, lineAt(want, i), lineAt(got, i))
+	}
+	lo := max(i-8, 0)
+	return fmt.Sprintf("byte %d (want %d bytes, got %d)\n  want: % x\n  got:  % x",
+		i, len(want), len(got), want[lo:min(i+8, len(want))], got[lo:min(i+8, len(got))])
+}
+
+func isText(b []byte) bool {
+	return utf8.Valid(b) && bytes.IndexByte(b, 0) < 0
+}
+
+// lineAt returns the line of b containing offset i, trimmed to a window
+// around i so minified JSON stays readable.
+func lineAt(b []byte, i int) string {
+	start := bytes.LastIndexByte(b[:min(i, len(b))], '\n') + 1
+	end := len(b)
+	if j := bytes.IndexByte(b[start:], '\n'); j >= 0 {
+		end = start + j
+	}
+	const ctx = 60
+	lo, hi := max(start, i-ctx), min(end, i+ctx)
+	s := string(b[lo:hi])
+	if lo > start {
+		s = "…" + s
+	}
+	if hi < end {
+		s += "…"
+	}
+	return strings.ToValidUTF8(s, "")
+}
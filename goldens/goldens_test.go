@@ -0,0 +1,19 @@
+package goldens
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGoldens(t *testing.T) {
+	Run(t, "testdata", Cases(), Encodings())
+}
+
+func TestDiff(t *testing.T) {
+	if d := Diff([]byte("{\"a\":1}\n{\"b\":2}"), []byte("{\"a\":1}\n{\"b\":3}")); !strings.HasPrefix(d, "line 2\n") {
+		t.Errorf("text diff = %q", d)
+	}
+	if d := Diff([]byte{0x0a, 0x00, 0x01}, []byte{0x0a, 0x00, 0x02}); !strings.HasPrefix(d, "byte 2 ") {
+		t.Errorf("binary diff = %q", d)
+	}
+}
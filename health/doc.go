@@ -0,0 +1,11 @@
+// health/doc.go
+// Package health fetches and checks the self-test reports
+// (types.HealthReport) served by transparency endpoints, so one monitor
+// can watch every publisher in the ecosystem.
+//
+//	r, err := health.Check(ctx, http.DefaultClient, "https://example.org/transparency/health")
+//	if err != nil { ... } // unreachable, malformed, or invalid
+//	for _, f := range health.Assess(r, health.Thresholds{MaxStaleness: 15 * time.Minute}, time.Now()) {
+//		log.Print(f)
+//	}
+package health
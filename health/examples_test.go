@@ -0,0 +1,30 @@
+package health_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/health"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func ExampleAssess() {
+	now := time.Date(2025, 1, 1, 1, 0, 0, 0, time.UTC)
+	r := &types.HealthReport{
+		SpecVersion: "0.3.0",
+		GeneratedAt: now.Add(-time.Minute),
+		Status:      types.HealthDegraded,
+		Window:      types.ISODuration(time.Hour),
+		Topics: []types.TopicHealth{
+			{Topic: "#vote", LastPublished: now.Add(-2 * time.Minute), Submitted: 400, Rejected: 2, FailureRate: 0.005},
+			{Topic: "#ballot", LastPublished: now.Add(-40 * time.Minute), Submitted: 50, Rejected: 10, FailureRate: 0.2},
+		},
+	}
+	for _, f := range health.Assess(r, health.Thresholds{MaxStaleness: 15 * time.Minute, MaxFailureRate: 0.05}, now) {
+		fmt.Println(f)
+	}
+	// Output:
+	// status: endpoint reports "degraded"
+	// stale #ballot: last published 40m0s ago
+	// failure_rate #ballot: 20.0% of 50 payloads rejected
+}
@@ -0,0 +1,137 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+	"github.com/civic-interconnect/civic-transparency-go-types/validate"
+)
+
+// MaxReportBytes bounds the response body Check reads.
+const MaxReportBytes = 1 << 20
+
+// StatusError is a non-2xx response from the endpoint.
+type StatusError struct {
+	Code int
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("health: HTTP %d: %s", e.Code, e.Body)
+}
+
+// Check fetches the health report at endpoint with client (http.DefaultClient
+// if nil) and validates it. A report that decodes but fails validation is
+// returned together with the validation error.
+func Check(ctx context.Context, client *http.Client, endpoint string) (*types.HealthReport, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, MaxReportBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode/100 != 2 {
+		return nil, &StatusError{Code: resp.StatusCode, Body: strings.TrimSpace(string(body))}
+	}
+	if len(body) > MaxReportBytes {
+		return nil, fmt.Errorf("health: report exceeds %d bytes", MaxReportBytes)
+	}
+	var r types.HealthReport
+	if err := json.Unmarshal(body, &r); err != nil {
+		return nil, fmt.Errorf("health: decode: %w", err)
+	}
+	return &r, validate.ValidateHealthReport(&r, validate.DefaultOptions())
+}
+
+// Thresholds are the limits Assess checks a report against. Zero values
+// disable the corresponding check.
+type Thresholds struct {
+	// MaxStaleness is how long a topic may go without publishing.
+	MaxStaleness time.Duration
+	// MaxFailureRate is the highest acceptable validation failure rate.
+	MaxFailureRate float64
+	// MaxReportAge is how old the report itself may be.
+	MaxReportAge time.Duration
+}
+
+// FindingKind classifies a Finding.
+type FindingKind string
+
+const (
+	FindingStatus      FindingKind = "status"       // endpoint reports itself degraded or down
+	FindingSpecVersion FindingKind = "spec_version" // major version differs from types.SpecVersion
+	FindingReportAge   FindingKind = "report_age"   // report older than MaxReportAge
+	FindingStale       FindingKind = "stale"        // topic not published within MaxStaleness
+	FindingFailureRate FindingKind = "failure_rate" // topic failure rate above MaxFailureRate
+)
+
+// Finding is one problem Assess found. Topic is empty for report-level
+// findings.
+type Finding struct {
+	Kind   FindingKind
+	Topic  string
+	Detail string
+}
+
+func (f Finding) String() string {
+	if f.Topic == "" {
+		return fmt.Sprintf("%s: %s", f.Kind, f.Detail)
+	}
+	return fmt.Sprintf("%s %s: %s", f.Kind, f.Topic, f.Detail)
+}
+
+// Assess checks r against th as of now and returns its findings in report
+// order; none means healthy.
+func Assess(r *types.HealthReport, th Thresholds, now time.Time) []Finding {
+	if r == nil {
+		return nil
+	}
+	var out []Finding
+	if r.Status != types.HealthOK {
+		out = append(out, Finding{Kind: FindingStatus, Detail: fmt.Sprintf("endpoint reports %q", r.Status)})
+	}
+	if major(r.SpecVersion) != major(types.SpecVersion) {
+		out = append(out, Finding{Kind: FindingSpecVersion,
+			Detail: fmt.Sprintf("publishes %s, monitor understands %s", r.SpecVersion, types.SpecVersion)})
+	}
+	if th.MaxReportAge > 0 && now.Sub(r.GeneratedAt) > th.MaxReportAge {
+		out = append(out, Finding{Kind: FindingReportAge, Detail: fmt.Sprintf("generated %s ago", now.Sub(r.GeneratedAt).Round(time.Second))})
+	}
+	for _, t := range r.Topics {
+		if th.MaxStaleness > 0 {
+			switch age := now.Sub(t.LastPublished); {
+			case t.LastPublished.IsZero():
+				out = append(out, Finding{Kind: FindingStale, Topic: t.Topic, Detail: "never published"})
+			case age > th.MaxStaleness:
+				out = append(out, Finding{Kind: FindingStale, Topic: t.Topic, Detail: fmt.Sprintf("last published %s ago", age.Round(time.Second))})
+			}
+		}
+		if th.MaxFailureRate > 0 && float64(t.FailureRate) > th.MaxFailureRate {
+			out = append(out, Finding{Kind: FindingFailureRate, Topic: t.Topic,
+				Detail: fmt.Sprintf("%.1f%% of %d payloads rejected", 100*float64(t.FailureRate), t.Submitted)})
+		}
+	}
+	return out
+}
+
+func major(v string) string {
+	m, _, _ := strings.Cut(v, ".")
+	return m
+}
@@ -0,0 +1,53 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/validate"
+)
+
+func serve(t *testing.T, code int, body string) string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(code)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+func TestCheck(t *testing.T) {
+	ctx := context.Background()
+	good := `{"spec_version":"0.3.0","generated_at":"2025-01-01T00:10:00Z","status":"ok","window":"PT1H",
+		"topics":[{"topic":"#vote","last_published":"2025-01-01T00:09:00Z","submitted":200,"rejected":3,"failure_rate":0.015}]}`
+	r, err := Check(ctx, nil, serve(t, 200, good))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Topics) != 1 || r.Topics[0].Rejected != 3 {
+		t.Errorf("report = %+v", r)
+	}
+
+	bad := `{"spec_version":"0.3","generated_at":"2025-01-01T00:10:00Z","status":"fine","window":"PT1H",
+		"topics":[{"topic":"#vote","submitted":2,"rejected":3,"failure_rate":0.5}]}`
+	r, err = Check(ctx, nil, serve(t, 200, bad))
+	if r == nil || err == nil {
+		t.Fatalf("Check(invalid) = %v, %v; want report and error", r, err)
+	}
+	var me *validate.MultiError
+	if !errors.As(err, &me) || len(me.Errors()) != 3 {
+		t.Errorf("validation error = %v", err)
+	}
+
+	var se *StatusError
+	if _, err := Check(ctx, nil, serve(t, 503, "maintenance\n")); !errors.As(err, &se) || se.Code != 503 || se.Body != "maintenance" {
+		t.Errorf("Check(503) = %v", err)
+	}
+	if _, err := Check(ctx, nil, serve(t, 200, "<html>")); err == nil {
+		t.Error("Check accepted a non-JSON body")
+	}
+}
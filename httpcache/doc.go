@@ -0,0 +1,16 @@
+// httpcache/doc.go
+// Package httpcache serves Series over HTTP with strong ETags, conditional
+// requests, and Cache-Control chosen by whether a series is final (see
+// types.SeriesState) or live, keeping encoded responses in a
+// read-through cache so unchanged archives are not re-serialized on every
+// request.
+//
+//	h := httpcache.Handler(func(r *http.Request) (*types.Series, error) {
+//		return loadSeries(r.Context(), r.URL.Path)
+//	}, httpcache.Options{})
+//
+// Entries are reloaded once their max-age has passed: FinalMaxAge for
+// final series, which can still be revised or retracted, and the interval
+// for live ones. Call Invalidate when a series is revised or retracted to
+// reload it at once.
+package httpcache
@@ -0,0 +1,271 @@
+package httpcache
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/store"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// Defaults for zero Options fields.
+const (
+	DefaultFinalMaxAge = 24 * time.Hour
+	DefaultMaxEntries  = 1024
+)
+
+// Options configures Handler. The zero value serves canonical JSON.
+type Options struct {
+	// Encode serializes s for r, e.g. after version negotiation, and
+	// returns the body and its content type. Nil selects json.Marshal and
+	// application/json.
+	Encode func(r *http.Request, s *types.Series) (body []byte, contentType string, err error)
+	// Key names the representation r asks for. Requests with equal keys
+	// share a cache entry. Nil selects the URL path and query plus the
+	// Accept header.
+	Key func(r *http.Request) string
+	// State returns the lifecycle state of s, e.g. from the publisher's
+	// own records. Nil selects types.StateOf, which infers it from s.
+	State func(s *types.Series) types.SeriesState
+	// FinalMaxAge is the max-age of final series (see
+	// types.SeriesState.Final), and how long the handler reuses one before
+	// loading it again, since a final series can still be revised or
+	// retracted. Zero selects DefaultFinalMaxAge.
+	FinalMaxAge time.Duration
+	// LiveMaxAge is the max-age of live series, and how long the handler
+	// reuses a live entry. Zero selects the series interval.
+	LiveMaxAge time.Duration
+	// MaxEntries bounds the cache, evicting the least recently used entry.
+	// Zero selects DefaultMaxEntries.
+	MaxEntries int
+	// Now returns the current time. Nil selects time.Now.
+	Now func() time.Time
+	// Logger receives load and encode errors, which clients see only as a
+	// generic 500 response. Nil selects slog.Default.
+	Logger *slog.Logger
+}
+
+func (o Options) withDefaults() Options {
+	if o.Encode == nil {
+		o.Encode = func(_ *http.Request, s *types.Series) ([]byte, string, error) {
+			b, err := json.Marshal(s)
+			return b, "application/json", err
+		}
+	}
+	if o.Key == nil {
+		o.Key = func(r *http.Request) string {
+			return r.URL.RequestURI() + "\x00" + r.Header.Get("Accept")
+		}
+	}
+	if o.State == nil {
+		o.State = types.StateOf
+	}
+	if o.FinalMaxAge == 0 {
+		o.FinalMaxAge = DefaultFinalMaxAge
+	}
+	if o.MaxEntries == 0 {
+		o.MaxEntries = DefaultMaxEntries
+	}
+	if o.Logger == nil {
+		o.Logger = slog.Default()
+	}
+	if o.Now == nil {
+		o.Now = time.Now
+	}
+	return o
+}
+
+// Finalized reports whether s is final by its inferred lifecycle state,
+// types.StateOf(s).Final(): it is retracted, or it was generated after the
+// UTC day of its last point ended plus the methodology's collection lag.
+func Finalized(s *types.Series) bool {
+	return types.StateOf(s).Final()
+}
+
+// ETag returns a strong entity tag for a representation: a quoted hex
+// SHA-256 prefix of its content type and body. Equal bytes always get the
+// same tag, and any change to the series changes it.
+func ETag(body []byte, contentType string) string {
+	h := sha256.New()
+	h.Write([]byte(contentType))
+	h.Write([]byte{0})
+	h.Write(body)
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// NoneMatch reports whether r's If-None-Match header lists etag (or "*"),
+// comparing weakly as RFC 9110 requires for GET and HEAD.
+func NoneMatch(r *http.Request, etag string) bool {
+	for _, v := range r.Header.Values("If-None-Match") {
+		for _, t := range strings.Split(v, ",") {
+			t = strings.TrimPrefix(strings.TrimSpace(t), "W/")
+			if t == "*" || t == strings.TrimPrefix(etag, "W/") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type entry struct {
+	key          string
+	body         []byte
+	contentType  string
+	etag         string
+	cacheControl string
+	expires      time.Time // when the handler stops reusing the entry
+}
+
+// CacheHandler is the http.Handler returned by Handler.
+type CacheHandler struct {
+	load  func(r *http.Request) (*types.Series, error)
+	opts  Options
+	cache *cache
+}
+
+// Handler serves the series load returns for each GET or HEAD request.
+// load returns an error wrapping store.ErrNotFound for a missing series,
+// which is answered with 404; other errors are answered with 500.
+func Handler(load func(r *http.Request) (*types.Series, error), opts Options) *CacheHandler {
+	opts = opts.withDefaults()
+	return &CacheHandler{load: load, opts: opts, cache: &cache{max: opts.MaxEntries, lru: list.New(), m: map[string]*list.Element{}}}
+}
+
+// Invalidate drops the cached entries for key, and for keys that extend it
+// with a NUL-separated suffix, so the next request loads the series again.
+// With the default Options.Key, Invalidate(r.URL.RequestURI()) drops every
+// representation of a URL. Call it when a series is revised or retracted.
+func (h *CacheHandler) Invalidate(key string) { h.cache.remove(key) }
+
+func (h *CacheHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	key, now := h.opts.Key(r), h.opts.Now()
+	e := h.cache.get(key, now)
+	if e == nil {
+		s, err := h.load(r)
+		switch {
+		case errors.Is(err, store.ErrNotFound):
+			http.NotFound(w, r)
+			return
+		case err != nil:
+			h.internalError(w, r, err)
+			return
+		}
+		if e, err = newEntry(r, key, s, h.opts, now); err != nil {
+			h.internalError(w, r, err)
+			return
+		}
+		h.cache.put(e)
+	}
+	serve(w, r, e)
+}
+
+// internalError logs err and sends a 500 that does not reveal it.
+func (h *CacheHandler) internalError(w http.ResponseWriter, r *http.Request, err error) {
+	h.opts.Logger.ErrorContext(r.Context(), "httpcache: serve series",
+		"method", r.Method, "uri", r.URL.RequestURI(), "err", err)
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
+
+func newEntry(r *http.Request, key string, s *types.Series, opts Options, now time.Time) (*entry, error) {
+	body, ct, err := opts.Encode(r, s)
+	if err != nil {
+		return nil, fmt.Errorf("httpcache: encode: %w", err)
+	}
+	e := &entry{key: key, body: body, contentType: ct, etag: ETag(body, ct)}
+	if opts.State(s).Final() {
+		e.expires = now.Add(opts.FinalMaxAge)
+		e.cacheControl = fmt.Sprintf("public, max-age=%d", int(opts.FinalMaxAge/time.Second))
+		return e, nil
+	}
+	age := opts.LiveMaxAge
+	if age == 0 {
+		age = s.Interval.Duration()
+	}
+	e.expires = now.Add(age)
+	e.cacheControl = fmt.Sprintf("public, max-age=%d, must-revalidate", int(age/time.Second))
+	return e, nil
+}
+
+func serve(w http.ResponseWriter, r *http.Request, e *entry) {
+	h := w.Header()
+	h.Set("ETag", e.etag)
+	h.Set("Cache-Control", e.cacheControl)
+	h.Add("Vary", "Accept")
+	if NoneMatch(r, e.etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	h.Set("Content-Type", e.contentType)
+	h.Set("Content-Length", fmt.Sprint(len(e.body)))
+	if r.Method == http.MethodHead {
+		return
+	}
+	w.Write(e.body)
+}
+
+// cache is an LRU of encoded responses, safe for concurrent use.
+type cache struct {
+	mu  sync.Mutex
+	max int
+	lru *list.List // front is most recent; values are *entry
+	m   map[string]*list.Element
+}
+
+// get returns the entry for key if it has not expired.
+func (c *cache) get(key string, now time.Time) *entry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.m[key]
+	if !ok {
+		return nil
+	}
+	e := el.Value.(*entry)
+	if !now.Before(e.expires) {
+		c.lru.Remove(el)
+		delete(c.m, key)
+		return nil
+	}
+	c.lru.MoveToFront(el)
+	return e
+}
+
+func (c *cache) put(e *entry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.m[e.key]; ok {
+		el.Value = e
+		c.lru.MoveToFront(el)
+		return
+	}
+	c.m[e.key] = c.lru.PushFront(e)
+	for c.lru.Len() > c.max {
+		old := c.lru.Back()
+		c.lru.Remove(old)
+		delete(c.m, old.Value.(*entry).key)
+	}
+}
+
+func (c *cache) remove(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k, el := range c.m {
+		if k == key || strings.HasPrefix(k, key+"\x00") {
+			c.lru.Remove(el)
+			delete(c.m, k)
+		}
+	}
+}
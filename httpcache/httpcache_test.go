@@ -0,0 +1,153 @@
+package httpcache
+
+import (
+	"bytes"
+	"container/list"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/store"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestHandler(t *testing.T) {
+	day := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	now := day.Add(36 * time.Hour)
+	series := map[string]*types.Series{
+		// A closed daily archive and today's live series.
+		"/archive": {Topic: "#vote", GeneratedAt: day.Add(25 * time.Hour), Interval: types.IntervalMinute,
+			Points: []types.Point{{TS: day.Add(23*time.Hour + 59*time.Minute), Volume: 5}}},
+		"/live": {Topic: "#vote", GeneratedAt: now, Interval: types.IntervalMinute,
+			Points: []types.Point{{TS: now.Add(-time.Minute), Volume: 5}}},
+	}
+	loads := map[string]int{}
+	h := Handler(func(r *http.Request) (*types.Series, error) {
+		loads[r.URL.Path]++
+		if s, ok := series[r.URL.Path]; ok {
+			return s, nil
+		}
+		return nil, fmt.Errorf("load %s: %w", r.URL.Path, store.ErrNotFound)
+	}, Options{Now: func() time.Time { return now }})
+
+	get := func(path, inm string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		if inm != "" {
+			r.Header.Set("If-None-Match", inm)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	w := get("/archive", "")
+	etag := w.Header().Get("ETag")
+	if w.Code != 200 || etag == "" || w.Header().Get("Cache-Control") != "public, max-age=86400" {
+		t.Fatalf("archive: %d %v", w.Code, w.Header())
+	}
+	if w := get("/archive", `"other", `+etag); w.Code != http.StatusNotModified || w.Body.Len() != 0 {
+		t.Errorf("conditional archive: %d", w.Code)
+	}
+	now = now.Add(time.Hour)
+	if w := get("/archive", ""); w.Code != 200 || w.Header().Get("ETag") != etag || loads["/archive"] != 1 {
+		t.Errorf("finalized entry reloaded within FinalMaxAge: loads %d", loads["/archive"])
+	}
+
+	// A retraction reaches clients once the final entry's max-age passes,
+	// or at once when the series is invalidated.
+	series["/archive"].Retraction = &types.Retraction{Topic: "#vote", Reason: types.RetractionDataError, EffectiveAt: now}
+	h.Invalidate("/archive")
+	if w := get("/archive", etag); w.Code != 200 || w.Header().Get("ETag") == etag || loads["/archive"] != 2 {
+		t.Errorf("invalidated entry: %d, loads %d", w.Code, loads["/archive"])
+	}
+	now = now.Add(24 * time.Hour)
+	if get("/archive", ""); loads["/archive"] != 3 {
+		t.Errorf("final entry past FinalMaxAge not reloaded: loads %d", loads["/archive"])
+	}
+
+	w = get("/live", "")
+	if w.Header().Get("Cache-Control") != "public, max-age=60, must-revalidate" {
+		t.Errorf("live Cache-Control = %q", w.Header().Get("Cache-Control"))
+	}
+	get("/live", "")
+	now = now.Add(time.Minute)
+	series["/live"].Points = append(series["/live"].Points, types.Point{TS: now.Add(-time.Minute), Volume: 1})
+	if w2 := get("/live", w.Header().Get("ETag")); w2.Code != 200 || loads["/live"] != 2 {
+		t.Errorf("expired live entry: %d, loads %d", w2.Code, loads["/live"])
+	}
+
+	if w := get("/missing", ""); w.Code != http.StatusNotFound {
+		t.Errorf("missing: %d", w.Code)
+	}
+}
+
+func TestCacheEvicts(t *testing.T) {
+	c := &cache{max: 2, lru: list.New(), m: map[string]*list.Element{}}
+	for _, k := range []string{"a", "b", "a", "c"} {
+		if c.get(k, time.Time{}) == nil {
+			c.put(&entry{key: k, expires: time.Unix(1<<40, 0)})
+		}
+	}
+	if c.get("b", time.Time{}) != nil || c.get("a", time.Time{}) == nil || c.get("c", time.Time{}) == nil {
+		t.Errorf("LRU kept %v", c.m)
+	}
+}
+
+func TestInvalidateVariants(t *testing.T) {
+	c := &cache{max: 10, lru: list.New(), m: map[string]*list.Element{}}
+	for _, k := range []string{"/a\x00application/json", "/a\x00text/csv", "/ab\x00application/json"} {
+		c.put(&entry{key: k, expires: time.Unix(1<<40, 0)})
+	}
+	c.remove("/a")
+	if len(c.m) != 1 || c.get("/ab\x00application/json", time.Time{}) == nil {
+		t.Errorf("Invalidate(/a) left %v", c.m)
+	}
+}
+
+func TestStateOption(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := &types.Series{Topic: "#vote", GeneratedAt: now, Interval: types.IntervalMinute,
+		Points: []types.Point{{TS: now.Add(-time.Minute), Volume: 5}}}
+	h := Handler(func(*http.Request) (*types.Series, error) { return s, nil }, Options{
+		Now:   func() time.Time { return now },
+		State: func(*types.Series) types.SeriesState { return types.SeriesRevised },
+	})
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/s", nil))
+	if cc := w.Header().Get("Cache-Control"); cc != "public, max-age=86400" {
+		t.Errorf("revised series Cache-Control = %q", cc)
+	}
+}
+
+func TestInternalErrorHidden(t *testing.T) {
+	var logs bytes.Buffer
+	opts := Options{Logger: slog.New(slog.NewTextHandler(&logs, nil))}
+	load := func(r *http.Request) (*types.Series, error) {
+		if r.URL.Path == "/down" {
+			return nil, errors.New("dial tcp 10.0.0.7:5432: connection refused")
+		}
+		return &types.Series{Topic: "#vote", Interval: types.IntervalMinute}, nil
+	}
+	opts.Encode = func(*http.Request, *types.Series) ([]byte, string, error) {
+		return nil, "", errors.New("template /srv/secret.tmpl: bad field")
+	}
+	h := Handler(load, opts)
+	for path, secret := range map[string]string{"/down": "10.0.0.7", "/encode": "/srv/secret.tmpl"} {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		if w.Code != http.StatusInternalServerError {
+			t.Fatalf("%s: status %d", path, w.Code)
+		}
+		if body := w.Body.String(); strings.Contains(body, secret) {
+			t.Errorf("%s: body %q reveals the error", path, body)
+		}
+		if !strings.Contains(logs.String(), secret) {
+			t.Errorf("%s: error not logged: %q", path, logs.String())
+		}
+	}
+}
@@ -0,0 +1,10 @@
+// httpvalidate/doc.go
+// Package httpvalidate provides net/http middleware that decodes and
+// validates request bodies, storing the typed value in the request context
+// and answering invalid requests with RFC 7807 problem details.
+//
+// The middleware has the standard func(http.Handler) http.Handler shape, so
+// it plugs into routers built on net/http directly and into echo with
+// echo.WrapMiddleware. For gin, wrap the protected handler with
+// gin.WrapH(httpvalidate.Series()(h)).
+package httpvalidate
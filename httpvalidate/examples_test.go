@@ -0,0 +1,31 @@
+package httpvalidate_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/httpvalidate"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func ExampleProvenanceTag() {
+	h := httpvalidate.ProvenanceTag()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tag, _ := httpvalidate.FromContext[types.ProvenanceTag](r.Context())
+		fmt.Fprintln(w, "accepted", tag.DedupHash)
+	}))
+
+	body := `{"acct_age_bucket":"1-6m","acct_type":"person","automation_flag":"manual",
+		"post_kind":"original","client_family":"web","media_provenance":"none","dedup_hash":"deadbeef"}`
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/tags", strings.NewReader(body)))
+	fmt.Print(rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/tags", strings.NewReader(`{}`)))
+	fmt.Println(rec.Code, rec.Header().Get("Content-Type"))
+	// Output:
+	// accepted deadbeef
+	// 422 application/problem+json
+}
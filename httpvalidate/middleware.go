@@ -0,0 +1,114 @@
+package httpvalidate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/codec"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+	"github.com/civic-interconnect/civic-transparency-go-types/validate"
+)
+
+// DefaultMaxBodyBytes caps request bodies when Options.MaxBodyBytes is zero.
+const DefaultMaxBodyBytes = 32 << 20
+
+// Options configures the middleware.
+type Options struct {
+	// MaxBodyBytes limits the request body. Zero selects DefaultMaxBodyBytes.
+	MaxBodyBytes int64
+	// DisallowUnknownFields rejects bodies with members the type lacks.
+	DisallowUnknownFields bool
+	// Source, if set, derives payload metadata from the request (e.g. the
+	// authenticated publisher and a batch header). It is attached to
+	// validation errors, so problem responses echo it, and stored in the
+	// context passed to next (see validate.SourceFromContext).
+	Source func(r *http.Request) validate.Source
+}
+
+type ctxKey[T any] struct{}
+
+// FromContext returns the validated value stored by Middleware.
+func FromContext[T any](ctx context.Context) (*T, bool) {
+	v, ok := ctx.Value(ctxKey[T]{}).(*T)
+	return v, ok
+}
+
+// Middleware decodes the body into a T, runs check, and on success calls
+// next with the value available via FromContext[T]. A types.Series body is
+// decoded with codec.UnmarshalWith, so compact and quantized documents are
+// converted before check sees them; other types use encoding/json. Decode
+// failures are answered with 400 and validation failures with 422, both as
+// application/problem+json.
+func Middleware[T any](check func(*T) error, opts Options) func(http.Handler) http.Handler {
+	limit := opts.MaxBodyBytes
+	if limit <= 0 {
+		limit = DefaultMaxBodyBytes
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			var src validate.Source
+			if opts.Source != nil {
+				src = opts.Source(r)
+				ctx = validate.WithSource(ctx, src)
+			}
+			v := new(T)
+			if err := decode(http.MaxBytesReader(w, r.Body, limit), v, opts); err != nil {
+				status := http.StatusBadRequest
+				var mbe *http.MaxBytesError
+				if errors.As(err, &mbe) {
+					status = http.StatusRequestEntityTooLarge
+				}
+				writeProblem(w, r, &validate.Problem{
+					Type:   "about:blank",
+					Title:  http.StatusText(status),
+					Status: status,
+					Detail: err.Error(),
+				})
+				return
+			}
+			if err := check(v); err != nil {
+				p := validate.ToProblem(validate.AttachSource(err, src))
+				writeProblem(w, r, p)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, ctxKey[T]{}, v)))
+		})
+	}
+}
+
+func decode(body io.Reader, v any, opts Options) error {
+	if s, ok := v.(*types.Series); ok {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		return codec.UnmarshalWith(data, s, codec.DecodeOptions{DisallowUnknownFields: opts.DisallowUnknownFields})
+	}
+	dec := json.NewDecoder(body)
+	if opts.DisallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+	return dec.Decode(v)
+}
+
+// Series is Middleware for types.Series using validate.ValidateSeries.
+func Series() func(http.Handler) http.Handler {
+	return Middleware(validate.ValidateSeries, Options{})
+}
+
+// ProvenanceTag is Middleware for types.ProvenanceTag using
+// validate.ValidateProvenanceTag.
+func ProvenanceTag() func(http.Handler) http.Handler {
+	return Middleware(validate.ValidateProvenanceTag, Options{})
+}
+
+func writeProblem(w http.ResponseWriter, r *http.Request, p *validate.Problem) {
+	p.Instance = r.URL.Path
+	w.Header().Set("Content-Type", validate.ProblemContentType)
+	w.WriteHeader(p.Status)
+	_ = json.NewEncoder(w).Encode(p)
+}
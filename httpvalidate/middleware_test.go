@@ -0,0 +1,37 @@
+package httpvalidate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestSeriesQuantized(t *testing.T) {
+	var got *types.Series
+	h := Series()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = FromContext[types.Series](r.Context())
+	}))
+	post := func(body string) int {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/series", strings.NewReader(body)))
+		return rec.Code
+	}
+	const head = `{"topic":"#vote","generated_at":"2025-01-01T00:05:00Z","interval":"PT1M","points":[{"ts":"2025-01-01T00:00:00Z","volume":3,`
+	if code := post(head + `"reshare_ratio_bp":2500,"post_kind_mix_bp":{"original":7500,"reshare":2500}}]}`); code != http.StatusOK {
+		t.Fatalf("valid quantized body: status %d", code)
+	}
+	if p := got.Points[0]; p.ReshareRatio != 0.25 || p.PostKindMix[types.PostKindReshare] != 0.25 {
+		t.Errorf("point = %+v", p)
+	}
+	for _, body := range []string{
+		head + `"reshare_ratio_bp":15000}]}`,
+		head + `"reshare_ratio_bp":2500,"post_kind_mix_bp":{"original":7000,"reshare":2500}}]}`,
+	} {
+		if code := post(body); code != http.StatusUnprocessableEntity {
+			t.Errorf("%s: status %d, want 422", body, code)
+		}
+	}
+}
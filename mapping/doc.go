@@ -0,0 +1,24 @@
+// mapping/doc.go
+// Package mapping turns arbitrary platform JSON exports into
+// ProvenanceTags using a declarative Config instead of a bespoke adapter
+// per platform. A Config names, for each tag field, a selector into the
+// export record and optionally a value map:
+//
+//	{
+//	  "platform": "example",
+//	  "records": "$.data[*]",
+//	  "observed_at": "created_time",
+//	  "fields": {
+//	    "acct_age_bucket": {"path": "author.created_at", "transform": "created_at"},
+//	    "acct_type":       {"path": "author.kind", "values": {"human": "person", "brand": "org"}},
+//	    "post_kind":       {"path": "type", "values": {"rt": "reshare", "post": "original"}},
+//	    "client_family":   {"path": "source.app", "default": "web"},
+//	    "dedup_hash":      {"path": "hashes['content-8']"}
+//	  }
+//	}
+//
+// Selectors are a JSONPath subset: member names separated by dots,
+// bracketed quoted names for keys that contain dots, and [N] array
+// indexes, with an optional leading "$". Only Config.Records may end in
+// [*], to select every element of an array.
+package mapping
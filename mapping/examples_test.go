@@ -0,0 +1,49 @@
+package mapping_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/mapping"
+)
+
+func ExampleMapper_Map() {
+	cfg, err := mapping.LoadConfig(strings.NewReader(`{
+		"platform": "example",
+		"records": "$.posts[*]",
+		"observed_at": "time",
+		"fields": {
+			"acct_age_bucket":  {"path": "author.joined", "transform": "created_at"},
+			"acct_type":        {"path": "author.class", "values": {"human": "person", "brand": "org"}},
+			"automation_flag":  {"const": "manual"},
+			"post_kind":        {"path": "type", "values": {"rt": "reshare", "post": "original"}},
+			"client_family":    {"path": "via", "default": "web"},
+			"media_provenance": {"const": "none"},
+			"dedup_hash":       {"path": "digest"}
+		}
+	}`))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	m, err := mapping.New(cfg)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	tags, err := m.Map([]byte(`{"posts": [
+		{"time": "2025-06-01T12:00:00Z", "author": {"joined": "2025-05-20T00:00:00Z", "class": "human"},
+		 "type": "rt", "via": "mobile", "digest": "a1b2c3d4"},
+		{"time": "2025-06-01T12:01:00Z", "author": {"joined": "2019-01-01T00:00:00Z", "class": "brand"},
+		 "type": "post", "digest": "00ff00ff"}
+	]}`))
+	if err != nil {
+		fmt.Println(err)
+	}
+	for _, t := range tags {
+		fmt.Println(t.AcctAgeBucket, t.AcctType, t.PostKind, t.ClientFamily, t.DedupHash)
+	}
+	// Output:
+	// 8-30d person reshare mobile a1b2c3d4
+	// 24m+ org original web 00ff00ff
+}
@@ -0,0 +1,431 @@
+package mapping
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/codec"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// Config describes how one platform's export maps onto ProvenanceTags. It
+// is usually stored as JSON next to the other per-platform settings and
+// read with LoadConfig.
+type Config struct {
+	// Platform names the export format in errors.
+	Platform string `json:"platform"`
+	// Records selects the export records: an array ending in [*], or a
+	// single object. Empty means the document itself, which may be an
+	// array of records.
+	Records string `json:"records,omitempty"`
+	// ObservedAt selects each record's own timestamp (RFC 3339 or Unix
+	// seconds), the reference time for the created_at transform. Records
+	// without one use Mapper.Now.
+	ObservedAt string `json:"observed_at,omitempty"`
+	// Fields maps ProvenanceTag JSON field names, or x-<platform>-<name>
+	// extension keys, to the record values that fill them.
+	Fields map[string]Field `json:"fields"`
+	// DefaultAliases resolves legacy enum spellings with
+	// codec.DefaultAliases after Field.Values, so configs need only list
+	// the platform's own vocabulary.
+	DefaultAliases bool `json:"default_aliases,omitempty"`
+}
+
+// Field fills one tag field.
+type Field struct {
+	// Path selects the record value. Strings, numbers, and booleans are
+	// used as their text; extensions may select any JSON value.
+	Path string `json:"path,omitempty"`
+	// Const is used instead of a selected value, for facts that hold for
+	// the whole export (e.g., a platform that has only one client family).
+	Const string `json:"const,omitempty"`
+	// Default is used when Path selects nothing or null.
+	Default string `json:"default,omitempty"`
+	// Transform converts the selected value before Values is applied:
+	// "lower" lowercases it, "created_at" turns an account creation time
+	// into its acct_age_bucket, and "age_days" does the same for an account
+	// age in whole days.
+	Transform string `json:"transform,omitempty"`
+	// Values maps platform values to schema values. Values it does not
+	// list are kept as they are.
+	Values map[string]string `json:"values,omitempty"`
+}
+
+// LoadConfig reads a JSON Config, rejecting unknown members so a typo in a
+// field option is not silently ignored.
+func LoadConfig(r io.Reader) (Config, error) {
+	var c Config
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&c); err != nil {
+		return Config{}, fmt.Errorf("mapping: config: %w", err)
+	}
+	return c, nil
+}
+
+// RecordError reports a record that could not be mapped.
+type RecordError struct {
+	Index int    // position among the selected records
+	Field string // tag field, or "" for the record as a whole
+	Err   error
+}
+
+func (e *RecordError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("record %d: %v", e.Index, e.Err)
+	}
+	return fmt.Sprintf("record %d: %s: %v", e.Index, e.Field, e.Err)
+}
+
+func (e *RecordError) Unwrap() error { return e.Err }
+
+// ErrNoRecords is returned by Map when Config.Records selects nothing.
+var ErrNoRecords = errors.New("mapping: records selector matched nothing")
+
+// enums lists the schema values of each enum field, for checking configs
+// and mapped values.
+var enums = map[string][]string{
+	"acct_age_bucket":  strs(types.AcctAgeValues()),
+	"acct_type":        strs(types.AcctTypeValues()),
+	"automation_flag":  strs(types.AutomationFlagValues()),
+	"post_kind":        strs(types.PostKindValues()),
+	"client_family":    strs(types.ClientFamilyValues()),
+	"media_provenance": strs(types.MediaProvenanceValues()),
+	"dedup_hash_alg":   strs(types.DedupHashAlgValues()),
+}
+
+func strs[T ~string](vs []T) []string {
+	out := make([]string, len(vs))
+	for i, v := range vs {
+		out[i] = string(v)
+	}
+	return out
+}
+
+// isEnumValue reports whether v is a schema or registered experimental
+// value of field; validation decides later whether the experiment is on.
+func isEnumValue(field, v string) bool {
+	for _, e := range enums[field] {
+		if e == v {
+			return true
+		}
+	}
+	_, ok := types.Experimental(field, v)
+	return ok
+}
+
+// scalarFields are the non-enum tag fields a Config may fill.
+var scalarFields = map[string]bool{"dedup_hash": true, "origin_hint": true}
+
+var transforms = map[string]bool{"lower": true, "created_at": true, "age_days": true}
+
+type field struct {
+	name string
+	Field
+	path path
+}
+
+// Mapper applies a Config. It is safe for concurrent use once Now is set.
+type Mapper struct {
+	// Now is the reference time for the created_at and age_days
+	// transforms on records without an observed_at value. Nil means
+	// time.Now.
+	Now func() time.Time
+
+	platform string
+	records  path
+	observed path
+	fields   []field // sorted by name
+	aliases  codec.Aliases
+}
+
+// New checks cfg and returns its Mapper. Every field name, selector, and
+// transform must be known, and every value a field can produce from Const,
+// Default, or Values must be valid for its enum, so mistakes surface when
+// the config is loaded rather than as one error per record.
+func New(cfg Config) (*Mapper, error) {
+	m := &Mapper{platform: cfg.Platform}
+	if cfg.DefaultAliases {
+		m.aliases = codec.DefaultAliases()
+	}
+	var errs []error
+	fail := func(format string, args ...any) {
+		errs = append(errs, fmt.Errorf("mapping: "+format, args...))
+	}
+	var err error
+	if m.records, err = parsePath(cfg.Records, true); err != nil {
+		errs = append(errs, err)
+	}
+	if cfg.ObservedAt != "" {
+		if m.observed, err = parsePath(cfg.ObservedAt, false); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(cfg.Fields) == 0 {
+		fail("no fields")
+	}
+	for name, f := range cfg.Fields {
+		_, enum := enums[name]
+		ext := strings.HasPrefix(name, "x-")
+		switch {
+		case ext && !types.ValidExtensionKey(name):
+			fail("%s: extension keys must be x-<platform>-<name>", name)
+		case !enum && !ext && !scalarFields[name]:
+			fail("%s: not a provenance tag field", name)
+		}
+		if (f.Path == "") == (f.Const == "") {
+			fail("%s: exactly one of path and const is required", name)
+		}
+		p, err := parsePath(f.Path, false)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		if f.Transform != "" && !transforms[f.Transform] {
+			fail("%s: unknown transform %q", name, f.Transform)
+		}
+		if (f.Transform == "created_at" || f.Transform == "age_days") && name != "acct_age_bucket" {
+			fail("%s: transform %s only applies to acct_age_bucket", name, f.Transform)
+		}
+		if enum {
+			var bad []string
+			for _, v := range f.Values {
+				if !isEnumValue(name, m.resolve(name, v)) {
+					bad = append(bad, v)
+				}
+			}
+			for _, v := range [...]string{f.Const, f.Default} {
+				if v != "" && !isEnumValue(name, m.resolve(name, v)) {
+					bad = append(bad, v)
+				}
+			}
+			if len(bad) > 0 {
+				sort.Strings(bad)
+				fail("%s: %q not in the schema", name, bad)
+			}
+		}
+		m.fields = append(m.fields, field{name: name, Field: f, path: p})
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	sort.Slice(m.fields, func(i, j int) bool { return m.fields[i].name < m.fields[j].name })
+	return m, nil
+}
+
+func (m *Mapper) resolve(name, v string) string {
+	if m.aliases == nil {
+		return v
+	}
+	return m.aliases.Resolve(name, v)
+}
+
+// Map decodes an export document and maps each selected record to a tag.
+// Records that fail to map are left out and reported as *RecordError
+// values joined into the returned error; the tags of the other records are
+// still returned, in record order. Tags are not validated beyond their
+// enum values; run validate.ValidateProvenanceTag before publishing.
+func (m *Mapper) Map(doc []byte) ([]types.ProvenanceTag, error) {
+	dec := json.NewDecoder(bytes.NewReader(doc))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, fmt.Errorf("mapping: %s: %w", m.platform, err)
+	}
+	sel, ok := m.records.get(v)
+	if !ok {
+		return nil, fmt.Errorf("%w (%s)", ErrNoRecords, m.platform)
+	}
+	records, ok := sel.([]any)
+	if !ok {
+		if m.records.wildcard() {
+			return nil, fmt.Errorf("%w (%s): not an array", ErrNoRecords, m.platform)
+		}
+		records = []any{sel}
+	}
+	tags := make([]types.ProvenanceTag, 0, len(records))
+	var errs []error
+	for i, rec := range records {
+		t, err := m.Record(rec)
+		if err != nil {
+			var re *RecordError
+			if errors.As(err, &re) {
+				re.Index = i
+			}
+			errs = append(errs, err)
+			continue
+		}
+		tags = append(tags, t)
+	}
+	return tags, errors.Join(errs...)
+}
+
+// Record maps one decoded record, as produced by encoding/json with
+// UseNumber (float64 numbers also work). Errors are *RecordError with
+// Index 0.
+func (m *Mapper) Record(rec any) (types.ProvenanceTag, error) {
+	var t types.ProvenanceTag
+	if _, ok := rec.(map[string]any); !ok {
+		return t, &RecordError{Err: fmt.Errorf("want a JSON object, got %T", rec)}
+	}
+	for _, f := range m.fields {
+		if strings.HasPrefix(f.name, "x-") {
+			if err := m.extension(&t, f, rec); err != nil {
+				return types.ProvenanceTag{}, &RecordError{Field: f.name, Err: err}
+			}
+			continue
+		}
+		v, err := m.value(f, rec)
+		if err != nil {
+			return types.ProvenanceTag{}, &RecordError{Field: f.name, Err: err}
+		}
+		if v == "" {
+			continue
+		}
+		if _, enum := enums[f.name]; enum && !isEnumValue(f.name, v) {
+			return types.ProvenanceTag{}, &RecordError{Field: f.name, Err: fmt.Errorf("unmapped value %q", v)}
+		}
+		set(&t, f.name, v)
+	}
+	return t, nil
+}
+
+// value returns the final text of an enum or scalar field, or "" when the
+// record has none and the field has no default.
+func (m *Mapper) value(f field, rec any) (string, error) {
+	if f.Const != "" {
+		return m.resolve(f.name, f.Const), nil
+	}
+	sel, ok := f.path.get(rec)
+	if !ok || sel == nil {
+		return m.resolve(f.name, f.Default), nil
+	}
+	s, err := text(sel)
+	if err != nil {
+		return "", err
+	}
+	switch f.Transform {
+	case "lower":
+		s = strings.ToLower(s)
+	case "created_at":
+		created, err := parseTime(sel)
+		if err != nil {
+			return "", err
+		}
+		s = string(types.AcctAgeBucketFor(created, m.observedAt(rec)))
+	case "age_days":
+		days, err := strconv.ParseFloat(s, 64)
+		if err != nil || days < 0 {
+			return "", fmt.Errorf("account age %q is not a number of days", s)
+		}
+		now := m.observedAt(rec)
+		s = string(types.AcctAgeBucketFor(now.Add(-time.Duration(days*float64(24*time.Hour))), now))
+	}
+	if c, ok := f.Values[s]; ok {
+		s = c
+	}
+	return m.resolve(f.name, s), nil
+}
+
+// extension sets an extension from Const, a mapped value, or the raw
+// selected JSON.
+func (m *Mapper) extension(t *types.ProvenanceTag, f field, rec any) error {
+	var v any
+	if f.Const != "" {
+		v = f.Const
+	} else if sel, ok := f.path.get(rec); ok && sel != nil {
+		v = sel
+		if s, err := text(sel); err == nil {
+			if f.Transform == "lower" {
+				s = strings.ToLower(s)
+				v = s
+			}
+			if c, ok := f.Values[s]; ok {
+				v = c
+			}
+		}
+	} else if f.Default != "" {
+		v = f.Default
+	} else {
+		return nil
+	}
+	return t.Extensions.Set(f.name, v)
+}
+
+func (m *Mapper) observedAt(rec any) time.Time {
+	if m.observed != nil {
+		if sel, ok := m.observed.get(rec); ok {
+			if at, err := parseTime(sel); err == nil {
+				return at
+			}
+		}
+	}
+	if m.Now != nil {
+		return m.Now()
+	}
+	return time.Now()
+}
+
+// text returns the text of a scalar JSON value.
+func text(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	}
+	return "", fmt.Errorf("want a string, number, or boolean, got %T", v)
+}
+
+// parseTime reads an RFC 3339 string or a number of Unix seconds.
+func parseTime(v any) (time.Time, error) {
+	s, err := text(v)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if _, isString := v.(string); isString {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("time %q is not RFC 3339", s)
+		}
+		return t, nil
+	}
+	sec, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("time %s is not Unix seconds", s)
+	}
+	return time.Unix(0, int64(sec*1e9)).UTC(), nil
+}
+
+func set(t *types.ProvenanceTag, name, v string) {
+	switch name {
+	case "acct_age_bucket":
+		t.AcctAgeBucket = types.AcctAge(v)
+	case "acct_type":
+		t.AcctType = types.AcctType(v)
+	case "automation_flag":
+		t.AutomationFlag = types.AutomationFlag(v)
+	case "post_kind":
+		t.PostKind = types.PostKind(v)
+	case "client_family":
+		t.ClientFamily = types.ClientFamily(v)
+	case "media_provenance":
+		t.MediaProvenance = types.MediaProvenance(v)
+	case "dedup_hash":
+		t.DedupHash = types.HexHash8(v)
+	case "dedup_hash_alg":
+		t.DedupHashAlg = types.DedupHashAlg(v)
+	case "origin_hint":
+		t.OriginHint = v
+	}
+}
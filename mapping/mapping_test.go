@@ -0,0 +1,144 @@
+package mapping
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestParsePath(t *testing.T) {
+	doc := map[string]any{"a": []any{map[string]any{"b.c": "x"}}}
+	for sel, want := range map[string]any{
+		"$.a[0]['b.c']": "x",
+		"a[0][\"b.c\"]": "x",
+		"$":             doc,
+	} {
+		p, err := parsePath(sel, false)
+		if err != nil {
+			t.Fatalf("%s: %v", sel, err)
+		}
+		got, ok := p.get(doc)
+		if !ok {
+			t.Fatalf("%s: no match", sel)
+		}
+		if s, isString := want.(string); isString && got != s {
+			t.Errorf("%s = %v, want %v", sel, got, want)
+		}
+	}
+	for _, sel := range []string{"a..b", "a[", "a[-1]", "a[*]", "a[x]"} {
+		if _, err := parsePath(sel, false); err == nil {
+			t.Errorf("%s: parsed", sel)
+		}
+	}
+	if _, err := parsePath("a[*].b", true); err == nil {
+		t.Error("[*] before the end parsed")
+	}
+}
+
+func TestNewRejectsBadConfig(t *testing.T) {
+	_, err := New(Config{Fields: map[string]Field{
+		"acct_kind":     {Path: "a"},
+		"acct_type":     {Path: "b", Values: map[string]string{"human": "persn"}},
+		"post_kind":     {Path: "c", Const: "original"},
+		"client_family": {Path: "d", Transform: "created_at"},
+		"x-bad":         {Path: "e"},
+	}})
+	if err == nil {
+		t.Fatal("bad config accepted")
+	}
+	for _, want := range []string{"acct_kind", `"persn"`, "exactly one", "only applies", "x-bad"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %s", err, want)
+		}
+	}
+	// The alias table makes legacy spellings valid targets.
+	if _, err := New(Config{DefaultAliases: true, Fields: map[string]Field{
+		"post_kind": {Path: "kind", Values: map[string]string{"rt": "retweet"}},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMap(t *testing.T) {
+	m, err := New(Config{
+		Platform:   "test",
+		Records:    "$.data[*]",
+		ObservedAt: "ts",
+		Fields: map[string]Field{
+			"acct_age_bucket":  {Path: "user.created", Transform: "created_at"},
+			"acct_type":        {Path: "user.kind", Transform: "lower", Values: map[string]string{"human": "person"}},
+			"automation_flag":  {Const: "manual"},
+			"post_kind":        {Path: "kind", Default: "original"},
+			"client_family":    {Path: "app", Values: map[string]string{"ios": "mobile"}},
+			"media_provenance": {Path: "media", Default: "none"},
+			"dedup_hash":       {Path: "hash"},
+			"x-test-verified":  {Path: "user.verified"},
+		},
+		DefaultAliases: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := `{"data": [
+		{"ts": "2025-03-01T00:00:00Z", "user": {"created": "2025-02-25T00:00:00Z", "kind": "HUMAN", "verified": true},
+		 "kind": "retweet", "app": "ios", "hash": "deadbeef"},
+		{"ts": 1740787200, "user": {"created": 1709251200, "kind": "organization"},
+		 "app": "web", "media": "c2pa", "hash": "0badf00d"},
+		{"user": {"created": "2025-02-25T00:00:00Z", "kind": "alien"}, "app": "web", "hash": "00000000"},
+		"oops"
+	]}`
+	tags, err := m.Map([]byte(doc))
+	if len(tags) != 2 {
+		t.Fatalf("got %d tags, want 2", len(tags))
+	}
+	want := []types.ProvenanceTag{
+		{AcctAgeBucket: types.AcctAge_0_7d, AcctType: types.AcctTypePerson, AutomationFlag: types.AutomationManual,
+			PostKind: types.PostKindReshare, ClientFamily: types.ClientMobile, MediaProvenance: types.MediaProvNone, DedupHash: "deadbeef"},
+		{AcctAgeBucket: types.AcctAge_6_24m, AcctType: types.AcctTypeOrg, AutomationFlag: types.AutomationManual,
+			PostKind: types.PostKindOriginal, ClientFamily: types.ClientWeb, MediaProvenance: types.MediaProvC2PA, DedupHash: "0badf00d"},
+	}
+	for i := range want {
+		got := tags[i]
+		if string(got.Extensions["x-test-verified"]) != map[int]string{0: "true", 1: ""}[i] {
+			t.Errorf("tag %d extension = %s", i, got.Extensions["x-test-verified"])
+		}
+		got.Extensions = nil
+		if !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("tag %d = %+v, want %+v", i, got, want[i])
+		}
+	}
+	var re *RecordError
+	if !errors.As(err, &re) || re.Index != 2 || re.Field != "acct_type" {
+		t.Fatalf("err = %v, want record 2 acct_type", err)
+	}
+	if !strings.Contains(err.Error(), "record 3: want a JSON object") {
+		t.Errorf("err = %v, want record 3 reported", err)
+	}
+
+	if _, err := m.Map([]byte(`{"items": []}`)); !errors.Is(err, ErrNoRecords) {
+		t.Errorf("missing records: %v", err)
+	}
+}
+
+func TestMapNow(t *testing.T) {
+	m, err := New(Config{Fields: map[string]Field{
+		"acct_age_bucket": {Path: "age", Transform: "age_days"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.Now = func() time.Time { return time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC) }
+	tags, err := m.Map([]byte(`[{"age": 3}, {"age": 45}, {"age": 800}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, want := range []types.AcctAge{types.AcctAge_0_7d, types.AcctAge_1_6m, types.AcctAge_24mPlus} {
+		if tags[i].AcctAgeBucket != want {
+			t.Errorf("tag %d = %s, want %s", i, tags[i].AcctAgeBucket, want)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package mapping
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// step is one selector segment: a member name, or an array index when
+// name is empty. index -1 is the [*] wildcard.
+type step struct {
+	name  string
+	index int
+}
+
+type path []step
+
+// parsePath parses a selector. wildcard allows a trailing [*].
+func parsePath(s string, wildcard bool) (path, error) {
+	rest := strings.TrimPrefix(strings.TrimSpace(s), "$")
+	var p path
+	for rest != "" {
+		switch {
+		case rest[0] == '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("mapping: selector %q: empty member name", s)
+			}
+			p, rest = append(p, step{name: rest[:end]}), rest[end:]
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("mapping: selector %q: unclosed [", s)
+			}
+			inner := rest[1:end]
+			rest = rest[end+1:]
+			switch {
+			case inner == "*":
+				if !wildcard || rest != "" {
+					return nil, fmt.Errorf("mapping: selector %q: [*] is only allowed at the end of records", s)
+				}
+				p = append(p, step{index: -1})
+			case len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0]:
+				p = append(p, step{name: inner[1 : len(inner)-1]})
+			default:
+				n, err := strconv.Atoi(inner)
+				if err != nil || n < 0 {
+					return nil, fmt.Errorf("mapping: selector %q: bad index [%s]", s, inner)
+				}
+				p = append(p, step{index: n})
+			}
+		case len(p) == 0:
+			rest = "." + rest // a leading name without "$."
+		default:
+			return nil, fmt.Errorf("mapping: selector %q: unexpected %q", s, rest[:1])
+		}
+	}
+	return p, nil
+}
+
+func (p path) wildcard() bool { return len(p) > 0 && p[len(p)-1].name == "" && p[len(p)-1].index < 0 }
+
+// get follows p through a decoded JSON value. It reports false when a
+// member or index is missing or the value has the wrong shape.
+func (p path) get(v any) (any, bool) {
+	for _, st := range p {
+		switch {
+		case st.name != "":
+			m, ok := v.(map[string]any)
+			if !ok {
+				return nil, false
+			}
+			if v, ok = m[st.name]; !ok {
+				return nil, false
+			}
+		case st.index >= 0:
+			a, ok := v.([]any)
+			if !ok || st.index >= len(a) {
+				return nil, false
+			}
+			v = a[st.index]
+		default:
+			return v, true // [*]: the caller iterates
+		}
+	}
+	return v, true
+}
@@ -0,0 +1,13 @@
+// negotiate/doc.go
+// Package negotiate selects a schema version between mixed-version clients
+// and servers and downgrades Series to the selected version.
+//
+// Over HTTP, clients list acceptable versions as media-type parameters:
+//
+//	Accept: application/vnd.civic-transparency.series+json; version=0.3.0,
+//	        application/vnd.civic-transparency.series+json; version=0.2.1; q=0.5
+//
+// Over gRPC, clients send one or more versions under the MetadataKey
+// metadata key. In both cases the server picks the best mutually supported
+// version with Select or SelectAccept and encodes with Marshal.
+package negotiate
@@ -0,0 +1,21 @@
+package negotiate_test
+
+import (
+	"fmt"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/negotiate"
+)
+
+func ExampleSelectAccept() {
+	accept := negotiate.MediaType + "; version=0.2.1; q=0.9, " + negotiate.MediaType + "; version=9.0.0"
+	v, _ := negotiate.SelectAccept(accept, negotiate.Supported)
+	fmt.Println(v)
+	fmt.Println(negotiate.ContentType(v))
+
+	v, _ = negotiate.Select([]string{"0.2.1", "0.3.0"}, negotiate.Supported)
+	fmt.Println(v)
+	// Output:
+	// 0.2.1
+	// application/vnd.civic-transparency.series+json; version=0.2.1
+	// 0.3.0
+}
@@ -0,0 +1,196 @@
+package negotiate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// MediaType is the base media type for Series documents.
+const MediaType = "application/vnd.civic-transparency.series+json"
+
+// MetadataKey is the gRPC metadata key carrying client-supported versions.
+const MetadataKey = "ct-schema-version"
+
+// ErrNoCommonVersion is returned when client and server share no version.
+var ErrNoCommonVersion = errors.New("negotiate: no mutually supported schema version")
+
+// Supported lists the versions Marshal can produce, newest first.
+var Supported = []string{types.SpecVersion, "0.3.0", "0.2.1"}
+
+// ContentType returns the Content-Type header value for version.
+func ContentType(version string) string {
+	return mime.FormatMediaType(MediaType, map[string]string{"version": version})
+}
+
+// Select returns the newest version present in both client and server.
+// Invalid version strings are ignored.
+func Select(client, server []string) (string, error) {
+	have := map[string]bool{}
+	for _, v := range server {
+		if _, ok := parse(v); ok {
+			have[v] = true
+		}
+	}
+	best := ""
+	for _, v := range client {
+		if have[v] && (best == "" || Compare(v, best) > 0) {
+			best = v
+		}
+	}
+	if best == "" {
+		return "", ErrNoCommonVersion
+	}
+	return best, nil
+}
+
+// SelectAccept parses an HTTP Accept header and returns the server version
+// with the highest client preference (q value), breaking ties by newest
+// version. A missing header, */*, or the bare media type without a version
+// parameter accepts any server version, in which case the newest wins.
+func SelectAccept(accept string, server []string) (string, error) {
+	if strings.TrimSpace(accept) == "" {
+		accept = "*/*"
+	}
+	type cand struct {
+		v string
+		q float64
+	}
+	var cands []cand
+	for _, part := range strings.Split(accept, ",") {
+		mt, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		if mt != MediaType && mt != "*/*" && mt != "application/*" && mt != "application/json" {
+			continue
+		}
+		q := 1.0
+		if s, ok := params["q"]; ok {
+			if f, err := strconv.ParseFloat(s, 64); err == nil {
+				q = f
+			}
+		}
+		if q <= 0 {
+			continue
+		}
+		if v, ok := params["version"]; ok {
+			cands = append(cands, cand{v, q})
+			continue
+		}
+		for _, v := range server {
+			cands = append(cands, cand{v, q})
+		}
+	}
+	have := map[string]bool{}
+	for _, v := range server {
+		have[v] = true
+	}
+	sort.SliceStable(cands, func(i, j int) bool {
+		if cands[i].q != cands[j].q {
+			return cands[i].q > cands[j].q
+		}
+		return Compare(cands[i].v, cands[j].v) > 0
+	})
+	for _, c := range cands {
+		if have[c.v] {
+			return c.v, nil
+		}
+	}
+	return "", ErrNoCommonVersion
+}
+
+// Compare orders MAJOR.MINOR.PATCH versions, returning -1, 0 or +1.
+// Unparseable versions sort before all valid ones.
+func Compare(a, b string) int {
+	pa, oka := parse(a)
+	pb, okb := parse(b)
+	switch {
+	case !oka && !okb:
+		return 0
+	case !oka:
+		return -1
+	case !okb:
+		return 1
+	}
+	for i := range pa {
+		if pa[i] != pb[i] {
+			if pa[i] < pb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func parse(v string) ([3]int, bool) {
+	var out [3]int
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return out, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return out, false
+		}
+		out[i] = n
+	}
+	return out, true
+}
+
+// Downgrade returns a copy of s restricted to what version can represent.
+// Fields newer than version are dropped: before 0.4.0, tenant,
+// jurisdiction, methodology, extensions, and the acct_type_shares and
+// post_kind_mix breakdowns; before 0.3.0, annotations and backfill
+// markers. Retracted series and intervals other than one minute cannot be
+// expressed before 0.3.0 and yield an error. s is not modified.
+func Downgrade(s *types.Series, version string) (*types.Series, error) {
+	if _, ok := parse(version); !ok {
+		return nil, fmt.Errorf("negotiate: invalid version %q", version)
+	}
+	if Compare(version, types.SpecVersion) > 0 {
+		return nil, fmt.Errorf("negotiate: version %s is newer than %s", version, types.SpecVersion)
+	}
+	pre030, pre040 := Compare(version, "0.3.0") < 0, Compare(version, "0.4.0") < 0
+	if pre030 && s.Retraction != nil {
+		return nil, fmt.Errorf("negotiate: retracted series cannot be encoded as %s", version)
+	}
+	if pre030 && s.Interval.Canonical() != types.IntervalMinute {
+		return nil, fmt.Errorf("negotiate: interval %s cannot be encoded as %s", s.Interval, version)
+	}
+	out := *s
+	if pre040 {
+		out.Tenant, out.Jurisdiction, out.Methodology, out.Extensions = "", "", nil, nil
+	}
+	if pre030 {
+		out.Annotations, out.Interval = nil, types.IntervalMinute
+	}
+	if pre040 {
+		out.Points = make([]types.Point, len(s.Points))
+		for i, p := range s.Points {
+			p.AcctTypeShares, p.PostKindMix = nil, nil
+			if pre030 {
+				p.Backfilled = false
+			}
+			out.Points[i] = p
+		}
+	}
+	return &out, nil
+}
+
+// Marshal downgrades s to version and encodes it as JSON.
+func Marshal(s *types.Series, version string) ([]byte, error) {
+	d, err := Downgrade(s, version)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(d)
+}
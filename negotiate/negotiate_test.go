@@ -0,0 +1,74 @@
+package negotiate
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func fullSeries() *types.Series {
+	t0 := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	return &types.Series{
+		Topic: "#vote", GeneratedAt: t0.Add(time.Hour), Interval: types.IntervalMinute,
+		Points: []types.Point{{
+			TS: t0, Volume: 10, ReshareRatio: 0.5, Backfilled: true,
+			AcctTypeShares: map[types.AcctType]types.Probability{types.AcctTypePerson: 1},
+			PostKindMix:    map[types.PostKind]types.Probability{types.PostKindOriginal: 0.5, types.PostKindReshare: 0.5},
+		}},
+		Annotations:  []types.Annotation{{Start: t0, Kind: types.AnnotationOutage}},
+		Extensions:   types.Extensions{"x-example-region": json.RawMessage(`"emea"`)},
+		Tenant:       "us.fec",
+		Jurisdiction: "US",
+		Methodology:  &types.Methodology{SuppressionThreshold: 10},
+	}
+}
+
+func TestDowngrade(t *testing.T) {
+	s := fullSeries()
+	orig := fullSeries()
+
+	d, err := Downgrade(s, types.SpecVersion)
+	if err != nil || !reflect.DeepEqual(d, s) {
+		t.Fatalf("Downgrade(current) = %+v, %v", d, err)
+	}
+
+	d, err = Downgrade(s, "0.3.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Tenant != "" || d.Jurisdiction != "" || d.Methodology != nil || d.Extensions != nil {
+		t.Errorf("0.3.0 kept 0.4.0 series fields: %+v", d)
+	}
+	if p := d.Points[0]; p.AcctTypeShares != nil || p.PostKindMix != nil || !p.Backfilled {
+		t.Errorf("0.3.0 point = %+v", p)
+	}
+	if len(d.Annotations) != 1 || d.Interval != types.IntervalMinute {
+		t.Errorf("0.3.0 dropped 0.3.0 fields: %+v", d)
+	}
+
+	b, err := Marshal(s, "0.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"topic":"#vote","generated_at":"2025-03-01T13:00:00Z","interval":"minute","points":[` +
+		`{"ts":"2025-03-01T12:00:00Z","volume":10,"reshare_ratio":0.5,"recycled_content_rate":0,"acct_age_mix":null,` +
+		`"automation_mix":null,"client_mix":null,"coordination_signals":{"burst_score":0,"synchrony_index":0,"duplication_clusters":0}}]}`
+	if string(b) != want {
+		t.Errorf("0.2.1:\ngot  %s\nwant %s", b, want)
+	}
+
+	if !reflect.DeepEqual(s, orig) {
+		t.Errorf("Downgrade modified its input: %+v", s)
+	}
+	s.Retraction = &types.Retraction{Topic: "#vote"}
+	if _, err := Downgrade(s, "0.2.1"); err == nil {
+		t.Error("retracted series downgraded to 0.2.1")
+	}
+	s.Retraction, s.Interval = nil, types.IntervalHour
+	if _, err := Downgrade(s, "0.2.1"); err == nil {
+		t.Error("hourly series downgraded to 0.2.1")
+	}
+}
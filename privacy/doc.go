@@ -0,0 +1,4 @@
+// privacy/doc.go
+// Package privacy provides helpers for publishing data without exposing
+// identifiers that are contractually or legally restricted.
+package privacy
@@ -0,0 +1,57 @@
+package privacy_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/privacy"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+	"github.com/civic-interconnect/civic-transparency-go-types/validate"
+)
+
+func ExampleRoundVolumes() {
+	s := types.Series{
+		Topic:       "#vote",
+		GeneratedAt: time.Date(2025, 1, 1, 0, 5, 0, 0, time.UTC),
+		Interval:    types.IntervalMinute,
+		Points: []types.Point{{
+			TS:           time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			Volume:       23,
+			ReshareRatio: 0.3, // 6.9 reshares
+			AcctAgeMix:   map[string]types.Probability{"0-7d": 0.1, "1-6m": 0.3, "24m+": 0.6},
+			CoordinationSignals: types.CoordinationSignals{
+				BurstScore: 0.4, DuplicationClusters: 3,
+			},
+		}},
+	}
+	n, _ := privacy.RoundVolumes(&s, 5)
+	p := s.Points[0]
+	fmt.Println(n, p.Volume, p.ReshareRatio, p.CoordinationSignals.DuplicationClusters, p.CoordinationSignals.BurstScore)
+	fmt.Println(p.AcctAgeMix["0-7d"], p.AcctAgeMix["1-6m"], p.AcctAgeMix["24m+"])
+	fmt.Println(validate.ValidateSeries(&s))
+	// Output:
+	// 1 25 0.2 5 0.4
+	// 0.2 0.2 0.6
+	// <nil>
+}
+
+func ExampleTokenKeys() {
+	// Two publishers that have agreed to cross-platform duplication
+	// analysis tokenize under a shared key; a third keeps its own.
+	shared := []byte("0123456789abcdef")
+	var keys privacy.TokenKeys
+	keys.Set("alpha", shared)
+	keys.Set("beta", shared)
+	keys.Set("gamma", []byte("fedcba9876543210"))
+
+	tokenize := func(publisher string, hash types.HexHash8) types.HexHash8 {
+		h, _ := keys.For(publisher)
+		t := types.ProvenanceTag{DedupHash: hash}
+		h.TokenizeTag(&t)
+		return t.DedupHash
+	}
+	a, b, c := tokenize("alpha", "0a1b2c3d"), tokenize("beta", "0a1b2c3d"), tokenize("gamma", "0a1b2c3d")
+	fmt.Println(len(a), a == b, a == c)
+	// Output:
+	// 8 true false
+}
@@ -0,0 +1,155 @@
+package privacy
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+	"math/big"
+)
+
+// ff1 is the FF1 format-preserving cipher of NIST SP 800-38G over AES, for
+// numeral strings in radix 2–36 written with the digits 0-9a-z.
+type ff1 struct {
+	block cipher.Block
+	radix int
+	tweak []byte
+}
+
+var errFF1Input = errors.New("privacy: invalid FF1 input")
+
+func newFF1(key []byte, radix int, tweak []byte) (*ff1, error) {
+	b, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return &ff1{block: b, radix: radix, tweak: append([]byte(nil), tweak...)}, nil
+}
+
+func (f *ff1) encrypt(x string) (string, error) { return f.crypt(x, true) }
+func (f *ff1) decrypt(x string) (string, error) { return f.crypt(x, false) }
+
+func (f *ff1) crypt(x string, encrypt bool) (string, error) {
+	n := len(x)
+	digits := make([]int, n)
+	for i := 0; i < n; i++ {
+		d := digitValue(x[i])
+		if d < 0 || d >= f.radix {
+			return "", errFF1Input
+		}
+		digits[i] = d
+	}
+	// The standard requires radix^n ≥ 1,000,000.
+	if n < 2 || new(big.Int).Exp(big.NewInt(int64(f.radix)), big.NewInt(int64(n)), nil).Cmp(big.NewInt(1_000_000)) < 0 {
+		return "", errFF1Input
+	}
+	u, v := n/2, n-n/2
+	a, b := digits[:u], digits[u:]
+
+	// b bytes hold a v-digit numeral; d bytes of PRF output feed each round.
+	bl := (bitLen(f.radix, v) + 7) / 8
+	dl := 4*((bl+3)/4) + 4
+	t := len(f.tweak)
+	p := []byte{1, 2, 1, byte(f.radix >> 16), byte(f.radix >> 8), byte(f.radix), 10, byte(u),
+		byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n), byte(t >> 24), byte(t >> 16), byte(t >> 8), byte(t)}
+	pad := ((-t-bl-1)%16 + 16) % 16
+	q := make([]byte, t+pad+1+bl)
+	copy(q, f.tweak)
+
+	radix := big.NewInt(int64(f.radix))
+	modU := new(big.Int).Exp(radix, big.NewInt(int64(u)), nil)
+	modV := new(big.Int).Exp(radix, big.NewInt(int64(v)), nil)
+	y, c := new(big.Int), new(big.Int)
+	for r := 0; r < 10; r++ {
+		i := r
+		if !encrypt {
+			i = 9 - r
+		}
+		src := b // the half fed to the round function
+		if !encrypt {
+			src = a
+		}
+		q[t+pad] = byte(i)
+		num(src, radix).FillBytes(q[t+pad+1:])
+		y.SetBytes(f.prf(p, q, dl))
+		m, mod := u, modU
+		if i%2 == 1 {
+			m, mod = v, modV
+		}
+		if encrypt {
+			c.Add(num(a, radix), y)
+		} else {
+			c.Sub(num(b, radix), y)
+		}
+		c.Mod(c, mod)
+		out := str(c, f.radix, m)
+		if encrypt {
+			a, b = b, out
+		} else {
+			a, b = out, a
+		}
+	}
+	res := make([]byte, 0, n)
+	for _, d := range append(append([]int(nil), a...), b...) {
+		res = append(res, "0123456789abcdefghijklmnopqrstuvwxyz"[d])
+	}
+	return string(res), nil
+}
+
+// prf returns d bytes of S: the CBC-MAC R of p||q followed by encryptions
+// of R XOR j for j = 1, 2, ...
+func (f *ff1) prf(p, q []byte, d int) []byte {
+	r := make([]byte, aes.BlockSize)
+	for _, msg := range [][]byte{p, q} {
+		for off := 0; off < len(msg); off += aes.BlockSize {
+			for k := 0; k < aes.BlockSize; k++ {
+				r[k] ^= msg[off+k]
+			}
+			f.block.Encrypt(r, r)
+		}
+	}
+	s := append([]byte(nil), r...)
+	for j := 1; len(s) < d; j++ {
+		blk := append([]byte(nil), r...)
+		for k := 0; k < 8; k++ {
+			blk[aes.BlockSize-1-k] ^= byte(j >> (8 * k))
+		}
+		f.block.Encrypt(blk, blk)
+		s = append(s, blk...)
+	}
+	return s[:d]
+}
+
+// bitLen returns ceil(v · log2(radix)), computed exactly.
+func bitLen(radix, v int) int {
+	max := new(big.Int).Exp(big.NewInt(int64(radix)), big.NewInt(int64(v)), nil)
+	return max.Sub(max, big.NewInt(1)).BitLen()
+}
+
+func num(digits []int, radix *big.Int) *big.Int {
+	x := new(big.Int)
+	for _, d := range digits {
+		x.Mul(x, radix).Add(x, big.NewInt(int64(d)))
+	}
+	return x
+}
+
+func str(x *big.Int, radix, m int) []int {
+	out := make([]int, m)
+	x = new(big.Int).Set(x)
+	r, d := big.NewInt(int64(radix)), new(big.Int)
+	for i := m - 1; i >= 0; i-- {
+		x.DivMod(x, r, d)
+		out[i] = int(d.Int64())
+	}
+	return out
+}
+
+func digitValue(c byte) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return int(c - '0')
+	case c >= 'a' && c <= 'z':
+		return int(c-'a') + 10
+	}
+	return -1
+}
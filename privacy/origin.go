@@ -0,0 +1,78 @@
+package privacy
+
+import (
+	"strings"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// RiskScorer estimates how much a tag's fields, taken together, narrow down
+// the author. Scores are unitless and compared against a policy maximum;
+// larger means riskier.
+type RiskScorer interface {
+	Score(t *types.ProvenanceTag) float64
+}
+
+// RiskScorerFunc adapts a function to RiskScorer.
+type RiskScorerFunc func(t *types.ProvenanceTag) float64
+
+// Score calls f(t).
+func (f RiskScorerFunc) Score(t *types.ProvenanceTag) float64 { return f(t) }
+
+// WeightedScorer sums a weight for each field value present in a tag. Values
+// without a weight contribute zero.
+type WeightedScorer struct {
+	AcctAge    map[types.AcctAge]float64
+	AcctType   map[types.AcctType]float64
+	Automation map[types.AutomationFlag]float64
+	Client     map[types.ClientFamily]float64
+	Media      map[types.MediaProvenance]float64
+}
+
+// Score implements RiskScorer.
+func (w WeightedScorer) Score(t *types.ProvenanceTag) float64 {
+	return w.AcctAge[t.AcctAgeBucket] + w.AcctType[t.AcctType] +
+		w.Automation[t.AutomationFlag] + w.Client[t.ClientFamily] + w.Media[t.MediaProvenance]
+}
+
+// DefaultMaxSubdivisionRisk is the score above which DefaultRiskScorer
+// treats a subdivision-level origin hint as re-identifying: for example a
+// new account posting from a third-party client.
+const DefaultMaxSubdivisionRisk = 0.5
+
+// DefaultRiskScorer weights the attributes that are rare among posters:
+// young accounts, third-party clients, automation, and embedded C2PA
+// manifests (which may carry creator identity).
+func DefaultRiskScorer() WeightedScorer {
+	return WeightedScorer{
+		AcctAge: map[types.AcctAge]float64{
+			types.AcctAge_0_7d: 0.4, types.AcctAge_8_30d: 0.25, types.AcctAge_1_6m: 0.1,
+		},
+		AcctType: map[types.AcctType]float64{types.AcctTypeUnverified: 0.1},
+		Automation: map[types.AutomationFlag]float64{
+			types.AutomationScheduled: 0.1, types.AutomationAPICLIENT: 0.1, types.AutomationDeclaredBot: 0.1,
+		},
+		Client: map[types.ClientFamily]float64{types.ClientThirdParty: 0.3},
+		Media:  map[types.MediaProvenance]float64{types.MediaProvC2PA: 0.2},
+	}
+}
+
+// HasSubdivision reports whether an ISO 3166 origin hint names a
+// subdivision ("US-CA") rather than only a country ("US").
+func HasSubdivision(originHint string) bool {
+	return strings.Contains(originHint, "-")
+}
+
+// CoarsenOriginHint reduces t.OriginHint to its country code when it names
+// a subdivision and s scores t above max. A nil s uses DefaultRiskScorer.
+// It reports whether t changed.
+func CoarsenOriginHint(t *types.ProvenanceTag, s RiskScorer, max float64) bool {
+	if s == nil {
+		s = DefaultRiskScorer()
+	}
+	if !HasSubdivision(t.OriginHint) || s.Score(t) <= max {
+		return false
+	}
+	t.OriginHint, _, _ = strings.Cut(t.OriginHint, "-")
+	return true
+}
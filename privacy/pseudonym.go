@@ -0,0 +1,45 @@
+package privacy
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// PseudonymPrefix marks IDs produced by Pseudonymize.
+const PseudonymPrefix = "pub_"
+
+// MinPseudonymKeyLen is the shortest key Pseudonymize accepts.
+const MinPseudonymKeyLen = 16
+
+// ErrPseudonymKey is returned for a pseudonym key shorter than
+// MinPseudonymKeyLen. With an empty or short key anyone could recompute the
+// mapping and the pseudonyms would protect nothing.
+var ErrPseudonymKey = errors.New("privacy: pseudonym key shorter than 16 bytes")
+
+// Pseudonymize returns a deterministic pseudonym for id under key using
+// HMAC-SHA256. The same (id, key) always yields the same pseudonym; without
+// key the mapping cannot be reversed or recomputed. The result is
+// PseudonymPrefix followed by 32 lowercase hex characters. It returns
+// ErrPseudonymKey if key is shorter than MinPseudonymKeyLen.
+func Pseudonymize(id string, key []byte) (string, error) {
+	if len(key) < MinPseudonymKeyLen {
+		return "", ErrPseudonymKey
+	}
+	m := hmac.New(sha256.New, key)
+	m.Write([]byte(id))
+	return PseudonymPrefix + hex.EncodeToString(m.Sum(nil)[:16]), nil
+}
+
+// PublisherRef returns a pseudonymous reference for the publisher id. It
+// returns ErrPseudonymKey if key is shorter than MinPseudonymKeyLen.
+func PublisherRef(id string, key []byte) (types.PublisherRef, error) {
+	p, err := Pseudonymize(id, key)
+	if err != nil {
+		return types.PublisherRef{}, err
+	}
+	return types.PublisherRef{ID: p, Pseudonymous: true}, nil
+}
@@ -0,0 +1,38 @@
+package privacy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestPseudonymize(t *testing.T) {
+	key := []byte("0123456789abcdef")
+	// HMAC-SHA256("0123456789abcdef", "platform-42"), first 16 bytes.
+	want := "pub_d5b9c82585477d698f0ca8dae7b5ab3c"
+	if got, err := Pseudonymize("platform-42", key); err != nil || got != want {
+		t.Errorf("Pseudonymize = %q, %v; want %q", got, err, want)
+	}
+	if other, _ := Pseudonymize("platform-43", key); other == want {
+		t.Error("distinct IDs share a pseudonym")
+	}
+	if other, _ := Pseudonymize("platform-42", []byte("fedcba9876543210")); other == want {
+		t.Error("distinct keys share a pseudonym")
+	}
+	for _, k := range [][]byte{nil, {}, key[:15]} {
+		if _, err := Pseudonymize("platform-42", k); !errors.Is(err, ErrPseudonymKey) {
+			t.Errorf("key of %d bytes: %v, want ErrPseudonymKey", len(k), err)
+		}
+	}
+}
+
+func TestPublisherRef(t *testing.T) {
+	ref, err := PublisherRef("platform-42", []byte("0123456789abcdef"))
+	if err != nil || ref != (types.PublisherRef{ID: "pub_d5b9c82585477d698f0ca8dae7b5ab3c", Pseudonymous: true}) {
+		t.Errorf("PublisherRef = %+v, %v", ref, err)
+	}
+	if _, err := PublisherRef("platform-42", nil); !errors.Is(err, ErrPseudonymKey) {
+		t.Errorf("nil key: %v", err)
+	}
+}
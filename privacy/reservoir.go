@@ -0,0 +1,182 @@
+package privacy
+
+import (
+	"container/heap"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/analyze"
+	"github.com/civic-interconnect/civic-transparency-go-types/classify"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// ReservoirOptions tunes a Reservoir. The zero value samples uniformly
+// from one-minute buckets.
+type ReservoirOptions struct {
+	// Interval is the bucket width; tags are bucketed by their UTC
+	// timestamp truncated to it. Zero means one minute.
+	Interval time.Duration
+	// Weight, if set, makes tags with a higher weight more likely to be
+	// kept, e.g. to favour tags from large coordination clusters. Tags
+	// with a weight of zero or less are counted but never kept. Nil gives
+	// every tag weight 1.
+	Weight func(analyze.TimedTag) float64
+	// Rand is the source of randomness; nil uses the math/rand global
+	// source. Set it for reproducible samples in tests.
+	Rand *rand.Rand
+	// KeepRestricted keeps the fields classify marks Restricted, such as
+	// the dedup hash and origin hint, in exemplar tags. By default they
+	// are zeroed with classify.Filter before a tag is retained, so
+	// exemplars can leave the collecting service. Set it only for audits
+	// that run inside that service.
+	KeepRestricted bool
+}
+
+// Exemplar is a retained tag and the number of tags it stands for.
+type Exemplar struct {
+	TS  time.Time           `json:"ts"`
+	Tag types.ProvenanceTag `json:"tag"`
+	// Weight is the tag's Horvitz–Thompson weight: summing Weight over a
+	// bucket's exemplars (or those matching a filter) gives an unbiased
+	// estimate of the total weight of all its tags (or of the matching
+	// ones). With unit weights that estimate is a tag count.
+	Weight float64 `json:"weight"`
+}
+
+// ReservoirBucket is the sample kept for one bucket.
+type ReservoirBucket struct {
+	TS        time.Time  `json:"ts"`        // bucket start, UTC
+	Seen      int        `json:"seen"`      // tags added to the bucket
+	Exemplars []Exemplar `json:"exemplars"` // at most k, in the order they were added
+}
+
+// Reservoir keeps at most k exemplar tags per bucket from a stream of
+// any length, for audits that need a few real tags behind each published
+// point without storing, or exposing, every tag. It uses priority
+// sampling (Duffield, Lund, and Thorup): each tag gets priority w/u for
+// its weight w and u uniform on (0, 1], the k highest priorities are kept,
+// and each exemplar's weight is max(w, τ) where τ is the highest priority
+// not kept. The weights make sampled counts of any tag subset unbiased,
+// with the lowest variance of any k-sample scheme in practice. While a
+// bucket has at most k tags, all are kept with their own weights.
+//
+// A Reservoir holds k+1 tags per bucket and is not safe for concurrent
+// use.
+type Reservoir struct {
+	k       int
+	opts    ReservoirOptions
+	buckets map[time.Time]*reservoirBucket
+}
+
+type reservoirBucket struct {
+	seen int
+	seq  int
+	heap priorityHeap // lowest priority first, at most k+1 entries
+}
+
+type prioritized struct {
+	Exemplar
+	priority float64
+	seq      int // arrival order, for output
+}
+
+// NewReservoir returns a Reservoir keeping k exemplars per bucket. With k
+// of 0 or less it only counts tags.
+func NewReservoir(k int, opts ReservoirOptions) *Reservoir {
+	if opts.Interval <= 0 {
+		opts.Interval = time.Minute
+	}
+	return &Reservoir{k: max(k, 0), opts: opts, buckets: map[time.Time]*reservoirBucket{}}
+}
+
+// Add offers one tag to its bucket's sample.
+func (r *Reservoir) Add(t analyze.TimedTag) {
+	ts := t.TS.UTC().Truncate(r.opts.Interval)
+	b := r.buckets[ts]
+	if b == nil {
+		b = &reservoirBucket{}
+		r.buckets[ts] = b
+	}
+	b.seen++
+	w := 1.0
+	if r.opts.Weight != nil {
+		w = r.opts.Weight(t)
+	}
+	if w <= 0 || r.k == 0 {
+		return
+	}
+	p := w / r.uniform()
+	if len(b.heap) == r.k+1 {
+		if p <= b.heap[0].priority {
+			return
+		}
+		heap.Pop(&b.heap)
+	}
+	tag := t.Tag
+	if !r.opts.KeepRestricted {
+		// Filter cannot fail for a pointer to a ProvenanceTag.
+		_ = classify.Filter(&tag, classify.AggregateOnly)
+	}
+	b.seq++
+	heap.Push(&b.heap, prioritized{Exemplar{t.TS, tag, w}, p, b.seq})
+}
+
+// uniform returns a value in (0, 1].
+func (r *Reservoir) uniform() float64 {
+	if r.opts.Rand != nil {
+		return 1 - r.opts.Rand.Float64()
+	}
+	return 1 - rand.Float64()
+}
+
+// Buckets returns the samples, ordered by bucket start. The Reservoir
+// can keep accepting tags afterwards.
+func (r *Reservoir) Buckets() []ReservoirBucket {
+	out := make([]ReservoirBucket, 0, len(r.buckets))
+	for ts, b := range r.buckets {
+		kept := append([]prioritized(nil), b.heap...)
+		tau := 0.0
+		if len(kept) > r.k {
+			sort.Slice(kept, func(i, j int) bool { return kept[i].priority < kept[j].priority })
+			tau, kept = kept[0].priority, kept[1:]
+		}
+		sort.Slice(kept, func(i, j int) bool { return kept[i].seq < kept[j].seq })
+		rb := ReservoirBucket{TS: ts, Seen: b.seen, Exemplars: make([]Exemplar, len(kept))}
+		for i, e := range kept {
+			e.Weight = max(e.Weight, tau)
+			rb.Exemplars[i] = e.Exemplar
+		}
+		out = append(out, rb)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].TS.Before(out[j].TS) })
+	return out
+}
+
+// ReservoirPerBucket samples at most k exemplars per minute from stream
+// with unit weights; see Reservoir.
+func ReservoirPerBucket(stream []analyze.TimedTag, k int) []ReservoirBucket {
+	return ReservoirPerBucketWith(stream, k, ReservoirOptions{})
+}
+
+// ReservoirPerBucketWith is ReservoirPerBucket with options.
+func ReservoirPerBucketWith(stream []analyze.TimedTag, k int, opts ReservoirOptions) []ReservoirBucket {
+	r := NewReservoir(k, opts)
+	for _, t := range stream {
+		r.Add(t)
+	}
+	return r.Buckets()
+}
+
+type priorityHeap []prioritized
+
+func (h priorityHeap) Len() int           { return len(h) }
+func (h priorityHeap) Less(i, j int) bool { return h[i].priority < h[j].priority }
+func (h priorityHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *priorityHeap) Push(x any)        { *h = append(*h, x.(prioritized)) }
+func (h *priorityHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
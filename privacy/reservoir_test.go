@@ -0,0 +1,104 @@
+package privacy
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/analyze"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestReservoirPerBucket(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	var stream []analyze.TimedTag
+	for i := 0; i < 100; i++ {
+		stream = append(stream, analyze.TimedTag{TS: t0.Add(time.Duration(i) * time.Second)})
+	}
+	got := ReservoirPerBucketWith(stream, 5, ReservoirOptions{Rand: rand.New(rand.NewSource(1))})
+	if len(got) != 2 {
+		t.Fatalf("got %d buckets, want 2", len(got))
+	}
+	for i, want := range []int{60, 40} {
+		b := got[i]
+		if !b.TS.Equal(t0.Add(time.Duration(i)*time.Minute)) || b.Seen != want || len(b.Exemplars) != 5 {
+			t.Errorf("bucket %d = %v seen %d with %d exemplars", i, b.TS, b.Seen, len(b.Exemplars))
+		}
+		for j, e := range b.Exemplars {
+			if e.TS.Truncate(time.Minute) != b.TS || (j > 0 && !e.TS.After(b.Exemplars[j-1].TS)) {
+				t.Errorf("bucket %d exemplar %d at %v out of place", i, j, e.TS)
+			}
+		}
+	}
+
+	// A bucket with at most k tags keeps them all at their own weight.
+	small := ReservoirPerBucket(stream[:3], 5)
+	if len(small[0].Exemplars) != 3 || small[0].Exemplars[0].Weight != 1 {
+		t.Errorf("small bucket = %+v", small[0])
+	}
+}
+
+func TestReservoirUnbiased(t *testing.T) {
+	// Estimate the number of bot tags, and the total weight, from many
+	// independent samples.
+	t0 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	var stream []analyze.TimedTag
+	bots, total := 0.0, 0.0
+	for i := 0; i < 200; i++ {
+		tag := types.ProvenanceTag{AutomationFlag: types.AutomationManual}
+		if i%10 == 0 {
+			tag.AutomationFlag = types.AutomationDeclaredBot
+		}
+		stream = append(stream, analyze.TimedTag{TS: t0, Tag: tag})
+	}
+	weight := func(t analyze.TimedTag) float64 {
+		if t.Tag.AutomationFlag == types.AutomationDeclaredBot {
+			return 5
+		}
+		return 1
+	}
+	for _, tt := range stream {
+		if tt.Tag.AutomationFlag == types.AutomationDeclaredBot {
+			bots += 5
+		}
+		total += weight(tt)
+	}
+	opts := ReservoirOptions{Weight: weight, Rand: rand.New(rand.NewSource(7))}
+	const runs = 2000
+	var estBots, estTotal float64
+	for r := 0; r < runs; r++ {
+		for _, e := range ReservoirPerBucketWith(stream, 10, opts)[0].Exemplars {
+			estTotal += e.Weight
+			if e.Tag.AutomationFlag == types.AutomationDeclaredBot {
+				estBots += e.Weight
+			}
+		}
+	}
+	estBots, estTotal = estBots/runs, estTotal/runs
+	if math.Abs(estBots-bots)/bots > 0.05 || math.Abs(estTotal-total)/total > 0.05 {
+		t.Errorf("estimates bots %.1f total %.1f, want %.0f and %.0f", estBots, estTotal, bots, total)
+	}
+}
+
+func TestReservoirZeroWeight(t *testing.T) {
+	r := NewReservoir(3, ReservoirOptions{Weight: func(analyze.TimedTag) float64 { return 0 }})
+	r.Add(analyze.TimedTag{TS: time.Unix(0, 0)})
+	b := r.Buckets()
+	if len(b) != 1 || b[0].Seen != 1 || len(b[0].Exemplars) != 0 {
+		t.Errorf("buckets = %+v", b)
+	}
+}
+
+func TestReservoirStripsRestricted(t *testing.T) {
+	tag := types.ProvenanceTag{AcctType: types.AcctTypePerson, DedupHash: "0123abcd", OriginHint: "US-CA"}
+	in := analyze.TimedTag{TS: time.Unix(0, 0), Tag: tag}
+	got := ReservoirPerBucket([]analyze.TimedTag{in}, 1)[0].Exemplars[0].Tag
+	if got.DedupHash != "" || got.OriginHint != "" || got.AcctType != types.AcctTypePerson {
+		t.Errorf("default exemplar tag = %+v, want restricted fields zeroed", got)
+	}
+	got = ReservoirPerBucketWith([]analyze.TimedTag{in}, 1, ReservoirOptions{KeepRestricted: true})[0].Exemplars[0].Tag
+	if got.DedupHash != tag.DedupHash || got.OriginHint != tag.OriginHint {
+		t.Errorf("KeepRestricted exemplar tag = %+v", got)
+	}
+}
@@ -0,0 +1,147 @@
+package privacy
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// RoundVolumes applies base-k rounding, a statistical disclosure control
+// some regulators require before public release: every count in s is
+// rounded to the nearest multiple of base (halves round up), and every
+// count-derived ratio is recomputed from the rounded counts so the
+// published point stays internally consistent.
+//
+// For each point with original volume V and rounded volume V':
+//
+//   - reshare_ratio and recycled_content_rate r become round_k(r·V)/V',
+//     capped at 1;
+//   - duplication_clusters is rounded and capped at V';
+//   - each breakdown (acct_age_mix, automation_mix, client_mix,
+//     acct_type_shares, post_kind_mix) is re-apportioned as V'/base units by largest
+//     remainder, so shares are multiples of base/V' and still sum to 1;
+//   - when post_kind_mix is present, reshare_ratio is set to its rounded
+//     reshare share so the two stay consistent;
+//   - burst_score and synchrony_index are not counts and are kept.
+//
+// When V' is 0 the ratios become 0 and the breakdowns are cleared.
+//
+// Bias: if remainders are spread evenly, rounding each count is unbiased
+// with error uniform on ±base/2 (variance base²/12), but errors do not
+// cancel in a sum — a total of n rounded points has error up to n·base/2
+// and is usually not itself a multiple of base. Counts below base/2 always
+// become 0, so sparse topics and small breakdown categories are
+// systematically undercounted; pair rounding with a minimum-volume
+// threshold rather than relying on it alone. Recomputed ratios have
+// resolution base/V', so they are coarse for small volumes, and a
+// category near zero can be rounded out of a breakdown entirely.
+//
+// RoundVolumes returns the number of points whose volume changed. It
+// returns an error if base is less than 1; base 1 leaves s unchanged.
+func RoundVolumes(s *types.Series, base int) (int, error) {
+	if base < 1 {
+		return 0, fmt.Errorf("privacy: rounding base must be >= 1, got %d", base)
+	}
+	if s == nil || base == 1 {
+		return 0, nil
+	}
+	changed := 0
+	for i := range s.Points {
+		p := &s.Points[i]
+		v := p.Volume
+		nv := roundK(float64(v), base)
+		if nv != v {
+			changed++
+		}
+		p.Volume = nv
+		p.ReshareRatio = roundRatio(p.ReshareRatio, v, nv, base)
+		p.RecycledContentRate = roundRatio(p.RecycledContentRate, v, nv, base)
+		if d := roundK(float64(p.CoordinationSignals.DuplicationClusters), base); d > nv {
+			p.CoordinationSignals.DuplicationClusters = nv
+		} else {
+			p.CoordinationSignals.DuplicationClusters = d
+		}
+		p.AcctAgeMix = roundShares(p.AcctAgeMix, nv, base)
+		p.AutomationMix = roundShares(p.AutomationMix, nv, base)
+		p.ClientMix = roundShares(p.ClientMix, nv, base)
+		p.AcctTypeShares = roundShares(p.AcctTypeShares, nv, base)
+		p.PostKindMix = roundShares(p.PostKindMix, nv, base)
+		if len(p.PostKindMix) > 0 {
+			// Keep reshare_ratio equal to the rounded reshare share.
+			p.ReshareRatio = p.PostKindMix[types.PostKindReshare]
+		}
+	}
+	return changed, nil
+}
+
+// roundK rounds x to the nearest multiple of base, halves up.
+func roundK(x float64, base int) int {
+	return int(math.Floor(x/float64(base)+0.5)) * base
+}
+
+func roundRatio(r types.Probability, v, nv, base int) types.Probability {
+	if nv == 0 {
+		return 0
+	}
+	c := roundK(float64(r)*float64(v), base)
+	if c >= nv {
+		return 1
+	}
+	return types.Probability(float64(c) / float64(nv))
+}
+
+// roundShares re-apportions shares into nv/base units by the
+// largest-remainder method. Ties go to the lexically smaller key.
+func roundShares[K ~string](m map[K]types.Probability, nv, base int) map[K]types.Probability {
+	if len(m) == 0 {
+		return m
+	}
+	if nv == 0 {
+		return nil
+	}
+	type part struct {
+		k     K
+		units int
+		rem   float64
+	}
+	// Sum in key order: a map-order sum can differ in the last bit, which
+	// would make ties between remainders break differently between runs.
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	var total float64
+	for _, k := range keys {
+		total += float64(m[k])
+	}
+	if total <= 0 {
+		return m
+	}
+	parts := make([]part, 0, len(m))
+	left := nv / base
+	for _, k := range keys {
+		exact := float64(m[k]) / total * float64(nv) / float64(base)
+		u := int(math.Floor(exact))
+		parts = append(parts, part{k, u, exact - float64(u)})
+		left -= u
+	}
+	sort.Slice(parts, func(i, j int) bool {
+		if parts[i].rem != parts[j].rem {
+			return parts[i].rem > parts[j].rem
+		}
+		return parts[i].k < parts[j].k
+	})
+	for i := 0; left > 0; i, left = (i+1)%len(parts), left-1 {
+		parts[i].units++
+	}
+	out := make(map[K]types.Probability, len(parts))
+	for _, p := range parts {
+		if p.units > 0 {
+			out[p.k] = types.Probability(float64(p.units*base) / float64(nv))
+		}
+	}
+	return out
+}
@@ -0,0 +1,173 @@
+package privacy
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// ErrTokenInput is returned for a dedup_hash (or token) that does not have
+// the shape its algorithm requires, or an undefined algorithm.
+var ErrTokenInput = errors.New("privacy: malformed dedup_hash")
+
+// HashTokenizer deterministically encrypts dedup_hash values with FF1 (NIST
+// SP 800-38G, AES) over the hex alphabet. A token has the length and
+// character set of the hash it replaces, so tokenized tags are still valid
+// ProvenanceTags, and equal hashes under one key give equal tokens, so
+// duplication analysis keeps working on tokenized data.
+//
+// Whether data from different publishers can be joined is then a matter of
+// keys, not of data shape: publishers that agree (by contract) to enable
+// cross-platform analysis tokenize under a shared key, and everyone else
+// uses their own. Retokenize moves tokens from one key to another when an
+// agreement starts, ends, or a key is rotated. The algorithm name is the
+// FF1 tweak, so equal hex strings from different algorithms never share a
+// token. A HashTokenizer is safe for concurrent use.
+type HashTokenizer struct {
+	ciphers map[types.DedupHashAlg]*ff1
+}
+
+// NewHashTokenizer returns a tokenizer for an AES key of 16, 24, or 32
+// bytes.
+func NewHashTokenizer(key []byte) (*HashTokenizer, error) {
+	h := &HashTokenizer{ciphers: map[types.DedupHashAlg]*ff1{}}
+	for _, alg := range types.DedupHashAlgValues() {
+		f, err := newFF1(key, 16, []byte(alg))
+		if err != nil {
+			return nil, fmt.Errorf("privacy: tokenizer key: %w", err)
+		}
+		h.ciphers[alg] = f
+	}
+	return h, nil
+}
+
+// Tokenize encrypts hash, a dedup_hash of algorithm alg (empty means
+// sha256-trunc8).
+func (h *HashTokenizer) Tokenize(hash types.HexHash8, alg types.DedupHashAlg) (types.HexHash8, error) {
+	return h.crypt(hash, alg, true)
+}
+
+// Detokenize reverses Tokenize.
+func (h *HashTokenizer) Detokenize(token types.HexHash8, alg types.DedupHashAlg) (types.HexHash8, error) {
+	return h.crypt(token, alg, false)
+}
+
+func (h *HashTokenizer) crypt(x types.HexHash8, alg types.DedupHashAlg, encrypt bool) (types.HexHash8, error) {
+	if alg == "" {
+		alg = types.DedupSHA256Trunc8
+	}
+	f := h.ciphers[alg]
+	if f == nil || !alg.MatchesShape(x) {
+		return "", ErrTokenInput
+	}
+	out, err := f.crypt(string(x), encrypt)
+	if err != nil {
+		return "", ErrTokenInput
+	}
+	return types.HexHash8(out), nil
+}
+
+// TokenizeTag replaces t.DedupHash with its token. t is unchanged on error.
+func (h *HashTokenizer) TokenizeTag(t *types.ProvenanceTag) error {
+	tok, err := h.Tokenize(t.DedupHash, t.DedupHashAlg)
+	if err != nil {
+		return err
+	}
+	t.DedupHash = tok
+	return nil
+}
+
+// DetokenizeTag reverses TokenizeTag.
+func (h *HashTokenizer) DetokenizeTag(t *types.ProvenanceTag) error {
+	hash, err := h.Detokenize(t.DedupHash, t.DedupHashAlg)
+	if err != nil {
+		return err
+	}
+	t.DedupHash = hash
+	return nil
+}
+
+// Retokenize re-encrypts the token in t from one key to another without
+// exposing the underlying hash to the caller. t is unchanged on error.
+func Retokenize(t *types.ProvenanceTag, from, to *HashTokenizer) error {
+	hash, err := from.Detokenize(t.DedupHash, t.DedupHashAlg)
+	if err != nil {
+		return err
+	}
+	tok, err := to.Tokenize(hash, t.DedupHashAlg)
+	if err != nil {
+		return err
+	}
+	t.DedupHash = tok
+	return nil
+}
+
+// TokenKeys holds one HashTokenizer per publisher. The zero value is ready
+// to use, and it is safe for concurrent use.
+type TokenKeys struct {
+	mu sync.RWMutex
+	m  map[string]*HashTokenizer
+}
+
+// Set installs key for publisher, replacing any previous key. Use Rekey
+// instead when tokens under the previous key must stay readable.
+func (k *TokenKeys) Set(publisher string, key []byte) error {
+	h, err := NewHashTokenizer(key)
+	if err != nil {
+		return err
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.m == nil {
+		k.m = map[string]*HashTokenizer{}
+	}
+	k.m[publisher] = h
+	return nil
+}
+
+// For returns the tokenizer of publisher.
+func (k *TokenKeys) For(publisher string) (*HashTokenizer, bool) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	h, ok := k.m[publisher]
+	return h, ok
+}
+
+// Remove forgets publisher's key. Tokens under it can no longer be
+// detokenized or re-keyed here.
+func (k *TokenKeys) Remove(publisher string) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	delete(k.m, publisher)
+}
+
+// Rekey moves publisher to newKey, re-encrypting tags (tokenized under the
+// publisher's current key) in place. Either every tag is converted and the
+// new key installed, or nothing changes.
+func (k *TokenKeys) Rekey(publisher string, newKey []byte, tags []types.ProvenanceTag) error {
+	to, err := NewHashTokenizer(newKey)
+	if err != nil {
+		return err
+	}
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	from, ok := k.m[publisher]
+	if !ok {
+		return fmt.Errorf("privacy: no token key for publisher %q", publisher)
+	}
+	toks := make([]types.HexHash8, len(tags))
+	for i := range tags {
+		t := tags[i]
+		if err := Retokenize(&t, from, to); err != nil {
+			return fmt.Errorf("privacy: rekey tags[%d]: %w", i, err)
+		}
+		toks[i] = t.DedupHash
+	}
+	for i := range tags {
+		tags[i].DedupHash = toks[i]
+	}
+	k.m[publisher] = to
+	return nil
+}
@@ -0,0 +1,95 @@
+package privacy
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// NIST SP 800-38G FF1 samples (AES-128, AES-192, AES-256 for radix 10 and 36).
+func TestFF1Vectors(t *testing.T) {
+	for _, v := range []struct {
+		key, tweak string
+		radix      int
+		pt, ct     string
+	}{
+		{"2b7e151628aed2a6abf7158809cf4f3c", "", 10, "0123456789", "2433477484"},
+		{"2b7e151628aed2a6abf7158809cf4f3c", "39383736353433323130", 10, "0123456789", "6124200773"},
+		{"2b7e151628aed2a6abf7158809cf4f3c", "3737373770717273373737", 36, "0123456789abcdefghi", "a9tv40mll9kdu509eum"},
+		{"2b7e151628aed2a6abf7158809cf4f3cef4359d8d580aa4f", "", 10, "0123456789", "2830668132"},
+		{"2b7e151628aed2a6abf7158809cf4f3cef4359d8d580aa4f7f036d6f04fc6a94", "", 10, "0123456789", "6657667009"},
+		{"2b7e151628aed2a6abf7158809cf4f3cef4359d8d580aa4f7f036d6f04fc6a94", "3737373770717273373737", 36, "0123456789abcdefghi", "xs8a0azh2avyalyzuwd"},
+	} {
+		key, _ := hex.DecodeString(v.key)
+		tweak, _ := hex.DecodeString(v.tweak)
+		f, err := newFF1(key, v.radix, tweak)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ct, err := f.encrypt(v.pt)
+		if err != nil || ct != v.ct {
+			t.Errorf("encrypt(%s) = %s, %v; want %s", v.pt, ct, err, v.ct)
+		}
+		if pt, err := f.decrypt(v.ct); err != nil || pt != v.pt {
+			t.Errorf("decrypt(%s) = %s, %v; want %s", v.ct, pt, err, v.pt)
+		}
+	}
+}
+
+func TestHashTokenizer(t *testing.T) {
+	a, _ := NewHashTokenizer(bytes.Repeat([]byte{1}, 16))
+	b, _ := NewHashTokenizer(bytes.Repeat([]byte{2}, 32))
+	tok, err := a.Tokenize("0a1b2c3d", "")
+	if err != nil || !types.DedupSHA256Trunc8.MatchesShape(tok) || tok == "0a1b2c3d" {
+		t.Fatalf("Tokenize = %q, %v", tok, err)
+	}
+	if again, _ := a.Tokenize("0a1b2c3d", types.DedupSHA256Trunc8); again != tok {
+		t.Errorf("not deterministic: %q vs %q", again, tok)
+	}
+	if other, _ := b.Tokenize("0a1b2c3d", ""); other == tok {
+		t.Errorf("different keys gave the same token %q", tok)
+	}
+	long, err := a.Tokenize("0a1b2c3d4e5f6071", types.DedupXXHash64)
+	if err != nil || len(long) != 16 {
+		t.Errorf("xxhash64 token = %q, %v", long, err)
+	}
+	if _, err := a.Tokenize("0a1b2c3d", types.DedupXXHash64); !errors.Is(err, ErrTokenInput) {
+		t.Errorf("wrong shape: err = %v", err)
+	}
+
+	tags := []types.ProvenanceTag{{DedupHash: "0a1b2c3d"}, {DedupHash: "ffffffff"}}
+	var keys TokenKeys
+	keys.Set("pub", bytes.Repeat([]byte{1}, 16))
+	h, _ := keys.For("pub")
+	for i := range tags {
+		if err := h.TokenizeTag(&tags[i]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := keys.Rekey("pub", bytes.Repeat([]byte{2}, 32), tags); err != nil {
+		t.Fatal(err)
+	}
+	if tags[0].DedupHash != tok2(b, "0a1b2c3d") {
+		t.Errorf("rekeyed token = %q, want %q", tags[0].DedupHash, tok2(b, "0a1b2c3d"))
+	}
+	h, _ = keys.For("pub")
+	if err := h.DetokenizeTag(&tags[1]); err != nil || tags[1].DedupHash != "ffffffff" {
+		t.Errorf("DetokenizeTag = %q, %v", tags[1].DedupHash, err)
+	}
+
+	bad := []types.ProvenanceTag{tags[0], {DedupHash: "xyz"}}
+	if err := keys.Rekey("pub", bytes.Repeat([]byte{3}, 16), bad); err == nil || bad[0].DedupHash != tags[0].DedupHash {
+		t.Errorf("failed Rekey changed tags or returned nil: %v", err)
+	}
+	if cur, _ := keys.For("pub"); cur != h {
+		t.Error("failed Rekey installed the new key")
+	}
+}
+
+func tok2(h *HashTokenizer, hash types.HexHash8) types.HexHash8 {
+	tok, _ := h.Tokenize(hash, "")
+	return tok
+}
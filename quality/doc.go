@@ -0,0 +1,14 @@
+// quality/doc.go
+// Package quality scores how far a published point can be trusted, so
+// dashboards show one consistently computed indicator instead of each
+// inventing its own.
+//
+//	q := quality.Score(p)           // 0–1 for one point
+//	sum := quality.Summarize(series) // mean, minimum, and counts per series
+//
+// A score combines three things: completeness (which breakdowns were
+// published), plausibility (which internal-consistency checks pass), and
+// suppression (a zero-volume point carries no measurements). Assess returns
+// the components; the weights are documented on Assess and do not change
+// between releases without a new Version.
+package quality
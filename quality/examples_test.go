@@ -0,0 +1,24 @@
+package quality_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/quality"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func ExampleScore() {
+	p := types.Point{
+		TS:            time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Volume:        120,
+		ReshareRatio:  0.25,
+		AcctAgeMix:    map[string]types.Probability{"0-7d": 0.1, "1y+": 0.9},
+		AutomationMix: map[string]types.Probability{"manual": 1},
+		ClientMix:     map[string]types.Probability{"web": 0.5, "mobile": 0.5},
+	}
+	a := quality.Assess(p)
+	fmt.Printf("completeness %.1f, plausibility %.1f, score %.1f\n", a.Completeness, a.Plausibility, a.Score)
+	// Output:
+	// completeness 0.6, plausibility 1.0, score 0.8
+}
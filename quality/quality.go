@@ -0,0 +1,181 @@
+package quality
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+	"github.com/civic-interconnect/civic-transparency-go-types/validate"
+)
+
+// Version identifies the scoring rules, for dashboards that store scores.
+const Version = 1
+
+// SuppressedWeight is the score factor for zero-volume points, which are
+// either genuinely quiet intervals or counts withheld below a suppression
+// floor; the two cannot be told apart from the point alone.
+const SuppressedWeight = 0.5
+
+// LowScore is the threshold below which Summarize counts a point as low
+// quality.
+const LowScore = 0.5
+
+// Assessment is the breakdown behind a point's score.
+type Assessment struct {
+	// Completeness is the share of the five breakdowns (acct_age_mix,
+	// automation_mix, client_mix, acct_type_shares, post_kind_mix) that are
+	// present. It is 1 for suppressed points, which have nothing to break
+	// down.
+	Completeness float64
+	// Plausibility is the share of consistency checks that pass.
+	Plausibility float64
+	// Checks and Failed count the plausibility checks applied and failed.
+	Checks, Failed int
+	// Suppressed reports a zero volume.
+	Suppressed bool
+	// Score is the combined quality in [0, 1].
+	Score float64
+}
+
+// Score returns p's quality in [0, 1]; see Assess.
+func Score(p types.Point) float64 { return Assess(p).Score }
+
+// Assess scores p. The plausibility checks are the per-point rules of
+// validate.ValidatePoint under default options, grouped as: ts set and in
+// UTC; volume ≥0; reshare_ratio, recycled_content_rate, and the
+// coordination probabilities within 0–1; duplication_clusters between 0
+// and volume; each present breakdown valid (values within 0–1 summing to 1,
+// and known keys); and, with post_kind_mix present, its reshare share
+// matching reshare_ratio. A check fails if any of its rules does.
+//
+// Score is Plausibility × (0.5 + 0.5 × Completeness), so a point that is
+// consistent but publishes no breakdowns scores 0.5. Suppressed points
+// score Plausibility × SuppressedWeight.
+func Assess(p types.Point) Assessment {
+	failed := map[string]bool{}
+	if p.TS.IsZero() {
+		failed["ts"] = true
+	}
+	var me *validate.MultiError
+	if errors.As(validate.ValidatePoint(p, time.Time{}, validate.Options{}), &me) {
+		for _, err := range me.Errors() {
+			var fe *validate.FieldError
+			if errors.As(err, &fe) {
+				failed[checkOf(fe)] = true
+			}
+		}
+	}
+
+	var a Assessment
+	check := func(name string) {
+		a.Checks++
+		if failed[name] {
+			a.Failed++
+		}
+	}
+	for _, name := range []string{
+		"ts", "volume", "reshare_ratio", "recycled_content_rate",
+		"coordination_signals", "coordination_signals.duplication_clusters",
+	} {
+		check(name)
+	}
+	present := 0
+	for _, b := range []struct {
+		name string
+		n    int
+	}{
+		{"acct_age_mix", len(p.AcctAgeMix)},
+		{"automation_mix", len(p.AutomationMix)},
+		{"client_mix", len(p.ClientMix)},
+		{"acct_type_shares", len(p.AcctTypeShares)},
+		{"post_kind_mix", len(p.PostKindMix)},
+	} {
+		if b.n > 0 {
+			present++
+			check(b.name)
+		}
+	}
+	if len(p.PostKindMix) > 0 {
+		check(reshareCheck)
+	}
+
+	a.Plausibility = float64(a.Checks-a.Failed) / float64(a.Checks)
+	a.Suppressed = p.Volume == 0
+	if a.Suppressed {
+		a.Completeness = 1
+		a.Score = a.Plausibility * SuppressedWeight
+	} else {
+		a.Completeness = float64(present) / 5
+		a.Score = a.Plausibility * (0.5 + 0.5*a.Completeness)
+	}
+	return a
+}
+
+// reshareCheck names the check that post_kind_mix agrees with
+// reshare_ratio, which validate reports on the reshare_ratio field.
+const reshareCheck = "post_kind_mix.reshare"
+
+// checkOf returns the check a validation failure counts against: the
+// top-level field, except that duplication_clusters is checked apart from
+// the coordination probabilities and reshare consistency apart from the
+// reshare_ratio range.
+func checkOf(fe *validate.FieldError) string {
+	switch {
+	case fe.Code == validate.CodeReshareInconsistent:
+		return reshareCheck
+	case fe.Field == "coordination_signals.duplication_clusters":
+		return fe.Field
+	}
+	name, _, _ := strings.Cut(fe.Field, ".")
+	return name
+}
+
+// Summary is the quality of a whole series.
+type Summary struct {
+	Points int
+	// Mean is the unweighted mean score, and Min the lowest.
+	Mean, Min float64
+	// VolumeWeighted is the mean score weighted by volume, which discounts
+	// quiet intervals; it is 0 when no point has positive volume.
+	VolumeWeighted float64
+	// Suppressed counts zero-volume points, Implausible points failing any
+	// check, and Low points scoring below LowScore.
+	Suppressed, Implausible, Low int
+}
+
+// Summarize scores every point of s. A nil or empty series has a zero
+// Summary.
+func Summarize(s *types.Series) Summary {
+	var sum Summary
+	if s == nil || len(s.Points) == 0 {
+		return sum
+	}
+	sum.Min = 1
+	var total, weighted, volume float64
+	for _, p := range s.Points {
+		a := Assess(p)
+		sum.Points++
+		total += a.Score
+		sum.Min = math.Min(sum.Min, a.Score)
+		if p.Volume > 0 {
+			weighted += a.Score * float64(p.Volume)
+			volume += float64(p.Volume)
+		}
+		if a.Suppressed {
+			sum.Suppressed++
+		}
+		if a.Failed > 0 {
+			sum.Implausible++
+		}
+		if a.Score < LowScore {
+			sum.Low++
+		}
+	}
+	sum.Mean = total / float64(sum.Points)
+	if volume > 0 {
+		sum.VolumeWeighted = weighted / volume
+	}
+	return sum
+}
@@ -0,0 +1,76 @@
+package quality
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+var ts = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func full() types.Point {
+	return types.Point{
+		TS: ts, Volume: 10, ReshareRatio: 0.4,
+		AcctAgeMix:     map[string]types.Probability{"0-7d": 1},
+		AutomationMix:  map[string]types.Probability{"manual": 1},
+		ClientMix:      map[string]types.Probability{"web": 1},
+		AcctTypeShares: map[types.AcctType]types.Probability{types.AcctTypePerson: 1},
+		PostKindMix:    map[types.PostKind]types.Probability{types.PostKindReshare: 0.4, types.PostKindOriginal: 0.6},
+	}
+}
+
+func TestAssess(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		edit  func(p *types.Point)
+		score float64
+	}{
+		{"complete", func(*types.Point) {}, 1},
+		{"no breakdowns", func(p *types.Point) {
+			p.AcctAgeMix, p.AutomationMix, p.ClientMix, p.AcctTypeShares, p.PostKindMix = nil, nil, nil, nil, nil
+		}, 0.5},
+		{"suppressed", func(p *types.Point) { *p = types.Point{TS: ts} }, SuppressedWeight},
+		// 12 checks, one failing; all five breakdowns present.
+		{"reshare mismatch", func(p *types.Point) { p.ReshareRatio = 0.9 }, 11.0 / 12},
+		{"bad sum", func(p *types.Point) { p.ClientMix["web"] = 0.5 }, 11.0 / 12},
+		{"unknown acct_type", func(p *types.Point) {
+			p.AcctTypeShares = map[types.AcctType]types.Probability{"robot": 1}
+		}, 11.0 / 12},
+		{"two bad probabilities, one check", func(p *types.Point) {
+			p.CoordinationSignals.BurstScore, p.CoordinationSignals.SynchronyIndex = 2, -1
+		}, 11.0 / 12},
+	} {
+		p := full()
+		tc.edit(&p)
+		if got := Score(p); math.Abs(got-tc.score) > 1e-12 {
+			t.Errorf("%s: Score = %v, want %v (%+v)", tc.name, got, tc.score, Assess(p))
+		}
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	bad := full()
+	bad.TS = ts.Add(time.Minute)
+	bad.Volume = 30
+	bad.ReshareRatio = 2
+	s := &types.Series{Points: []types.Point{full(), {TS: ts.Add(2 * time.Minute)}, bad}}
+	sum := Summarize(s)
+	if sum.Points != 3 || sum.Suppressed != 1 || sum.Implausible != 1 || sum.Low != 0 {
+		t.Errorf("counts = %+v", sum)
+	}
+	badScore := Score(bad)
+	if want := (1 + SuppressedWeight + badScore) / 3; math.Abs(sum.Mean-want) > 1e-12 {
+		t.Errorf("Mean = %v, want %v", sum.Mean, want)
+	}
+	if want := (10 + 30*badScore) / 40; math.Abs(sum.VolumeWeighted-want) > 1e-12 {
+		t.Errorf("VolumeWeighted = %v, want %v", sum.VolumeWeighted, want)
+	}
+	if sum.Min != SuppressedWeight {
+		t.Errorf("Min = %v", sum.Min)
+	}
+	if Summarize(nil) != (Summary{}) {
+		t.Error("nil series has non-zero summary")
+	}
+}
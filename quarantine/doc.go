@@ -0,0 +1,19 @@
+// quarantine/doc.go
+// Package quarantine holds payloads that are valid but implausible (see
+// validate.CheckSeries) for manual review, as types.QuarantineRecord
+// values, and moves them through release or rejection.
+//
+//	s, qr, dl := quarantine.ScreenSeries(body, "pub_0f3a", subID, opts, time.Now())
+//	switch {
+//	case dl != nil:
+//		deadLetters.Put(*dl) // invalid
+//	case qr != nil:
+//		held.Hold(*qr) // awaiting review
+//	default:
+//		publish(s)
+//	}
+//
+// Every transition is passed to the Store's Hook, which AuditHook connects
+// to an audit.Log so review decisions are recorded with the rest of
+// ingestion.
+package quarantine
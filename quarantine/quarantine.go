@@ -0,0 +1,177 @@
+package quarantine
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/audit"
+	"github.com/civic-interconnect/civic-transparency-go-types/dlq"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+	"github.com/civic-interconnect/civic-transparency-go-types/validate"
+)
+
+var (
+	// ErrNotFound is returned for a key with no record.
+	ErrNotFound = errors.New("quarantine: no such record")
+	// ErrDecided is returned when releasing or rejecting a record that is
+	// no longer pending.
+	ErrDecided = errors.New("quarantine: record already decided")
+)
+
+// New returns a pending record for payload, from submission sub (empty if
+// unknown), held for reasons. The payload is copied.
+func New(payload []byte, source string, sub types.SubmissionID, reasons []string, now time.Time) types.QuarantineRecord {
+	return types.QuarantineRecord{
+		Key:           dlq.Key(payload),
+		SubmissionID:  sub,
+		Source:        source,
+		Payload:       append([]byte(nil), payload...),
+		Reasons:       append([]string(nil), reasons...),
+		State:         types.QuarantinePending,
+		QuarantinedAt: now.UTC(),
+	}
+}
+
+// SeriesReasons returns the plausibility findings for s: the warnings of
+// validate.CheckSeries. It returns nil for a plausible series.
+func SeriesReasons(s *types.Series, opts validate.Options) []string {
+	ws, _ := validate.CheckSeries(s, opts)
+	var out []string
+	for _, w := range ws {
+		out = append(out, w.String())
+	}
+	return out
+}
+
+// ScreenSeries decodes and validates payload like dlq.DecodeSeries, then
+// checks plausibility. Exactly one result is non-nil: the series if it is
+// valid and plausible, a pending QuarantineRecord if it is valid but
+// implausible, or a DeadLetter if it is invalid. Both records carry sub.
+func ScreenSeries(payload []byte, source string, sub types.SubmissionID, opts validate.Options, now time.Time) (*types.Series, *types.QuarantineRecord, *types.DeadLetter) {
+	s, dl := dlq.DecodeSeries(payload, source, sub, opts, now)
+	if dl != nil {
+		return nil, nil, dl
+	}
+	if reasons := SeriesReasons(s, opts); len(reasons) > 0 {
+		qr := New(payload, source, sub, reasons, now)
+		return nil, &qr, nil
+	}
+	return s, nil, nil
+}
+
+// Store keeps quarantine records by Key, including decided ones, so the
+// outcome of a review stays visible. It is safe for concurrent use; the
+// zero value is ready to use.
+type Store struct {
+	// Hook, if set, is called with the record after it is held, released,
+	// or rejected, outside the store's lock. Set it before first use.
+	Hook func(types.QuarantineRecord)
+
+	mu sync.Mutex
+	m  map[string]types.QuarantineRecord
+}
+
+// Hold adds a pending record. A payload already in the store keeps its
+// existing record, which is returned with held false; a rejected payload
+// that arrives again therefore stays rejected.
+func (st *Store) Hold(r types.QuarantineRecord) (rec types.QuarantineRecord, held bool) {
+	st.mu.Lock()
+	if st.m == nil {
+		st.m = map[string]types.QuarantineRecord{}
+	}
+	if prev, ok := st.m[r.Key]; ok {
+		st.mu.Unlock()
+		return prev, false
+	}
+	r.State = types.QuarantinePending
+	st.m[r.Key] = r
+	st.mu.Unlock()
+	st.notify(r)
+	return r, true
+}
+
+// Get returns the record for key.
+func (st *Store) Get(key string) (types.QuarantineRecord, bool) {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	r, ok := st.m[key]
+	return r, ok
+}
+
+// List returns the records in state (all records if state is empty),
+// oldest QuarantinedAt first.
+func (st *Store) List(state types.QuarantineState) []types.QuarantineRecord {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	var out []types.QuarantineRecord
+	for _, r := range st.m {
+		if state == "" || r.State == state {
+			out = append(out, r)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if !out[i].QuarantinedAt.Equal(out[j].QuarantinedAt) {
+			return out[i].QuarantinedAt.Before(out[j].QuarantinedAt)
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
+
+// Release marks a pending record released by reviewer. The caller then
+// publishes its payload.
+func (st *Store) Release(key, reviewer, note string, now time.Time) (types.QuarantineRecord, error) {
+	return st.decide(key, types.QuarantineReleased, reviewer, note, now)
+}
+
+// Reject marks a pending record rejected by reviewer.
+func (st *Store) Reject(key, reviewer, note string, now time.Time) (types.QuarantineRecord, error) {
+	return st.decide(key, types.QuarantineRejected, reviewer, note, now)
+}
+
+func (st *Store) decide(key string, to types.QuarantineState, reviewer, note string, now time.Time) (types.QuarantineRecord, error) {
+	st.mu.Lock()
+	r, ok := st.m[key]
+	switch {
+	case !ok:
+		st.mu.Unlock()
+		return types.QuarantineRecord{}, ErrNotFound
+	case r.State != types.QuarantinePending:
+		st.mu.Unlock()
+		return r, fmt.Errorf("%w: %s is %s", ErrDecided, key, r.State)
+	}
+	decided := now.UTC()
+	r.State, r.DecidedAt, r.Reviewer, r.Note = to, &decided, reviewer, note
+	st.m[key] = r
+	st.mu.Unlock()
+	st.notify(r)
+	return r, nil
+}
+
+func (st *Store) notify(r types.QuarantineRecord) {
+	if st.Hook != nil {
+		st.Hook(r)
+	}
+}
+
+// AuditHook returns a Store.Hook that records review decisions in l:
+// released payloads as accepted, rejected ones as rejected with the
+// quarantine reasons as error codes. Holds are not decisions and are not
+// recorded. Append errors are passed to onErr if it is non-nil.
+func AuditHook(l *audit.Log, onErr func(error)) func(types.QuarantineRecord) {
+	return func(r types.QuarantineRecord) {
+		var err error
+		switch r.State {
+		case types.QuarantineReleased:
+			_, err = l.Append(r.Payload, audit.Accepted, nil)
+		case types.QuarantineRejected:
+			_, err = l.Append(r.Payload, audit.Rejected, r.Reasons)
+		}
+		if err != nil && onErr != nil {
+			onErr(err)
+		}
+	}
+}
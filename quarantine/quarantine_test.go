@@ -0,0 +1,74 @@
+package quarantine
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/audit"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+	"github.com/civic-interconnect/civic-transparency-go-types/validate"
+)
+
+func TestScreenAndReview(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	series := func(volumes ...int) []byte {
+		s := types.Series{Topic: "#vote", GeneratedAt: t0.Add(time.Hour), Interval: types.IntervalMinute}
+		for i, v := range volumes {
+			s.Points = append(s.Points, types.Point{TS: t0.Add(time.Duration(i) * time.Minute), Volume: v, ReshareRatio: types.Probability(i%3) / 4})
+		}
+		b, _ := json.Marshal(s)
+		return b
+	}
+	opts := validate.DefaultOptions()
+
+	if s, qr, dl := ScreenSeries(series(3, 4), "p1", "", opts, t0); s == nil || qr != nil || dl != nil {
+		t.Fatalf("plausible series: %v %v %v", s, qr, dl)
+	}
+	const sub types.SubmissionID = "01JGFJJZ000000000000000000"
+	if s, qr, dl := ScreenSeries(series(-1), "p1", sub, opts, t0); s != nil || qr != nil || dl == nil || dl.SubmissionID != sub {
+		t.Fatalf("invalid series: %v %v %+v", s, qr, dl)
+	}
+	implausible := series(0, 0, 0)
+	_, qr, _ := ScreenSeries(implausible, "p1", sub, opts, t0)
+	if qr == nil || qr.State != types.QuarantinePending || len(qr.Reasons) == 0 || qr.SubmissionID != sub {
+		t.Fatalf("implausible series: %+v", qr)
+	}
+
+	var buf bytes.Buffer
+	var hooked []types.QuarantineState
+	log := audit.NewLog(&buf)
+	audited := AuditHook(log, func(err error) { t.Error(err) })
+	st := &Store{Hook: func(r types.QuarantineRecord) {
+		hooked = append(hooked, r.State)
+		audited(r)
+	}}
+	if _, held := st.Hold(*qr); !held {
+		t.Fatal("Hold = false")
+	}
+	if _, held := st.Hold(*qr); held {
+		t.Error("second Hold of the same payload held again")
+	}
+	if got := st.List(types.QuarantinePending); len(got) != 1 {
+		t.Errorf("pending = %d", len(got))
+	}
+	r, err := st.Reject(qr.Key, "reviewer-1", "synthetic zeros", t0.Add(time.Hour))
+	if err != nil || r.State != types.QuarantineRejected || r.Reviewer != "reviewer-1" {
+		t.Fatalf("Reject = %+v, %v", r, err)
+	}
+	if _, err := st.Release(qr.Key, "reviewer-2", "", t0); !errors.Is(err, ErrDecided) {
+		t.Errorf("Release after Reject: %v", err)
+	}
+	if _, err := st.Release("nope", "", "", t0); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Release of unknown key: %v", err)
+	}
+	if len(hooked) != 2 || hooked[0] != types.QuarantinePending || hooked[1] != types.QuarantineRejected {
+		t.Errorf("hook saw %v", hooked)
+	}
+	found, err := audit.Find(&buf, implausible)
+	if err != nil || len(found) != 1 || found[0].Decision != audit.Rejected {
+		t.Errorf("audit entries = %+v, %v", found, err)
+	}
+}
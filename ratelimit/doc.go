@@ -0,0 +1,4 @@
+// ratelimit/doc.go
+// Package ratelimit enforces types.PublisherQuota with per-publisher token
+// buckets, so every ingestion endpoint applies the same quota semantics.
+package ratelimit
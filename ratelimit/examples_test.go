@@ -0,0 +1,31 @@
+package ratelimit_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/ratelimit"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func ExampleLimiter() {
+	l := ratelimit.New(ratelimit.StaticQuotas(
+		types.PublisherQuota{PublisherID: "pub_a", RatePerSecond: 0.5, Burst: 2},
+	), nil)
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, at := range []time.Duration{0, 0, 0, 2 * time.Second} {
+		d, _ := l.Allow("pub_a", t0.Add(at))
+		fmt.Println(d.Allowed, d.Remaining, d.RetryAfter)
+	}
+	d, _ := l.Allow("unknown", t0)
+	fmt.Println(d.Allowed)
+	_, err := l.AllowN("pub_a", 0, t0)
+	fmt.Println(err)
+	// Output:
+	// true 1 0s
+	// true 0 0s
+	// false 0 2s
+	// true 0 0s
+	// false
+	// ratelimit: token count must be positive
+}
@@ -0,0 +1,135 @@
+package ratelimit
+
+import (
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// ErrCount is returned by AllowN for a non-positive token count.
+var ErrCount = errors.New("ratelimit: token count must be positive")
+
+// State is the persisted bucket of one publisher.
+type State struct {
+	Tokens  float64   `json:"tokens"`
+	Updated time.Time `json:"updated"`
+}
+
+// Store persists bucket state between processes or replicas. Load reports
+// ok=false for publishers it has never seen. Implementations must be safe
+// for concurrent use.
+type Store interface {
+	Load(publisherID string) (st State, ok bool, err error)
+	Save(publisherID string, st State) error
+}
+
+// Decision is the outcome of Limiter.AllowN.
+type Decision struct {
+	Allowed    bool
+	Remaining  int           // whole tokens left after this request
+	RetryAfter time.Duration // when denied, wait this long before retrying
+}
+
+// Limiter applies token buckets keyed by publisher ID. It is safe for
+// concurrent use.
+type Limiter struct {
+	quotas func(publisherID string) (types.PublisherQuota, bool)
+	store  Store
+	mu     sync.Mutex
+}
+
+// New returns a Limiter that looks up quotas with quotas and keeps bucket
+// state in store, loading and saving it on every request, so replicas
+// sharing a store share each publisher's bucket. A store shared by
+// replicas should apply Save atomically; two replicas admitting the same
+// publisher at the same instant can each spend tokens the other has not
+// yet saved. A nil store keeps state in memory, one entry per publisher
+// that has a quota. Publishers without a quota are always denied.
+func New(quotas func(publisherID string) (types.PublisherQuota, bool), store Store) *Limiter {
+	if store == nil {
+		store = &memStore{m: make(map[string]State)}
+	}
+	return &Limiter{quotas: quotas, store: store}
+}
+
+// memStore is the in-process Store used when New is given none.
+type memStore struct {
+	mu sync.Mutex
+	m  map[string]State
+}
+
+func (s *memStore) Load(id string) (State, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, ok := s.m[id]
+	return st, ok, nil
+}
+
+func (s *memStore) Save(id string, st State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.m[id] = st
+	return nil
+}
+
+// StaticQuotas adapts a fixed quota list for New.
+func StaticQuotas(qs ...types.PublisherQuota) func(string) (types.PublisherQuota, bool) {
+	m := make(map[string]types.PublisherQuota, len(qs))
+	for _, q := range qs {
+		m[q.PublisherID] = q
+	}
+	return func(id string) (types.PublisherQuota, bool) {
+		q, ok := m[id]
+		return q, ok
+	}
+}
+
+// Allow is AllowN with n = 1.
+func (l *Limiter) Allow(publisherID string, now time.Time) (Decision, error) {
+	return l.AllowN(publisherID, 1, now)
+}
+
+// AllowN takes n tokens from the publisher's bucket if available. It
+// returns ErrCount if n < 1.
+func (l *Limiter) AllowN(publisherID string, n int, now time.Time) (Decision, error) {
+	if n < 1 {
+		return Decision{}, ErrCount
+	}
+	q, ok := l.quotas(publisherID)
+	if !ok || q.RatePerSecond <= 0 || q.Burst < 1 {
+		return Decision{}, nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	st, ok, err := l.store.Load(publisherID)
+	if err != nil {
+		return Decision{}, err
+	}
+	if !ok {
+		st = State{Tokens: float64(q.Burst), Updated: now}
+	}
+
+	if elapsed := now.Sub(st.Updated).Seconds(); elapsed > 0 {
+		st.Tokens = math.Min(float64(q.Burst), st.Tokens+elapsed*q.RatePerSecond)
+		st.Updated = now
+	}
+
+	var d Decision
+	if need := float64(n); st.Tokens >= need {
+		st.Tokens -= need
+		d.Allowed = true
+	} else if n <= q.Burst {
+		d.RetryAfter = time.Duration((need - st.Tokens) / q.RatePerSecond * float64(time.Second))
+	}
+	d.Remaining = int(st.Tokens)
+
+	if err := l.store.Save(publisherID, st); err != nil {
+		return d, err
+	}
+	return d, nil
+}
@@ -0,0 +1,35 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestLimitersShareStore(t *testing.T) {
+	quotas := StaticQuotas(types.PublisherQuota{PublisherID: "pub-a", RatePerSecond: 1, Burst: 3})
+	store := &memStore{m: make(map[string]State)}
+	a, b := New(quotas, store), New(quotas, store)
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if d, err := a.AllowN("pub-a", 2, now); err != nil || !d.Allowed {
+		t.Fatalf("a.AllowN = %+v, %v; want allowed", d, err)
+	}
+	d, err := b.AllowN("pub-a", 2, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Allowed || d.Remaining != 1 {
+		t.Fatalf("b.AllowN = %+v; want denied with 1 remaining", d)
+	}
+	if d, err := b.Allow("pub-a", now); err != nil || !d.Allowed {
+		t.Fatalf("b.Allow = %+v, %v; want allowed", d, err)
+	}
+	if d, err := a.Allow("pub-a", now); err != nil || d.Allowed {
+		t.Fatalf("a.Allow = %+v, %v; want denied after b spent the last token", d, err)
+	}
+	if d, err := a.Allow("pub-a", now.Add(time.Second)); err != nil || !d.Allowed {
+		t.Fatalf("a.Allow after refill = %+v, %v; want allowed", d, err)
+	}
+}
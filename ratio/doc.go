@@ -0,0 +1,12 @@
+// ratio/doc.go
+// Package ratio combines ratios without drift. Averaging ratios naively,
+// once per rollup level, accumulates rounding error until values such as
+// reshare_ratio land above 1 or equal inputs stop averaging to themselves.
+//
+//	r := ratio.Combine([]types.Probability{0.2, 0.7}, []float64{30, 10}) // 0.325
+//
+// Combine and Mean use compensated (Kahan-Babuška) summation and keep the
+// result within the range of their inputs, so a weighted mean of equal
+// ratios is exactly that ratio and a mean of ratios in [0, 1] never leaves
+// it, however many times results are combined again.
+package ratio
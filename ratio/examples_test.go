@@ -0,0 +1,14 @@
+package ratio_test
+
+import (
+	"fmt"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/ratio"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func ExampleCombine() {
+	// Two minutes: 30 posts at 20% reshares, 10 posts at 70%.
+	fmt.Println(ratio.Combine([]types.Probability{0.2, 0.7}, []float64{30, 10}))
+	// Output: 0.325
+}
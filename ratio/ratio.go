@@ -0,0 +1,80 @@
+package ratio
+
+import (
+	"math"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// Sum is a compensated floating-point sum (Neumaier's variant of Kahan
+// summation). The zero value is an empty sum.
+type Sum struct {
+	sum, c float64
+}
+
+// Add adds x to the sum.
+func (s *Sum) Add(x float64) {
+	t := s.sum + x
+	if math.Abs(s.sum) >= math.Abs(x) {
+		s.c += (s.sum - t) + x
+	} else {
+		s.c += (x - t) + s.sum
+	}
+	s.sum = t
+}
+
+// Value returns the sum.
+func (s Sum) Value() float64 { return s.sum + s.c }
+
+// Mean accumulates a weighted mean of ratios. The zero value is empty.
+type Mean struct {
+	num, den Sum
+	lo, hi   types.Probability
+	n        int
+}
+
+// Add adds ratio r with weight w. Weights that are not positive, and NaN
+// ratios, are ignored.
+func (m *Mean) Add(r types.Probability, w float64) {
+	if !(w > 0) || math.IsNaN(float64(r)) {
+		return
+	}
+	if m.n == 0 || r < m.lo {
+		m.lo = r
+	}
+	if m.n == 0 || r > m.hi {
+		m.hi = r
+	}
+	m.n++
+	m.num.Add(float64(r) * w)
+	m.den.Add(w)
+}
+
+// Weight returns the total weight added.
+func (m *Mean) Weight() float64 { return m.den.Value() }
+
+// Value returns the weighted mean, clamped to the smallest and largest
+// ratio added, or 0 if nothing was added.
+func (m *Mean) Value() types.Probability {
+	if m.n == 0 {
+		return 0
+	}
+	if m.lo == m.hi {
+		return m.lo
+	}
+	v := types.Probability(m.num.Value() / m.den.Value())
+	return min(max(v, m.lo), m.hi)
+}
+
+// Combine returns the mean of ratios weighted by weights, typically point
+// volumes, as Mean does. It panics if the slices differ in length.
+func Combine(ratios []types.Probability, weights []float64) types.Probability {
+	if len(ratios) != len(weights) {
+		panic("ratio: Combine: len(ratios) != len(weights)")
+	}
+	var m Mean
+	for i, r := range ratios {
+		m.Add(r, weights[i])
+	}
+	return m.Value()
+}
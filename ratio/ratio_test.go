@@ -0,0 +1,90 @@
+package ratio
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestSumCompensates(t *testing.T) {
+	var s Sum
+	naive := 0.0
+	for i := 0; i < 1_000_000; i++ {
+		s.Add(0.1)
+		naive += 0.1
+	}
+	if got := s.Value(); got != 100000 {
+		t.Errorf("Sum = %.17g, want 100000 (naive %.17g)", got, naive)
+	}
+	var big Sum
+	for _, x := range []float64{1, 1e100, 1, -1e100} {
+		big.Add(x)
+	}
+	if big.Value() != 2 {
+		t.Errorf("Neumaier sum = %v, want 2", big.Value())
+	}
+}
+
+func TestCombineInvariants(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 200; trial++ {
+		n := 1 + r.Intn(50)
+		ratios := make([]types.Probability, n)
+		weights := make([]float64, n)
+		same := types.Probability(r.Float64())
+		for i := range ratios {
+			ratios[i] = same
+			weights[i] = float64(1 + r.Intn(1e6))
+		}
+		// Equal ratios average to themselves exactly.
+		if got := Combine(ratios, weights); got != same {
+			t.Fatalf("Combine of equal ratios %v = %v", same, got)
+		}
+		lo, hi := types.Probability(1), types.Probability(0)
+		for i := range ratios {
+			ratios[i] = types.Probability(r.Float64())
+			lo, hi = min(lo, ratios[i]), max(hi, ratios[i])
+		}
+		got := Combine(ratios, weights)
+		if got < lo || got > hi {
+			t.Fatalf("Combine = %v outside [%v, %v]", got, lo, hi)
+		}
+		// Order does not matter beyond the last bit.
+		r.Shuffle(n, func(i, j int) {
+			ratios[i], ratios[j] = ratios[j], ratios[i]
+			weights[i], weights[j] = weights[j], weights[i]
+		})
+		if again := Combine(ratios, weights); math.Abs(float64(again-got)) > 1e-15 {
+			t.Fatalf("Combine depends on order: %v vs %v", got, again)
+		}
+	}
+}
+
+func TestRepeatedRollupsStayInRange(t *testing.T) {
+	// Roll up near-1 ratios level over level, reusing each result as an
+	// input to the next, as minute → hour → day pipelines do.
+	r := rand.New(rand.NewSource(2))
+	level := make([]types.Probability, 60)
+	for i := range level {
+		level[i] = types.Probability(1 - float64(r.Intn(3))*0x1p-53)
+	}
+	for depth := 0; depth < 100; depth++ {
+		weights := make([]float64, len(level))
+		for i := range weights {
+			weights[i] = float64(1 + r.Intn(1000))
+		}
+		c := Combine(level, weights)
+		if c > 1 {
+			t.Fatalf("depth %d: %v > 1", depth, c)
+		}
+		level[r.Intn(len(level))] = c
+	}
+	if got := Combine(nil, nil); got != 0 {
+		t.Errorf("empty Combine = %v", got)
+	}
+	if got := Combine([]types.Probability{0.5, 0.9}, []float64{0, -1}); got != 0 {
+		t.Errorf("Combine with no positive weight = %v", got)
+	}
+}
@@ -0,0 +1,16 @@
+// reconcile/doc.go
+// Package reconcile checks a published Series against the tag stream it
+// was aggregated from, to catch collector bucketing bugs (time zone or
+// boundary errors, dropped or double-counted minutes) before publication.
+//
+//	r, err := reconcile.Check(tags, &series)
+//	if err == nil && !r.OK() { ... r.Discrepancies ... }
+//
+// Tags are bucketed by the series interval and each bucket's count is
+// compared with its point's volume, within a tolerance that allows for
+// privacy rounding and suppression. Only intervals that evenly divide a
+// day are supported; calendar intervals such as months are rejected.
+//
+// Tags are passed as analyze.TimedTag because a ProvenanceTag carries no
+// timestamp; the observation time comes from the collector.
+package reconcile
@@ -0,0 +1,37 @@
+package reconcile_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/analyze"
+	"github.com/civic-interconnect/civic-transparency-go-types/reconcile"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func ExampleCheck() {
+	t0 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	// A collector that bucketed in local time would shift every count; here
+	// one tag at 12:00:59.9 was counted in the next minute.
+	tags := []analyze.TimedTag{
+		{TS: t0.Add(10 * time.Second)},
+		{TS: t0.Add(59900 * time.Millisecond)},
+		{TS: t0.Add(70 * time.Second)},
+	}
+	s := &types.Series{Interval: types.IntervalMinute, Points: []types.Point{
+		{TS: t0, Volume: 1},
+		{TS: t0.Add(time.Minute), Volume: 2},
+	}}
+	r, err := reconcile.Check(tags, s)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(r.OK())
+	for _, d := range r.Discrepancies {
+		fmt.Println(d)
+	}
+	// Output:
+	// false
+	// 2025-01-01T12:00:00Z mismatch: 2 tags, volume 1
+	// 2025-01-01T12:01:00Z mismatch: 1 tags, volume 2
+}
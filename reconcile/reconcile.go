@@ -0,0 +1,143 @@
+package reconcile
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/analyze"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// ErrInterval is returned for a series whose interval cannot be bucketed
+// on a fixed grid: undefined, calendar-based (months, years), or not a
+// divisor of a day, such as a week, whose epoch-aligned buckets would not
+// match calendar weeks.
+var ErrInterval = errors.New("reconcile: interval is not a fixed divisor of a day")
+
+// Options tunes CheckWith. The zero value requires exact counts.
+type Options struct {
+	// Abs is the largest allowed absolute difference between a bucket's tag
+	// count and its point's volume, e.g. the step given to
+	// privacy.RoundVolumes.
+	Abs int
+	// Rel is the largest allowed difference as a fraction of the tag count,
+	// for collectors that sample or drop late events. A bucket passes if it
+	// is within either Abs or Rel.
+	Rel float64
+	// MinVolume is the suppression floor the series was published with:
+	// a zero-volume point matches any tag count below it.
+	MinVolume int
+}
+
+func (o Options) allows(tags, volume int) bool {
+	if volume == 0 && tags < o.MinVolume {
+		return true
+	}
+	diff := tags - volume
+	if diff < 0 {
+		diff = -diff
+	}
+	return diff <= o.Abs || float64(diff) <= o.Rel*float64(tags)
+}
+
+// Kind classifies a Discrepancy.
+type Kind string
+
+const (
+	// Mismatch is a bucket whose tag count and volume differ beyond the
+	// tolerance.
+	Mismatch Kind = "mismatch"
+	// MissingPoint is a bucket with tags but no point, inside the span of
+	// the series.
+	MissingPoint Kind = "missing_point"
+	// Misaligned is a point whose ts is not on an interval boundary, so no
+	// tags can be attributed to it.
+	Misaligned Kind = "misaligned"
+)
+
+// Discrepancy is one bucket that failed to reconcile.
+type Discrepancy struct {
+	TS     time.Time `json:"ts"` // bucket start, UTC
+	Kind   Kind      `json:"kind"`
+	Tags   int       `json:"tags"`   // tags observed in the bucket
+	Volume int       `json:"volume"` // published volume; 0 without a point
+}
+
+func (d Discrepancy) String() string {
+	return fmt.Sprintf("%s %s: %d tags, volume %d", d.TS.Format(time.RFC3339), d.Kind, d.Tags, d.Volume)
+}
+
+// Report is the result of Check.
+type Report struct {
+	Buckets       int           `json:"buckets"`       // buckets compared
+	Tags          int           `json:"tags"`          // tags inside the series span
+	Volume        int           `json:"volume"`        // sum of point volumes
+	Outside       int           `json:"outside"`       // tags before the first or after the last point's bucket
+	Discrepancies []Discrepancy `json:"discrepancies"` // in time order
+}
+
+// OK reports whether every bucket reconciled.
+func (r Report) OK() bool { return len(r.Discrepancies) == 0 }
+
+// Check is CheckWith with exact counts required.
+func Check(tags []analyze.TimedTag, s *types.Series) (Report, error) {
+	return CheckWith(tags, s, Options{})
+}
+
+// CheckWith buckets tags by s.Interval, aligned to midnight UTC, and
+// compares each bucket's count with the volume of the point at that
+// bucket. It returns ErrInterval unless the interval evenly divides a day. Buckets from the first
+// point through the last are compared, including those where only one side
+// has data; tags outside that span are counted in Outside and otherwise
+// ignored, since tag exports rarely end exactly where a series does. tags
+// need not be sorted. Points with the same ts are summed.
+func CheckWith(tags []analyze.TimedTag, s *types.Series, opts Options) (Report, error) {
+	const day = 24 * time.Hour
+	step := s.Interval.Duration()
+	if step <= 0 || step > day || day%step != 0 {
+		return Report{}, fmt.Errorf("%w: %q", ErrInterval, s.Interval)
+	}
+	var r Report
+	volume := map[time.Time]int{}
+	var first, last time.Time
+	for _, p := range s.Points {
+		ts := p.TS.UTC()
+		if !ts.Equal(ts.Truncate(step)) {
+			r.Discrepancies = append(r.Discrepancies, Discrepancy{TS: ts, Kind: Misaligned, Volume: p.Volume})
+			continue
+		}
+		volume[ts] += p.Volume
+		r.Volume += p.Volume
+		if first.IsZero() || ts.Before(first) {
+			first = ts
+		}
+		if ts.After(last) {
+			last = ts
+		}
+	}
+	count := map[time.Time]int{}
+	for _, t := range tags {
+		ts := t.TS.UTC().Truncate(step)
+		if len(volume) == 0 || ts.Before(first) || ts.After(last) {
+			r.Outside++
+			continue
+		}
+		count[ts]++
+		r.Tags++
+	}
+	for ts := first; len(volume) > 0 && !ts.After(last); ts = ts.Add(step) {
+		n, v := count[ts], volume[ts]
+		_, hasPoint := volume[ts]
+		r.Buckets++
+		switch {
+		case !hasPoint && n > 0:
+			r.Discrepancies = append(r.Discrepancies, Discrepancy{TS: ts, Kind: MissingPoint, Tags: n})
+		case hasPoint && !opts.allows(n, v):
+			r.Discrepancies = append(r.Discrepancies, Discrepancy{TS: ts, Kind: Mismatch, Tags: n, Volume: v})
+		}
+	}
+	sort.SliceStable(r.Discrepancies, func(i, j int) bool { return r.Discrepancies[i].TS.Before(r.Discrepancies[j].TS) })
+	return r, nil
+}
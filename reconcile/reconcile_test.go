@@ -0,0 +1,71 @@
+package reconcile
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/analyze"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestCheckWith(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	var tags []analyze.TimedTag
+	add := func(minute, n int) {
+		for i := 0; i < n; i++ {
+			tags = append(tags, analyze.TimedTag{TS: t0.Add(time.Duration(minute)*time.Minute + time.Duration(i)*time.Second)})
+		}
+	}
+	add(-1, 4) // before the series
+	add(0, 10)
+	add(1, 12)
+	add(2, 3) // suppressed
+	add(3, 7) // no point
+	add(4, 20)
+	s := &types.Series{Interval: types.IntervalMinute, Points: []types.Point{
+		{TS: t0, Volume: 10},
+		{TS: t0.Add(time.Minute), Volume: 10},
+		{TS: t0.Add(2 * time.Minute), Volume: 0},
+		{TS: t0.Add(4 * time.Minute), Volume: 20},
+		{TS: t0.Add(5*time.Minute + time.Second), Volume: 1},
+	}}
+
+	r, err := CheckWith(tags, s, Options{Abs: 1, MinVolume: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Discrepancy{
+		{TS: t0.Add(time.Minute), Kind: Mismatch, Tags: 12, Volume: 10},
+		{TS: t0.Add(3 * time.Minute), Kind: MissingPoint, Tags: 7},
+		{TS: t0.Add(5*time.Minute + time.Second), Kind: Misaligned, Volume: 1},
+	}
+	if len(r.Discrepancies) != len(want) {
+		t.Fatalf("Discrepancies = %v, want %v", r.Discrepancies, want)
+	}
+	for i, d := range r.Discrepancies {
+		if d != want[i] {
+			t.Errorf("Discrepancies[%d] = %v, want %v", i, d, want[i])
+		}
+	}
+	if r.Buckets != 5 || r.Tags != 52 || r.Outside != 4 || r.Volume != 40 {
+		t.Errorf("Report = %+v", r)
+	}
+	if r, _ := CheckWith(tags, s, Options{Rel: 0.2, MinVolume: 5}); len(r.Discrepancies) != 2 {
+		t.Errorf("Rel tolerance: %v", r.Discrepancies)
+	}
+}
+
+func TestCheckRejectsCalendarIntervals(t *testing.T) {
+	for _, iv := range []types.Interval{"", "P1M", "P1Y", "P1W", "PT7M", "P2D"} {
+		s := &types.Series{Interval: iv, Points: []types.Point{{TS: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Volume: 1}}}
+		if _, err := Check(nil, s); !errors.Is(err, ErrInterval) {
+			t.Errorf("Check(interval %q) = %v, want ErrInterval", iv, err)
+		}
+	}
+	for _, iv := range []types.Interval{types.IntervalMinute, "PT5M", types.IntervalHour, types.IntervalDay} {
+		if _, err := Check(nil, &types.Series{Interval: iv}); err != nil {
+			t.Errorf("Check(interval %q) = %v", iv, err)
+		}
+	}
+}
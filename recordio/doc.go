@@ -0,0 +1,11 @@
+// recordio/doc.go
+// Package recordio frames records for append-only files so that torn
+// writes and bit rot are detected on read. Each record is written as
+//
+//	magic (4 bytes) | length (uint32 LE) | CRC-32C (uint32 LE) | payload
+//
+// where the checksum covers the length and payload. A Reader rejects the
+// first bad record by default; in salvage mode it skips corrupt records,
+// resynchronizing on the next magic number, and stops quietly at a torn
+// final record, so as much of a damaged archive as possible is recovered.
+package recordio
@@ -0,0 +1,226 @@
+package recordio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+)
+
+// MaxRecordSize is the largest payload a Writer accepts and a Reader
+// trusts. A corrupt length above it is treated as corruption rather than
+// an allocation request.
+const MaxRecordSize = 16 << 20
+
+const headerLen = 12
+
+// magic starts every record. It is not valid UTF-8, so it never occurs
+// inside a JSON payload and salvage cannot resynchronize in the middle of
+// one.
+var magic = []byte{0xc7, 0x52, 0xec, 0x0d}
+
+var castagnoli = crc32.MakeTable(crc32.Castagnoli)
+
+var (
+	// ErrCorrupt is returned for a record whose framing or checksum is
+	// wrong, including a record torn off at the end of the input.
+	ErrCorrupt = errors.New("recordio: corrupt record")
+	// ErrTooLarge is returned by Writer.Append for a payload over
+	// MaxRecordSize.
+	ErrTooLarge = errors.New("recordio: record too large")
+)
+
+func checksum(hdr, payload []byte) uint32 {
+	return crc32.Update(crc32.Checksum(hdr[4:8], castagnoli), castagnoli, payload)
+}
+
+// Writer appends framed records to an io.Writer. Each record is passed to
+// the underlying writer in a single Write call. It is not safe for
+// concurrent use.
+type Writer struct {
+	w   io.Writer
+	buf []byte
+}
+
+// NewWriter returns a Writer appending to w.
+func NewWriter(w io.Writer) *Writer { return &Writer{w: w} }
+
+// Append writes rec as one record.
+func (w *Writer) Append(rec []byte) error {
+	if len(rec) > MaxRecordSize {
+		return ErrTooLarge
+	}
+	w.buf = append(w.buf[:0], magic...)
+	w.buf = binary.LittleEndian.AppendUint32(w.buf, uint32(len(rec)))
+	w.buf = binary.LittleEndian.AppendUint32(w.buf, checksum(w.buf, rec))
+	w.buf = append(w.buf, rec...)
+	_, err := w.w.Write(w.buf)
+	return err
+}
+
+// Reader reads framed records, in the style of bufio.Scanner:
+//
+//	r := recordio.NewReader(f)
+//	for r.Next() {
+//		use(r.Record())
+//	}
+//	if err := r.Err(); err != nil { ... }
+type Reader struct {
+	// Salvage skips corrupt records instead of stopping at the first one.
+	// Set it before the first Next.
+	Salvage bool
+
+	r       io.Reader
+	data    []byte // data[pos:] is read but not consumed
+	pos     int
+	off     int64 // input offset of data[pos]
+	eof     bool
+	rec     []byte
+	recOff  int64
+	err     error
+	corrupt int
+	skipped int64
+}
+
+// NewReader returns a Reader over r.
+func NewReader(r io.Reader) *Reader { return &Reader{r: r} }
+
+// Next reads the next record and reports whether one was available. It
+// returns false at the end of the input or, unless Salvage is set, at the
+// first corrupt record.
+func (r *Reader) Next() bool {
+	r.rec = nil
+	for r.err == nil {
+		if !r.fill(headerLen) {
+			return r.torn()
+		}
+		hdr := r.avail()[:headerLen]
+		n := binary.LittleEndian.Uint32(hdr[4:8])
+		if !bytes.Equal(hdr[:4], magic) || n > MaxRecordSize {
+			r.bad("bad header")
+			continue
+		}
+		if !r.fill(headerLen + int(n)) {
+			return r.torn()
+		}
+		buf := r.avail()
+		payload := buf[headerLen : headerLen+int(n)]
+		if binary.LittleEndian.Uint32(buf[8:12]) != checksum(buf, payload) {
+			r.bad("checksum mismatch")
+			continue
+		}
+		r.rec, r.recOff = payload, r.off
+		r.consume(headerLen + int(n))
+		return true
+	}
+	return false
+}
+
+// Record returns the payload read by the last successful Next. It is
+// valid only until the next call to Next.
+func (r *Reader) Record() []byte { return r.rec }
+
+// Offset returns the input offset of the last record returned.
+func (r *Reader) Offset() int64 { return r.recOff }
+
+// Err returns the error that stopped reading, if any. A clean end of input
+// is not an error.
+func (r *Reader) Err() error { return r.err }
+
+// Corrupt returns how many corrupt regions Salvage has skipped, counting a
+// torn final record. Consecutive corrupt records count once when they are
+// skipped together.
+func (r *Reader) Corrupt() int { return r.corrupt }
+
+// Skipped returns how many input bytes Salvage has discarded.
+func (r *Reader) Skipped() int64 { return r.skipped }
+
+func (r *Reader) avail() []byte { return r.data[r.pos:] }
+
+func (r *Reader) consume(n int) {
+	r.pos += n
+	r.off += int64(n)
+}
+
+// fill reads until n bytes are available and reports whether they are.
+func (r *Reader) fill(n int) bool {
+	for len(r.avail()) < n {
+		if !r.more() {
+			return false
+		}
+	}
+	return true
+}
+
+// more reads at least one more byte, or reports false at the end of the
+// input or on a read error (recorded in r.err).
+func (r *Reader) more() bool {
+	if r.eof || r.err != nil {
+		return false
+	}
+	if r.pos > 0 {
+		r.data = r.data[:copy(r.data, r.data[r.pos:])]
+		r.pos = 0
+	}
+	if cap(r.data)-len(r.data) < 4096 {
+		r.data = append(r.data[:cap(r.data)], make([]byte, max(4096, cap(r.data)))...)[:len(r.data)]
+	}
+	for {
+		m, err := r.r.Read(r.data[len(r.data):cap(r.data)])
+		r.data = r.data[:len(r.data)+m]
+		if err == io.EOF {
+			r.eof = true
+		} else if err != nil {
+			r.err = err
+		}
+		if m > 0 {
+			return true
+		}
+		if err != nil {
+			return false
+		}
+	}
+}
+
+// torn handles input that ends inside a record.
+func (r *Reader) torn() bool {
+	if n := len(r.avail()); n > 0 && r.err == nil {
+		if r.Salvage {
+			r.corrupt++
+			r.skipped += int64(n)
+			r.consume(n)
+		} else {
+			r.err = fmt.Errorf("%w: torn record at offset %d", ErrCorrupt, r.off)
+		}
+	}
+	return false
+}
+
+// bad handles a corrupt record at the current offset: an error, or in
+// salvage mode a skip to the next magic number.
+func (r *Reader) bad(why string) {
+	if !r.Salvage {
+		r.err = fmt.Errorf("%w: %s at offset %d", ErrCorrupt, why, r.off)
+		return
+	}
+	r.corrupt++
+	r.discard(1)
+	for {
+		if i := bytes.Index(r.avail(), magic); i >= 0 {
+			r.discard(i)
+			return
+		}
+		r.discard(max(len(r.avail())-(len(magic)-1), 0))
+		if !r.more() {
+			r.discard(len(r.avail()))
+			return
+		}
+	}
+}
+
+func (r *Reader) discard(n int) {
+	r.skipped += int64(n)
+	r.consume(n)
+}
@@ -0,0 +1,80 @@
+package recordio
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+	"testing/iotest"
+)
+
+func write(t *testing.T, recs ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	for _, rec := range recs {
+		if err := w.Append([]byte(rec)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buf.Bytes()
+}
+
+func readAll(data []byte, salvage bool) (recs []string, r *Reader) {
+	r = NewReader(iotest.OneByteReader(bytes.NewReader(data)))
+	r.Salvage = salvage
+	for r.Next() {
+		recs = append(recs, string(r.Record()))
+	}
+	return recs, r
+}
+
+func TestRoundTrip(t *testing.T) {
+	big := string(bytes.Repeat([]byte("x"), 10000))
+	data := write(t, "a", "", big, `{"ts":"2025-01-01T00:00:00Z"}`)
+	recs, r := readAll(data, false)
+	if r.Err() != nil || len(recs) != 4 || recs[2] != big || recs[3] != `{"ts":"2025-01-01T00:00:00Z"}` {
+		t.Fatalf("got %d records, err %v", len(recs), r.Err())
+	}
+	if r.Offset() != int64(len(data)-headerLen-len(recs[3])) {
+		t.Errorf("Offset = %d", r.Offset())
+	}
+}
+
+func TestCorruption(t *testing.T) {
+	data := write(t, "first", "second", "third", "fourth")
+	second := headerLen + len("first")
+	fourth := len(data) - headerLen - len("fourth")
+
+	flipped := bytes.Clone(data)
+	flipped[second+headerLen+2] ^= 0x10 // bit rot in "second"
+	torn := data[:len(data)-3]          // torn write of "fourth"
+	garbage := append(append(bytes.Clone(data[:fourth]), "\x00junk\xc7\x52"...), data[fourth:]...)
+
+	for _, tc := range []struct {
+		name         string
+		data         []byte
+		strict       []string
+		salvage      []string
+		corrupt      int
+		strictOffset int
+	}{
+		{"bit rot", flipped, []string{"first"}, []string{"first", "third", "fourth"}, 1, second},
+		{"torn", torn, []string{"first", "second", "third"}, []string{"first", "second", "third"}, 1, fourth},
+		{"garbage", garbage, []string{"first", "second", "third"}, []string{"first", "second", "third", "fourth"}, 1, fourth},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			recs, r := readAll(tc.data, false)
+			if fmt.Sprint(recs) != fmt.Sprint(tc.strict) || !errors.Is(r.Err(), ErrCorrupt) {
+				t.Errorf("strict: %q, %v", recs, r.Err())
+			}
+			if want := fmt.Sprintf("offset %d", tc.strictOffset); r.Err() != nil && !bytes.Contains([]byte(r.Err().Error()), []byte(want)) {
+				t.Errorf("strict error %q does not mention %s", r.Err(), want)
+			}
+			recs, r = readAll(tc.data, true)
+			if fmt.Sprint(recs) != fmt.Sprint(tc.salvage) || r.Err() != nil || r.Corrupt() != tc.corrupt || r.Skipped() == 0 {
+				t.Errorf("salvage: %q, err %v, corrupt %d, skipped %d", recs, r.Err(), r.Corrupt(), r.Skipped())
+			}
+		})
+	}
+}
@@ -0,0 +1,3 @@
+// render/doc.go
+// Package render provides template helpers for Civic Transparency reports.
+package render
@@ -0,0 +1,38 @@
+package render_test
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/render"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func ExampleFuncMap() {
+	tmpl := template.Must(template.New("report").Funcs(render.FuncMap()).Parse(
+		"{{ .Topic }} {{ sparkline . }}\n{{ range .Points }}{{ .ReshareRatio | percent }}\n{{ end }}"))
+
+	s := types.Series{
+		Topic: "#vote",
+		Points: []types.Point{
+			{Volume: 1, ReshareRatio: 0.125},
+			{Volume: 8, ReshareRatio: 0.5},
+			{Volume: 4, ReshareRatio: 1},
+		},
+	}
+	_ = tmpl.Execute(os.Stdout, s)
+	// Output:
+	// #vote ▁█▄
+	// 12.5%
+	// 50.0%
+	// 100.0%
+}
+
+func ExampleHumanize() {
+	fmt.Println(render.Humanize(types.AcctTypePublicOfficial))
+	fmt.Println(render.Humanize(types.ClientThirdParty))
+	// Output:
+	// Public official
+	// Third party API
+}
@@ -0,0 +1,144 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// sparkRunes are the block glyphs used by Sparkline, lowest to highest.
+var sparkRunes = []rune("▁▂▃▄▅▆▇█")
+
+// FuncMap returns helpers for text/template and html/template.
+// html/template.FuncMap is an alias of text/template.FuncMap, so the
+// result can be passed to either package's Funcs method.
+//
+//	percent    Probability -> "12.5%"
+//	percentN   (int, Probability) -> percent with N decimals
+//	humanize   enum or snake_case string -> "Public official"
+//	sparkline  []int or Series -> "▁▃█▅"
+//	volumes    Series -> []int of point volumes
+//	utc        time.Time -> RFC 3339 in UTC
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"percent":   Percent,
+		"percentN":  PercentN,
+		"humanize":  Humanize,
+		"sparkline": sparkline,
+		"volumes":   Volumes,
+		"utc":       UTC,
+	}
+}
+
+// Percent formats p as a percentage with one decimal place.
+func Percent(p types.Probability) string {
+	return PercentN(1, p)
+}
+
+// PercentN formats p as a percentage with the given number of decimals.
+// The argument order suits template pipelines: {{ .ReshareRatio | percentN 2 }}.
+func PercentN(decimals int, p types.Probability) string {
+	if decimals < 0 {
+		decimals = 0
+	}
+	return fmt.Sprintf("%.*f%%", decimals, float64(p)*100)
+}
+
+// Humanize renders an enum value (or any snake_case string) for display,
+// e.g. "public_official" -> "Public official", "third_party_api" -> "Third party API".
+func Humanize(v any) string {
+	var s string
+	switch x := v.(type) {
+	case string:
+		s = x
+	case fmt.Stringer:
+		s = x.String()
+	default:
+		s = fmt.Sprint(v)
+	}
+	if s == "" {
+		return ""
+	}
+	words := strings.Split(s, "_")
+	for i, w := range words {
+		switch w {
+		case "api", "c2pa", "iso":
+			words[i] = strings.ToUpper(w)
+			continue
+		}
+		if i == 0 && w != "" {
+			r, n := utf8.DecodeRuneInString(w)
+			words[i] = string(unicode.ToUpper(r)) + w[n:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// Sparkline renders volumes as a single-line bar chart scaled between the
+// minimum and maximum value. Negative values are treated as zero.
+func Sparkline(vals []int) string {
+	if len(vals) == 0 {
+		return ""
+	}
+	lo, hi := vals[0], vals[0]
+	for _, v := range vals {
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+	if lo < 0 {
+		lo = 0
+	}
+	var b strings.Builder
+	top := len(sparkRunes) - 1
+	for _, v := range vals {
+		if v < 0 {
+			v = 0
+		}
+		idx := 0
+		if hi > lo {
+			idx = (v - lo) * top / (hi - lo)
+		}
+		b.WriteRune(sparkRunes[idx])
+	}
+	return b.String()
+}
+
+// Volumes returns the Volume of each point in s, in order.
+func Volumes(s types.Series) []int {
+	out := make([]int, len(s.Points))
+	for i, p := range s.Points {
+		out[i] = p.Volume
+	}
+	return out
+}
+
+// UTC formats t as RFC 3339 in UTC.
+func UTC(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+// sparkline lets templates pass either a Series or raw volumes.
+func sparkline(v any) (string, error) {
+	switch x := v.(type) {
+	case []int:
+		return Sparkline(x), nil
+	case types.Series:
+		return Sparkline(Volumes(x)), nil
+	case *types.Series:
+		if x == nil {
+			return "", nil
+		}
+		return Sparkline(Volumes(*x)), nil
+	default:
+		return "", fmt.Errorf("sparkline: unsupported type %T", v)
+	}
+}
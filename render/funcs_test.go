@@ -0,0 +1,18 @@
+package render
+
+import "testing"
+
+func TestHumanize(t *testing.T) {
+	for in, want := range map[string]string{
+		"public_official": "Public official",
+		"third_party_api": "Third party API",
+		"_x":              " x",
+		"x_":              "X ",
+		"__":              "  ",
+		"élan_vital":      "Élan vital",
+	} {
+		if got := Humanize(in); got != want {
+			t.Errorf("Humanize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
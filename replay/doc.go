@@ -0,0 +1,15 @@
+// replay/doc.go
+// Package replay re-runs the current validators over archived payloads and
+// reports the ones that would now be rejected.
+//
+// Run it before tightening validation in production: point it at an archive
+// directory with the proposed validate.Options (and, optionally, the options
+// the payloads were originally accepted under) and review which files would
+// start failing.
+//
+//	r, err := replay.Run(os.DirFS("archive/2025"), replay.Options{
+//		Validate: proposed,
+//		Baseline: &current,
+//	})
+//	r.WriteText(os.Stdout)
+package replay
@@ -0,0 +1,212 @@
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/codec"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+	"github.com/civic-interconnect/civic-transparency-go-types/validate"
+)
+
+// Kind names the payload type a Finding refers to.
+type Kind string
+
+const (
+	KindSeries        Kind = "series"
+	KindProvenanceTag Kind = "provenance_tag"
+)
+
+// Options configures Run.
+type Options struct {
+	// Validate holds the rules payloads are replayed against.
+	Validate validate.Options
+	// Baseline, if set, holds the rules payloads were accepted under. A
+	// failure that also fails the baseline is marked PreviouslyFailing, so
+	// Regressions lists only what the new rules would newly reject.
+	Baseline *validate.Options
+	// SeriesRules are extra checks run on every Series after validation.
+	SeriesRules []func(*types.Series) error
+	// Match selects files to replay. The default accepts .json and .ndjson.
+	Match func(name string) bool
+}
+
+// Finding is one payload that failed replay, or one entry that could not
+// be decoded at all.
+type Finding struct {
+	Path              string
+	Line              int    // 1-based line for NDJSON files, 0 for whole-file JSON
+	Kind              Kind   // empty for entries that are not valid JSON
+	Topic             string // empty for provenance tags
+	Err               error
+	PreviouslyFailing bool
+}
+
+func (f Finding) String() string {
+	loc := f.Path
+	if f.Line > 0 {
+		loc = fmt.Sprintf("%s:%d", f.Path, f.Line)
+	}
+	switch {
+	case f.Topic != "":
+		return fmt.Sprintf("%s: %s %s: %v", loc, f.Kind, f.Topic, f.Err)
+	case f.Kind == "":
+		return fmt.Sprintf("%s: %v", loc, f.Err)
+	}
+	return fmt.Sprintf("%s: %s: %v", loc, f.Kind, f.Err)
+}
+
+// Report summarizes a replay.
+type Report struct {
+	Files    int // files read
+	Payloads int // series and provenance tags checked
+	Skipped  int // JSON values that were neither (manifests, bundles, ...)
+	Failures []Finding
+	// Errors lists entries that could not be decoded, under either set of
+	// rules. They say nothing about the rule change and are neither
+	// Failures nor Regressions.
+	Errors []Finding
+}
+
+// Regressions returns the failures that the baseline accepted.
+func (r *Report) Regressions() []Finding {
+	var out []Finding
+	for _, f := range r.Failures {
+		if !f.PreviouslyFailing {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// WriteText writes a human-readable summary followed by each regression
+// and each decode error.
+func (r *Report) WriteText(w io.Writer) error {
+	reg := r.Regressions()
+	if _, err := fmt.Fprintf(w, "replayed %d payloads in %d files (%d skipped, %d malformed): %d failing, %d newly failing\n",
+		r.Payloads, r.Files, r.Skipped, len(r.Errors), len(r.Failures), len(reg)); err != nil {
+		return err
+	}
+	for _, f := range append(reg, r.Errors...) {
+		if _, err := fmt.Fprintln(w, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run replays every matching file in fsys, in lexical path order. Entries
+// that do not decode are listed in Report.Errors; only errors reading fsys
+// abort the run.
+func Run(fsys fs.FS, opts Options) (*Report, error) {
+	match := opts.Match
+	if match == nil {
+		match = func(name string) bool {
+			ext := path.Ext(name)
+			return ext == ".json" || ext == ".ndjson"
+		}
+	}
+	var names []string
+	err := fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && match(p) {
+			names = append(names, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	r := &Report{}
+	for _, name := range names {
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		r.Files++
+		if path.Ext(name) == ".ndjson" {
+			sc := bufio.NewScanner(bytes.NewReader(b))
+			sc.Buffer(nil, len(b)+1)
+			for line := 1; sc.Scan(); line++ {
+				if len(bytes.TrimSpace(sc.Bytes())) > 0 {
+					r.check(name, line, sc.Bytes(), opts)
+				}
+			}
+			continue
+		}
+		r.check(name, 0, b, opts)
+	}
+	return r, nil
+}
+
+// check classifies one JSON value and records any failure.
+func (r *Report) check(name string, line int, data []byte, opts Options) {
+	f := Finding{Path: name, Line: line}
+	var probe map[string]json.RawMessage
+	if err := json.Unmarshal(data, &probe); err != nil {
+		f.Err = err
+		r.Errors = append(r.Errors, f)
+		return
+	}
+	var now, before error
+	switch {
+	case probe["points"] != nil:
+		f.Kind = KindSeries
+		var s types.Series
+		if err := codec.Unmarshal(data, &s); err != nil {
+			f.Err = err
+			r.Errors = append(r.Errors, f)
+			return
+		}
+		f.Topic = s.Topic
+		now = validateSeries(&s, opts.Validate, opts.SeriesRules)
+		if now != nil && opts.Baseline != nil {
+			before = validateSeries(&s, *opts.Baseline, opts.SeriesRules)
+		}
+	case probe["acct_type"] != nil:
+		f.Kind = KindProvenanceTag
+		var t types.ProvenanceTag
+		if err := json.Unmarshal(data, &t); err != nil {
+			f.Err = err
+			r.Errors = append(r.Errors, f)
+			return
+		}
+		now = validate.ValidateProvenanceTagWith(&t, opts.Validate)
+		if now != nil && opts.Baseline != nil {
+			before = validate.ValidateProvenanceTagWith(&t, *opts.Baseline)
+		}
+	default:
+		r.Skipped++
+		return
+	}
+	r.Payloads++
+	if now == nil {
+		return
+	}
+	f.Err = now
+	f.PreviouslyFailing = opts.Baseline != nil && before != nil
+	r.Failures = append(r.Failures, f)
+}
+
+func validateSeries(s *types.Series, vo validate.Options, rules []func(*types.Series) error) error {
+	var me validate.MultiError
+	if err := validate.ValidateSeriesWith(s, vo); err != nil {
+		me.Append(err)
+	}
+	for _, rule := range rules {
+		if err := rule(s); err != nil {
+			me.Append(err)
+		}
+	}
+	return me.NilOrError()
+}
@@ -0,0 +1,55 @@
+package replay
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/validate"
+)
+
+func TestRun(t *testing.T) {
+	const (
+		loose  = `{"topic":"#a","generated_at":"2025-01-01T01:00:00Z","interval":"PT1M","points":[{"ts":"2025-01-01T00:00:00Z","volume":5,"reshare_ratio":0,"recycled_content_rate":0,"acct_age_mix":{"24m+":0.995},"automation_mix":null,"client_mix":null,"coordination_signals":{"burst_score":0,"synchrony_index":0,"duplication_clusters":0}}]}`
+		broken = `{"topic":"#b","generated_at":"2025-01-01T01:00:00Z","interval":"PT1M","points":[{"ts":"2025-01-01T00:00:00Z","volume":-1,"reshare_ratio":0,"recycled_content_rate":0,"acct_age_mix":null,"automation_mix":null,"client_mix":null,"coordination_signals":{"burst_score":0,"synchrony_index":0,"duplication_clusters":0}}]}`
+		tag    = `{"acct_age_bucket":"24m+","acct_type":"person","automation_flag":"manual","post_kind":"original","client_family":"web","media_provenance":"none","dedup_hash":"deadbeef"}`
+	)
+	fsys := fstest.MapFS{
+		"2025/a.json":        {Data: []byte(loose)},
+		"2025/b.ndjson":      {Data: []byte(tag + "\n\n" + broken + "\n{\"points\":\n" + `{"acct_type":7}` + "\n")},
+		"2025/manifest.json": {Data: []byte(`{"version":1,"root":"00"}`)},
+		"README.md":          {Data: []byte("not replayed")},
+	}
+
+	baseline := validate.Options{SumEpsilon: 0.01}
+	r, err := Run(fsys, Options{Validate: validate.DefaultOptions(), Baseline: &baseline})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Files != 3 || r.Payloads != 3 || r.Skipped != 1 || len(r.Failures) != 2 || len(r.Errors) != 2 {
+		t.Fatalf("report = %+v", r)
+	}
+	reg := r.Regressions()
+	if len(reg) != 1 || reg[0].Path != "2025/a.json" || reg[0].Topic != "#a" {
+		t.Fatalf("Regressions = %v", reg)
+	}
+	if f := r.Failures[1]; f.Path != "2025/b.ndjson" || f.Line != 3 || !f.PreviouslyFailing {
+		t.Fatalf("Failures[1] = %+v", f)
+	}
+	if e := r.Errors; e[0].Line != 4 || e[0].Kind != "" || e[1].Line != 5 || e[1].Kind != KindProvenanceTag {
+		t.Fatalf("Errors = %+v", e)
+	}
+
+	// Without a baseline, malformed entries are still not regressions.
+	if r, _ := Run(fsys, Options{Validate: validate.DefaultOptions()}); len(r.Regressions()) != 2 || len(r.Errors) != 2 {
+		t.Fatalf("no baseline: regressions %v, errors %v", r.Regressions(), r.Errors)
+	}
+
+	var out strings.Builder
+	if err := r.WriteText(&out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out.String(), "replayed 3 payloads in 3 files (1 skipped, 2 malformed): 2 failing, 1 newly failing\n2025/a.json: series #a: ") {
+		t.Fatalf("WriteText = %q", out.String())
+	}
+}
@@ -0,0 +1,17 @@
+// retention/doc.go
+// Package retention applies tiered retention policies to a store: series
+// are rolled up to hourly once they are older than KeepRaw, to daily once
+// they are older than KeepHourly, and deleted once they are older than
+// KeepDaily. The thresholds are ages, not durations spent in a tier, so
+// they must increase and a series' fate depends only on its age.
+//
+//	rep, err := retention.Apply(ctx, st, retention.Policy{
+//		KeepRaw:    30 * 24 * time.Hour,
+//		KeepHourly: 365 * 24 * time.Hour,
+//		KeepDaily:  retention.Forever,
+//	}, retention.Options{DryRun: true})
+//
+// Each stored object is rolled up in place (under the same key, guarded by
+// its ETag) with seriesops.Rollup, so the store never holds both a raw
+// object and its rollup. Age is measured from the object's GeneratedAt.
+package retention
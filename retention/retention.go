@@ -0,0 +1,193 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/seriesops"
+	"github.com/civic-interconnect/civic-transparency-go-types/store"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// Forever disables the age limit of a tier.
+const Forever time.Duration = 0
+
+// ErrPolicy is returned by Apply for a Policy whose finite thresholds are
+// not increasing.
+var ErrPolicy = errors.New("retention: thresholds must increase from KeepRaw to KeepDaily")
+
+// Policy gives the ages, measured from GeneratedAt, at which a series
+// leaves each resolution. Every threshold applies to every series whatever
+// its current resolution, so a minute series older than KeepHourly goes
+// straight to daily and any series older than KeepDaily is deleted. A
+// threshold set to Forever never applies.
+type Policy struct {
+	KeepRaw    time.Duration // older series are at most hourly
+	KeepHourly time.Duration // older series are at most daily
+	KeepDaily  time.Duration // older series are deleted
+}
+
+// Validate reports whether the finite thresholds of p are increasing.
+func (p Policy) Validate() error {
+	var prev time.Duration
+	for _, d := range []time.Duration{p.KeepRaw, p.KeepHourly, p.KeepDaily} {
+		if d < 0 || d > 0 && d <= prev {
+			return fmt.Errorf("%w: %+v", ErrPolicy, p)
+		}
+		if d > 0 {
+			prev = d
+		}
+	}
+	return nil
+}
+
+// target returns the operation p requires on a series of age whose bucket
+// is d long, or "" if none.
+func (p Policy) target(age, d time.Duration) (Op, types.Interval) {
+	switch {
+	case p.KeepDaily > 0 && age > p.KeepDaily:
+		return OpDelete, ""
+	case p.KeepHourly > 0 && age > p.KeepHourly && d < 24*time.Hour:
+		return OpRollupDaily, types.IntervalDay
+	case p.KeepRaw > 0 && age > p.KeepRaw && d < time.Hour:
+		return OpRollupHourly, types.IntervalHour
+	}
+	return "", ""
+}
+
+// Store is a SeriesStore that can enumerate its topics, such as blob.Store.
+type Store interface {
+	store.SeriesStore
+	Topics(ctx context.Context) ([]string, error)
+}
+
+// Options configures Apply.
+type Options struct {
+	// DryRun reports what would change without writing.
+	DryRun bool
+	// Now overrides the clock (tests).
+	Now func() time.Time
+}
+
+// Op is the action taken on one object.
+type Op string
+
+const (
+	OpRollupHourly Op = "rollup_hourly"
+	OpRollupDaily  Op = "rollup_daily"
+	OpDelete       Op = "delete"
+)
+
+// Action records one change made (or, in a dry run, planned).
+type Action struct {
+	Key        store.Key
+	Op         Op
+	PointsFrom int // points before the action
+	PointsTo   int // points after; 0 for deletes
+}
+
+// Report lists the actions of one Apply run.
+type Report struct {
+	DryRun  bool
+	Scanned int // objects inspected
+	Actions []Action
+	// Conflicts lists objects skipped because they changed during the run.
+	Conflicts []store.Key
+}
+
+// Apply enforces p on every topic in st. An object that changes between
+// being read and rewritten is skipped and listed in Report.Conflicts;
+// other errors stop the run and are returned with the partial report. It
+// returns ErrPolicy, changing nothing, if p is not valid.
+func Apply(ctx context.Context, st Store, p Policy, opts Options) (*Report, error) {
+	now := time.Now
+	if opts.Now != nil {
+		now = opts.Now
+	}
+	t := now()
+	rep := &Report{DryRun: opts.DryRun}
+	if err := p.Validate(); err != nil {
+		return rep, err
+	}
+	oldest := minPositive(p.KeepRaw, p.KeepHourly, p.KeepDaily)
+	if oldest == 0 {
+		return rep, nil
+	}
+	topics, err := st.Topics(ctx)
+	if err != nil {
+		return rep, err
+	}
+	for _, topic := range topics {
+		infos, err := st.ListWindow(ctx, topic, time.Time{}, t.Add(-oldest))
+		if err != nil {
+			return rep, err
+		}
+		for _, info := range infos {
+			rep.Scanned++
+			if err := apply(ctx, st, p, opts, t, info, rep); err != nil {
+				return rep, err
+			}
+		}
+	}
+	return rep, nil
+}
+
+func apply(ctx context.Context, st Store, p Policy, opts Options, now time.Time, info store.Info, rep *Report) error {
+	age := now.Sub(info.Key.GeneratedAt)
+	s, cur, err := st.Get(ctx, info.Key)
+	if errors.Is(err, store.ErrNotFound) {
+		rep.Conflicts = append(rep.Conflicts, info.Key)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	op, to := p.target(age, s.Interval.Duration())
+	if op == "" {
+		return nil
+	}
+	a := Action{Key: info.Key, Op: op, PointsFrom: len(s.Points)}
+	cond := store.Condition{IfMatch: cur.ETag}
+	if op == OpDelete {
+		if !opts.DryRun {
+			if err := st.Delete(ctx, info.Key, cond); err != nil {
+				return conflict(err, info.Key, rep)
+			}
+		}
+		rep.Actions = append(rep.Actions, a)
+		return nil
+	}
+	r, err := seriesops.Rollup(s, to)
+	if err != nil {
+		return fmt.Errorf("retention: %s %s: %w", info.Key.Topic, info.Key.GeneratedAt.Format(time.RFC3339), err)
+	}
+	a.PointsTo = len(r.Points)
+	if !opts.DryRun {
+		if _, err := st.Put(ctx, r, cond); err != nil {
+			return conflict(err, info.Key, rep)
+		}
+	}
+	rep.Actions = append(rep.Actions, a)
+	return nil
+}
+
+// conflict records precondition failures and passes other errors through.
+func conflict(err error, k store.Key, rep *Report) error {
+	if errors.Is(err, store.ErrPreconditionFailed) || errors.Is(err, store.ErrNotFound) {
+		rep.Conflicts = append(rep.Conflicts, k)
+		return nil
+	}
+	return err
+}
+
+func minPositive(ds ...time.Duration) time.Duration {
+	var m time.Duration
+	for _, d := range ds {
+		if d > 0 && (m == 0 || d < m) {
+			m = d
+		}
+	}
+	return m
+}
@@ -0,0 +1,109 @@
+package retention
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/store"
+	"github.com/civic-interconnect/civic-transparency-go-types/store/blob"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestApply(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	st := blob.New(blob.NewMemBucket(), blob.Options{})
+
+	put := func(age time.Duration, iv types.Interval, n int) store.Key {
+		gen := now.Add(-age).Truncate(day)
+		s := &types.Series{Topic: "#vote", GeneratedAt: gen, Interval: iv}
+		for i := 0; i < n; i++ {
+			s.Points = append(s.Points, types.Point{TS: gen.Add(-time.Duration(i+1) * iv.Duration()), Volume: 1})
+		}
+		if _, err := st.Put(ctx, s, store.Condition{IfNoneMatch: true}); err != nil {
+			t.Fatal(err)
+		}
+		return store.KeyOf(s)
+	}
+	fresh := put(2*day, types.IntervalMinute, 120)
+	raw := put(40*day, types.IntervalMinute, 120)
+	hourly := put(400*day, types.IntervalHour, 48)
+	daily := put(800*day, types.IntervalDay, 3)
+	staleRaw := put(401*day, types.IntervalMinute, 120)
+	ancientRaw := put(900*day, types.IntervalMinute, 5)
+
+	p := Policy{KeepRaw: 30 * day, KeepHourly: 365 * day, KeepDaily: 730 * day}
+	opts := Options{DryRun: true, Now: func() time.Time { return now }}
+	dry, err := Apply(ctx, st, p, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[store.Key]Action{
+		raw:    {Key: raw, Op: OpRollupHourly, PointsFrom: 120, PointsTo: 2},
+		hourly: {Key: hourly, Op: OpRollupDaily, PointsFrom: 48, PointsTo: 2},
+		daily:  {Key: daily, Op: OpDelete, PointsFrom: 3},
+		// Thresholds are ages, so old raw series skip intermediate tiers.
+		staleRaw:   {Key: staleRaw, Op: OpRollupDaily, PointsFrom: 120, PointsTo: 1},
+		ancientRaw: {Key: ancientRaw, Op: OpDelete, PointsFrom: 5},
+	}
+	check := func(rep *Report) {
+		t.Helper()
+		if len(rep.Actions) != len(want) || len(rep.Conflicts) != 0 {
+			t.Fatalf("report = %+v", rep)
+		}
+		for _, a := range rep.Actions {
+			if a != want[a.Key] {
+				t.Errorf("action = %+v, want %+v", a, want[a.Key])
+			}
+		}
+	}
+	check(dry)
+	if s, _, _ := st.Get(ctx, raw); s.Interval != types.IntervalMinute {
+		t.Fatalf("dry run rewrote %v", raw)
+	}
+
+	opts.DryRun = false
+	rep, err := Apply(ctx, st, p, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check(rep)
+	if s, _, _ := st.Get(ctx, raw); s.Interval != types.IntervalHour || len(s.Points) != 2 {
+		t.Errorf("raw after = %s, %d points", s.Interval, len(s.Points))
+	}
+	if s, _, _ := st.Get(ctx, hourly); s.Interval != types.IntervalDay {
+		t.Errorf("hourly after = %s", s.Interval)
+	}
+	if _, _, err := st.Get(ctx, daily); err != store.ErrNotFound {
+		t.Errorf("daily after: err = %v", err)
+	}
+	if s, _, _ := st.Get(ctx, fresh); s.Interval != types.IntervalMinute {
+		t.Errorf("fresh after = %s", s.Interval)
+	}
+
+	// A second pass has nothing left to do.
+	again, err := Apply(ctx, st, p, opts)
+	if err != nil || len(again.Actions) != 0 {
+		t.Errorf("second pass = %+v, %v", again, err)
+	}
+}
+
+func TestApplyRejectsInconsistentPolicy(t *testing.T) {
+	st := blob.New(blob.NewMemBucket(), blob.Options{})
+	day := 24 * time.Hour
+	for _, p := range []Policy{
+		{KeepRaw: 30 * day, KeepHourly: 30 * day},
+		{KeepRaw: 365 * day, KeepDaily: 30 * day},
+		{KeepHourly: -day},
+	} {
+		if _, err := Apply(context.Background(), st, p, Options{}); !errors.Is(err, ErrPolicy) {
+			t.Errorf("Apply(%+v) = %v, want ErrPolicy", p, err)
+		}
+	}
+	if err := (Policy{KeepRaw: 30 * day, KeepDaily: 365 * day}).Validate(); err != nil {
+		t.Errorf("Validate with a Forever tier: %v", err)
+	}
+}
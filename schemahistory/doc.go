@@ -0,0 +1,13 @@
+// schemahistory/doc.go
+// Package schemahistory embeds a machine-readable history of schema
+// changes — fields, types, and enum values added, changed, or deprecated,
+// keyed by the spec version that introduced them — so clients can discover
+// at runtime what to expect after an upgrade:
+//
+//	for _, c := range schemahistory.Since("0.2.1") {
+//		fmt.Println(c)
+//	}
+//
+// The history is maintained by hand in history.json alongside changes to
+// package types.
+package schemahistory
@@ -0,0 +1,37 @@
+package schemahistory_test
+
+import (
+	"fmt"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/schemahistory"
+)
+
+func ExampleSince() {
+	for _, c := range schemahistory.Since("0.2.1") {
+		if c.Kind == schemahistory.KindField && c.Change == schemahistory.Added {
+			fmt.Println(c.Path)
+		}
+	}
+	fmt.Println(len(schemahistory.Since("1.2.0")))
+	// Output:
+	// Point.backfilled
+	// Series.annotations
+	// Series.retraction
+	// Point.acct_type_shares
+	// Point.post_kind_mix
+	// Series.extensions
+	// Series.tenant
+	// Series.jurisdiction
+	// Series.methodology
+	// ProvenanceTag.dedup_hash_alg
+	// ProvenanceTag.extensions
+	// 0
+}
+
+func ExamplePath() {
+	for _, c := range schemahistory.Path("Interval.PT1H") {
+		fmt.Println(c)
+	}
+	// Output:
+	// 0.3.0: added enum_value Interval.PT1H
+}
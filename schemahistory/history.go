@@ -0,0 +1,96 @@
+package schemahistory
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/negotiate"
+)
+
+// ChangeType says what happened to an element.
+type ChangeType string
+
+const (
+	Added      ChangeType = "added"
+	Changed    ChangeType = "changed"
+	Deprecated ChangeType = "deprecated"
+	Removed    ChangeType = "removed"
+)
+
+// Kind is the sort of schema element a Change describes.
+type Kind string
+
+const (
+	KindType      Kind = "type"       // Path is a type name, e.g. "Annotation"
+	KindField     Kind = "field"      // Path is Type.json_name, e.g. "Point.backfilled"
+	KindEnum      Kind = "enum"       // Path is an enumeration type; Note lists its values
+	KindEnumValue Kind = "enum_value" // Path is Enum.value, e.g. "Interval.PT1H"
+)
+
+// Change is one entry in the schema history.
+type Change struct {
+	Version string     `json:"version"` // spec version that introduced the change
+	Change  ChangeType `json:"change"`
+	Kind    Kind       `json:"kind"`
+	Path    string     `json:"path"`
+	Note    string     `json:"note,omitempty"`
+}
+
+func (c Change) String() string {
+	s := fmt.Sprintf("%s: %s %s %s", c.Version, c.Change, c.Kind, c.Path)
+	if c.Note != "" {
+		s += " (" + c.Note + ")"
+	}
+	return s
+}
+
+//go:embed history.json
+var historyJSON []byte
+
+var history = func() []Change {
+	var h []Change
+	if err := json.Unmarshal(historyJSON, &h); err != nil {
+		panic("schemahistory: " + err.Error())
+	}
+	sort.SliceStable(h, func(i, j int) bool { return negotiate.Compare(h[i].Version, h[j].Version) < 0 })
+	return h
+}()
+
+// All returns the full history, oldest version first.
+func All() []Change { return append([]Change(nil), history...) }
+
+// Since returns the changes introduced after version, oldest first. Since
+// of the current types.SpecVersion is empty.
+func Since(version string) []Change {
+	var out []Change
+	for _, c := range history {
+		if negotiate.Compare(c.Version, version) > 0 {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Versions returns the distinct versions in the history, oldest first.
+func Versions() []string {
+	var out []string
+	for _, c := range history {
+		if len(out) == 0 || out[len(out)-1] != c.Version {
+			out = append(out, c.Version)
+		}
+	}
+	return out
+}
+
+// Path returns the history of one element, oldest first.
+func Path(path string) []Change {
+	var out []Change
+	for _, c := range history {
+		if c.Path == path {
+			out = append(out, c)
+		}
+	}
+	return out
+}
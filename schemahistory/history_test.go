@@ -0,0 +1,45 @@
+package schemahistory
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/negotiate"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// TestFieldsRecorded fails when a JSON field is added to a core type without
+// a history entry.
+func TestFieldsRecorded(t *testing.T) {
+	added := map[string]bool{}
+	for _, c := range All() {
+		if c.Kind == KindField && c.Change == Added {
+			added[c.Path] = true
+		}
+	}
+	for _, v := range []any{types.Series{}, types.Point{}, types.CoordinationSignals{}, types.ProvenanceTag{}} {
+		rt := reflect.TypeOf(v)
+		for i := 0; i < rt.NumField(); i++ {
+			name, _, _ := strings.Cut(rt.Field(i).Tag.Get("json"), ",")
+			if p := rt.Name() + "." + name; !added[p] {
+				t.Errorf("%s has no %q history entry", p, Added)
+			}
+		}
+	}
+}
+
+func TestVersions(t *testing.T) {
+	vs := Versions()
+	if vs[len(vs)-1] != types.SpecVersion {
+		t.Errorf("latest history version %s, SpecVersion %s", vs[len(vs)-1], types.SpecVersion)
+	}
+	for _, c := range All() {
+		if negotiate.Compare(c.Version, "0.0.0") < 0 {
+			t.Errorf("invalid version in %v", c)
+		}
+	}
+	if got := Since(types.SpecVersion); len(got) != 0 {
+		t.Errorf("Since(current) = %v", got)
+	}
+}
@@ -0,0 +1,134 @@
+package seriesops
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/topic"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// Sourced is a series tagged with the platform it was published by.
+type Sourced struct {
+	Platform string
+	Series   *types.Series
+}
+
+// MergeAliased aligns series from several platforms on one topic taxonomy:
+// each series' topic is resolved through am (labels am does not know are
+// kept as-is), series resolving to the same canonical topic are merged, and
+// points sharing a timestamp are folded with Combine. The result has one
+// series per canonical topic, sorted by topic, with the latest GeneratedAt
+// and the merged annotations of its inputs. Series merged together must
+// share an interval, tenant, and jurisdiction. Their methodologies are
+// combined by mergeMethodology, so the result keeps the strictest
+// suppression threshold; extensions are platform-specific and dropped.
+// Retracted series are skipped. Inputs are not modified.
+func MergeAliased(am *topic.AliasMap, in ...Sourced) ([]*types.Series, error) {
+	type group struct {
+		s      *types.Series
+		byTime map[time.Time][]types.Point
+		meths  []*types.Methodology
+	}
+	groups := map[string]*group{}
+	for _, src := range in {
+		s := src.Series
+		if s == nil || s.Retraction != nil {
+			continue
+		}
+		name := s.Topic
+		if c, ok := am.Canonical(src.Platform, s.Topic); ok {
+			name = c
+		}
+		g := groups[name]
+		if g == nil {
+			g = &group{
+				s: &types.Series{
+					Topic: name, GeneratedAt: s.GeneratedAt, Interval: s.Interval,
+					Tenant: s.Tenant, Jurisdiction: s.Jurisdiction,
+				},
+				byTime: map[time.Time][]types.Point{},
+			}
+			groups[name] = g
+		} else if g.s.Interval.Canonical() != s.Interval.Canonical() {
+			return nil, fmt.Errorf("seriesops: merge %q: interval %s from %s does not match %s", name, s.Interval, src.Platform, g.s.Interval)
+		} else if g.s.Tenant != s.Tenant || g.s.Jurisdiction != s.Jurisdiction {
+			return nil, fmt.Errorf("seriesops: merge %q: tenant %q jurisdiction %q from %s does not match tenant %q jurisdiction %q",
+				name, s.Tenant, s.Jurisdiction, src.Platform, g.s.Tenant, g.s.Jurisdiction)
+		}
+		g.meths = append(g.meths, s.Methodology)
+		if s.GeneratedAt.After(g.s.GeneratedAt) {
+			g.s.GeneratedAt = s.GeneratedAt
+		}
+		g.s.Annotations = types.MergeAnnotations(g.s.Annotations, s.Annotations)
+		for _, p := range s.Points {
+			k := p.TS.UTC()
+			g.byTime[k] = append(g.byTime[k], p)
+		}
+	}
+
+	out := make([]*types.Series, 0, len(groups))
+	for _, g := range groups {
+		for k, pts := range g.byTime {
+			p := Combine(pts...)
+			p.TS = k
+			g.s.Points = append(g.s.Points, p)
+		}
+		SortByTime(g.s.Points)
+		g.s.Methodology = mergeMethodology(g.meths)
+		out = append(out, g.s)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Topic < out[j].Topic })
+	return out, nil
+}
+
+// mergeMethodology combines the methodologies of series summed together,
+// nil for an input that declared none. The result is nil if no input
+// declared one. It keeps the highest suppression threshold and collection
+// lag, since the sum is only as protected and as complete as its least
+// protected and slowest input. Sampling rate, rounding, and noise are kept
+// only if every input declared the same value; otherwise they are left
+// unset and a caveat says why. Caveats are combined without duplicates.
+func mergeMethodology(ms []*types.Methodology) *types.Methodology {
+	var out *types.Methodology
+	for _, m := range ms {
+		if m != nil {
+			out = &types.Methodology{}
+			break
+		}
+	}
+	if out == nil {
+		return nil
+	}
+	first := ms[0]
+	if first == nil {
+		first = &types.Methodology{}
+	}
+	out.SamplingRate, out.VolumeRounding, out.NoiseEpsilon = first.SamplingRate, first.VolumeRounding, first.NoiseEpsilon
+	mixed := false
+	for _, m := range ms {
+		if m == nil {
+			m = &types.Methodology{}
+		}
+		out.SuppressionThreshold = max(out.SuppressionThreshold, m.SuppressionThreshold)
+		out.CollectionLag = max(out.CollectionLag, m.CollectionLag)
+		if m.SamplingRate != out.SamplingRate || m.VolumeRounding != out.VolumeRounding || m.NoiseEpsilon != out.NoiseEpsilon {
+			mixed = true
+		}
+		for _, c := range m.Caveats {
+			if !slices.Contains(out.Caveats, c) {
+				out.Caveats = append(out.Caveats, c)
+			}
+		}
+	}
+	if mixed {
+		out.SamplingRate, out.VolumeRounding, out.NoiseEpsilon = 0, 0, 0
+		out.Caveats = append(out.Caveats, mixedMethodologyCaveat)
+	}
+	return out
+}
+
+const mixedMethodologyCaveat = "Merged from sources with different sampling, rounding, or noise; " +
+	"those settings do not apply to the combined volumes."
@@ -0,0 +1,95 @@
+package seriesops
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/topic"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestMergeAliased(t *testing.T) {
+	am := topic.NewAliasMap(topic.Options{})
+	if err := am.Add("bsky", "#vote2024", "#election"); err != nil {
+		t.Fatal(err)
+	}
+	if err := am.Add("bsky", "#Vote2024", "#turnout"); err == nil {
+		t.Fatal("expected ambiguity")
+	}
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mk := func(name string, gen time.Duration, vols ...int) *types.Series {
+		s := &types.Series{Topic: name, GeneratedAt: t0.Add(gen), Interval: types.IntervalMinute}
+		for i, v := range vols {
+			s.Points = append(s.Points, types.Point{TS: t0.Add(time.Duration(i) * time.Minute), Volume: v, ReshareRatio: types.Probability(float64(i) / 2)})
+		}
+		return s
+	}
+	out, err := MergeAliased(am,
+		Sourced{"bsky", mk("#Vote2024", time.Hour, 10, 10)},
+		Sourced{"mastodon", mk("#election", 2*time.Hour, 30)},
+		Sourced{"mastodon", mk("#vote2024", time.Hour, 5)},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 2 || out[0].Topic != "#election" || out[1].Topic != "#vote2024" {
+		t.Fatalf("topics = %+v", out)
+	}
+	e := out[0]
+	if !e.GeneratedAt.Equal(t0.Add(2*time.Hour)) || len(e.Points) != 2 || e.Points[0].Volume != 40 || e.Points[1].Volume != 10 {
+		t.Fatalf("merged = %+v", e)
+	}
+	if e.Points[1].ReshareRatio != 0.5 {
+		t.Errorf("reshare = %v", e.Points[1].ReshareRatio)
+	}
+
+	hourly := mk("#election", 0, 1)
+	hourly.Interval = types.IntervalHour
+	if _, err := MergeAliased(am, Sourced{"bsky", mk("#vote2024", 0, 1)}, Sourced{"x", hourly}); err == nil {
+		t.Error("expected interval mismatch")
+	}
+
+	other := mk("#election", 0, 1)
+	other.Jurisdiction = "US-CA"
+	if _, err := MergeAliased(am, Sourced{"bsky", mk("#vote2024", 0, 1)}, Sourced{"x", other}); err == nil {
+		t.Error("expected jurisdiction mismatch")
+	}
+	other.Jurisdiction, other.Tenant = "", "us.fec"
+	if _, err := MergeAliased(am, Sourced{"bsky", mk("#vote2024", 0, 1)}, Sourced{"x", other}); err == nil {
+		t.Error("expected tenant mismatch")
+	}
+}
+
+func TestMergeAliasedMethodology(t *testing.T) {
+	am := topic.NewAliasMap(topic.Options{})
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mk := func(m *types.Methodology) Sourced {
+		return Sourced{"x", &types.Series{Topic: "#vote", GeneratedAt: t0, Interval: types.IntervalMinute,
+			Points: []types.Point{{TS: t0, Volume: 20}}, Methodology: m}}
+	}
+	a := &types.Methodology{SamplingRate: 1, SuppressionThreshold: 5, Caveats: []string{"a"}}
+	b := &types.Methodology{SamplingRate: 1, SuppressionThreshold: 10, CollectionLag: types.ISODuration(time.Hour), Caveats: []string{"a", "b"}}
+
+	out, err := MergeAliased(am, mk(a), mk(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &types.Methodology{SamplingRate: 1, SuppressionThreshold: 10, CollectionLag: types.ISODuration(time.Hour), Caveats: []string{"a", "b"}}
+	if got := out[0].Methodology; !reflect.DeepEqual(got, want) {
+		t.Errorf("Methodology = %+v, want %+v", got, want)
+	}
+
+	c := &types.Methodology{SamplingRate: 0.5, SuppressionThreshold: 3}
+	out, _ = MergeAliased(am, mk(a), mk(c), mk(nil))
+	if m := out[0].Methodology; m.SuppressionThreshold != 5 || m.SamplingRate != 0 || m.Caveats[len(m.Caveats)-1] != mixedMethodologyCaveat {
+		t.Errorf("mixed Methodology = %+v", m)
+	}
+	if a.Caveats[0] != "a" || len(a.Caveats) != 1 {
+		t.Errorf("input modified: %+v", a)
+	}
+
+	if out, _ := MergeAliased(am, mk(nil), mk(nil)); out[0].Methodology != nil {
+		t.Errorf("Methodology without inputs = %+v", out[0].Methodology)
+	}
+}
@@ -0,0 +1,41 @@
+package seriesops
+
+import (
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// MarkBackfill sets Backfilled on every point whose TS is before cutoff and
+// returns how many points were newly marked. Points already marked are left
+// as-is; points at or after cutoff are never unmarked.
+func MarkBackfill(s *types.Series, cutoff time.Time) int {
+	if s == nil {
+		return 0
+	}
+	n := 0
+	for i := range s.Points {
+		p := &s.Points[i]
+		if !p.Backfilled && p.TS.Before(cutoff) {
+			p.Backfilled = true
+			n++
+		}
+	}
+	return n
+}
+
+// SplitBackfill returns the live and backfilled points of s, preserving order.
+// The returned slices share no backing array with s.Points.
+func SplitBackfill(s *types.Series) (live, backfilled []types.Point) {
+	if s == nil {
+		return nil, nil
+	}
+	for _, p := range s.Points {
+		if p.Backfilled {
+			backfilled = append(backfilled, p)
+		} else {
+			live = append(live, p)
+		}
+	}
+	return live, backfilled
+}
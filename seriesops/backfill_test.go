@@ -0,0 +1,90 @@
+package seriesops
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestBackfill(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(m int) time.Time { return t0.Add(time.Duration(m) * time.Minute) }
+	pt := func(m int, backfilled bool) types.Point {
+		return types.Point{TS: at(m), Volume: m, Backfilled: backfilled}
+	}
+	tests := []struct {
+		name     string
+		points   []types.Point
+		cutoff   time.Time
+		marked   int
+		live     []int // volumes of live points after marking, in order
+		backfill []int // volumes of backfilled points after marking, in order
+	}{
+		{name: "empty", cutoff: at(5)},
+		{
+			name:   "mixed",
+			points: []types.Point{pt(1, false), pt(2, true), pt(5, false), pt(6, true), pt(7, false)},
+			cutoff: at(5), marked: 1,
+			live: []int{5, 7}, backfill: []int{1, 2, 6},
+		},
+		{
+			name:   "all backfilled",
+			points: []types.Point{pt(1, true), pt(2, true), pt(8, true)},
+			cutoff: at(5), marked: 0,
+			backfill: []int{1, 2, 8},
+		},
+		{
+			name:   "all before cutoff",
+			points: []types.Point{pt(1, false), pt(2, false)},
+			cutoff: at(5), marked: 2,
+			backfill: []int{1, 2},
+		},
+		{
+			name:   "none before cutoff",
+			points: []types.Point{pt(5, false), pt(6, false)},
+			cutoff: at(5), marked: 0,
+			live: []int{5, 6},
+		},
+	}
+	volumes := func(ps []types.Point) []int {
+		var v []int
+		for _, p := range ps {
+			v = append(v, p.Volume)
+		}
+		return v
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &types.Series{Topic: "#vote", Interval: types.IntervalMinute, Points: tt.points}
+			if got := MarkBackfill(s, tt.cutoff); got != tt.marked {
+				t.Errorf("MarkBackfill = %d, want %d", got, tt.marked)
+			}
+			if got := MarkBackfill(s, tt.cutoff); got != 0 {
+				t.Errorf("second MarkBackfill = %d, want 0", got)
+			}
+			live, backfilled := SplitBackfill(s)
+			if !reflect.DeepEqual(volumes(live), tt.live) || !reflect.DeepEqual(volumes(backfilled), tt.backfill) {
+				t.Errorf("SplitBackfill = %v, %v; want %v, %v", volumes(live), volumes(backfilled), tt.live, tt.backfill)
+			}
+			if len(live) > 0 {
+				live[0].Volume = -1
+			}
+			if len(backfilled) > 0 {
+				backfilled[0].Volume = -1
+			}
+			for _, p := range s.Points {
+				if p.Volume < 0 {
+					t.Fatal("SplitBackfill result shares storage with s.Points")
+				}
+			}
+		})
+	}
+	if MarkBackfill(nil, t0) != 0 {
+		t.Error("MarkBackfill(nil) != 0")
+	}
+	if live, backfilled := SplitBackfill(nil); live != nil || backfilled != nil {
+		t.Error("SplitBackfill(nil) returned points")
+	}
+}
@@ -0,0 +1,31 @@
+package seriesops
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// Composite builds a CompositeSeries from s and its rollups to each of
+// intervals. s is the finest level and is copied, not normalized; the
+// intervals may be given in any order but must each be a multiple of
+// s.Interval.
+func Composite(s *types.Series, intervals ...types.Interval) (*types.CompositeSeries, error) {
+	c := &types.CompositeSeries{Topic: s.Topic, GeneratedAt: s.GeneratedAt, Levels: []types.Series{*s}}
+	ivs := append([]types.Interval(nil), intervals...)
+	sort.SliceStable(ivs, func(i, j int) bool { return ivs[i].Duration() < ivs[j].Duration() })
+	prev := s.Interval.Duration()
+	for _, iv := range ivs {
+		if iv.Duration() == prev {
+			return nil, fmt.Errorf("seriesops: composite: duplicate interval %q", iv)
+		}
+		prev = iv.Duration()
+		r, err := Rollup(s, iv)
+		if err != nil {
+			return nil, err
+		}
+		c.Levels = append(c.Levels, *r)
+	}
+	return c, nil
+}
@@ -0,0 +1,63 @@
+package seriesops
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+	"github.com/civic-interconnect/civic-transparency-go-types/validate"
+)
+
+func TestCompositeValidates(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 22, 0, 0, 0, time.UTC)
+	s := &types.Series{Topic: "#vote", GeneratedAt: t0.Add(4 * time.Hour), Interval: types.IntervalMinute}
+	for m := 0; m < 180; m += 7 {
+		s.Points = append(s.Points, types.Point{TS: t0.Add(time.Duration(m) * time.Minute), Volume: m})
+	}
+	c, err := Composite(s, types.IntervalDay, types.IntervalHour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c.Levels) != 3 || c.Level("PT60M") != &c.Levels[1] || len(c.Levels[2].Points) != 2 {
+		t.Fatalf("levels = %d, hour level %v", len(c.Levels), c.Level("PT60M"))
+	}
+	if got := c.Resolve(90 * time.Minute); got != &c.Levels[1] {
+		t.Errorf("Resolve(90m) = %v", got.Interval)
+	}
+	if got := c.Resolve(time.Second); got != &c.Levels[0] {
+		t.Errorf("Resolve(1s) = %v", got.Interval)
+	}
+	if err := validate.ValidateCompositeSeries(c, validate.Options{}); err != nil {
+		t.Fatalf("valid composite: %v", err)
+	}
+
+	c.Levels[1].Points[0].Volume++
+	c.Levels[2].Points = c.Levels[2].Points[:1]
+	err = validate.ValidateCompositeSeries(c, validate.Options{})
+	var me *validate.MultiError
+	if !errors.As(err, &me) {
+		t.Fatalf("err = %v", err)
+	}
+	var fields []string
+	for _, e := range me.Errors() {
+		fe := e.(*validate.FieldError)
+		if fe.Code != validate.CodeCompositeInconsistent {
+			t.Errorf("code = %s", fe.Code)
+		}
+		fields = append(fields, fe.Field)
+	}
+	want := []string{"levels[1].points[0].volume", "levels[2].points[0].volume", "levels[2].points"}
+	if len(fields) != len(want) {
+		t.Fatalf("fields = %q, want %q", fields, want)
+	}
+	for i := range want {
+		if fields[i] != want[i] {
+			t.Errorf("fields = %q, want %q", fields, want)
+		}
+	}
+
+	if _, err := Composite(s, types.IntervalHour, "PT60M"); err == nil {
+		t.Error("duplicate interval accepted")
+	}
+}
@@ -0,0 +1,119 @@
+package seriesops
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// Patch is a delta between two published versions of a Series. It carries
+// only points that are new or changed, plus the ts of points that were
+// dropped, so minute-by-minute republication need not resend the whole day.
+type Patch struct {
+	Topic           string             `json:"topic"`
+	BaseGeneratedAt time.Time          `json:"base_generated_at"` // generated_at of the version the delta applies to
+	GeneratedAt     time.Time          `json:"generated_at"`      // generated_at of the resulting version
+	Upserts         []types.Point      `json:"upserts,omitempty"` // added or changed points, in ts order
+	Removed         []time.Time        `json:"removed,omitempty"` // ts of points no longer present
+	Annotations     []types.Annotation `json:"annotations,omitempty"`
+	Header          *PatchHeader       `json:"header,omitempty"` // set when any series-level field changed
+}
+
+// PatchHeader holds the series-level fields of the version a Patch
+// produces. It is carried whole, so a field cleared in the new version is
+// cleared by ApplyDelta.
+type PatchHeader struct {
+	Interval     types.Interval     `json:"interval"`
+	Retraction   *types.Retraction  `json:"retraction,omitempty"`
+	Extensions   types.Extensions   `json:"extensions,omitempty"`
+	Tenant       string             `json:"tenant,omitempty"`
+	Jurisdiction string             `json:"jurisdiction,omitempty"`
+	Methodology  *types.Methodology `json:"methodology,omitempty"`
+}
+
+func headerOf(s *types.Series) PatchHeader {
+	return PatchHeader{
+		Interval:     s.Interval,
+		Retraction:   s.Retraction,
+		Extensions:   s.Extensions,
+		Tenant:       s.Tenant,
+		Jurisdiction: s.Jurisdiction,
+		Methodology:  s.Methodology,
+	}
+}
+
+// ErrDeltaBase is returned by ApplyDelta when the patch was computed against
+// a different version than the one given.
+var ErrDeltaBase = errors.New("seriesops: delta does not apply to this series version")
+
+// Delta computes the patch that turns old into new. Both must share a topic.
+// Annotations, and the series-level fields in PatchHeader, are carried
+// whole when they differ.
+func Delta(old, new *types.Series) (*Patch, error) {
+	if old.Topic != new.Topic {
+		return nil, fmt.Errorf("seriesops: delta across topics %q and %q", old.Topic, new.Topic)
+	}
+	d := &Patch{Topic: new.Topic, BaseGeneratedAt: old.GeneratedAt, GeneratedAt: new.GeneratedAt}
+
+	prev := make(map[time.Time]*types.Point, len(old.Points))
+	for i := range old.Points {
+		prev[old.Points[i].TS.UTC()] = &old.Points[i]
+	}
+	for _, p := range new.Points {
+		k := p.TS.UTC()
+		if o, ok := prev[k]; !ok || !reflect.DeepEqual(*o, p) {
+			d.Upserts = append(d.Upserts, p)
+		}
+		delete(prev, k)
+	}
+	for _, p := range old.Points {
+		if _, gone := prev[p.TS.UTC()]; gone {
+			d.Removed = append(d.Removed, p.TS)
+		}
+	}
+	SortByTime(d.Upserts)
+	if !reflect.DeepEqual(old.Annotations, new.Annotations) {
+		d.Annotations = new.Annotations
+		if d.Annotations == nil {
+			d.Annotations = []types.Annotation{}
+		}
+	}
+	if h := headerOf(new); !reflect.DeepEqual(headerOf(old), h) {
+		d.Header = &h
+	}
+	return d, nil
+}
+
+// ApplyDelta updates s in place to the version described by p. Points end
+// up sorted by ts.
+func ApplyDelta(s *types.Series, p *Patch) error {
+	if s.Topic != p.Topic || !s.GeneratedAt.Equal(p.BaseGeneratedAt) {
+		return ErrDeltaBase
+	}
+	removed := make(map[time.Time]bool, len(p.Removed))
+	for _, t := range p.Removed {
+		removed[t.UTC()] = true
+	}
+	kept := s.Points[:0]
+	for _, pt := range s.Points {
+		if !removed[pt.TS.UTC()] {
+			kept = append(kept, pt)
+		}
+	}
+	s.Points = Merge(kept, p.Upserts)
+	if p.Annotations != nil {
+		s.Annotations = p.Annotations
+		if len(s.Annotations) == 0 {
+			s.Annotations = nil
+		}
+	}
+	if h := p.Header; h != nil {
+		s.Interval, s.Retraction, s.Extensions = h.Interval, h.Retraction, h.Extensions
+		s.Tenant, s.Jurisdiction, s.Methodology = h.Tenant, h.Jurisdiction, h.Methodology
+	}
+	s.GeneratedAt = p.GeneratedAt
+	return nil
+}
@@ -0,0 +1,81 @@
+package seriesops
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestDeltaRoundTrip(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(m int) time.Time { return t0.Add(time.Duration(m) * time.Minute) }
+	old := &types.Series{Topic: "#vote", GeneratedAt: at(3), Interval: types.IntervalMinute,
+		Points: []types.Point{{TS: at(0), Volume: 1}, {TS: at(1), Volume: 2}, {TS: at(2), Volume: 3}}}
+	new := &types.Series{Topic: "#vote", GeneratedAt: at(4), Interval: types.IntervalMinute,
+		Points: []types.Point{{TS: at(0), Volume: 1}, {TS: at(2), Volume: 30}, {TS: at(3), Volume: 4}}}
+
+	d, err := Delta(old, new)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Upserts) != 2 || len(d.Removed) != 1 || !d.Removed[0].Equal(at(1)) {
+		t.Fatalf("delta = %+v", d)
+	}
+
+	b, _ := json.Marshal(d)
+	var wire Patch
+	if err := json.Unmarshal(b, &wire); err != nil {
+		t.Fatal(err)
+	}
+	if err := ApplyDelta(old, &wire); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(old, new) {
+		t.Errorf("applied = %+v\nwant %+v", old, new)
+	}
+	if err := ApplyDelta(old, &wire); err != ErrDeltaBase {
+		t.Errorf("reapply err = %v, want ErrDeltaBase", err)
+	}
+}
+
+func TestDeltaSeriesFields(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	old := &types.Series{Topic: "#vote", GeneratedAt: t0.Add(time.Minute), Interval: types.IntervalMinute,
+		Points:      []types.Point{{TS: t0, Volume: 1}},
+		Tenant:      "us.fec",
+		Methodology: &types.Methodology{SuppressionThreshold: 5},
+		Extensions:  types.Extensions{"x-example-region": json.RawMessage(`"emea"`)},
+	}
+	new := &types.Series{Topic: "#vote", GeneratedAt: t0.Add(2 * time.Minute), Interval: types.IntervalMinute,
+		Points:       []types.Point{{TS: t0, Volume: 1}},
+		Jurisdiction: "US",
+		Methodology:  &types.Methodology{SuppressionThreshold: 10},
+		Retraction:   &types.Retraction{Topic: "#vote", Reason: types.RetractionDataError, EffectiveAt: t0},
+	}
+	d, err := Delta(old, new)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Header == nil || len(d.Upserts) != 0 {
+		t.Fatalf("delta = %+v", d)
+	}
+	b, _ := json.Marshal(d)
+	var wire Patch
+	if err := json.Unmarshal(b, &wire); err != nil {
+		t.Fatal(err)
+	}
+	if err := ApplyDelta(old, &wire); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(old, new) {
+		t.Errorf("applied = %+v\nwant %+v", old, new)
+	}
+
+	same, _ := Delta(new, new)
+	if same.Header != nil {
+		t.Errorf("unchanged header carried: %+v", same.Header)
+	}
+}
@@ -0,0 +1,3 @@
+// seriesops/doc.go
+// Package seriesops provides in-place operations over types.Series.
+package seriesops
@@ -0,0 +1,47 @@
+package seriesops
+
+import (
+	"sort"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// SortByTime sorts pts by PointTime, keeping the order of equal times.
+func SortByTime[P types.PointLike](pts []P) {
+	sort.SliceStable(pts, func(i, j int) bool { return pts[i].PointTime().Before(pts[j].PointTime()) })
+}
+
+// Window returns the points with from <= time < to, in their existing order.
+// A zero from or to leaves that side open. The result is a new slice.
+func Window[P types.PointLike](pts []P, from, to time.Time) []P {
+	var out []P
+	for _, p := range pts {
+		t := p.PointTime()
+		if (!from.IsZero() && t.Before(from)) || (!to.IsZero() && !t.Before(to)) {
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// Merge returns the union of a and b sorted by time. When both contain a
+// point for the same time, the one from b wins.
+func Merge[P types.PointLike](a, b []P) []P {
+	byTime := make(map[time.Time]int, len(a)+len(b))
+	out := make([]P, 0, len(a)+len(b))
+	for _, src := range [][]P{a, b} {
+		for _, p := range src {
+			k := p.PointTime().UTC()
+			if i, ok := byTime[k]; ok {
+				out[i] = p
+				continue
+			}
+			byTime[k] = len(out)
+			out = append(out, p)
+		}
+	}
+	SortByTime(out)
+	return out
+}
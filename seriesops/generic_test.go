@@ -0,0 +1,46 @@
+package seriesops
+
+import (
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+	"github.com/civic-interconnect/civic-transparency-go-types/validate"
+)
+
+// adPoint is an extension point type, as a downstream package would define.
+type adPoint struct {
+	TS        time.Time `json:"ts"`
+	AdSpend   float64   `json:"ad_spend"`
+	Sponsored int       `json:"sponsored"`
+}
+
+func (p adPoint) PointTime() time.Time { return p.TS }
+
+func TestGenericSeries(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(m int) time.Time { return t0.Add(time.Duration(m) * time.Minute) }
+
+	old := []adPoint{{TS: at(0), AdSpend: 1}, {TS: at(1), AdSpend: 2}}
+	upd := []adPoint{{TS: at(2), AdSpend: 5}, {TS: at(1), AdSpend: 3}}
+	merged := Merge(old, upd)
+	if len(merged) != 3 || merged[1].AdSpend != 3 || !merged[2].TS.Equal(at(2)) {
+		t.Fatalf("Merge = %+v", merged)
+	}
+	if w := Window(merged, at(1), at(2)); len(w) != 1 || w[0].AdSpend != 3 {
+		t.Errorf("Window = %+v", w)
+	}
+
+	s := &types.SeriesOf[adPoint]{Topic: "#ads", GeneratedAt: at(5), Interval: types.IntervalMinute,
+		Points: append(merged, adPoint{TS: at(0), Sponsored: -1})}
+	err := validate.ValidateSeriesOf(s, func(i int, p adPoint) error {
+		if p.Sponsored < 0 {
+			return &validate.FieldError{Field: "sponsored", Msg: "sponsored must be ≥0"}
+		}
+		return nil
+	})
+	want := "points[3].ts duplicates an earlier point; sponsored must be ≥0"
+	if err == nil || err.Error() != want {
+		t.Errorf("ValidateSeriesOf = %v, want %q", err, want)
+	}
+}
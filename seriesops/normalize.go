@@ -0,0 +1,75 @@
+package seriesops
+
+import (
+	"sort"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/topic"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// NormalizeOptions tunes NormalizeWith. The zero value is the default.
+type NormalizeOptions struct {
+	// Resolution is the bucket width timestamps are truncated to before
+	// deduplication. Zero uses the series interval, or one minute if the
+	// interval is not a fixed duration.
+	Resolution time.Duration
+	// KeepTrailingEmpty keeps zero-volume points at the end of the series.
+	KeepTrailingEmpty bool
+	// KeepTopic leaves the topic as published instead of replacing it with
+	// its comparison key.
+	KeepTopic bool
+	// Topic controls topic normalization (see topic.Key).
+	Topic topic.Options
+}
+
+// Normalize is NormalizeWith using the default options.
+func Normalize(s *types.Series) int {
+	return NormalizeWith(s, NormalizeOptions{})
+}
+
+// NormalizeWith puts s in canonical form in place, as a single step before
+// hashing, signing, or diffing: timestamps are converted to UTC and
+// truncated to the resolution, points are sorted by time, points sharing a
+// timestamp are reduced to the last one in input order, trailing
+// zero-volume points are dropped, and the topic is replaced by its
+// topic.Key. It returns the number of points removed. Normalizing twice
+// changes nothing.
+func NormalizeWith(s *types.Series, opts NormalizeOptions) int {
+	res := opts.Resolution
+	if res <= 0 {
+		res = s.Interval.Duration()
+	}
+	if res <= 0 {
+		res = time.Minute
+	}
+	if !opts.KeepTopic {
+		s.Topic = topic.Key(s.Topic, opts.Topic)
+	}
+	if !s.GeneratedAt.IsZero() {
+		s.GeneratedAt = s.GeneratedAt.UTC()
+	}
+
+	n := len(s.Points)
+	for i := range s.Points {
+		s.Points[i].TS = s.Points[i].TS.UTC().Truncate(res)
+	}
+	// A stable sort keeps input order among equal times, so the last of
+	// each run is the last in input order.
+	sort.SliceStable(s.Points, func(i, j int) bool { return s.Points[i].TS.Before(s.Points[j].TS) })
+	out := s.Points[:0]
+	for i, p := range s.Points {
+		if i+1 < len(s.Points) && s.Points[i+1].TS.Equal(p.TS) {
+			continue
+		}
+		out = append(out, p)
+	}
+	if !opts.KeepTrailingEmpty {
+		for len(out) > 0 && out[len(out)-1].Volume == 0 {
+			out = out[:len(out)-1]
+		}
+	}
+	clear(s.Points[len(out):])
+	s.Points = out
+	return n - len(out)
+}
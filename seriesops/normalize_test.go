@@ -0,0 +1,39 @@
+package seriesops
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestNormalize(t *testing.T) {
+	est := time.FixedZone("EST", -5*3600)
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &types.Series{
+		Topic:       "  #Élection ",
+		GeneratedAt: t0.Add(time.Hour).In(est),
+		Interval:    types.IntervalMinute,
+		Points: []types.Point{
+			{TS: t0.Add(2 * time.Minute), Volume: 7},
+			{TS: t0.Add(30 * time.Second), Volume: 1}, // same minute as the next, superseded
+			{TS: t0.In(est), Volume: 2},
+			{TS: t0.Add(3 * time.Minute)}, // trailing empty
+			{TS: t0.Add(4 * time.Minute)}, // trailing empty
+		},
+	}
+	if removed := Normalize(s); removed != 3 {
+		t.Fatalf("Normalize removed %d points, want 3", removed)
+	}
+	want := []types.Point{{TS: t0, Volume: 2}, {TS: t0.Add(2 * time.Minute), Volume: 7}}
+	if s.Topic != "#élection" || s.GeneratedAt.Location() != time.UTC || !reflect.DeepEqual(s.Points, want) {
+		t.Fatalf("Normalize = %q %v %+v", s.Topic, s.GeneratedAt, s.Points)
+	}
+
+	before := *s
+	before.Points = append([]types.Point(nil), s.Points...)
+	if removed := Normalize(s); removed != 0 || !reflect.DeepEqual(*s, before) {
+		t.Fatalf("second Normalize changed the series: %+v", s)
+	}
+}
@@ -0,0 +1,39 @@
+package seriesops
+
+import "github.com/civic-interconnect/civic-transparency-go-types/types"
+
+// RetractMode selects how ApplyRetraction treats matching series.
+type RetractMode int
+
+const (
+	// Tombstone keeps the series metadata, drops its points and annotations,
+	// and records the Retraction on the series so mirrors can propagate it.
+	Tombstone RetractMode = iota
+	// Remove deletes matching series entirely.
+	Remove
+)
+
+// ApplyRetraction applies r to every matching series in ss and returns the
+// resulting slice and the number of series affected. With Remove the
+// returned slice reuses ss's backing array. Callers are responsible for
+// checking r's signature and effective time before applying it.
+func ApplyRetraction(ss []types.Series, r *types.Retraction, mode RetractMode) ([]types.Series, int) {
+	n := 0
+	out := ss[:0]
+	for _, s := range ss {
+		if !r.Matches(&s) {
+			out = append(out, s)
+			continue
+		}
+		n++
+		if mode == Remove {
+			continue
+		}
+		rc := *r
+		s.Points = nil
+		s.Annotations = nil
+		s.Retraction = &rc
+		out = append(out, s)
+	}
+	return out, n
+}
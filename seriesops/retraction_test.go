@@ -0,0 +1,51 @@
+package seriesops
+
+import (
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestApplyRetraction(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	series := func() []types.Series {
+		return []types.Series{
+			{Topic: "#vote", GeneratedAt: t0, Points: []types.Point{{TS: t0, Volume: 1}},
+				Annotations: []types.Annotation{{Start: t0, Kind: types.AnnotationElection}}},
+			{Topic: "#vote", GeneratedAt: t0.Add(time.Hour), Points: []types.Point{{TS: t0, Volume: 2}}},
+			{Topic: "#ballot", GeneratedAt: t0, Points: []types.Point{{TS: t0, Volume: 3}}},
+		}
+	}
+	all := &types.Retraction{Topic: "#vote", Reason: types.RetractionDataError, EffectiveAt: t0}
+	one := &types.Retraction{Topic: "#vote", GeneratedAt: t0, Reason: types.RetractionDataError, EffectiveAt: t0}
+
+	for _, tc := range []struct {
+		name    string
+		r       *types.Retraction
+		mode    RetractMode
+		n       int
+		topics  []string
+		retired []bool
+	}{
+		{"tombstone all versions", all, Tombstone, 2, []string{"#vote", "#vote", "#ballot"}, []bool{true, true, false}},
+		{"tombstone one version", one, Tombstone, 1, []string{"#vote", "#vote", "#ballot"}, []bool{true, false, false}},
+		{"remove all versions", all, Remove, 2, []string{"#ballot"}, []bool{false}},
+		{"no match", &types.Retraction{Topic: "#other"}, Remove, 0, []string{"#vote", "#vote", "#ballot"}, []bool{false, false, false}},
+	} {
+		out, n := ApplyRetraction(series(), tc.r, tc.mode)
+		if n != tc.n || len(out) != len(tc.topics) {
+			t.Errorf("%s: n = %d, %d series; want %d, %d", tc.name, n, len(out), tc.n, len(tc.topics))
+			continue
+		}
+		for i, s := range out {
+			retired := s.Retraction != nil
+			if s.Topic != tc.topics[i] || retired != tc.retired[i] {
+				t.Errorf("%s: out[%d] = %s retracted=%v", tc.name, i, s.Topic, retired)
+			}
+			if retired && (s.Points != nil || s.Annotations != nil || s.Retraction == tc.r) {
+				t.Errorf("%s: tombstone %d keeps data or shares the retraction", tc.name, i)
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package seriesops
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// RevisionLog is the publication history of one series: the first
+// published version followed by the patches (see Delta) that produced each
+// later version, in order.
+type RevisionLog struct {
+	Base    *types.Series `json:"base"`
+	Patches []Patch       `json:"patches,omitempty"`
+}
+
+// NewRevisionLog starts a log at base. base is copied.
+func NewRevisionLog(base *types.Series) *RevisionLog {
+	return &RevisionLog{Base: cloneSeries(base)}
+}
+
+// Head returns the generated_at of the latest version in l.
+func (l *RevisionLog) Head() time.Time {
+	if n := len(l.Patches); n > 0 {
+		return l.Patches[n-1].GeneratedAt
+	}
+	return l.Base.GeneratedAt
+}
+
+// Append adds p as the next revision. p must apply to the current head and
+// be generated after it.
+func (l *RevisionLog) Append(p Patch) error {
+	head := l.Head()
+	if p.Topic != l.Base.Topic || !p.BaseGeneratedAt.Equal(head) {
+		return ErrDeltaBase
+	}
+	if !p.GeneratedAt.After(head) {
+		return fmt.Errorf("seriesops: revision at %s is not after %s", p.GeneratedAt.Format(time.RFC3339), head.Format(time.RFC3339))
+	}
+	l.Patches = append(l.Patches, p)
+	return nil
+}
+
+// Versions returns the generated_at of every version in l, oldest first.
+func (l *RevisionLog) Versions() []time.Time {
+	out := []time.Time{l.Base.GeneratedAt}
+	for _, p := range l.Patches {
+		out = append(out, p.GeneratedAt)
+	}
+	return out
+}
+
+// AsOf reconstructs the series as it was published at time t: the latest
+// version in log generated at or before t, including its series-level
+// fields. It returns nil if t precedes the base version. The result is a
+// deep copy sharing no memory with log, which is not modified. Replay
+// stops at the first patch that does not chain onto its predecessor, so a
+// log assembled without Append yields the last consistent version.
+func AsOf(log *RevisionLog, t time.Time) *types.Series {
+	if log == nil || log.Base == nil || t.Before(log.Base.GeneratedAt) {
+		return nil
+	}
+	s := *log.Base
+	s.Points = append([]types.Point(nil), log.Base.Points...) // ApplyDelta filters in place
+	for i := range log.Patches {
+		p := &log.Patches[i]
+		if p.GeneratedAt.After(t) || ApplyDelta(&s, p) != nil {
+			break
+		}
+	}
+	return cloneSeries(&s)
+}
+
+// cloneSeries copies s deeply: the copy shares no maps, slices, or
+// pointers with s.
+func cloneSeries(s *types.Series) *types.Series {
+	c := *s
+	if s.Points != nil {
+		c.Points = make([]types.Point, len(s.Points))
+		for i, p := range s.Points {
+			p.AcctAgeMix = maps.Clone(p.AcctAgeMix)
+			p.AutomationMix = maps.Clone(p.AutomationMix)
+			p.ClientMix = maps.Clone(p.ClientMix)
+			p.AcctTypeShares = maps.Clone(p.AcctTypeShares)
+			p.PostKindMix = maps.Clone(p.PostKindMix)
+			c.Points[i] = p
+		}
+	}
+	c.Annotations = slices.Clone(s.Annotations)
+	if s.Retraction != nil {
+		r := *s.Retraction
+		c.Retraction = &r
+	}
+	if s.Methodology != nil {
+		m := *s.Methodology
+		m.Caveats = slices.Clone(m.Caveats)
+		c.Methodology = &m
+	}
+	if s.Extensions != nil {
+		c.Extensions = make(types.Extensions, len(s.Extensions))
+		for k, v := range s.Extensions {
+			c.Extensions[k] = slices.Clone(v)
+		}
+	}
+	return &c
+}
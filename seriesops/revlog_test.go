@@ -0,0 +1,102 @@
+package seriesops
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestAsOf(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	version := func(gen time.Duration, vols ...int) *types.Series {
+		s := &types.Series{Topic: "#vote", GeneratedAt: t0.Add(gen), Interval: types.IntervalMinute}
+		for i, v := range vols {
+			s.Points = append(s.Points, types.Point{TS: t0.Add(time.Duration(i) * time.Minute), Volume: v})
+		}
+		return s
+	}
+	vs := []*types.Series{
+		version(5*time.Minute, 1, 2),
+		version(10*time.Minute, 1, 3, 4),    // correction of minute 1
+		version(15*time.Minute, 1, 3, 4, 5), // new minute
+	}
+	log := NewRevisionLog(vs[0])
+	for i := 1; i < len(vs); i++ {
+		p, err := Delta(vs[i-1], vs[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := log.Append(*p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := log.Append(Patch{Topic: "#vote", BaseGeneratedAt: vs[0].GeneratedAt, GeneratedAt: t0.Add(time.Hour)}); err != ErrDeltaBase {
+		t.Fatalf("stale patch: err = %v", err)
+	}
+
+	if s := AsOf(log, t0); s != nil {
+		t.Errorf("before base: %+v", s)
+	}
+	for _, tc := range []struct {
+		at   time.Duration
+		want *types.Series
+	}{
+		{5 * time.Minute, vs[0]},
+		{9 * time.Minute, vs[0]},
+		{10 * time.Minute, vs[1]},
+		{time.Hour, vs[2]},
+	} {
+		if got := AsOf(log, t0.Add(tc.at)); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("AsOf(+%s) = %+v, want %+v", tc.at, got, tc.want)
+		}
+	}
+	// Replaying must not disturb the log.
+	if !reflect.DeepEqual(AsOf(log, t0.Add(5*time.Minute)), vs[0]) {
+		t.Error("log modified by replay")
+	}
+}
+
+func TestAsOfSeriesFieldsAndCopy(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	v0 := &types.Series{Topic: "#vote", GeneratedAt: t0.Add(5 * time.Minute), Interval: types.IntervalMinute,
+		Points:      []types.Point{{TS: t0, Volume: 10, AcctTypeShares: map[types.AcctType]types.Probability{types.AcctTypePerson: 1}}},
+		Methodology: &types.Methodology{SuppressionThreshold: 5, Caveats: []string{"sampled"}},
+	}
+	v1 := &types.Series{Topic: "#vote", GeneratedAt: t0.Add(10 * time.Minute), Interval: types.IntervalMinute,
+		Points:      []types.Point{{TS: t0, Volume: 10, AcctTypeShares: map[types.AcctType]types.Probability{types.AcctTypeOrg: 1}}},
+		Tenant:      "us.fec",
+		Methodology: &types.Methodology{SuppressionThreshold: 10},
+		Retraction:  &types.Retraction{Topic: "#vote", Reason: types.RetractionDataError, EffectiveAt: t0},
+	}
+	log := NewRevisionLog(v0)
+	p, err := Delta(v0, v1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := log.Append(*p); err != nil {
+		t.Fatal(err)
+	}
+	if got := AsOf(log, t0.Add(5*time.Minute)); !reflect.DeepEqual(got, v0) {
+		t.Errorf("AsOf(v0) = %+v, want %+v", got, v0)
+	}
+	got := AsOf(log, t0.Add(time.Hour))
+	if !reflect.DeepEqual(got, v1) {
+		t.Fatalf("AsOf(v1) = %+v, want %+v", got, v1)
+	}
+
+	// Mutating results must not reach the log.
+	got.Points[0].AcctTypeShares[types.AcctTypeOrg] = 0
+	got.Methodology.SuppressionThreshold = 0
+	got.Retraction.Reason = ""
+	old := AsOf(log, t0.Add(5*time.Minute))
+	old.Points[0].AcctTypeShares[types.AcctTypePerson] = 0
+	old.Methodology.Caveats[0] = ""
+	if again := AsOf(log, t0.Add(time.Hour)); !reflect.DeepEqual(again, v1) {
+		t.Errorf("log changed through AsOf(v1) result: %+v", again)
+	}
+	if again := AsOf(log, t0.Add(5*time.Minute)); !reflect.DeepEqual(again, v0) {
+		t.Errorf("log changed through AsOf(v0) result: %+v", again)
+	}
+}
@@ -0,0 +1,127 @@
+package seriesops
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/ratio"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// Combine folds points describing the same interval (e.g., shards of one
+// minute) into one. Volumes and duplication clusters add; ratios, coordination
+// indicators, and every breakdown including AcctTypeShares and PostKindMix are averaged
+// weighted by volume. TS is taken from the first point, and the result is
+// Backfilled if any input is. Combine of no points is the zero Point.
+// Averages use package ratio, so repeated rollups do not drift.
+func Combine(pts ...types.Point) types.Point {
+	var out types.Point
+	if len(pts) == 0 {
+		return out
+	}
+	out.TS = pts[0].TS
+	var reshare, recycled, burst, sync ratio.Mean
+	age := shareSum[string]{}
+	auto := shareSum[string]{}
+	client := shareSum[string]{}
+	acct := shareSum[types.AcctType]{}
+	kind := shareSum[types.PostKind]{}
+	for _, p := range pts {
+		w := float64(p.Volume)
+		out.Volume += p.Volume
+		out.CoordinationSignals.DuplicationClusters += p.CoordinationSignals.DuplicationClusters
+		out.Backfilled = out.Backfilled || p.Backfilled
+		reshare.Add(p.ReshareRatio, w)
+		recycled.Add(p.RecycledContentRate, w)
+		burst.Add(p.CoordinationSignals.BurstScore, w)
+		sync.Add(p.CoordinationSignals.SynchronyIndex, w)
+		age.add(p.AcctAgeMix, w)
+		auto.add(p.AutomationMix, w)
+		client.add(p.ClientMix, w)
+		acct.add(p.AcctTypeShares, w)
+		kind.add(p.PostKindMix, w)
+	}
+	if out.Volume > 0 {
+		out.ReshareRatio = reshare.Value()
+		out.RecycledContentRate = recycled.Value()
+		out.CoordinationSignals.BurstScore = burst.Value()
+		out.CoordinationSignals.SynchronyIndex = sync.Value()
+	}
+	out.AcctAgeMix = age.shares()
+	out.AutomationMix = auto.shares()
+	out.ClientMix = client.shares()
+	out.AcctTypeShares = acct.shares()
+	out.PostKindMix = kind.shares()
+	return out
+}
+
+// Rollup returns a copy of s re-aggregated to the coarser interval to,
+// combining the points in each bucket with Combine. Buckets are aligned to
+// the Unix epoch in UTC; each rolled-up point is stamped with its bucket
+// start. to must be a fixed-length interval no finer than s.Interval.
+func Rollup(s *types.Series, to types.Interval) (*types.Series, error) {
+	from, step := s.Interval.Duration(), to.Duration()
+	if from == 0 || step == 0 {
+		return nil, fmt.Errorf("seriesops: rollup: unsupported interval %q or %q", s.Interval, to)
+	}
+	if step%from != 0 {
+		return nil, fmt.Errorf("seriesops: rollup: %s is not a multiple of %s", to, s.Interval)
+	}
+	out := *s
+	out.Interval = to
+	out.Points = nil
+	buckets := map[time.Time][]types.Point{}
+	var keys []time.Time
+	for _, p := range s.Points {
+		k := p.TS.UTC().Truncate(step)
+		if _, ok := buckets[k]; !ok {
+			keys = append(keys, k)
+		}
+		buckets[k] = append(buckets[k], p)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Before(keys[j]) })
+	for _, k := range keys {
+		p := Combine(buckets[k]...)
+		p.TS = k
+		out.Points = append(out.Points, p)
+	}
+	return &out, nil
+}
+
+// shareSum accumulates volume-weighted breakdowns.
+type shareSum[K ~string] struct {
+	sum    map[K]*ratio.Sum
+	weight ratio.Sum
+}
+
+func (s *shareSum[K]) add(m map[K]types.Probability, w float64) {
+	if len(m) == 0 || w <= 0 {
+		return
+	}
+	if s.sum == nil {
+		s.sum = make(map[K]*ratio.Sum, len(m))
+	}
+	for k, v := range m {
+		if s.sum[k] == nil {
+			s.sum[k] = &ratio.Sum{}
+		}
+		s.sum[k].Add(w * float64(v))
+	}
+	s.weight.Add(w)
+}
+
+// shares normalizes by the weight of inputs that carried a breakdown, so
+// points without one do not dilute the others. It returns nil if none did.
+// Each share is clamped to 0–1 against residual rounding.
+func (s *shareSum[K]) shares() map[K]types.Probability {
+	w := s.weight.Value()
+	if w == 0 {
+		return nil
+	}
+	out := make(map[K]types.Probability, len(s.sum))
+	for k, v := range s.sum {
+		out[k] = min(max(types.Probability(v.Value()/w), 0), 1)
+	}
+	return out
+}
@@ -0,0 +1,52 @@
+package seriesops
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+	"github.com/civic-interconnect/civic-transparency-go-types/validate"
+)
+
+func TestRollupAcctTypeShares(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &types.Series{
+		Topic: "#vote", GeneratedAt: t0.Add(3 * time.Hour), Interval: types.IntervalMinute,
+		Points: []types.Point{
+			{TS: t0, Volume: 30, ReshareRatio: 0.5,
+				AcctTypeShares: map[types.AcctType]types.Probability{types.AcctTypePerson: 1}},
+			{TS: t0.Add(time.Minute), Volume: 10, ReshareRatio: 0.1,
+				AcctTypeShares: map[types.AcctType]types.Probability{types.AcctTypePerson: 0.5, types.AcctTypeMedia: 0.5}},
+			{TS: t0.Add(time.Hour), Volume: 5},
+		},
+	}
+	if err := validate.ValidateSeries(s); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := Rollup(s, types.IntervalHour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Points) != 2 || r.Points[0].Volume != 40 || !r.Points[1].TS.Equal(t0.Add(time.Hour)) {
+		t.Fatalf("Rollup = %+v", r.Points)
+	}
+	p := r.Points[0]
+	near := func(got types.Probability, want float64) bool { return math.Abs(float64(got)-want) < 1e-9 }
+	if !near(p.ReshareRatio, 0.4) || !near(p.AcctTypeShares[types.AcctTypePerson], 0.875) ||
+		!near(p.AcctTypeShares[types.AcctTypeMedia], 0.125) {
+		t.Fatalf("point 0 = %+v", p)
+	}
+	if r.Points[1].AcctTypeShares != nil {
+		t.Fatalf("point 1 shares = %v, want nil", r.Points[1].AcctTypeShares)
+	}
+	if _, err := Rollup(r, types.IntervalMinute); err == nil {
+		t.Fatal("rollup to a finer interval: want error")
+	}
+
+	s.Points[1].AcctTypeShares["bot"] = 0.5
+	if err := validate.ValidateSeries(s); err == nil {
+		t.Fatal("unknown acct_type and bad sum: want error")
+	}
+}
@@ -0,0 +1,30 @@
+package sim_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/analyze"
+	"github.com/civic-interconnect/civic-transparency-go-types/sim"
+)
+
+// Benchmark analyze.ClientSpikes against a scenario with one bot burst.
+func ExampleEvaluateIntervals() {
+	evs := sim.Generate(sim.Scenario{
+		Seed:        1,
+		Start:       time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		Duration:    2 * time.Hour,
+		OrganicRate: 60,
+		Campaigns: []sim.Campaign{
+			{Kind: sim.BotBurst, Offset: 90 * time.Minute, Duration: 5 * time.Minute, Accounts: 40, Rate: 60},
+		},
+	})
+	trends, _ := analyze.ClientTrends(sim.Tags(evs), time.Minute)
+	var spikes []time.Time
+	for _, s := range analyze.ClientSpikes(trends, analyze.SpikeOptions{}) {
+		spikes = append(spikes, s.TS)
+	}
+	m := sim.EvaluateIntervals(evs, time.Minute, spikes)
+	fmt.Printf("tp=%d fp=%d fn=%d precision=%.2f recall=%.2f\n", m.TP, m.FP, m.FN, m.Precision(), m.Recall())
+	// Output: tp=5 fp=2 fn=0 precision=0.71 recall=1.00
+}
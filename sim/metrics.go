@@ -0,0 +1,86 @@
+package sim
+
+import "time"
+
+// Metrics is a confusion matrix of detector output against ground truth.
+type Metrics struct {
+	TP, FP, FN, TN int
+}
+
+// Precision is TP/(TP+FP), or 0 when nothing was flagged.
+func (m Metrics) Precision() float64 {
+	if m.TP+m.FP == 0 {
+		return 0
+	}
+	return float64(m.TP) / float64(m.TP+m.FP)
+}
+
+// Recall is TP/(TP+FN), or 0 when there was nothing to find.
+func (m Metrics) Recall() float64 {
+	if m.TP+m.FN == 0 {
+		return 0
+	}
+	return float64(m.TP) / float64(m.TP+m.FN)
+}
+
+// F1 is the harmonic mean of precision and recall.
+func (m Metrics) F1() float64 {
+	p, r := m.Precision(), m.Recall()
+	if p+r == 0 {
+		return 0
+	}
+	return 2 * p * r / (p + r)
+}
+
+// Evaluate scores per-event predictions: flagged[i] says whether the
+// detector marked evs[i] as coordinated. Missing entries count as not
+// flagged.
+func Evaluate(evs []Event, flagged []bool) Metrics {
+	var m Metrics
+	for i, e := range evs {
+		f := i < len(flagged) && flagged[i]
+		m.add(e.Coordinated(), f)
+	}
+	return m
+}
+
+// EvaluateIntervals scores interval-level detectors, such as
+// analyze.ClientSpikes. Events are bucketed into UTC-aligned intervals of
+// length step; an interval is truly coordinated when any campaign event
+// falls in it, and predicted coordinated when its start is in flagged.
+// Intervals without events are scored only when flagged, as false
+// positives.
+func EvaluateIntervals(evs []Event, step time.Duration, flagged []time.Time) Metrics {
+	truth := map[time.Time]bool{}
+	for _, e := range evs {
+		k := e.TS.UTC().Truncate(step)
+		truth[k] = truth[k] || e.Coordinated()
+	}
+	pred := make(map[time.Time]bool, len(flagged))
+	for _, t := range flagged {
+		pred[t.UTC().Truncate(step)] = true
+	}
+	var m Metrics
+	for k, c := range truth {
+		m.add(c, pred[k])
+	}
+	for k := range pred {
+		if _, ok := truth[k]; !ok {
+			m.FP++
+		}
+	}
+	return m
+}
+
+func (m *Metrics) add(truth, flagged bool) {
+	switch {
+	case truth && flagged:
+		m.TP++
+	case truth:
+		m.FN++
+	case flagged:
+		m.FP++
+	default:
+		m.TN++
+	}
+}
@@ -0,0 +1,198 @@
+package sim
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/analyze"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// Label is the ground truth of an event.
+type Label string
+
+const (
+	Organic Label = "organic"
+	// BotBurst is a short, dense run of original posts from new, automated
+	// accounts on third-party clients, sharing a handful of texts.
+	BotBurst Label = "bot_burst"
+	// ReshareRing is a fixed group of established accounts resharing a
+	// rotating set of the group's own posts at a steady pace.
+	ReshareRing Label = "reshare_ring"
+	// RecycledWave is a surge of posts re-using content that already
+	// circulated organically earlier in the scenario.
+	RecycledWave Label = "recycled_wave"
+)
+
+// Campaign is one coordinated pattern injected into a scenario.
+type Campaign struct {
+	Kind     Label
+	Offset   time.Duration // start, relative to Scenario.Start
+	Duration time.Duration
+	Accounts int     // participating accounts; default 20
+	Rate     float64 // mean posts per minute across the campaign
+	Texts    int     // distinct contents posted; default 3
+}
+
+// Scenario describes a synthetic stream.
+type Scenario struct {
+	Seed        int64
+	Start       time.Time
+	Duration    time.Duration
+	OrganicRate float64 // mean organic posts per minute
+	Campaigns   []Campaign
+}
+
+// Event is one generated post with its ground truth.
+type Event struct {
+	TS       time.Time
+	Tag      types.ProvenanceTag
+	Label    Label
+	Campaign int    // index into Scenario.Campaigns plus one; 0 for organic
+	Account  string // synthetic account ID; tags themselves carry none
+}
+
+// Coordinated reports whether e belongs to a campaign.
+func (e Event) Coordinated() bool { return e.Campaign > 0 }
+
+// Generate returns the events of sc in time order. Arrivals are Poisson at
+// the configured rates; every tag passes validate.ValidateProvenanceTag.
+func Generate(sc Scenario) []Event {
+	g := &gen{r: rand.New(rand.NewSource(sc.Seed))}
+	var out []Event
+	for _, ts := range g.arrivals(sc.Start, sc.Duration, sc.OrganicRate) {
+		out = append(out, g.organic(ts))
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].TS.Before(out[j].TS) })
+	for i, c := range sc.Campaigns {
+		out = append(out, g.campaign(sc.Start, i+1, c, out)...)
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].TS.Before(out[j].TS) })
+	return out
+}
+
+// Tags strips ground truth, leaving what a detector would see.
+func Tags(evs []Event) []analyze.TimedTag {
+	out := make([]analyze.TimedTag, len(evs))
+	for i, e := range evs {
+		out[i] = analyze.TimedTag{TS: e.TS, Tag: e.Tag}
+	}
+	return out
+}
+
+type gen struct {
+	r        *rand.Rand
+	accounts int
+}
+
+func (g *gen) arrivals(start time.Time, d time.Duration, perMinute float64) []time.Time {
+	if perMinute <= 0 || d <= 0 {
+		return nil
+	}
+	var out []time.Time
+	mean := float64(time.Minute) / perMinute
+	for t := time.Duration(g.r.ExpFloat64() * mean); t < d; t += time.Duration(g.r.ExpFloat64() * mean) {
+		out = append(out, start.Add(t))
+	}
+	return out
+}
+
+func (g *gen) hash() types.HexHash8 {
+	return types.HexHash8(fmt.Sprintf("%08x", g.r.Uint32()))
+}
+
+func (g *gen) account() string {
+	g.accounts++
+	return fmt.Sprintf("acct-%05d", g.accounts)
+}
+
+func pick[T any](r *rand.Rand, xs []T) T { return xs[r.Intn(len(xs))] }
+
+// organicClients and organicAutomation weight the organic mix toward
+// typical traffic.
+var (
+	organicClients    = []types.ClientFamily{types.ClientWeb, types.ClientWeb, types.ClientMobile, types.ClientMobile, types.ClientMobile, types.ClientThirdParty}
+	organicAutomation = []types.AutomationFlag{types.AutomationManual, types.AutomationManual, types.AutomationManual, types.AutomationManual, types.AutomationScheduled}
+)
+
+func (g *gen) organic(ts time.Time) Event {
+	return Event{
+		TS:      ts,
+		Label:   Organic,
+		Account: g.account(),
+		Tag: types.ProvenanceTag{
+			AcctAgeBucket:   pick(g.r, types.AcctAgeValues()),
+			AcctType:        pick(g.r, []types.AcctType{types.AcctTypePerson, types.AcctTypePerson, types.AcctTypePerson, types.AcctTypeOrg, types.AcctTypeMedia}),
+			AutomationFlag:  pick(g.r, organicAutomation),
+			PostKind:        pick(g.r, types.PostKindValues()),
+			ClientFamily:    pick(g.r, organicClients),
+			MediaProvenance: pick(g.r, types.MediaProvenanceValues()),
+			DedupHash:       g.hash(),
+		},
+	}
+}
+
+func (g *gen) campaign(start time.Time, id int, c Campaign, prev []Event) []Event {
+	if c.Accounts <= 0 {
+		c.Accounts = 20
+	}
+	if c.Texts <= 0 {
+		c.Texts = 3
+	}
+	accts := make([]string, c.Accounts)
+	for i := range accts {
+		accts[i] = g.account()
+	}
+	texts := make([]types.HexHash8, c.Texts)
+	for i := range texts {
+		texts[i] = g.hash()
+	}
+	if c.Kind == RecycledWave {
+		// Re-use content seen organically before the wave.
+		cut := start.Add(c.Offset)
+		var prior []types.HexHash8
+		for _, e := range prev {
+			if e.Label == Organic && e.TS.Before(cut) {
+				prior = append(prior, e.Tag.DedupHash)
+			}
+		}
+		for i := range texts {
+			if len(prior) > 0 {
+				texts[i] = pick(g.r, prior)
+			}
+		}
+	}
+
+	var out []Event
+	for _, ts := range g.arrivals(start.Add(c.Offset), c.Duration, c.Rate) {
+		e := Event{TS: ts, Label: c.Kind, Campaign: id, Account: pick(g.r, accts)}
+		t := &e.Tag
+		t.DedupHash = pick(g.r, texts)
+		t.MediaProvenance = types.MediaProvNone
+		switch c.Kind {
+		case BotBurst:
+			t.AcctAgeBucket = pick(g.r, []types.AcctAge{types.AcctAge_0_7d, types.AcctAge_0_7d, types.AcctAge_8_30d})
+			t.AcctType = types.AcctTypeUnverified
+			t.AutomationFlag = pick(g.r, []types.AutomationFlag{types.AutomationScheduled, types.AutomationAPICLIENT})
+			t.PostKind = types.PostKindOriginal
+			t.ClientFamily = types.ClientThirdParty
+		case ReshareRing:
+			t.AcctAgeBucket = pick(g.r, []types.AcctAge{types.AcctAge_6_24m, types.AcctAge_24mPlus})
+			t.AcctType = types.AcctTypePerson
+			t.AutomationFlag = types.AutomationManual
+			t.PostKind = types.PostKindReshare
+			t.ClientFamily = pick(g.r, organicClients)
+		default: // RecycledWave and unknown kinds
+			t.AcctAgeBucket = pick(g.r, types.AcctAgeValues())
+			t.AcctType = types.AcctTypePerson
+			t.AutomationFlag = pick(g.r, organicAutomation)
+			t.PostKind = pick(g.r, []types.PostKind{types.PostKindOriginal, types.PostKindQuote})
+			t.ClientFamily = pick(g.r, organicClients)
+			t.MediaProvenance = types.MediaProvHash
+		}
+		out = append(out, e)
+	}
+	return out
+}
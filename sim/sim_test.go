@@ -0,0 +1,73 @@
+package sim
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/validate"
+)
+
+func TestGenerate(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	sc := Scenario{
+		Seed: 7, Start: t0, Duration: time.Hour, OrganicRate: 20,
+		Campaigns: []Campaign{
+			{Kind: BotBurst, Offset: 10 * time.Minute, Duration: 5 * time.Minute, Rate: 50},
+			{Kind: ReshareRing, Offset: 20 * time.Minute, Duration: 30 * time.Minute, Accounts: 8, Rate: 5},
+			{Kind: RecycledWave, Offset: 40 * time.Minute, Duration: 10 * time.Minute, Rate: 30},
+		},
+	}
+	evs := Generate(sc)
+	if !reflect.DeepEqual(evs, Generate(sc)) {
+		t.Fatal("Generate is not deterministic")
+	}
+	organic := map[string]bool{}
+	counts := map[Label]int{}
+	ring := map[string]bool{}
+	for i, e := range evs {
+		if i > 0 && e.TS.Before(evs[i-1].TS) {
+			t.Fatalf("event %d out of order", i)
+		}
+		if err := validate.ValidateProvenanceTag(&e.Tag); err != nil {
+			t.Fatalf("event %d: %v", i, err)
+		}
+		counts[e.Label]++
+		switch e.Label {
+		case Organic:
+			if e.Campaign != 0 {
+				t.Fatalf("organic event in campaign %d", e.Campaign)
+			}
+			organic[string(e.Tag.DedupHash)] = true
+		case ReshareRing:
+			ring[e.Account] = true
+		case RecycledWave:
+			if !organic[string(e.Tag.DedupHash)] {
+				t.Errorf("recycled content %s not seen organically before", e.Tag.DedupHash)
+			}
+		}
+		if c := e.Campaign; c > 0 {
+			start := t0.Add(sc.Campaigns[c-1].Offset)
+			if e.TS.Before(start) || !e.TS.Before(start.Add(sc.Campaigns[c-1].Duration)) {
+				t.Errorf("campaign %d event at %v outside its window", c, e.TS)
+			}
+		}
+	}
+	for _, l := range []Label{Organic, BotBurst, ReshareRing, RecycledWave} {
+		if counts[l] == 0 {
+			t.Errorf("no %s events", l)
+		}
+	}
+	if len(ring) > 8 {
+		t.Errorf("reshare ring used %d accounts", len(ring))
+	}
+
+	flagged := make([]bool, len(evs))
+	for i, e := range evs {
+		flagged[i] = e.Label == BotBurst
+	}
+	m := Evaluate(evs, flagged)
+	if m.Precision() != 1 || m.FN != counts[ReshareRing]+counts[RecycledWave] {
+		t.Errorf("metrics = %+v", m)
+	}
+}
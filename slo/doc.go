@@ -0,0 +1,14 @@
+// slo/doc.go
+// Package slo checks series against the coverage commitments of
+// transparency agreements (types.CoverageSLO).
+//
+//	res, err := slo.Evaluate(published, types.CoverageSLO{
+//		TargetPercent: 99,
+//		Window:        types.ISODuration(30 * 24 * time.Hour),
+//		Deadline:      types.ISODuration(15 * time.Minute),
+//	})
+//
+// Results carry burn-rate style metrics alongside pass/fail: the share of
+// the error budget (100 - TargetPercent) consumed, so dashboards can alert
+// before an agreement is breached.
+package slo
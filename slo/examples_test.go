@@ -0,0 +1,36 @@
+package slo_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/slo"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func ExampleEvaluateAt() {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	minutes := func(from, to int) []types.Point {
+		var ps []types.Point
+		for m := from; m < to; m++ {
+			ps = append(ps, types.Point{TS: t0.Add(time.Duration(m) * time.Minute), Volume: 1})
+		}
+		return ps
+	}
+	published := []*types.Series{
+		{Topic: "#vote", Interval: types.IntervalMinute, GeneratedAt: t0.Add(12 * time.Minute), Points: minutes(0, 8)},
+		{Topic: "#vote", Interval: types.IntervalMinute, GeneratedAt: t0.Add(40 * time.Minute), Points: minutes(8, 9)},
+	}
+	res, err := slo.EvaluateAt(published, types.CoverageSLO{
+		TargetPercent: 75,
+		Deadline:      types.ISODuration(15 * time.Minute),
+	}, t0.Add(25*time.Minute))
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(res.Pass, res.Expected, res.OnTime, res.Late, res.Missing)
+	fmt.Printf("%.0f%% burn %.2f remaining %.2f\n", res.Percent, res.BurnRate, res.BudgetRemaining)
+	// Output:
+	// true 10 8 1 1
+	// 80% burn 0.80 remaining 0.20
+}
@@ -0,0 +1,162 @@
+package slo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+var (
+	// ErrInvalidSLO is returned for an SLO with a target outside (0, 100]
+	// or a negative window or deadline.
+	ErrInvalidSLO = errors.New("slo: invalid SLO")
+	// ErrNoSeries is returned when there is nothing to evaluate.
+	ErrNoSeries = errors.New("slo: no series")
+)
+
+// Result is the outcome of evaluating one CoverageSLO.
+type Result struct {
+	Pass bool
+	From time.Time // first bucket evaluated
+	To   time.Time // end of the last bucket evaluated (exclusive)
+
+	Expected int // buckets counted against the SLO
+	OnTime   int // reported within the deadline
+	Late     int // reported, but after the deadline
+	Missing  int // never reported
+	Excluded int // inside an excluded annotation range; not in Expected
+
+	// Percent is the on-time share of Expected buckets, 0–100. It is 100
+	// when Expected is zero.
+	Percent float64
+	// BurnRate is the share of the error budget (100 - TargetPercent)
+	// consumed: 1 means the budget is exactly spent, above 1 the SLO is
+	// breached. It is +Inf for a 100% target with any miss.
+	BurnRate float64
+	// BudgetRemaining is 1 - BurnRate; negative once the SLO is breached.
+	BudgetRemaining float64
+}
+
+// Evaluate checks series, all published for the same stream, against slo
+// as of the latest series' GeneratedAt.
+func Evaluate(series []*types.Series, slo types.CoverageSLO) (Result, error) {
+	var at time.Time
+	for _, s := range series {
+		if s != nil && s.GeneratedAt.After(at) {
+			at = s.GeneratedAt
+		}
+	}
+	return EvaluateAt(series, slo, at)
+}
+
+// EvaluateAt is Evaluate as of at. Buckets whose deadline has not passed
+// by at are not yet counted.
+func EvaluateAt(series []*types.Series, slo types.CoverageSLO, at time.Time) (Result, error) {
+	if !(slo.TargetPercent > 0 && slo.TargetPercent <= 100) || slo.Window < 0 || slo.Deadline < 0 {
+		return Result{}, fmt.Errorf("%w: target %g, window %s, deadline %s", ErrInvalidSLO,
+			slo.TargetPercent, time.Duration(slo.Window), time.Duration(slo.Deadline))
+	}
+
+	var step time.Duration
+	reported := map[int64]time.Time{} // bucket start → earliest GeneratedAt
+	var excluded []types.Annotation
+	var first time.Time
+	for _, s := range series {
+		if s == nil {
+			continue
+		}
+		d := s.Interval.Duration()
+		if d <= 0 || (step != 0 && d != step) {
+			return Result{}, fmt.Errorf("slo: series %q: interval %q does not match the other series", s.Topic, s.Interval)
+		}
+		step = d
+		for _, p := range s.Points {
+			b := p.TS.UTC().Truncate(step)
+			if r, ok := reported[b.Unix()]; !ok || s.GeneratedAt.Before(r) {
+				reported[b.Unix()] = s.GeneratedAt
+			}
+			if first.IsZero() || b.Before(first) {
+				first = b
+			}
+		}
+		for _, a := range s.Annotations {
+			for _, k := range slo.Exclusions {
+				if a.Kind == k {
+					excluded = append(excluded, a)
+					break
+				}
+			}
+		}
+	}
+	if step == 0 || first.IsZero() {
+		return Result{}, ErrNoSeries
+	}
+
+	from := first
+	if slo.Window > 0 {
+		from = ceil(at.Add(-time.Duration(slo.Window)), step)
+	}
+	deadline := time.Duration(slo.Deadline)
+	res := Result{From: from, To: from}
+	for b := from; !b.Add(step + deadline).After(at); b = b.Add(step) {
+		res.To = b.Add(step)
+		if skip(excluded, b, step) {
+			res.Excluded++
+			continue
+		}
+		res.Expected++
+		r, ok := reported[b.Unix()]
+		switch {
+		case !ok:
+			res.Missing++
+		case deadline > 0 && r.After(b.Add(step+deadline)):
+			res.Late++
+		default:
+			res.OnTime++
+		}
+	}
+
+	res.Percent = 100
+	if res.Expected > 0 {
+		res.Percent = 100 * float64(res.OnTime) / float64(res.Expected)
+	}
+	res.Pass = float64(res.OnTime)*100 >= slo.TargetPercent*float64(res.Expected)
+	switch budget := 100 - slo.TargetPercent; {
+	case res.OnTime == res.Expected:
+		res.BurnRate = 0
+	case budget == 0:
+		res.BurnRate = math.Inf(1)
+	default:
+		res.BurnRate = (100 - res.Percent) / budget
+	}
+	res.BudgetRemaining = 1 - res.BurnRate
+	return res, nil
+}
+
+// ceil rounds t up to a multiple of step.
+func ceil(t time.Time, step time.Duration) time.Time {
+	b := t.UTC().Truncate(step)
+	if b.Before(t) {
+		b = b.Add(step)
+	}
+	return b
+}
+
+// skip reports whether bucket b falls in one of the excluded ranges, using
+// the same bucket rule as types.Series.CoverageWith.
+func skip(excluded []types.Annotation, b time.Time, step time.Duration) bool {
+	for _, a := range excluded {
+		start := a.Start.UTC().Truncate(step)
+		end := a.End
+		if end.IsZero() {
+			end = start.Add(step)
+		}
+		if !b.Before(start) && b.Before(end) {
+			return true
+		}
+	}
+	return false
+}
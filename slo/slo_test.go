@@ -0,0 +1,78 @@
+package slo
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+var t0 = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func minuteSeries(gen time.Duration, from, to int, ann ...types.Annotation) *types.Series {
+	s := &types.Series{Topic: "#t", Interval: types.IntervalMinute, GeneratedAt: t0.Add(gen), Annotations: ann}
+	for m := from; m < to; m++ {
+		s.Points = append(s.Points, types.Point{TS: t0.Add(time.Duration(m) * time.Minute)})
+	}
+	return s
+}
+
+func TestEvaluateWindowAndExclusions(t *testing.T) {
+	outage := types.Annotation{Kind: types.AnnotationOutage, Start: t0.Add(50 * time.Minute), End: t0.Add(55 * time.Minute)}
+	series := []*types.Series{
+		minuteSeries(30*time.Minute, 0, 30),
+		minuteSeries(60*time.Minute, 30, 50, outage),
+	}
+	res, err := Evaluate(series, types.CoverageSLO{
+		TargetPercent: 99,
+		Window:        types.ISODuration(30 * time.Minute),
+		Exclusions:    []types.AnnotationKind{types.AnnotationOutage},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Buckets 00:30–00:59: 20 reported, 5 excluded, 5 missing.
+	if !res.From.Equal(t0.Add(30*time.Minute)) || !res.To.Equal(t0.Add(60*time.Minute)) {
+		t.Errorf("range = %v–%v", res.From, res.To)
+	}
+	if res.Expected != 25 || res.OnTime != 20 || res.Missing != 5 || res.Excluded != 5 || res.Pass {
+		t.Errorf("result = %+v", res)
+	}
+	if math.Abs(res.BurnRate-20) > 1e-9 {
+		t.Errorf("BurnRate = %g, want 20", res.BurnRate)
+	}
+}
+
+func TestEvaluatePerfectTarget(t *testing.T) {
+	series := []*types.Series{minuteSeries(10*time.Minute, 0, 9)}
+	res, err := Evaluate(series, types.CoverageSLO{TargetPercent: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Pass || !math.IsInf(res.BurnRate, 1) || res.Missing != 1 {
+		t.Errorf("result = %+v", res)
+	}
+	series[0].Points = append(series[0].Points, types.Point{TS: t0.Add(9 * time.Minute)})
+	if res, _ := Evaluate(series, types.CoverageSLO{TargetPercent: 100}); !res.Pass || res.BurnRate != 0 || res.BudgetRemaining != 1 {
+		t.Errorf("complete result = %+v", res)
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	good := []*types.Series{minuteSeries(time.Hour, 0, 1)}
+	for _, c := range []types.CoverageSLO{{TargetPercent: 0}, {TargetPercent: 101}, {TargetPercent: 99, Window: -1}} {
+		if _, err := Evaluate(good, c); !errors.Is(err, ErrInvalidSLO) {
+			t.Errorf("Evaluate(%+v) = %v, want ErrInvalidSLO", c, err)
+		}
+	}
+	if _, err := Evaluate(nil, types.CoverageSLO{TargetPercent: 99}); !errors.Is(err, ErrNoSeries) {
+		t.Errorf("Evaluate(nil) = %v, want ErrNoSeries", err)
+	}
+	hourly := minuteSeries(time.Hour, 0, 1)
+	hourly.Interval = "PT1H"
+	if _, err := Evaluate(append(good, hourly), types.CoverageSLO{TargetPercent: 99}); err == nil {
+		t.Error("mixed intervals accepted")
+	}
+}
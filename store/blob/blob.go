@@ -0,0 +1,164 @@
+package blob
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/codec"
+	"github.com/civic-interconnect/civic-transparency-go-types/store"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// DigestMetadataKey is the object metadata key holding the body's SHA-256.
+const DigestMetadataKey = "sha256"
+
+// timeLayout is fixed-width so lexical order matches time order.
+const timeLayout = "20060102T150405.000000000Z"
+
+// Object describes one stored blob.
+type Object struct {
+	Key      string
+	ETag     string
+	Size     int64
+	Metadata map[string]string // may be nil in List results
+}
+
+// Bucket is the subset of an object store that Store needs. Put and Delete
+// must enforce cond atomically and report a failed condition as
+// store.ErrPreconditionFailed; Get reports a missing key as
+// store.ErrNotFound.
+type Bucket interface {
+	Put(ctx context.Context, key string, body []byte, meta map[string]string, cond store.Condition) (etag string, err error)
+	Get(ctx context.Context, key string) ([]byte, Object, error)
+	// List returns every object whose key has prefix and sorts after
+	// startAfter, in ascending key order.
+	List(ctx context.Context, prefix, startAfter string) ([]Object, error)
+	Delete(ctx context.Context, key string, cond store.Condition) error
+}
+
+// Options configures a Store.
+type Options struct {
+	// Prefix is prepended to every object key (e.g., "transparency/v1/").
+	Prefix string
+	// Format selects the body encoding; Unmarshal reads any codec Format.
+	Format codec.Format
+}
+
+// Store is a store.SeriesStore over a Bucket.
+type Store struct {
+	b    Bucket
+	opts Options
+}
+
+var _ store.SeriesStore = (*Store)(nil)
+
+// New returns a Store over b.
+func New(b Bucket, opts Options) *Store {
+	return &Store{b: b, opts: opts}
+}
+
+// ObjectKey returns the object key for k.
+func (st *Store) ObjectKey(k store.Key) string {
+	return st.topicPrefix(k.Topic) + k.GeneratedAt.UTC().Format(timeLayout) + ".json"
+}
+
+func (st *Store) topicPrefix(topic string) string {
+	return st.opts.Prefix + url.PathEscape(topic) + "/"
+}
+
+// Put implements store.SeriesStore.
+func (st *Store) Put(ctx context.Context, s *types.Series, cond store.Condition) (store.Info, error) {
+	body, err := codec.Marshal(s, st.opts.Format)
+	if err != nil {
+		return store.Info{}, fmt.Errorf("blob: encode: %w", err)
+	}
+	info := store.Info{Key: store.KeyOf(s), Digest: store.Digest(body), Size: int64(len(body))}
+	info.ETag, err = st.b.Put(ctx, st.ObjectKey(info.Key), body,
+		map[string]string{DigestMetadataKey: info.Digest}, cond)
+	if err != nil {
+		return store.Info{}, err
+	}
+	return info, nil
+}
+
+// Get implements store.SeriesStore.
+func (st *Store) Get(ctx context.Context, k store.Key) (*types.Series, store.Info, error) {
+	body, obj, err := st.b.Get(ctx, st.ObjectKey(k))
+	if err != nil {
+		return nil, store.Info{}, err
+	}
+	info := store.Info{Key: k, ETag: obj.ETag, Digest: store.Digest(body), Size: int64(len(body))}
+	if want := obj.Metadata[DigestMetadataKey]; want != "" && want != info.Digest {
+		return nil, info, fmt.Errorf("%w: %s", store.ErrDigestMismatch, obj.Key)
+	}
+	var s types.Series
+	if err := codec.Unmarshal(body, &s); err != nil {
+		return nil, info, fmt.Errorf("blob: decode %s: %w", obj.Key, err)
+	}
+	return &s, info, nil
+}
+
+// ListWindow implements store.SeriesStore. Info.Digest is set only when the
+// Bucket returns metadata in listings.
+func (st *Store) ListWindow(ctx context.Context, topic string, from, to time.Time) ([]store.Info, error) {
+	prefix := st.topicPrefix(topic)
+	var after string
+	if !from.IsZero() {
+		after = prefix + from.UTC().Add(-time.Nanosecond).Format(timeLayout) + ".json"
+	}
+	objs, err := st.b.List(ctx, prefix, after)
+	if err != nil {
+		return nil, err
+	}
+	var out []store.Info
+	for _, o := range objs {
+		name := strings.TrimSuffix(strings.TrimPrefix(o.Key, prefix), ".json")
+		gen, err := time.Parse(timeLayout, name)
+		if err != nil {
+			continue // not written by Store
+		}
+		if !from.IsZero() && gen.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !gen.Before(to) {
+			break
+		}
+		out = append(out, store.Info{
+			Key:    store.Key{Topic: topic, GeneratedAt: gen},
+			ETag:   o.ETag,
+			Digest: o.Metadata[DigestMetadataKey],
+			Size:   o.Size,
+		})
+	}
+	return out, nil
+}
+
+// Topics returns the distinct topics with at least one stored object, in
+// lexical order of their escaped form.
+func (st *Store) Topics(ctx context.Context) ([]string, error) {
+	objs, err := st.b.List(ctx, st.opts.Prefix, "")
+	if err != nil {
+		return nil, err
+	}
+	var out []string
+	last := ""
+	for _, o := range objs {
+		esc, _, ok := strings.Cut(strings.TrimPrefix(o.Key, st.opts.Prefix), "/")
+		if !ok || esc == last {
+			continue
+		}
+		last = esc
+		if t, err := url.PathUnescape(esc); err == nil {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+// Delete implements store.SeriesStore.
+func (st *Store) Delete(ctx context.Context, k store.Key, cond store.Condition) error {
+	return st.b.Delete(ctx, st.ObjectKey(k), cond)
+}
@@ -0,0 +1,67 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/codec"
+	"github.com/civic-interconnect/civic-transparency-go-types/store"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	mem := NewMemBucket()
+	st := New(mem, Options{Prefix: "v1/", Format: codec.Compact})
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	series := func(h int) *types.Series {
+		gen := t0.Add(time.Duration(h) * time.Hour)
+		return &types.Series{Topic: "#vote", GeneratedAt: gen, Interval: types.IntervalMinute,
+			Points: []types.Point{{TS: gen.Add(-time.Minute), Volume: h}}}
+	}
+
+	var infos []store.Info
+	for h := 0; h < 3; h++ {
+		info, err := st.Put(ctx, series(h), store.Condition{IfNoneMatch: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		infos = append(infos, info)
+	}
+	if _, err := st.Put(ctx, series(1), store.Condition{IfNoneMatch: true}); !errors.Is(err, store.ErrPreconditionFailed) {
+		t.Fatalf("duplicate create: %v", err)
+	}
+	if _, err := st.Put(ctx, series(1), store.Condition{IfMatch: infos[1].ETag}); err != nil {
+		t.Fatalf("conditional overwrite: %v", err)
+	}
+
+	got, info, err := st.Get(ctx, infos[2].Key)
+	if err != nil || got.Points[0].Volume != 2 || info.Digest != infos[2].Digest {
+		t.Fatalf("Get = %+v, %+v, %v", got, info, err)
+	}
+
+	w, err := st.ListWindow(ctx, "#vote", t0.Add(time.Hour), t0.Add(2*time.Hour))
+	if err != nil || len(w) != 1 || !w[0].Key.GeneratedAt.Equal(t0.Add(time.Hour)) {
+		t.Fatalf("ListWindow = %+v, %v", w, err)
+	}
+	if all, _ := st.ListWindow(ctx, "#vote", time.Time{}, time.Time{}); len(all) != 3 {
+		t.Fatalf("open ListWindow = %d objects, want 3", len(all))
+	}
+
+	k := st.ObjectKey(infos[0].Key)
+	cur := mem.objs[k]
+	cur.body = []byte(`{"topic":"#tampered"}`)
+	mem.objs[k] = cur
+	if _, _, err := st.Get(ctx, infos[0].Key); !errors.Is(err, store.ErrDigestMismatch) {
+		t.Fatalf("tampered Get: %v", err)
+	}
+
+	if err := st.Delete(ctx, infos[0].Key, store.Condition{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := st.Get(ctx, infos[0].Key); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("Get after Delete: %v", err)
+	}
+}
@@ -0,0 +1,9 @@
+// store/blob/doc.go
+// Package blob implements store.SeriesStore over any object store that can
+// write and delete conditionally.
+//
+// Each Series is one object at <prefix><escaped topic>/<generated_at>.json,
+// where generated_at is a fixed-width UTC timestamp so that lexical key order
+// is time order and ListWindow is a single prefix listing. The SHA-256 of the
+// body is stored as object metadata and checked on every Get.
+package blob
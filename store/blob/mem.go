@@ -0,0 +1,95 @@
+package blob
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/store"
+)
+
+// MemBucket is an in-memory Bucket for tests and local development.
+type MemBucket struct {
+	mu   sync.Mutex
+	objs map[string]memObject
+	seq  int
+}
+
+type memObject struct {
+	body []byte
+	obj  Object
+}
+
+// NewMemBucket returns an empty MemBucket.
+func NewMemBucket() *MemBucket {
+	return &MemBucket{objs: map[string]memObject{}}
+}
+
+func (m *MemBucket) check(key string, cond store.Condition) error {
+	cur, ok := m.objs[key]
+	if cond.IfNoneMatch && ok {
+		return store.ErrPreconditionFailed
+	}
+	if cond.IfMatch != "" && (!ok || cur.obj.ETag != cond.IfMatch) {
+		return store.ErrPreconditionFailed
+	}
+	return nil
+}
+
+// Put implements Bucket.
+func (m *MemBucket) Put(_ context.Context, key string, body []byte, meta map[string]string, cond store.Condition) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.check(key, cond); err != nil {
+		return "", err
+	}
+	m.seq++
+	md := make(map[string]string, len(meta))
+	for k, v := range meta {
+		md[k] = v
+	}
+	o := Object{Key: key, ETag: `"` + strconv.Itoa(m.seq) + `"`, Size: int64(len(body)), Metadata: md}
+	m.objs[key] = memObject{body: append([]byte(nil), body...), obj: o}
+	return o.ETag, nil
+}
+
+// Get implements Bucket.
+func (m *MemBucket) Get(_ context.Context, key string) ([]byte, Object, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cur, ok := m.objs[key]
+	if !ok {
+		return nil, Object{}, store.ErrNotFound
+	}
+	return append([]byte(nil), cur.body...), cur.obj, nil
+}
+
+// List implements Bucket.
+func (m *MemBucket) List(_ context.Context, prefix, startAfter string) ([]Object, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var out []Object
+	for k, o := range m.objs {
+		if strings.HasPrefix(k, prefix) && k > startAfter {
+			out = append(out, o.obj)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Key < out[j].Key })
+	return out, nil
+}
+
+// Delete implements Bucket.
+func (m *MemBucket) Delete(_ context.Context, key string, cond store.Condition) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.check(key, cond); err != nil {
+		return err
+	}
+	if _, ok := m.objs[key]; !ok {
+		return store.ErrNotFound
+	}
+	delete(m.objs, key)
+	return nil
+}
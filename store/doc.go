@@ -0,0 +1,10 @@
+// store/doc.go
+// Package store defines SeriesStore, the interface downstream services code
+// against to persist published Series, and the conditions and metadata shared
+// by its implementations.
+//
+// Implementations live in subpackages: blob lays Series out in any object
+// store that offers conditional writes, and s3 provides such a bucket for
+// Amazon S3 and S3-compatible endpoints (including Google Cloud Storage's
+// XML API with HMAC keys) using only net/http.
+package store
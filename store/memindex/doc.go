@@ -0,0 +1,9 @@
+// store/memindex/doc.go
+// Package memindex keeps recent Series points in memory, indexed by topic
+// and time, for API servers that serve a hot window (e.g., 72 hours).
+//
+// Each topic holds its points in sorted, fixed-capacity chunks, so inserts
+// touch one chunk, range queries binary-search to the first chunk, and
+// eviction drops whole chunks. Topics are locked independently, so readers
+// of one topic never wait on writers of another.
+package memindex
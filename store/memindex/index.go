@@ -0,0 +1,229 @@
+package memindex
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// DefaultChunkSize is the number of points per chunk when Options.ChunkSize
+// is zero: one day of minute points.
+const DefaultChunkSize = 1440
+
+// Options configures an Index.
+type Options struct {
+	// TTL, if positive, is how long points are kept relative to the clock;
+	// Evict drops anything older.
+	TTL time.Duration
+	// ChunkSize is the maximum points per chunk. Zero selects DefaultChunkSize.
+	ChunkSize int
+	// Now overrides the clock used by Evict (tests).
+	Now func() time.Time
+}
+
+// Index maps topics to time-ordered points. It is safe for concurrent use.
+type Index struct {
+	opts   Options
+	mu     sync.RWMutex
+	topics map[string]*topic
+}
+
+type topic struct {
+	mu     sync.RWMutex
+	chunks [][]types.Point // each sorted by TS; chunks ordered and non-overlapping
+	// removed is set, under mu, once EvictBefore has dropped the topic from
+	// the index, so an Insert that looked it up earlier retries.
+	removed bool
+}
+
+// New returns an empty Index.
+func New(opts Options) *Index {
+	if opts.ChunkSize <= 0 {
+		opts.ChunkSize = DefaultChunkSize
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	return &Index{opts: opts, topics: make(map[string]*topic)}
+}
+
+func (x *Index) get(name string, create bool) *topic {
+	x.mu.RLock()
+	t := x.topics[name]
+	x.mu.RUnlock()
+	if t != nil || !create {
+		return t
+	}
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if t = x.topics[name]; t == nil {
+		t = &topic{}
+		x.topics[name] = t
+	}
+	return t
+}
+
+// Insert adds points to topic. A point whose TS equals an existing point's
+// TS replaces it.
+func (x *Index) Insert(name string, pts ...types.Point) {
+	if len(pts) == 0 {
+		return
+	}
+	for {
+		t := x.get(name, true)
+		t.mu.Lock()
+		if !t.removed {
+			for _, p := range pts {
+				t.insert(p, x.opts.ChunkSize)
+			}
+			t.mu.Unlock()
+			return
+		}
+		t.mu.Unlock()
+	}
+}
+
+// InsertSeries adds all points of s under s.Topic.
+func (x *Index) InsertSeries(s *types.Series) { x.Insert(s.Topic, s.Points...) }
+
+func (t *topic) insert(p types.Point, max int) {
+	if len(t.chunks) == 0 {
+		t.chunks = append(t.chunks, []types.Point{p})
+		return
+	}
+	// First chunk whose last point is at or after p; append to the last chunk otherwise.
+	ci := sort.Search(len(t.chunks), func(i int) bool {
+		c := t.chunks[i]
+		return !c[len(c)-1].TS.Before(p.TS)
+	})
+	if ci == len(t.chunks) {
+		ci--
+	}
+	c := t.chunks[ci]
+	pi := sort.Search(len(c), func(i int) bool { return !c[i].TS.Before(p.TS) })
+	if pi < len(c) && c[pi].TS.Equal(p.TS) {
+		c[pi] = p
+		return
+	}
+	c = append(c, types.Point{})
+	copy(c[pi+1:], c[pi:])
+	c[pi] = p
+	if len(c) <= max {
+		t.chunks[ci] = c
+		return
+	}
+	half := len(c) / 2
+	left := append([]types.Point(nil), c[:half]...)
+	right := append([]types.Point(nil), c[half:]...)
+	t.chunks = append(t.chunks, nil)
+	copy(t.chunks[ci+2:], t.chunks[ci+1:])
+	t.chunks[ci], t.chunks[ci+1] = left, right
+}
+
+// Range returns a copy of topic's points with from <= TS < to, in TS order.
+// A zero from or to leaves that side open.
+func (x *Index) Range(name string, from, to time.Time) []types.Point {
+	t := x.get(name, false)
+	if t == nil {
+		return nil
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	start := 0
+	if !from.IsZero() {
+		start = sort.Search(len(t.chunks), func(i int) bool {
+			c := t.chunks[i]
+			return !c[len(c)-1].TS.Before(from)
+		})
+	}
+	var out []types.Point
+	for _, c := range t.chunks[start:] {
+		for _, p := range c {
+			if !from.IsZero() && p.TS.Before(from) {
+				continue
+			}
+			if !to.IsZero() && !p.TS.Before(to) {
+				return out
+			}
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// Len returns the number of points held for topic.
+func (x *Index) Len(name string) int {
+	t := x.get(name, false)
+	if t == nil {
+		return 0
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	n := 0
+	for _, c := range t.chunks {
+		n += len(c)
+	}
+	return n
+}
+
+// Topics returns the indexed topics in lexical order.
+func (x *Index) Topics() []string {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	out := make([]string, 0, len(x.topics))
+	for k := range x.topics {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// Evict drops points older than the configured TTL and removes topics left
+// empty. It returns the number of points dropped and is a no-op without a
+// positive TTL.
+func (x *Index) Evict() int {
+	if x.opts.TTL <= 0 {
+		return 0
+	}
+	return x.EvictBefore(x.opts.Now().Add(-x.opts.TTL))
+}
+
+// EvictBefore drops points with TS before cutoff across all topics and
+// removes topics left empty. It returns the number of points dropped.
+func (x *Index) EvictBefore(cutoff time.Time) int {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	n := 0
+	for name, t := range x.topics {
+		t.mu.Lock()
+		n += t.evict(cutoff)
+		t.removed = len(t.chunks) == 0
+		t.mu.Unlock()
+		if t.removed {
+			delete(x.topics, name)
+		}
+	}
+	return n
+}
+
+func (t *topic) evict(cutoff time.Time) int {
+	n := 0
+	for len(t.chunks) > 0 {
+		c := t.chunks[0]
+		if c[len(c)-1].TS.Before(cutoff) {
+			n += len(c)
+			t.chunks[0] = nil
+			t.chunks = t.chunks[1:]
+			continue
+		}
+		i := sort.Search(len(c), func(i int) bool { return !c[i].TS.Before(cutoff) })
+		if i > 0 {
+			t.chunks[0] = append([]types.Point(nil), c[i:]...)
+			n += i
+		}
+		break
+	}
+	return n
+}
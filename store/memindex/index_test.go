@@ -0,0 +1,93 @@
+package memindex
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestInsertRangeEvict(t *testing.T) {
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	x := New(Options{ChunkSize: 4})
+	perm := rand.New(rand.NewSource(1)).Perm(50)
+	for _, m := range perm {
+		x.Insert("#vote", types.Point{TS: base.Add(time.Duration(m) * time.Minute), Volume: m})
+	}
+	x.Insert("#vote", types.Point{TS: base.Add(10 * time.Minute), Volume: 99})
+
+	if got := x.Len("#vote"); got != 50 {
+		t.Fatalf("Len = %d, want 50", got)
+	}
+	got := x.Range("#vote", base.Add(8*time.Minute), base.Add(12*time.Minute))
+	want := []int{8, 9, 99, 11}
+	if len(got) != len(want) {
+		t.Fatalf("Range len = %d, want %d", len(got), len(want))
+	}
+	for i, p := range got {
+		if p.Volume != want[i] {
+			t.Errorf("Range[%d].Volume = %d, want %d", i, p.Volume, want[i])
+		}
+	}
+
+	if n := x.EvictBefore(base.Add(45 * time.Minute)); n != 45 {
+		t.Errorf("EvictBefore dropped %d, want 45", n)
+	}
+	if n := x.EvictBefore(base.Add(time.Hour)); n != 5 {
+		t.Errorf("EvictBefore dropped %d, want 5", n)
+	}
+	if len(x.Topics()) != 0 {
+		t.Errorf("Topics = %v, want none", x.Topics())
+	}
+}
+
+// TestInsertDuringEvict checks that an Insert racing an EvictBefore that
+// empties the topic is never written into a topic the index has dropped.
+// Run with -race.
+func TestInsertDuringEvict(t *testing.T) {
+	x := New(Options{})
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	old, fresh := types.Point{TS: t0}, types.Point{TS: t0.Add(time.Hour)}
+	const writers, rounds = 8, 1000
+
+	stop := make(chan struct{})
+	evicted := make(chan struct{})
+	go func() {
+		defer close(evicted)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				x.EvictBefore(t0.Add(time.Minute))
+			}
+		}
+	}()
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < rounds; i++ {
+				name := fmt.Sprintf("#w%d-%d", w, i)
+				x.Insert(name, old)
+				x.Insert(name, fresh)
+			}
+		}(w)
+	}
+	wg.Wait()
+	close(stop)
+	<-evicted
+	x.EvictBefore(t0.Add(time.Minute))
+	for w := 0; w < writers; w++ {
+		for i := 0; i < rounds; i++ {
+			name := fmt.Sprintf("#w%d-%d", w, i)
+			if got := x.Range(name, time.Time{}, time.Time{}); len(got) != 1 || !got[0].TS.Equal(fresh.TS) {
+				t.Fatalf("%s = %v, want the fresh point", name, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,13 @@
+// store/s3/doc.go
+// Package s3 is a blob.Bucket for Amazon S3 and S3-compatible object stores,
+// implemented with net/http and AWS Signature Version 4 so the module stays
+// free of SDK dependencies.
+//
+// Conditional writes use If-None-Match and If-Match, which S3 enforces
+// atomically. Google Cloud Storage is supported through its XML API
+// (Endpoint "https://storage.googleapis.com", Region "auto") with HMAC keys.
+//
+//	b := s3.New(s3.Config{Endpoint: "https://s3.us-east-1.amazonaws.com",
+//		Region: "us-east-1", Bucket: "transparency", AccessKeyID: id, SecretAccessKey: secret})
+//	st := blob.New(b, blob.Options{Prefix: "v1/"})
+package s3
@@ -0,0 +1,190 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/store"
+	"github.com/civic-interconnect/civic-transparency-go-types/store/blob"
+)
+
+const metaPrefix = "X-Amz-Meta-"
+
+// Config locates a bucket and carries its credentials.
+type Config struct {
+	Endpoint        string // scheme and host, e.g. "https://s3.us-east-1.amazonaws.com"
+	Region          string
+	Bucket          string // empty for virtual-hosted Endpoints ("https://bkt.s3.amazonaws.com")
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string // optional, for temporary credentials
+
+	// HTTPClient defaults to http.DefaultClient.
+	HTTPClient *http.Client
+	// Now overrides the signing clock (tests).
+	Now func() time.Time
+}
+
+// Bucket is a blob.Bucket backed by an S3 bucket.
+type Bucket struct {
+	cfg Config
+}
+
+var _ blob.Bucket = (*Bucket)(nil)
+
+// New returns a Bucket for cfg.
+func New(cfg Config) *Bucket {
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = http.DefaultClient
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	cfg.Endpoint = strings.TrimRight(cfg.Endpoint, "/")
+	return &Bucket{cfg: cfg}
+}
+
+// StatusError is an unexpected response from the object store.
+type StatusError struct {
+	Code int
+	Body string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("s3: HTTP %d: %s", e.Code, e.Body)
+}
+
+// Put implements blob.Bucket.
+func (b *Bucket) Put(ctx context.Context, key string, body []byte, meta map[string]string, cond store.Condition) (string, error) {
+	h := http.Header{}
+	for k, v := range meta {
+		h.Set(metaPrefix+k, v)
+	}
+	setCondition(h, cond)
+	resp, err := b.do(ctx, http.MethodPut, key, nil, h, body)
+	if err != nil {
+		return "", err
+	}
+	return resp.header.Get("ETag"), nil
+}
+
+// Get implements blob.Bucket.
+func (b *Bucket) Get(ctx context.Context, key string) ([]byte, blob.Object, error) {
+	resp, err := b.do(ctx, http.MethodGet, key, nil, nil, nil)
+	if err != nil {
+		return nil, blob.Object{}, err
+	}
+	o := blob.Object{Key: key, ETag: resp.header.Get("ETag"), Size: int64(len(resp.body)), Metadata: map[string]string{}}
+	for k, v := range resp.header {
+		if strings.HasPrefix(k, metaPrefix) && len(v) > 0 {
+			o.Metadata[strings.ToLower(k[len(metaPrefix):])] = v[0]
+		}
+	}
+	return resp.body, o, nil
+}
+
+type listResult struct {
+	Contents []struct {
+		Key  string
+		ETag string
+		Size int64
+	}
+	IsTruncated           bool
+	NextContinuationToken string
+}
+
+// List implements blob.Bucket, following continuation tokens.
+func (b *Bucket) List(ctx context.Context, prefix, startAfter string) ([]blob.Object, error) {
+	var out []blob.Object
+	q := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+	if startAfter != "" {
+		q.Set("start-after", startAfter)
+	}
+	for {
+		resp, err := b.do(ctx, http.MethodGet, "", q, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		var lr listResult
+		if err := xml.Unmarshal(resp.body, &lr); err != nil {
+			return nil, fmt.Errorf("s3: decode list: %w", err)
+		}
+		for _, c := range lr.Contents {
+			out = append(out, blob.Object{Key: c.Key, ETag: c.ETag, Size: c.Size})
+		}
+		if !lr.IsTruncated || lr.NextContinuationToken == "" {
+			return out, nil
+		}
+		q.Set("continuation-token", lr.NextContinuationToken)
+	}
+}
+
+// Delete implements blob.Bucket.
+func (b *Bucket) Delete(ctx context.Context, key string, cond store.Condition) error {
+	h := http.Header{}
+	setCondition(h, cond)
+	_, err := b.do(ctx, http.MethodDelete, key, nil, h, nil)
+	return err
+}
+
+func setCondition(h http.Header, cond store.Condition) {
+	if cond.IfNoneMatch {
+		h.Set("If-None-Match", "*")
+	}
+	if cond.IfMatch != "" {
+		h.Set("If-Match", cond.IfMatch)
+	}
+}
+
+type response struct {
+	header http.Header
+	body   []byte
+}
+
+// do sends one signed request. Object keys are addressed path-style unless
+// Config.Bucket is empty, in which case Endpoint names the bucket's own host.
+func (b *Bucket) do(ctx context.Context, method, key string, q url.Values, h http.Header, body []byte) (*response, error) {
+	path := "/" + key
+	if b.cfg.Bucket != "" {
+		path = "/" + b.cfg.Bucket + strings.TrimSuffix(path, "/")
+	}
+	u := b.cfg.Endpoint + encodePath(path)
+	if len(q) > 0 {
+		u += "?" + canonicalQuery(q)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.ContentLength = int64(len(body))
+	for k, v := range h {
+		req.Header[k] = v
+	}
+	b.sign(req, body)
+	resp, err := b.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	rb, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, store.ErrNotFound
+	case resp.StatusCode == http.StatusPreconditionFailed,
+		resp.StatusCode == http.StatusConflict && bytes.Contains(rb, []byte("ConditionalRequestConflict")):
+		return nil, store.ErrPreconditionFailed
+	case resp.StatusCode/100 != 2:
+		return nil, &StatusError{Code: resp.StatusCode, Body: string(rb)}
+	}
+	return &response{header: resp.Header, body: rb}, nil
+}
@@ -0,0 +1,119 @@
+package s3
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const (
+	sigAlgorithm = "AWS4-HMAC-SHA256"
+	amzDate      = "20060102T150405Z"
+)
+
+// sign adds AWS Signature Version 4 headers to req for the s3 service.
+// Every header already on req is signed along with Host.
+func (b *Bucket) sign(req *http.Request, body []byte) {
+	now := b.cfg.Now().UTC()
+	stamp := now.Format(amzDate)
+	day := stamp[:8]
+	payload := sha256.Sum256(body)
+	payloadHex := hex.EncodeToString(payload[:])
+
+	req.Header.Set("X-Amz-Date", stamp)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHex)
+	if b.cfg.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", b.cfg.SessionToken)
+	}
+
+	names := []string{"host"}
+	values := map[string]string{"host": req.URL.Host}
+	for k, v := range req.Header {
+		lk := strings.ToLower(k)
+		names = append(names, lk)
+		values[lk] = strings.Join(trimAll(v), ",")
+	}
+	sort.Strings(names)
+	var ch strings.Builder
+	for _, n := range names {
+		ch.WriteString(n + ":" + values[n] + "\n")
+	}
+	signed := strings.Join(names, ";")
+
+	canonical := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		ch.String(),
+		signed,
+		payloadHex,
+	}, "\n")
+	scope := day + "/" + b.cfg.Region + "/s3/aws4_request"
+	crh := sha256.Sum256([]byte(canonical))
+	toSign := sigAlgorithm + "\n" + stamp + "\n" + scope + "\n" + hex.EncodeToString(crh[:])
+
+	k := hmacSHA256([]byte("AWS4"+b.cfg.SecretAccessKey), day)
+	k = hmacSHA256(k, b.cfg.Region)
+	k = hmacSHA256(k, "s3")
+	k = hmacSHA256(k, "aws4_request")
+	sig := hex.EncodeToString(hmacSHA256(k, toSign))
+
+	req.Header.Set("Authorization", sigAlgorithm+" Credential="+b.cfg.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signed+", Signature="+sig)
+}
+
+func hmacSHA256(key []byte, msg string) []byte {
+	m := hmac.New(sha256.New, key)
+	m.Write([]byte(msg))
+	return m.Sum(nil)
+}
+
+func trimAll(v []string) []string {
+	out := make([]string, len(v))
+	for i, s := range v {
+		out[i] = strings.Join(strings.Fields(s), " ")
+	}
+	return out
+}
+
+// uriEncode percent-encodes everything except RFC 3986 unreserved
+// characters, and '/' when keepSlash is set, as SigV4 requires.
+func uriEncode(s string, keepSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', keepSlash && c == '/':
+			b.WriteByte(c)
+		default:
+			b.WriteString("%" + strings.ToUpper(hex.EncodeToString([]byte{c})))
+		}
+	}
+	return b.String()
+}
+
+func encodePath(p string) string { return uriEncode(p, true) }
+
+// canonicalQuery sorts and encodes q as SigV4 requires; it is also used to
+// build request URLs so the two always agree.
+func canonicalQuery(q url.Values) string {
+	keys := make([]string, 0, len(q))
+	for k := range q {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, k := range keys {
+		vs := append([]string(nil), q[k]...)
+		sort.Strings(vs)
+		for _, v := range vs {
+			parts = append(parts, uriEncode(k, false)+"="+uriEncode(v, false))
+		}
+	}
+	return strings.Join(parts, "&")
+}
@@ -0,0 +1,293 @@
+package sqlite
+
+import (
+	"bufio"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// cliDriver is a database/sql driver for tests that talks to the sqlite3
+// command-line shell over pipes, one shell process per connection, so the
+// package's SQL runs against real SQLite without a cgo or third-party
+// driver. Arguments are inlined as SQL literals and results are read in
+// the shell's quote mode.
+type cliDriver struct{}
+
+func init() { sql.Register("sqlite3cli", cliDriver{}) }
+
+func (cliDriver) Open(name string) (driver.Conn, error) {
+	path, err := exec.LookPath("sqlite3")
+	if err != nil {
+		return nil, err
+	}
+	cmd := exec.Command(path, "-batch", name)
+	in, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	r, w, err := os.Pipe()
+	if err != nil {
+		return nil, err
+	}
+	cmd.Stdout, cmd.Stderr = w, w
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	w.Close()
+	c := &cliConn{cmd: cmd, in: in, out: bufio.NewReader(r)}
+	if _, err := fmt.Fprintln(in, ".mode quote"); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+type cliConn struct {
+	cmd *exec.Cmd
+	in  io.WriteCloser
+	out *bufio.Reader
+	n   int
+}
+
+// run executes query and returns its result rows.
+func (c *cliConn) run(query string) ([][]driver.Value, error) {
+	c.n++
+	end := fmt.Sprintf("'end-%d'", c.n)
+	if _, err := fmt.Fprintf(c.in, "%s;\nSELECT %s;\n", strings.TrimRight(strings.TrimSpace(query), ";"), end); err != nil {
+		return nil, err
+	}
+	var rows [][]driver.Value
+	var failed error
+	var rec strings.Builder
+	for {
+		line, err := c.out.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("sqlite3: %w", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		if failed != nil || rec.Len() == 0 && isShellError(line) {
+			if failed == nil {
+				failed = errors.New(line)
+			}
+			if line == end {
+				return nil, failed
+			}
+			continue
+		}
+		rec.WriteString(line)
+		if strings.Count(rec.String(), "'")%2 != 0 {
+			rec.WriteByte('\n') // newline inside a string literal
+			continue
+		}
+		s := rec.String()
+		rec.Reset()
+		if s == end {
+			return rows, nil
+		}
+		row, err := parseQuoted(s)
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+}
+
+func isShellError(line string) bool {
+	return strings.HasPrefix(line, "Parse error") || strings.HasPrefix(line, "Runtime error") ||
+		strings.HasPrefix(line, "Error:")
+}
+
+// parseQuoted splits one quote-mode output row into values.
+func parseQuoted(s string) ([]driver.Value, error) {
+	var out []driver.Value
+	for len(s) > 0 {
+		var v driver.Value
+		switch {
+		case s[0] == '\'':
+			var b strings.Builder
+			i := 1
+			for ; i < len(s); i++ {
+				if s[i] == '\'' {
+					if i+1 < len(s) && s[i+1] == '\'' {
+						b.WriteByte('\'')
+						i++
+						continue
+					}
+					break
+				}
+				b.WriteByte(s[i])
+			}
+			v, s = b.String(), s[i+1:]
+		case strings.HasPrefix(s, "X'"):
+			j := strings.IndexByte(s[2:], '\'')
+			raw, err := hex.DecodeString(s[2 : 2+j])
+			if err != nil {
+				return nil, err
+			}
+			v, s = raw, s[3+j:]
+		default:
+			j := strings.IndexByte(s, ',')
+			if j < 0 {
+				j = len(s)
+			}
+			tok := s[:j]
+			s = s[j:]
+			switch {
+			case tok == "NULL":
+				v = nil
+			case strings.ContainsAny(tok, ".eE"):
+				f, err := strconv.ParseFloat(tok, 64)
+				if err != nil {
+					return nil, err
+				}
+				v = f
+			default:
+				n, err := strconv.ParseInt(tok, 10, 64)
+				if err != nil {
+					return nil, err
+				}
+				v = n
+			}
+		}
+		out = append(out, v)
+		s = strings.TrimPrefix(s, ",")
+	}
+	return out, nil
+}
+
+// bind replaces each ? outside string literals with the SQL literal of the
+// next argument.
+func bind(query string, args []driver.Value) (string, error) {
+	var b strings.Builder
+	quoted := false
+	for i := 0; i < len(query); i++ {
+		ch := query[i]
+		switch {
+		case ch == '\'':
+			quoted = !quoted
+		case ch == '?' && !quoted:
+			if len(args) == 0 {
+				return "", errors.New("sqlite3: too few arguments")
+			}
+			b.WriteString(literal(args[0]))
+			args = args[1:]
+			continue
+		}
+		b.WriteByte(ch)
+	}
+	if len(args) != 0 {
+		return "", errors.New("sqlite3: too many arguments")
+	}
+	return b.String(), nil
+}
+
+func literal(v driver.Value) string {
+	switch x := v.(type) {
+	case nil:
+		return "NULL"
+	case int64:
+		return strconv.FormatInt(x, 10)
+	case float64:
+		return strconv.FormatFloat(x, 'g', -1, 64)
+	case bool:
+		if x {
+			return "1"
+		}
+		return "0"
+	case []byte:
+		return "X'" + hex.EncodeToString(x) + "'"
+	case string:
+		return "'" + strings.ReplaceAll(x, "'", "''") + "'"
+	}
+	return fmt.Sprintf("'%v'", v)
+}
+
+func (c *cliConn) Prepare(query string) (driver.Stmt, error) { return &cliStmt{c, query}, nil }
+
+func (c *cliConn) Close() error {
+	c.in.Close()
+	return c.cmd.Wait()
+}
+
+func (c *cliConn) Begin() (driver.Tx, error) {
+	if _, err := c.run("BEGIN"); err != nil {
+		return nil, err
+	}
+	return cliTx{c}, nil
+}
+
+type cliTx struct{ c *cliConn }
+
+func (t cliTx) Commit() error {
+	_, err := t.c.run("COMMIT")
+	return err
+}
+
+func (t cliTx) Rollback() error {
+	_, err := t.c.run("ROLLBACK")
+	return err
+}
+
+type cliStmt struct {
+	c     *cliConn
+	query string
+}
+
+func (s *cliStmt) Close() error  { return nil }
+func (s *cliStmt) NumInput() int { return -1 }
+
+func (s *cliStmt) Exec(args []driver.Value) (driver.Result, error) {
+	q, err := bind(s.query, args)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.c.run(q + ";\nSELECT changes()")
+	if err != nil {
+		return nil, err
+	}
+	n := rows[len(rows)-1][0].(int64)
+	return driver.RowsAffected(n), nil
+}
+
+func (s *cliStmt) Query(args []driver.Value) (driver.Rows, error) {
+	q, err := bind(s.query, args)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.c.run(q)
+	if err != nil {
+		return nil, err
+	}
+	r := &cliRows{rows: rows}
+	if len(rows) > 0 {
+		r.cols = make([]string, len(rows[0]))
+		for i := range r.cols {
+			r.cols[i] = "c" + strconv.Itoa(i)
+		}
+	}
+	return r, nil
+}
+
+type cliRows struct {
+	cols []string
+	rows [][]driver.Value
+}
+
+func (r *cliRows) Columns() []string { return r.cols }
+func (r *cliRows) Close() error      { return nil }
+
+func (r *cliRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,16 @@
+// store/sqlite/doc.go
+// Package sqlite is a persistent local cache of Series backed by SQLite.
+//
+// A Store keeps one merged series per tenant and topic for windowed reads
+// (Upsert, Window) and also implements store.SeriesStore, keeping every
+// published version by topic and generated_at. Every field of a Series
+// round-trips, with timestamps at nanosecond precision. Open migrates
+// databases written by earlier versions of this package; the schema
+// version is kept in PRAGMA user_version.
+//
+// The package is driver-agnostic: callers open a *sql.DB with any SQLite
+// driver (for example mattn/go-sqlite3 or modernc.org/sqlite) and pass it to
+// Open, which keeps this module free of cgo and third-party dependencies.
+// SQLite 3.24 or newer is required for upserts. Foreign keys are enabled on
+// each connection before writing, so removing a topic removes its points.
+package sqlite
@@ -0,0 +1,599 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/codec"
+	"github.com/civic-interconnect/civic-transparency-go-types/store"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// Times are stored as INTEGER nanoseconds since the Unix epoch (UTC), so
+// sub-second timestamps survive a round trip. Maps and nested objects are
+// stored as JSON text.
+//
+// migrations[i] brings a database from schema version i to i+1, and PRAGMA
+// user_version records how many have run. Databases written before the
+// schema was versioned report version 0 and may already have some tables
+// and columns, so the first two steps tolerate them. Append new steps;
+// never edit released ones.
+var migrations = []func(ctx context.Context, tx *sql.Tx) error{
+	// 1: series and points keyed by topic.
+	execStep(`
+CREATE TABLE IF NOT EXISTS series (
+	topic        TEXT PRIMARY KEY,
+	interval     TEXT NOT NULL,
+	generated_at INTEGER NOT NULL
+);
+CREATE TABLE IF NOT EXISTS points (
+	topic                 TEXT    NOT NULL REFERENCES series(topic) ON DELETE CASCADE,
+	ts                    INTEGER NOT NULL,
+	volume                INTEGER NOT NULL,
+	reshare_ratio         REAL    NOT NULL,
+	recycled_content_rate REAL    NOT NULL,
+	acct_age_mix          TEXT    NOT NULL,
+	automation_mix        TEXT    NOT NULL,
+	client_mix            TEXT    NOT NULL,
+	burst_score           REAL    NOT NULL,
+	synchrony_index       REAL    NOT NULL,
+	duplication_clusters  INTEGER NOT NULL,
+	backfilled            INTEGER NOT NULL DEFAULT 0,
+	PRIMARY KEY (topic, ts)
+);
+CREATE INDEX IF NOT EXISTS points_ts ON points(ts);`),
+
+	// 2: series metadata, per-point breakdowns, and published versions.
+	func(ctx context.Context, tx *sql.Tx) error {
+		if err := addColumns(ctx, tx, "series",
+			"annotations  TEXT NOT NULL DEFAULT 'null'",
+			"retraction   TEXT NOT NULL DEFAULT 'null'",
+			"extensions   TEXT NOT NULL DEFAULT 'null'",
+			"tenant       TEXT NOT NULL DEFAULT ''",
+			"jurisdiction TEXT NOT NULL DEFAULT ''",
+			"methodology  TEXT NOT NULL DEFAULT 'null'"); err != nil {
+			return err
+		}
+		if err := addColumns(ctx, tx, "points",
+			"acct_type_shares TEXT NOT NULL DEFAULT 'null'",
+			"post_kind_mix    TEXT NOT NULL DEFAULT 'null'"); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `
+CREATE TABLE IF NOT EXISTS versions (
+	topic        TEXT    NOT NULL,
+	generated_at INTEGER NOT NULL,
+	etag         TEXT    NOT NULL,
+	digest       TEXT    NOT NULL,
+	body         BLOB    NOT NULL,
+	PRIMARY KEY (topic, generated_at)
+)`)
+		return err
+	},
+
+	// 3: key series and points on (tenant, topic), so tenants may share a
+	// topic. SQLite cannot change a primary key in place; the tables are
+	// rebuilt.
+	execStep(`
+CREATE TABLE series_v3 (
+	tenant       TEXT    NOT NULL DEFAULT '',
+	topic        TEXT    NOT NULL,
+	interval     TEXT    NOT NULL,
+	generated_at INTEGER NOT NULL,
+	annotations  TEXT    NOT NULL DEFAULT 'null',
+	retraction   TEXT    NOT NULL DEFAULT 'null',
+	extensions   TEXT    NOT NULL DEFAULT 'null',
+	jurisdiction TEXT    NOT NULL DEFAULT '',
+	methodology  TEXT    NOT NULL DEFAULT 'null',
+	PRIMARY KEY (tenant, topic)
+);
+INSERT INTO series_v3 (tenant, topic, interval, generated_at, annotations, retraction, extensions,
+	jurisdiction, methodology)
+SELECT tenant, topic, interval, generated_at, annotations, retraction, extensions,
+	jurisdiction, methodology FROM series;
+CREATE TABLE points_v3 (
+	tenant                TEXT    NOT NULL DEFAULT '',
+	topic                 TEXT    NOT NULL,
+	ts                    INTEGER NOT NULL,
+	volume                INTEGER NOT NULL,
+	reshare_ratio         REAL    NOT NULL,
+	recycled_content_rate REAL    NOT NULL,
+	acct_age_mix          TEXT    NOT NULL,
+	automation_mix        TEXT    NOT NULL,
+	client_mix            TEXT    NOT NULL,
+	acct_type_shares      TEXT    NOT NULL DEFAULT 'null',
+	post_kind_mix         TEXT    NOT NULL DEFAULT 'null',
+	burst_score           REAL    NOT NULL,
+	synchrony_index       REAL    NOT NULL,
+	duplication_clusters  INTEGER NOT NULL,
+	backfilled            INTEGER NOT NULL DEFAULT 0,
+	PRIMARY KEY (tenant, topic, ts),
+	FOREIGN KEY (tenant, topic) REFERENCES series(tenant, topic) ON DELETE CASCADE
+);
+INSERT INTO points_v3 (tenant, topic, ts, volume, reshare_ratio, recycled_content_rate,
+	acct_age_mix, automation_mix, client_mix, acct_type_shares, post_kind_mix,
+	burst_score, synchrony_index, duplication_clusters, backfilled)
+SELECT s.tenant, p.topic, p.ts, p.volume, p.reshare_ratio, p.recycled_content_rate,
+	p.acct_age_mix, p.automation_mix, p.client_mix, p.acct_type_shares, p.post_kind_mix,
+	p.burst_score, p.synchrony_index, p.duplication_clusters, p.backfilled
+FROM points p JOIN series s ON s.topic = p.topic;
+DROP TABLE points;
+DROP TABLE series;
+ALTER TABLE series_v3 RENAME TO series;
+ALTER TABLE points_v3 RENAME TO points;
+CREATE INDEX points_ts ON points(ts);`),
+}
+
+// execStep returns a migration that runs stmts.
+func execStep(stmts string) func(context.Context, *sql.Tx) error {
+	return func(ctx context.Context, tx *sql.Tx) error {
+		_, err := tx.ExecContext(ctx, stmts)
+		return err
+	}
+}
+
+// addColumns adds each column definition to table unless a column of that
+// name already exists.
+func addColumns(ctx context.Context, tx *sql.Tx, table string, defs ...string) error {
+	rows, err := tx.QueryContext(ctx, `SELECT name FROM pragma_table_info(?)`, table)
+	if err != nil {
+		return err
+	}
+	have := make(map[string]bool)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return err
+		}
+		have[name] = true
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	for _, def := range defs {
+		if have[strings.Fields(def)[0]] {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `ALTER TABLE `+table+` ADD COLUMN `+def); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ErrNotFound is returned by Window and Remove when the topic has never
+// been stored.
+var ErrNotFound = errors.New("sqlite: topic not found")
+
+// Options configures a Store.
+type Options struct {
+	// Retention, if positive, makes every Upsert delete points whose ts is
+	// older than now minus Retention.
+	Retention time.Duration
+	// Now overrides the clock used for automatic pruning (tests).
+	Now func() time.Time
+	// Format selects the encoding of versions written by Put; Get reads
+	// any codec Format.
+	Format codec.Format
+}
+
+// Store caches Series in a SQLite database. Upsert and Window maintain one
+// merged series per topic; Put, Get, ListWindow, and Delete keep every
+// published version and implement store.SeriesStore. It is safe for
+// concurrent use to the extent the underlying *sql.DB and driver are.
+type Store struct {
+	db   *sql.DB
+	opts Options
+}
+
+var _ store.SeriesStore = (*Store)(nil)
+
+// Open creates or migrates the schema as needed and returns a Store over
+// db.
+func Open(ctx context.Context, db *sql.DB, opts Options) (*Store, error) {
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if err := migrate(ctx, db); err != nil {
+		return nil, fmt.Errorf("sqlite: migrate schema: %w", err)
+	}
+	return &Store{db: db, opts: opts}, nil
+}
+
+// migrate runs the migrations db has not yet seen in one transaction, with
+// foreign keys off so tables can be rebuilt.
+func migrate(ctx context.Context, db *sql.DB) (err error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if _, err = conn.ExecContext(ctx, `PRAGMA foreign_keys = OFF`); err != nil {
+		return err
+	}
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	var version int
+	if err = tx.QueryRowContext(ctx, `PRAGMA user_version`).Scan(&version); err != nil {
+		return err
+	}
+	if version > len(migrations) {
+		return fmt.Errorf("schema version %d is newer than this package (%d)", version, len(migrations))
+	}
+	if version == len(migrations) {
+		return tx.Rollback()
+	}
+	for i := version; i < len(migrations); i++ {
+		if err = migrations[i](ctx, tx); err != nil {
+			return fmt.Errorf("to version %d: %w", i+1, err)
+		}
+	}
+	if _, err = tx.ExecContext(ctx, fmt.Sprintf(`PRAGMA user_version = %d`, len(migrations))); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// tx runs fn in a transaction on one connection with foreign keys
+// enforced. SQLite enables them per connection and ignores the pragma
+// inside a transaction, so it is set before each one.
+func (st *Store) tx(ctx context.Context, fn func(*sql.Tx) error) (err error) {
+	conn, err := st.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if _, err = conn.ExecContext(ctx, `PRAGMA foreign_keys = ON`); err != nil {
+		return fmt.Errorf("sqlite: enable foreign keys: %w", err)
+	}
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	if err = fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Upsert stores s under its tenant and topic, replacing any existing points
+// that share a ts with the incoming ones. Series-level fields are
+// overwritten.
+func (st *Store) Upsert(ctx context.Context, s *types.Series) error {
+	head, err := jsonColumns(s.Annotations, s.Retraction, s.Extensions, s.Methodology)
+	if err != nil {
+		return err
+	}
+	return st.tx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO series (tenant, topic, interval, generated_at, annotations, retraction, extensions,
+				jurisdiction, methodology)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(tenant, topic) DO UPDATE SET
+				interval = excluded.interval,
+				generated_at = excluded.generated_at,
+				annotations = excluded.annotations,
+				retraction = excluded.retraction,
+				extensions = excluded.extensions,
+				jurisdiction = excluded.jurisdiction,
+				methodology = excluded.methodology`,
+			s.Tenant, s.Topic, string(s.Interval), s.GeneratedAt.UTC().UnixNano(), head[0], head[1], head[2],
+			s.Jurisdiction, head[3]); err != nil {
+			return fmt.Errorf("sqlite: upsert series %q: %w", s.Topic, err)
+		}
+
+		stmt, err := tx.PrepareContext(ctx, `
+			INSERT INTO points (tenant, topic, ts, volume, reshare_ratio, recycled_content_rate,
+				acct_age_mix, automation_mix, client_mix, acct_type_shares, post_kind_mix,
+				burst_score, synchrony_index, duplication_clusters, backfilled)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(tenant, topic, ts) DO UPDATE SET
+				volume = excluded.volume,
+				reshare_ratio = excluded.reshare_ratio,
+				recycled_content_rate = excluded.recycled_content_rate,
+				acct_age_mix = excluded.acct_age_mix,
+				automation_mix = excluded.automation_mix,
+				client_mix = excluded.client_mix,
+				acct_type_shares = excluded.acct_type_shares,
+				post_kind_mix = excluded.post_kind_mix,
+				burst_score = excluded.burst_score,
+				synchrony_index = excluded.synchrony_index,
+				duplication_clusters = excluded.duplication_clusters,
+				backfilled = excluded.backfilled`)
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		for i, p := range s.Points {
+			mixes, err := jsonColumns(p.AcctAgeMix, p.AutomationMix, p.ClientMix, p.AcctTypeShares, p.PostKindMix)
+			if err != nil {
+				return fmt.Errorf("sqlite: points[%d]: %w", i, err)
+			}
+			if _, err := stmt.ExecContext(ctx, s.Tenant, s.Topic, p.TS.UTC().UnixNano(), p.Volume,
+				float64(p.ReshareRatio), float64(p.RecycledContentRate),
+				mixes[0], mixes[1], mixes[2], mixes[3], mixes[4],
+				float64(p.CoordinationSignals.BurstScore), float64(p.CoordinationSignals.SynchronyIndex),
+				p.CoordinationSignals.DuplicationClusters, p.Backfilled); err != nil {
+				return fmt.Errorf("sqlite: upsert points[%d]: %w", i, err)
+			}
+		}
+
+		if st.opts.Retention > 0 {
+			cutoff := st.opts.Now().Add(-st.opts.Retention)
+			if _, err := tx.ExecContext(ctx, `DELETE FROM points WHERE ts < ?`, cutoff.UTC().UnixNano()); err != nil {
+				return fmt.Errorf("sqlite: prune: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// Window returns the stored series for tenant and topic restricted to
+// points with from <= ts < to, ordered by ts. A zero from or to leaves that
+// side open. Series without a tenant are stored under "".
+func (st *Store) Window(ctx context.Context, tenant, topic string, from, to time.Time) (*types.Series, error) {
+	s := &types.Series{Tenant: tenant, Topic: topic}
+	var (
+		interval                               string
+		gen                                    int64
+		annotations, retraction, ext, methodol string
+	)
+	err := st.db.QueryRowContext(ctx, `
+		SELECT interval, generated_at, annotations, retraction, extensions, jurisdiction, methodology
+		FROM series WHERE tenant = ? AND topic = ?`, tenant, topic).Scan(&interval, &gen, &annotations, &retraction, &ext,
+		&s.Jurisdiction, &methodol)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.Interval = types.Interval(interval)
+	s.GeneratedAt = time.Unix(0, gen).UTC()
+	if err := unmarshalColumns([]string{annotations, retraction, ext, methodol},
+		&s.Annotations, &s.Retraction, &s.Extensions, &s.Methodology); err != nil {
+		return nil, fmt.Errorf("sqlite: series %q: %w", topic, err)
+	}
+
+	lo, hi := int64(-1<<63), int64(1<<63-1)
+	if !from.IsZero() {
+		lo = from.UTC().UnixNano()
+	}
+	if !to.IsZero() {
+		hi = to.UTC().UnixNano()
+	}
+	rows, err := st.db.QueryContext(ctx, `
+		SELECT ts, volume, reshare_ratio, recycled_content_rate,
+			acct_age_mix, automation_mix, client_mix, acct_type_shares, post_kind_mix,
+			burst_score, synchrony_index, duplication_clusters, backfilled
+		FROM points WHERE tenant = ? AND topic = ? AND ts >= ? AND ts < ? ORDER BY ts`, tenant, topic, lo, hi)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			p                           types.Point
+			ts                          int64
+			age, auto, client, acct, pk string
+			reshare, recycled           float64
+			burst, synchrony            float64
+		)
+		if err := rows.Scan(&ts, &p.Volume, &reshare, &recycled, &age, &auto, &client, &acct, &pk,
+			&burst, &synchrony, &p.CoordinationSignals.DuplicationClusters, &p.Backfilled); err != nil {
+			return nil, err
+		}
+		p.TS = time.Unix(0, ts).UTC()
+		p.ReshareRatio = types.Probability(reshare)
+		p.RecycledContentRate = types.Probability(recycled)
+		p.CoordinationSignals.BurstScore = types.Probability(burst)
+		p.CoordinationSignals.SynchronyIndex = types.Probability(synchrony)
+		if err := unmarshalColumns([]string{age, auto, client, acct, pk},
+			&p.AcctAgeMix, &p.AutomationMix, &p.ClientMix, &p.AcctTypeShares, &p.PostKindMix); err != nil {
+			return nil, fmt.Errorf("sqlite: point %s: %w", p.TS.Format(time.RFC3339Nano), err)
+		}
+		s.Points = append(s.Points, p)
+	}
+	return s, rows.Err()
+}
+
+// Topics returns all stored topics, across tenants, in lexical order.
+func (st *Store) Topics(ctx context.Context) ([]string, error) {
+	rows, err := st.db.QueryContext(ctx, `SELECT DISTINCT topic FROM series ORDER BY topic`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []string
+	for rows.Next() {
+		var t string
+		if err := rows.Scan(&t); err != nil {
+			return nil, err
+		}
+		out = append(out, t)
+	}
+	return out, rows.Err()
+}
+
+// Remove deletes the cached series for tenant and topic and, through the
+// foreign key, its points. Versions written by Put are kept.
+func (st *Store) Remove(ctx context.Context, tenant, topic string) error {
+	return st.tx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.ExecContext(ctx, `DELETE FROM series WHERE tenant = ? AND topic = ?`, tenant, topic)
+		if err != nil {
+			return fmt.Errorf("sqlite: remove %q: %w", topic, err)
+		}
+		if n, err := res.RowsAffected(); err == nil && n == 0 {
+			return ErrNotFound
+		}
+		return nil
+	})
+}
+
+// Prune deletes points with ts before cutoff across all topics and returns
+// the number of points removed.
+func (st *Store) Prune(ctx context.Context, cutoff time.Time) (int64, error) {
+	res, err := st.db.ExecContext(ctx, `DELETE FROM points WHERE ts < ?`, cutoff.UTC().UnixNano())
+	if err != nil {
+		return 0, fmt.Errorf("sqlite: prune: %w", err)
+	}
+	return res.RowsAffected()
+}
+
+// Put implements store.SeriesStore. The ETag of a version is the digest of
+// its encoded bytes.
+func (st *Store) Put(ctx context.Context, s *types.Series, cond store.Condition) (store.Info, error) {
+	body, err := codec.Marshal(s, st.opts.Format)
+	if err != nil {
+		return store.Info{}, fmt.Errorf("sqlite: encode: %w", err)
+	}
+	digest := store.Digest(body)
+	info := store.Info{Key: store.KeyOf(s), ETag: digest, Digest: digest, Size: int64(len(body))}
+	err = st.tx(ctx, func(tx *sql.Tx) error {
+		if err := checkCondition(ctx, tx, info.Key, cond); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO versions (topic, generated_at, etag, digest, body) VALUES (?, ?, ?, ?, ?)
+			ON CONFLICT(topic, generated_at) DO UPDATE SET
+				etag = excluded.etag, digest = excluded.digest, body = excluded.body`,
+			info.Key.Topic, info.Key.GeneratedAt.UnixNano(), info.ETag, info.Digest, body)
+		return err
+	})
+	if err != nil {
+		return store.Info{}, err
+	}
+	return info, nil
+}
+
+// Get implements store.SeriesStore.
+func (st *Store) Get(ctx context.Context, k store.Key) (*types.Series, store.Info, error) {
+	var etag, digest string
+	var body []byte
+	err := st.db.QueryRowContext(ctx, `SELECT etag, digest, body FROM versions WHERE topic = ? AND generated_at = ?`,
+		k.Topic, k.GeneratedAt.UTC().UnixNano()).Scan(&etag, &digest, &body)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, store.Info{}, store.ErrNotFound
+	}
+	if err != nil {
+		return nil, store.Info{}, err
+	}
+	info := store.Info{Key: k, ETag: etag, Digest: store.Digest(body), Size: int64(len(body))}
+	if info.Digest != digest {
+		return nil, info, fmt.Errorf("%w: %s at %s", store.ErrDigestMismatch, k.Topic, k.GeneratedAt.Format(time.RFC3339Nano))
+	}
+	var s types.Series
+	if err := codec.Unmarshal(body, &s); err != nil {
+		return nil, info, fmt.Errorf("sqlite: decode %s at %s: %w", k.Topic, k.GeneratedAt.Format(time.RFC3339Nano), err)
+	}
+	return &s, info, nil
+}
+
+// ListWindow implements store.SeriesStore.
+func (st *Store) ListWindow(ctx context.Context, topic string, from, to time.Time) ([]store.Info, error) {
+	lo, hi := int64(-1<<63), int64(1<<63-1)
+	if !from.IsZero() {
+		lo = from.UTC().UnixNano()
+	}
+	if !to.IsZero() {
+		hi = to.UTC().UnixNano()
+	}
+	rows, err := st.db.QueryContext(ctx, `
+		SELECT generated_at, etag, digest, length(body) FROM versions
+		WHERE topic = ? AND generated_at >= ? AND generated_at < ? ORDER BY generated_at`, topic, lo, hi)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []store.Info
+	for rows.Next() {
+		var gen int64
+		info := store.Info{Key: store.Key{Topic: topic}}
+		if err := rows.Scan(&gen, &info.ETag, &info.Digest, &info.Size); err != nil {
+			return nil, err
+		}
+		info.Key.GeneratedAt = time.Unix(0, gen).UTC()
+		out = append(out, info)
+	}
+	return out, rows.Err()
+}
+
+// Delete implements store.SeriesStore.
+func (st *Store) Delete(ctx context.Context, k store.Key, cond store.Condition) error {
+	return st.tx(ctx, func(tx *sql.Tx) error {
+		if err := checkCondition(ctx, tx, k, cond); err != nil {
+			return err
+		}
+		res, err := tx.ExecContext(ctx, `DELETE FROM versions WHERE topic = ? AND generated_at = ?`,
+			k.Topic, k.GeneratedAt.UTC().UnixNano())
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err == nil && n == 0 {
+			return store.ErrNotFound
+		}
+		return nil
+	})
+}
+
+// checkCondition reports store.ErrPreconditionFailed if cond does not hold
+// for the version under k, read within tx.
+func checkCondition(ctx context.Context, tx *sql.Tx, k store.Key, cond store.Condition) error {
+	if !cond.IfNoneMatch && cond.IfMatch == "" {
+		return nil
+	}
+	var etag string
+	err := tx.QueryRowContext(ctx, `SELECT etag FROM versions WHERE topic = ? AND generated_at = ?`,
+		k.Topic, k.GeneratedAt.UTC().UnixNano()).Scan(&etag)
+	exists := err == nil
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+	if (cond.IfNoneMatch && exists) || (cond.IfMatch != "" && etag != cond.IfMatch) {
+		return store.ErrPreconditionFailed
+	}
+	return nil
+}
+
+// jsonColumns encodes each value as JSON text.
+func jsonColumns(vs ...any) ([]string, error) {
+	out := make([]string, len(vs))
+	for i, v := range vs {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = string(b)
+	}
+	return out, nil
+}
+
+// unmarshalColumns decodes cols[i] into dst[i].
+func unmarshalColumns(cols []string, dst ...any) error {
+	for i, c := range cols {
+		if err := json.Unmarshal([]byte(c), dst[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,311 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/codec"
+	"github.com/civic-interconnect/civic-transparency-go-types/store"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func openDB(t *testing.T) *sql.DB {
+	t.Helper()
+	if _, err := exec.LookPath("sqlite3"); err != nil {
+		t.Skip("sqlite3 shell not installed")
+	}
+	db, err := sql.Open("sqlite3cli", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func openTest(t *testing.T, opts Options) *Store {
+	t.Helper()
+	st, err := Open(context.Background(), openDB(t), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return st
+}
+
+// fullSeries sets every Series and Point field, with sub-second times.
+func fullSeries() *types.Series {
+	t0 := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	return &types.Series{
+		Topic: "#vote", GeneratedAt: t0.Add(5*time.Minute + 123456789), Interval: types.IntervalMinute,
+		Points: []types.Point{
+			{
+				TS: t0, Volume: 1200, ReshareRatio: 0.375, RecycledContentRate: 0.125,
+				AcctAgeMix:          map[string]types.Probability{"0-7d": 0.25, "24m+": 0.75},
+				AutomationMix:       map[string]types.Probability{"manual": 1},
+				ClientMix:           map[string]types.Probability{"web": 0.5, "mobile": 0.5},
+				AcctTypeShares:      map[types.AcctType]types.Probability{types.AcctTypePerson: 0.75, types.AcctTypeOrg: 0.25},
+				PostKindMix:         map[types.PostKind]types.Probability{types.PostKindOriginal: 0.625, types.PostKindReshare: 0.375},
+				CoordinationSignals: types.CoordinationSignals{BurstScore: 0.5, SynchronyIndex: 0.25, DuplicationClusters: 3},
+			},
+			{TS: t0.Add(time.Minute + 500*time.Millisecond), Volume: 7, Backfilled: true},
+		},
+		Annotations:  []types.Annotation{{Start: t0, End: t0.Add(time.Hour), Kind: types.AnnotationElection, Note: "primary"}},
+		Retraction:   &types.Retraction{Topic: "#vote", Reason: types.RetractionDataError, EffectiveAt: t0.Add(time.Hour), Signature: "c2ln"},
+		Extensions:   types.Extensions{"x-example-region": json.RawMessage(`"emea"`)},
+		Tenant:       "us.fec",
+		Jurisdiction: "US-CA",
+		Methodology:  &types.Methodology{SamplingRate: 0.25, SuppressionThreshold: 10, Caveats: []string{"Deleted posts are excluded."}},
+	}
+}
+
+func TestUpsertWindowRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	st := openTest(t, Options{})
+	s := fullSeries()
+	if err := st.Upsert(ctx, s); err != nil {
+		t.Fatal(err)
+	}
+	got, err := st.Window(ctx, s.Tenant, s.Topic, time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, s) {
+		t.Errorf("round trip:\ngot  %+v\nwant %+v", got, s)
+	}
+
+	// Sub-second bounds select by the stored nanosecond ts.
+	w, err := st.Window(ctx, s.Tenant, s.Topic, s.Points[1].TS, time.Time{})
+	if err != nil || len(w.Points) != 1 || !w.Points[0].TS.Equal(s.Points[1].TS) {
+		t.Fatalf("Window(from ts[1]) = %+v, %v", w, err)
+	}
+
+	// Upsert replaces points sharing a ts and keeps the rest.
+	upd := fullSeries()
+	upd.Points = []types.Point{{TS: s.Points[0].TS, Volume: 1}}
+	upd.Methodology = nil
+	if err := st.Upsert(ctx, upd); err != nil {
+		t.Fatal(err)
+	}
+	got, err = st.Window(ctx, s.Tenant, s.Topic, time.Time{}, time.Time{})
+	if err != nil || len(got.Points) != 2 || got.Points[0].Volume != 1 || got.Methodology != nil {
+		t.Fatalf("after update = %+v, %v", got, err)
+	}
+}
+
+func TestTenantsShareTopic(t *testing.T) {
+	ctx := context.Background()
+	st := openTest(t, Options{})
+	a, b := fullSeries(), fullSeries()
+	b.Tenant, b.Points = "ca.elections", b.Points[:1]
+	for _, s := range []*types.Series{a, b} {
+		if err := st.Upsert(ctx, s); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, want := range []*types.Series{a, b} {
+		got, err := st.Window(ctx, want.Tenant, want.Topic, time.Time{}, time.Time{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("tenant %q:\ngot  %+v\nwant %+v", want.Tenant, got, want)
+		}
+	}
+	if topics, err := st.Topics(ctx); err != nil || !reflect.DeepEqual(topics, []string{"#vote"}) {
+		t.Errorf("Topics = %v, %v", topics, err)
+	}
+	if err := st.Remove(ctx, b.Tenant, b.Topic); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := st.Window(ctx, a.Tenant, a.Topic, time.Time{}, time.Time{}); err != nil || len(got.Points) != 2 {
+		t.Errorf("other tenant after Remove = %+v, %v", got, err)
+	}
+}
+
+// TestMigrateUnversioned opens a database written by the first release,
+// before the schema was versioned or keyed by tenant.
+func TestMigrateUnversioned(t *testing.T) {
+	ctx := context.Background()
+	db := openDB(t)
+	if _, err := db.ExecContext(ctx, `
+CREATE TABLE series (
+	topic        TEXT PRIMARY KEY,
+	interval     TEXT NOT NULL,
+	generated_at INTEGER NOT NULL
+);
+CREATE TABLE points (
+	topic                 TEXT    NOT NULL REFERENCES series(topic) ON DELETE CASCADE,
+	ts                    INTEGER NOT NULL,
+	volume                INTEGER NOT NULL,
+	reshare_ratio         REAL    NOT NULL,
+	recycled_content_rate REAL    NOT NULL,
+	acct_age_mix          TEXT    NOT NULL,
+	automation_mix        TEXT    NOT NULL,
+	client_mix            TEXT    NOT NULL,
+	burst_score           REAL    NOT NULL,
+	synchrony_index       REAL    NOT NULL,
+	duplication_clusters  INTEGER NOT NULL,
+	backfilled            INTEGER NOT NULL DEFAULT 0,
+	PRIMARY KEY (topic, ts)
+);
+INSERT INTO series VALUES ('#vote', 'minute', 60000000000);
+INSERT INTO points VALUES ('#vote', 0, 12, 0.5, 0, '{"0-7d":1}', '{"manual":1}', '{"web":1}', 0.25, 0, 1, 1)`); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ { // the second Open finds nothing to do
+		if _, err := Open(ctx, db, Options{}); err != nil {
+			t.Fatalf("Open #%d: %v", i+1, err)
+		}
+	}
+	var version int
+	if err := db.QueryRowContext(ctx, `PRAGMA user_version`).Scan(&version); err != nil || version != len(migrations) {
+		t.Fatalf("user_version = %d, %v; want %d", version, err, len(migrations))
+	}
+
+	st, err := Open(ctx, db, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := st.Window(ctx, "", "#vote", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := &types.Series{
+		Topic: "#vote", GeneratedAt: time.Unix(60, 0).UTC(), Interval: types.IntervalMinute,
+		Points: []types.Point{{
+			TS: time.Unix(0, 0).UTC(), Volume: 12, ReshareRatio: 0.5,
+			AcctAgeMix:          map[string]types.Probability{"0-7d": 1},
+			AutomationMix:       map[string]types.Probability{"manual": 1},
+			ClientMix:           map[string]types.Probability{"web": 1},
+			CoordinationSignals: types.CoordinationSignals{BurstScore: 0.25, DuplicationClusters: 1},
+			Backfilled:          true,
+		}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("migrated:\ngot  %+v\nwant %+v", got, want)
+	}
+
+	s := fullSeries()
+	if err := st.Upsert(ctx, s); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.Remove(ctx, "", "#vote"); err != nil {
+		t.Fatal(err)
+	}
+	var n int
+	if err := db.QueryRowContext(ctx, `SELECT count(*) FROM points WHERE tenant = ''`).Scan(&n); err != nil || n != 0 {
+		t.Fatalf("points left after Remove: %d, %v", n, err)
+	}
+	if _, err := st.Put(ctx, s, store.Condition{}); err != nil {
+		t.Fatalf("Put after migration: %v", err)
+	}
+}
+
+func TestRemoveCascades(t *testing.T) {
+	ctx := context.Background()
+	st := openTest(t, Options{})
+	s := fullSeries()
+	if err := st.Upsert(ctx, s); err != nil {
+		t.Fatal(err)
+	}
+	if err := st.Remove(ctx, s.Tenant, s.Topic); err != nil {
+		t.Fatal(err)
+	}
+	var n int
+	if err := st.db.QueryRowContext(ctx, `SELECT count(*) FROM points`).Scan(&n); err != nil || n != 0 {
+		t.Fatalf("points left after Remove: %d, %v", n, err)
+	}
+	if _, err := st.Window(ctx, s.Tenant, s.Topic, time.Time{}, time.Time{}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Window after Remove: %v", err)
+	}
+	if err := st.Remove(ctx, s.Tenant, s.Topic); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second Remove: %v", err)
+	}
+}
+
+func TestRetention(t *testing.T) {
+	ctx := context.Background()
+	s := fullSeries()
+	st := openTest(t, Options{Retention: time.Minute, Now: func() time.Time { return s.Points[1].TS.Add(30 * time.Second) }})
+	if err := st.Upsert(ctx, s); err != nil {
+		t.Fatal(err)
+	}
+	got, err := st.Window(ctx, s.Tenant, s.Topic, time.Time{}, time.Time{})
+	if err != nil || len(got.Points) != 1 || !got.Points[0].TS.Equal(s.Points[1].TS) {
+		t.Fatalf("after retention = %+v, %v", got, err)
+	}
+}
+
+func TestSeriesStore(t *testing.T) {
+	ctx := context.Background()
+	st := openTest(t, Options{Format: codec.Compact})
+	version := func(h int) *types.Series {
+		s := fullSeries()
+		s.GeneratedAt = s.GeneratedAt.Add(time.Duration(h) * time.Hour)
+		s.Points[0].Volume = 100 + h
+		return s
+	}
+
+	var infos []store.Info
+	for h := 0; h < 3; h++ {
+		info, err := st.Put(ctx, version(h), store.Condition{IfNoneMatch: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		infos = append(infos, info)
+	}
+	if _, err := st.Put(ctx, version(1), store.Condition{IfNoneMatch: true}); !errors.Is(err, store.ErrPreconditionFailed) {
+		t.Fatalf("duplicate create: %v", err)
+	}
+	if _, err := st.Put(ctx, version(1), store.Condition{IfMatch: "stale"}); !errors.Is(err, store.ErrPreconditionFailed) {
+		t.Fatalf("stale IfMatch: %v", err)
+	}
+	if _, err := st.Put(ctx, version(1), store.Condition{IfMatch: infos[1].ETag}); err != nil {
+		t.Fatalf("conditional overwrite: %v", err)
+	}
+
+	got, info, err := st.Get(ctx, infos[2].Key)
+	if err != nil || info.Digest != infos[2].Digest {
+		t.Fatalf("Get = %+v, %v", info, err)
+	}
+	if want := version(2); !reflect.DeepEqual(got, want) {
+		t.Errorf("Get round trip:\ngot  %+v\nwant %+v", got, want)
+	}
+
+	w, err := st.ListWindow(ctx, "#vote", infos[1].Key.GeneratedAt, infos[2].Key.GeneratedAt)
+	if err != nil || len(w) != 1 || w[0] != infos[1] {
+		t.Fatalf("ListWindow = %+v, %v", w, err)
+	}
+	if all, _ := st.ListWindow(ctx, "#vote", time.Time{}, time.Time{}); len(all) != 3 {
+		t.Fatalf("open ListWindow = %d versions, want 3", len(all))
+	}
+
+	if _, err := st.db.ExecContext(ctx, `UPDATE versions SET body = X'7B7D' WHERE generated_at = ?`,
+		infos[0].Key.GeneratedAt.UnixNano()); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := st.Get(ctx, infos[0].Key); !errors.Is(err, store.ErrDigestMismatch) {
+		t.Fatalf("tampered Get: %v", err)
+	}
+
+	if err := st.Delete(ctx, infos[2].Key, store.Condition{IfMatch: "stale"}); !errors.Is(err, store.ErrPreconditionFailed) {
+		t.Fatalf("stale Delete: %v", err)
+	}
+	if err := st.Delete(ctx, infos[2].Key, store.Condition{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := st.Get(ctx, infos[2].Key); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("Get after Delete: %v", err)
+	}
+	if err := st.Delete(ctx, infos[2].Key, store.Condition{}); !errors.Is(err, store.ErrNotFound) {
+		t.Fatalf("second Delete: %v", err)
+	}
+}
@@ -0,0 +1,68 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+var (
+	// ErrNotFound is returned when no object exists for a Key.
+	ErrNotFound = errors.New("store: not found")
+	// ErrPreconditionFailed is returned when a Condition does not hold.
+	ErrPreconditionFailed = errors.New("store: precondition failed")
+	// ErrDigestMismatch is returned by Get when stored bytes no longer match
+	// the digest recorded at Put.
+	ErrDigestMismatch = errors.New("store: content digest mismatch")
+)
+
+// Key identifies one published Series: a topic's generation at a time.
+type Key struct {
+	Topic       string
+	GeneratedAt time.Time
+}
+
+// KeyOf returns the Key under which s is stored.
+func KeyOf(s *types.Series) Key {
+	return Key{Topic: s.Topic, GeneratedAt: s.GeneratedAt.UTC()}
+}
+
+// Condition guards a write or delete. The zero Condition is unconditional.
+type Condition struct {
+	// IfNoneMatch requires that no object exists yet (create-only).
+	IfNoneMatch bool
+	// IfMatch, if set, requires the current object's ETag to equal it.
+	IfMatch string
+}
+
+// Info describes a stored Series.
+type Info struct {
+	Key    Key
+	ETag   string // opaque version token for Condition.IfMatch
+	Digest string // lowercase hex SHA-256 of the stored bytes
+	Size   int64
+}
+
+// SeriesStore persists Series by Key. Implementations must be safe for
+// concurrent use and must enforce Conditions atomically.
+type SeriesStore interface {
+	// Put stores s under KeyOf(s) if cond holds.
+	Put(ctx context.Context, s *types.Series, cond Condition) (Info, error)
+	// Get returns the Series stored under k, verifying its digest.
+	Get(ctx context.Context, k Key) (*types.Series, Info, error)
+	// ListWindow returns the objects for topic with from <= GeneratedAt < to,
+	// ordered by GeneratedAt. A zero from or to leaves that side open.
+	ListWindow(ctx context.Context, topic string, from, to time.Time) ([]Info, error)
+	// Delete removes the object under k if cond holds.
+	Delete(ctx context.Context, k Key, cond Condition) error
+}
+
+// Digest returns the lowercase hex SHA-256 of b, as recorded in Info.Digest.
+func Digest(b []byte) string {
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
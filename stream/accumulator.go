@@ -0,0 +1,109 @@
+package stream
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/seriesops"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// ErrOutOfOrder is returned by SeriesAccumulator.Add for a point older than
+// the latest one.
+var ErrOutOfOrder = errors.New("stream: point is older than the latest point")
+
+// accChunk is the number of points per chunk. Full chunks are never written
+// again, so every snapshot shares them.
+const accChunk = 128
+
+// SeriesAccumulator collects the points of an in-progress series while
+// concurrent readers take consistent snapshots of it.
+//
+// Points are held in fixed-size chunks. Writers only append past the end
+// of a chunk, or replace the latest point in a fresh copy of its chunk
+// (copy-on-write), and then publish an immutable view with an atomic store.
+// Snapshot loads the current view without locking, so readers never block
+// writers and never observe a half-applied Add. Writers serialize among
+// themselves.
+type SeriesAccumulator struct {
+	h    Header
+	mu   sync.Mutex // serializes writers
+	view atomic.Pointer[accView]
+}
+
+// accView is one published state. Its chunks are never modified.
+type accView struct {
+	chunks [][]types.Point // all full except possibly the last
+	n      int
+	latest time.Time
+}
+
+// NewSeriesAccumulator returns an empty accumulator for the series h.
+func NewSeriesAccumulator(h Header) *SeriesAccumulator {
+	a := &SeriesAccumulator{h: h}
+	a.view.Store(&accView{})
+	return a
+}
+
+// Add records p. A point with the same TS as the latest point is folded
+// into it with seriesops.Combine (e.g., a shard of the current minute
+// arriving late); a later TS starts a new point; an earlier TS returns
+// ErrOutOfOrder. The maps of p must not be modified after Add.
+func (a *SeriesAccumulator) Add(p types.Point) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	v := a.view.Load()
+	ts := p.TS.UTC()
+	nv := &accView{chunks: v.chunks, n: v.n, latest: ts}
+	switch {
+	case v.n > 0 && ts.Before(v.latest):
+		return ErrOutOfOrder
+	case v.n > 0 && ts.Equal(v.latest):
+		last := len(v.chunks) - 1
+		c := make([]types.Point, len(v.chunks[last]), accChunk)
+		copy(c, v.chunks[last])
+		merged := seriesops.Combine(c[len(c)-1], p)
+		merged.TS = c[len(c)-1].TS
+		c[len(c)-1] = merged
+		nv.chunks = append(v.chunks[:last:last], c)
+	default:
+		last := len(v.chunks) - 1
+		if last < 0 || len(v.chunks[last]) == accChunk {
+			c := make([]types.Point, 1, accChunk)
+			c[0] = p
+			nv.chunks = append(v.chunks[:len(v.chunks):len(v.chunks)], c)
+		} else {
+			// Writing past len(chunk) is invisible to existing views.
+			nv.chunks = append(v.chunks[:last:last], append(v.chunks[last], p))
+		}
+		nv.n++
+	}
+	a.view.Store(nv)
+	return nil
+}
+
+// Len returns the number of points recorded.
+func (a *SeriesAccumulator) Len() int { return a.view.Load().n }
+
+// Snapshot returns the series as of the latest completed Add. Its
+// GeneratedAt is the header's; set it to the snapshot time before
+// publishing a partial series. Points are copied into a new slice; their
+// maps are shared with the accumulator and must be treated as read-only.
+func (a *SeriesAccumulator) Snapshot() *types.Series {
+	v := a.view.Load()
+	pts := make([]types.Point, 0, v.n)
+	for _, c := range v.chunks {
+		pts = append(pts, c...)
+	}
+	return &types.Series{
+		Topic:        a.h.Topic,
+		GeneratedAt:  a.h.GeneratedAt,
+		Interval:     a.h.Interval,
+		Tenant:       a.h.Tenant,
+		Jurisdiction: a.h.Jurisdiction,
+		Methodology:  a.h.Methodology,
+		Points:       pts,
+	}
+}
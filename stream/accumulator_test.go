@@ -0,0 +1,62 @@
+package stream
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestSeriesAccumulatorSnapshots(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := NewSeriesAccumulator(Header{Topic: "#vote", Interval: types.IntervalMinute})
+	const n = 3 * accChunk / 2
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+	for r := 0; r < 4; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				s := a.Snapshot()
+				for i, p := range s.Points {
+					if !p.TS.Equal(t0.Add(time.Duration(i) * time.Minute)) {
+						t.Errorf("point %d at %v", i, p.TS)
+						return
+					}
+					// Every minute is added twice; only the latest may be
+					// half done.
+					if p.Volume != 2 && (i != len(s.Points)-1 || p.Volume != 1) {
+						t.Errorf("point %d of %d has volume %d", i, len(s.Points), p.Volume)
+						return
+					}
+				}
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		for k := 0; k < 2; k++ {
+			p := types.Point{TS: t0.Add(time.Duration(i) * time.Minute), Volume: 1, ReshareRatio: types.Probability(k)}
+			if err := a.Add(p); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	close(done)
+	wg.Wait()
+
+	s := a.Snapshot()
+	if a.Len() != n || len(s.Points) != n || s.Points[n-1].ReshareRatio != 0.5 {
+		t.Fatalf("len %d/%d, last %+v", a.Len(), len(s.Points), s.Points[n-1])
+	}
+	if err := a.Add(types.Point{TS: t0}); err != ErrOutOfOrder {
+		t.Fatalf("old point: err = %v", err)
+	}
+}
@@ -0,0 +1,9 @@
+// stream/doc.go
+// Package stream writes Series incrementally, so collectors can start
+// transmitting a minute's points before the series is finalized,
+// accumulates in-progress series that live APIs can snapshot while
+// collection continues, frames series as checksummed records (see package
+// recordio) for archives that must survive torn writes, and reads large
+// NDJSON dumps of ProvenanceTags by random access through a line index
+// over a memory-mapped file.
+package stream
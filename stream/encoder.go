@@ -0,0 +1,158 @@
+package stream
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/recordio"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// Mode selects the stream framing.
+type Mode int
+
+const (
+	// JSON writes a single Series object whose "points" array grows as
+	// points are appended. The output is valid JSON once Close returns.
+	JSON Mode = iota
+	// NDJSON writes a header line with the series fields, then one line per
+	// point. Every line is a complete JSON value.
+	NDJSON
+	// Framed writes the records of NDJSON mode as recordio frames, for
+	// append-only archives that must survive torn writes. Read it back with
+	// ReadFramed.
+	Framed
+)
+
+// ErrClosed is returned when writing to a closed SeriesEncoder.
+var ErrClosed = errors.New("stream: encoder closed")
+
+// Header carries the series-level fields written before any point.
+type Header struct {
+	Topic        string             `json:"topic"`
+	GeneratedAt  time.Time          `json:"generated_at"`
+	Interval     types.Interval     `json:"interval"`
+	Tenant       string             `json:"tenant,omitempty"`
+	Jurisdiction string             `json:"jurisdiction,omitempty"`
+	Methodology  *types.Methodology `json:"methodology,omitempty"`
+}
+
+// flusher matches http.Flusher without importing net/http.
+type flusher interface{ Flush() }
+
+// SeriesEncoder streams one Series. Each Append is flushed through to the
+// underlying writer (and its Flush method, if any) before returning.
+// It is not safe for concurrent use.
+type SeriesEncoder struct {
+	w      io.Writer
+	bw     *bufio.Writer
+	mode   Mode
+	rw     *recordio.Writer // Framed mode
+	n      int
+	closed bool
+}
+
+// NewSeriesEncoder writes h to w and returns an encoder for its points.
+func NewSeriesEncoder(w io.Writer, h Header, mode Mode) (*SeriesEncoder, error) {
+	e := &SeriesEncoder{w: w, bw: bufio.NewWriter(w), mode: mode}
+	e.rw = recordio.NewWriter(e.bw)
+	hb, err := json.Marshal(h)
+	if err != nil {
+		return nil, err
+	}
+	switch mode {
+	case NDJSON, Framed:
+		if err := e.record(hb); err != nil {
+			return nil, err
+		}
+	default:
+		// Reopen the header object and start the points array.
+		e.bw.Write(hb[:len(hb)-1])
+		e.bw.WriteString(`,"points":[`)
+	}
+	return e, e.flush()
+}
+
+// Append writes p and flushes it.
+func (e *SeriesEncoder) Append(p types.Point) error {
+	if e.closed {
+		return ErrClosed
+	}
+	b, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+	if e.mode == NDJSON || e.mode == Framed {
+		if err := e.record(b); err != nil {
+			return err
+		}
+	} else {
+		if e.n > 0 {
+			e.bw.WriteByte(',')
+		}
+		e.bw.Write(b)
+	}
+	e.n++
+	return e.flush()
+}
+
+// Close terminates the document. In JSON mode, annotations, if any, are
+// written after the points. The underlying writer is not closed.
+func (e *SeriesEncoder) Close(annotations ...types.Annotation) error {
+	if e.closed {
+		return ErrClosed
+	}
+	e.closed = true
+	if e.mode == NDJSON || e.mode == Framed {
+		for _, a := range annotations {
+			b, err := json.Marshal(struct {
+				Annotation types.Annotation `json:"annotation"`
+			}{a})
+			if err != nil {
+				return err
+			}
+			if err := e.record(b); err != nil {
+				return err
+			}
+		}
+		return e.flush()
+	}
+	e.bw.WriteByte(']')
+	if len(annotations) > 0 {
+		b, err := json.Marshal(annotations)
+		if err != nil {
+			return err
+		}
+		e.bw.WriteString(`,"annotations":`)
+		e.bw.Write(b)
+	}
+	e.bw.WriteString("}\n")
+	return e.flush()
+}
+
+// record writes one NDJSON line or Framed record to the buffer. Write
+// errors surface from the following flush.
+func (e *SeriesEncoder) record(b []byte) error {
+	if e.mode == Framed {
+		return e.rw.Append(b)
+	}
+	e.bw.Write(b)
+	e.bw.WriteByte('\n')
+	return nil
+}
+
+// Len returns the number of points written.
+func (e *SeriesEncoder) Len() int { return e.n }
+
+func (e *SeriesEncoder) flush() error {
+	if err := e.bw.Flush(); err != nil {
+		return err
+	}
+	if f, ok := e.w.(flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package stream
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/recordio"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestJSONModeProducesSeries(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	enc, err := NewSeriesEncoder(&buf, Header{Topic: "#vote", GeneratedAt: t0, Interval: types.IntervalMinute}, JSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := enc.Append(types.Point{TS: t0.Add(time.Duration(i) * time.Minute), Volume: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := enc.Close(types.Annotation{Start: t0, Kind: types.AnnotationOther}); err != nil {
+		t.Fatal(err)
+	}
+	var s types.Series
+	if err := json.Unmarshal(buf.Bytes(), &s); err != nil {
+		t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if s.Topic != "#vote" || len(s.Points) != 3 || s.Points[2].Volume != 2 || len(s.Annotations) != 1 {
+		t.Errorf("decoded = %+v", s)
+	}
+	if err := enc.Append(types.Point{}); err != ErrClosed {
+		t.Errorf("Append after Close = %v, want ErrClosed", err)
+	}
+}
+
+func TestFramedModeSurvivesTornWrite(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	enc, err := NewSeriesEncoder(&buf, Header{Topic: "#vote", GeneratedAt: t0, Interval: types.IntervalMinute}, Framed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		if err := enc.Append(types.Point{TS: t0.Add(time.Duration(i) * time.Minute), Volume: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := enc.Close(types.Annotation{Start: t0, Kind: types.AnnotationOther}); err != nil {
+		t.Fatal(err)
+	}
+	s, n, err := ReadFramed(bytes.NewReader(buf.Bytes()), false)
+	if err != nil || n != 0 || s.Topic != "#vote" || len(s.Points) != 3 || len(s.Annotations) != 1 {
+		t.Fatalf("ReadFramed = %+v, %d, %v", s, n, err)
+	}
+
+	torn := buf.Bytes()[:buf.Len()-5]
+	if _, _, err := ReadFramed(bytes.NewReader(torn), false); !errors.Is(err, recordio.ErrCorrupt) {
+		t.Errorf("strict read of torn stream: err = %v", err)
+	}
+	s, n, err = ReadFramed(bytes.NewReader(torn), true)
+	if err != nil || n != 1 || len(s.Points) != 3 || len(s.Annotations) != 0 {
+		t.Errorf("salvage read of torn stream = %+v, %d, %v", s, n, err)
+	}
+}
@@ -0,0 +1,23 @@
+package stream_test
+
+import (
+	"os"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/stream"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func ExampleNewSeriesEncoder() {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	h := stream.Header{Topic: "#vote", GeneratedAt: t0.Add(2 * time.Minute), Interval: types.IntervalMinute}
+
+	enc, _ := stream.NewSeriesEncoder(os.Stdout, h, stream.NDJSON)
+	_ = enc.Append(types.Point{TS: t0, Volume: 3})
+	_ = enc.Append(types.Point{TS: t0.Add(time.Minute), Volume: 5})
+	_ = enc.Close()
+	// Output:
+	// {"topic":"#vote","generated_at":"2025-01-01T00:02:00Z","interval":"minute"}
+	// {"ts":"2025-01-01T00:00:00Z","volume":3,"reshare_ratio":0,"recycled_content_rate":0,"acct_age_mix":null,"automation_mix":null,"client_mix":null,"coordination_signals":{"burst_score":0,"synchrony_index":0,"duplication_clusters":0}}
+	// {"ts":"2025-01-01T00:01:00Z","volume":5,"reshare_ratio":0,"recycled_content_rate":0,"acct_age_mix":null,"automation_mix":null,"client_mix":null,"coordination_signals":{"burst_score":0,"synchrony_index":0,"duplication_clusters":0}}
+}
@@ -0,0 +1,69 @@
+package stream
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/recordio"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// ErrNoHeader is returned by ReadFramed when no header record was found.
+var ErrNoHeader = errors.New("stream: framed series has no header")
+
+// ReadFramed reads a series written by a SeriesEncoder in Framed mode. A
+// stream whose encoder was never closed (the writer crashed) reads as the
+// points written so far.
+//
+// With salvage false, the first corrupt or torn record is an error
+// wrapping recordio.ErrCorrupt. With salvage true, corrupt records are
+// skipped and counted in the returned int; records that pass their
+// checksum but do not decode are still errors, since they indicate a
+// writer bug rather than damage.
+func ReadFramed(r io.Reader, salvage bool) (*types.Series, int, error) {
+	rr := recordio.NewReader(r)
+	rr.Salvage = salvage
+	var s *types.Series
+	for rr.Next() {
+		var rec struct {
+			Topic      *string           `json:"topic"`
+			Annotation *types.Annotation `json:"annotation"`
+		}
+		if err := json.Unmarshal(rr.Record(), &rec); err != nil {
+			return s, rr.Corrupt(), fmt.Errorf("stream: record at offset %d: %w", rr.Offset(), err)
+		}
+		switch {
+		case rec.Topic != nil:
+			var h Header
+			if s != nil {
+				return s, rr.Corrupt(), fmt.Errorf("stream: record at offset %d: second header", rr.Offset())
+			}
+			if err := json.Unmarshal(rr.Record(), &h); err != nil {
+				return s, rr.Corrupt(), fmt.Errorf("stream: record at offset %d: %w", rr.Offset(), err)
+			}
+			s = &types.Series{
+				Topic: h.Topic, GeneratedAt: h.GeneratedAt, Interval: h.Interval,
+				Tenant: h.Tenant, Jurisdiction: h.Jurisdiction, Methodology: h.Methodology,
+			}
+		case s == nil:
+			return nil, rr.Corrupt(), ErrNoHeader
+		case rec.Annotation != nil:
+			s.Annotations = append(s.Annotations, *rec.Annotation)
+		default:
+			var p types.Point
+			if err := json.Unmarshal(rr.Record(), &p); err != nil {
+				return s, rr.Corrupt(), fmt.Errorf("stream: record at offset %d: %w", rr.Offset(), err)
+			}
+			s.Points = append(s.Points, p)
+		}
+	}
+	if err := rr.Err(); err != nil {
+		return s, rr.Corrupt(), fmt.Errorf("stream: %w", err)
+	}
+	if s == nil {
+		return nil, rr.Corrupt(), ErrNoHeader
+	}
+	return s, rr.Corrupt(), nil
+}
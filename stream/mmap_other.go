@@ -0,0 +1,11 @@
+//go:build !unix
+
+package stream
+
+import "os"
+
+// mapFile reads path into memory on platforms without syscall.Mmap.
+func mapFile(path string) ([]byte, func() error, error) {
+	data, err := os.ReadFile(path)
+	return data, nil, err
+}
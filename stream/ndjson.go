@@ -0,0 +1,121 @@
+package stream
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// NDJSONIndex is a line-offset index over an NDJSON file whose bytes are
+// memory-mapped where the platform supports it. Lines are addressed by
+// record number, counting only non-blank lines. Raw returns slices of the
+// mapping itself, so they are valid only until Close.
+type NDJSONIndex struct {
+	data   []byte
+	starts []int
+	unmap  func() error
+}
+
+// IndexNDJSON maps the file at path and records where each non-blank line
+// starts. It does not decode any records.
+func IndexNDJSON(path string) (*NDJSONIndex, error) {
+	data, unmap, err := mapFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("stream: index %s: %w", path, err)
+	}
+	return &NDJSONIndex{data: data, starts: lineStarts(data), unmap: unmap}, nil
+}
+
+func lineStarts(data []byte) []int {
+	starts := make([]int, 0, bytes.Count(data, []byte{'\n'})+1)
+	for off := 0; off < len(data); {
+		end := bytes.IndexByte(data[off:], '\n')
+		if end < 0 {
+			end = len(data) - off
+		}
+		if len(bytes.TrimSpace(data[off:off+end])) > 0 {
+			starts = append(starts, off)
+		}
+		off += end + 1
+	}
+	return starts
+}
+
+// Len returns the number of records.
+func (x *NDJSONIndex) Len() int { return len(x.starts) }
+
+// Raw returns record i without its line terminator. The slice aliases the
+// mapped file and must not be modified or used after Close.
+func (x *NDJSONIndex) Raw(i int) []byte {
+	line := x.data[x.starts[i]:]
+	if end := bytes.IndexByte(line, '\n'); end >= 0 {
+		line = line[:end]
+	}
+	return bytes.TrimSuffix(line, []byte{'\r'})
+}
+
+// Tag decodes record i.
+func (x *NDJSONIndex) Tag(i int) (types.ProvenanceTag, error) {
+	var t types.ProvenanceTag
+	if err := json.Unmarshal(x.Raw(i), &t); err != nil {
+		return t, fmt.Errorf("stream: record %d: %w", i, err)
+	}
+	return t, nil
+}
+
+// Range returns a reader over records from <= i < to, clamped to the
+// index. Records are decoded one at a time as the reader advances.
+func (x *NDJSONIndex) Range(from, to int) *TagReader {
+	from, to = max(from, 0), min(to, x.Len())
+	return &TagReader{x: x, next: from, end: max(from, to)}
+}
+
+// Close releases the mapping. Slices returned by Raw become invalid.
+func (x *NDJSONIndex) Close() error {
+	x.data, x.starts = nil, nil
+	if x.unmap == nil {
+		return nil
+	}
+	err := x.unmap()
+	x.unmap = nil
+	return err
+}
+
+// TagReader iterates a range of an NDJSONIndex, in the style of
+// bufio.Scanner:
+//
+//	r := idx.Range(1000, 2000)
+//	for r.Next() {
+//		use(r.Index(), r.Tag())
+//	}
+//	if err := r.Err(); err != nil { ... }
+type TagReader struct {
+	x         *NDJSONIndex
+	next, end int
+	cur       int
+	tag       types.ProvenanceTag
+	err       error
+}
+
+// Next decodes the next record and reports whether one was available. It
+// returns false at the end of the range or on the first decode error.
+func (r *TagReader) Next() bool {
+	if r.err != nil || r.next >= r.end {
+		return false
+	}
+	r.cur = r.next
+	r.next++
+	r.tag, r.err = r.x.Tag(r.cur)
+	return r.err == nil
+}
+
+// Tag returns the record decoded by the last successful Next.
+func (r *TagReader) Tag() types.ProvenanceTag { return r.tag }
+
+// Index returns the record number of Tag.
+func (r *TagReader) Index() int { return r.cur }
+
+// Err returns the decode error that stopped iteration, if any.
+func (r *TagReader) Err() error { return r.err }
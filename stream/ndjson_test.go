@@ -0,0 +1,57 @@
+package stream
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestIndexNDJSON(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < 10; i++ {
+		fmt.Fprintf(&b, `{"acct_type":"person","dedup_hash":"%08x"}`+"\r\n", i)
+		if i == 4 {
+			b.WriteString("\n   \n") // blank lines are not records
+		}
+	}
+	b.WriteString(`{"acct_type":"org","dedup_hash":"ffffffff"}`) // no trailing newline
+	path := filepath.Join(t.TempDir(), "tags.ndjson")
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	idx, err := IndexNDJSON(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer idx.Close()
+	if idx.Len() != 11 {
+		t.Fatalf("Len = %d, want 11", idx.Len())
+	}
+	if got := string(idx.Raw(10)); got != `{"acct_type":"org","dedup_hash":"ffffffff"}` {
+		t.Fatalf("Raw(10) = %q", got)
+	}
+
+	r := idx.Range(5, 100)
+	var hashes []string
+	for r.Next() {
+		hashes = append(hashes, fmt.Sprint(r.Index(), ":", r.Tag().DedupHash))
+	}
+	if err := r.Err(); err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(hashes, " "); got != "5:00000005 6:00000006 7:00000007 8:00000008 9:00000009 10:ffffffff" {
+		t.Fatalf("Range(5, 100) = %s", got)
+	}
+	if r := idx.Range(3, 1); r.Next() {
+		t.Fatal("empty range yielded a record")
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.ndjson")
+	os.WriteFile(empty, nil, 0o644)
+	if x, err := IndexNDJSON(empty); err != nil || x.Len() != 0 || x.Close() != nil {
+		t.Fatalf("empty file: %v", err)
+	}
+}
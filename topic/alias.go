@@ -0,0 +1,188 @@
+package topic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+)
+
+// AnyPlatform is the platform of aliases that apply to every platform.
+const AnyPlatform = "*"
+
+// Alias maps one platform's label to a canonical topic.
+type Alias struct {
+	Platform  string // platform name, or AnyPlatform
+	Label     string // topic as the platform spells it
+	Canonical string // civic topic it denotes
+}
+
+// AmbiguityError reports a label mapped to two canonical topics on the same
+// platform.
+type AmbiguityError struct {
+	Platform   string
+	Label      string
+	Canonicals [2]string // existing mapping, then the rejected one
+}
+
+func (e *AmbiguityError) Error() string {
+	return fmt.Sprintf("topic: %s label %q maps to both %q and %q", e.Platform, e.Label, e.Canonicals[0], e.Canonicals[1])
+}
+
+// AliasMap maps platform-specific topic labels to canonical civic topics so
+// series from several platforms align on one taxonomy. Labels are compared
+// by Key under the map's Options. A platform-specific alias takes
+// precedence over an AnyPlatform alias for the same label, and every
+// canonical topic matches itself.
+//
+// The zero value is not usable; call NewAliasMap. An AliasMap is safe for
+// concurrent use.
+type AliasMap struct {
+	opts Options
+
+	mu      sync.RWMutex
+	forward map[aliasKey]Alias
+	canon   map[string]string  // Key(canonical) -> canonical
+	reverse map[string][]Alias // canonical -> aliases, in insertion order
+}
+
+type aliasKey struct{ platform, key string }
+
+// NewAliasMap returns an empty AliasMap comparing labels under opts.
+func NewAliasMap(opts Options) *AliasMap {
+	return &AliasMap{
+		opts:    opts,
+		forward: map[aliasKey]Alias{},
+		canon:   map[string]string{},
+		reverse: map[string][]Alias{},
+	}
+}
+
+// Add maps label on platform (or AnyPlatform) to canonical. Re-adding an
+// identical mapping is a no-op. Mapping a label that already resolves to a
+// different canonical topic on the same platform returns an
+// *AmbiguityError and leaves the map unchanged.
+func (m *AliasMap) Add(platform, label, canonical string) error {
+	if platform == "" {
+		platform = AnyPlatform
+	}
+	if label == "" || canonical == "" {
+		return fmt.Errorf("topic: alias needs a label and a canonical topic")
+	}
+	k := aliasKey{platform, Key(label, m.opts)}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if prev, ok := m.forward[k]; ok {
+		if prev.Canonical == canonical {
+			return nil
+		}
+		return &AmbiguityError{Platform: platform, Label: label, Canonicals: [2]string{prev.Canonical, canonical}}
+	}
+	// A canonical topic matches itself on every platform, so it must not
+	// collide with another canonical topic or an AnyPlatform alias.
+	ck := Key(canonical, m.opts)
+	if c, ok := m.canon[ck]; ok && c != canonical {
+		return &AmbiguityError{Platform: AnyPlatform, Label: canonical, Canonicals: [2]string{c, canonical}}
+	}
+	if a, ok := m.forward[aliasKey{AnyPlatform, ck}]; ok && a.Canonical != canonical {
+		return &AmbiguityError{Platform: AnyPlatform, Label: canonical, Canonicals: [2]string{a.Canonical, canonical}}
+	}
+	if c, ok := m.canon[k.key]; ok && c != canonical && platform == AnyPlatform {
+		return &AmbiguityError{Platform: platform, Label: label, Canonicals: [2]string{c, canonical}}
+	}
+	a := Alias{Platform: platform, Label: label, Canonical: canonical}
+	m.forward[k] = a
+	m.canon[ck] = canonical
+	m.reverse[canonical] = append(m.reverse[canonical], a)
+	return nil
+}
+
+// Canonical returns the canonical topic for label as posted on platform.
+// It reports false when label is neither an alias nor a canonical topic.
+func (m *AliasMap) Canonical(platform, label string) (string, bool) {
+	k := Key(label, m.opts)
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if a, ok := m.forward[aliasKey{platform, k}]; ok && platform != "" {
+		return a.Canonical, true
+	}
+	if a, ok := m.forward[aliasKey{AnyPlatform, k}]; ok {
+		return a.Canonical, true
+	}
+	c, ok := m.canon[k]
+	return c, ok
+}
+
+// Aliases returns the aliases of canonical in the order they were added.
+func (m *AliasMap) Aliases(canonical string) []Alias {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]Alias(nil), m.reverse[canonical]...)
+}
+
+// Topics returns the canonical topics, sorted.
+func (m *AliasMap) Topics() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make([]string, 0, len(m.reverse))
+	for c := range m.reverse {
+		out = append(out, c)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// LoadAliasMap reads a JSON alias file of the form
+//
+//	{
+//	  "#election": {"*": ["#elections"], "bsky": ["#vote2024"]},
+//	  "#ballot":   {"mastodon": ["#stimmzettel"]}
+//	}
+//
+// mapping each canonical topic to its labels per platform ("*" for every
+// platform). All ambiguities are reported together in one error joined with
+// errors.Join; use errors.As to reach each *AmbiguityError.
+func LoadAliasMap(r io.Reader, opts Options) (*AliasMap, error) {
+	var doc map[string]map[string][]string
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("topic: alias file: %w", err)
+	}
+	m := NewAliasMap(opts)
+	canonicals := make([]string, 0, len(doc))
+	for c := range doc {
+		canonicals = append(canonicals, c)
+	}
+	sort.Strings(canonicals)
+	var errs []error
+	for _, c := range canonicals {
+		platforms := make([]string, 0, len(doc[c]))
+		for p := range doc[c] {
+			platforms = append(platforms, p)
+		}
+		sort.Strings(platforms)
+		for _, p := range platforms {
+			for _, label := range doc[c][p] {
+				if err := m.Add(p, label, c); err != nil {
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// LoadAliasFile is LoadAliasMap on the named file.
+func LoadAliasFile(path string, opts Options) (*AliasMap, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return LoadAliasMap(f, opts)
+}
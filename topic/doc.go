@@ -0,0 +1,6 @@
+// topic/doc.go
+// Package topic compares and normalizes Series topics (hashtags, keywords)
+// across scripts and locales using Unicode case folding and optional
+// diacritic stripping. NewMatcher resolves variants to a canonical topic;
+// NewStrictMatcher accepts only exact spellings.
+package topic
@@ -0,0 +1,65 @@
+package topic_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/topic"
+)
+
+func ExampleEqual() {
+	fmt.Println(topic.Equal("#Élection", "#election", topic.Options{}))
+	fmt.Println(topic.Equal("#Élection", "#election", topic.Options{StripDiacritics: true}))
+	fmt.Println(topic.Equal("#Straße", "#STRASSE", topic.Options{}))
+	fmt.Println(topic.Equal("#Istanbul", "#ıstanbul", topic.Options{Turkic: true}))
+	fmt.Println(topic.Equal("#Café", "#café", topic.Options{StripDiacritics: true}))
+	// Output:
+	// false
+	// true
+	// true
+	// true
+	// true
+}
+
+func ExampleMatcher() {
+	m := topic.NewMatcher(topic.Options{StripDiacritics: true}, "#Élection2024", "#Vote")
+	fmt.Println(m.Match("#ELECTION2024"))
+
+	strict := topic.NewStrictMatcher("#Élection2024", "#Vote")
+	fmt.Println(strict.Match("#ELECTION2024"))
+	fmt.Println(strict.Match("#Vote"))
+	// Output:
+	// #Élection2024 true
+	//  false
+	// #Vote true
+}
+
+func ExampleLoadAliasMap() {
+	m, err := topic.LoadAliasMap(strings.NewReader(`{
+		"#election": {"*": ["#elections"], "bsky": ["#vote2024"]},
+		"#ballot":   {"mastodon": ["#Stimmzettel"]}
+	}`), topic.Options{})
+	fmt.Println(err)
+	fmt.Println(m.Canonical("bsky", "#Vote2024"))
+	fmt.Println(m.Canonical("mastodon", "#vote2024"))
+	fmt.Println(m.Canonical("x", "#ELECTIONS"))
+	for _, a := range m.Aliases("#election") {
+		fmt.Println(a.Platform, a.Label)
+	}
+
+	_, err = topic.LoadAliasMap(strings.NewReader(`{
+		"#election": {"*": ["#vote"]},
+		"#turnout":  {"*": ["#Vote"]}
+	}`), topic.Options{})
+	var amb *topic.AmbiguityError
+	fmt.Println(errors.As(err, &amb), err)
+	// Output:
+	// <nil>
+	// #election true
+	//  false
+	// #election true
+	// * #elections
+	// bsky #vote2024
+	// true topic: * label "#Vote" maps to both "#election" and "#turnout"
+}
@@ -0,0 +1,44 @@
+package topic
+
+// latinBase maps precomposed lowercase Latin letters to their unaccented
+// base (Latin-1 Supplement, Latin Extended-A/B, Latin Extended Additional)
+// plus a few letters without a canonical decomposition. Decomposed input is
+// handled separately by dropping combining marks.
+var latinBase = func() map[rune]string {
+	groups := map[string]string{
+		"a": "àáâãäåāăąǎǟǡǻȁȃȧḁạảấầẩẫậắằẳẵặ",
+		"b": "ḃḅḇ",
+		"c": "çćĉċčḉ",
+		"d": "ďḋḍḏḑḓ",
+		"e": "èéêëēĕėęěȅȇȩḕḗḙḛḝẹẻẽếềểễệ",
+		"f": "ḟ",
+		"g": "ĝğġģǧǵḡ",
+		"h": "ĥȟḣḥḧḩḫẖ",
+		"i": "ìíîïĩīĭįǐȉȋḭḯỉị",
+		"j": "ĵǰ",
+		"k": "ķǩḱḳḵ",
+		"l": "ĺļľḷḹḻḽ",
+		"m": "ḿṁṃ",
+		"n": "ñńņňǹṅṇṉṋ",
+		"o": "òóôõöōŏőơǒǫǭȍȏȫȭȯȱṍṏṑṓọỏốồổỗộớờởỡợ",
+		"p": "ṕṗ",
+		"r": "ŕŗřȑȓṙṛṝṟ",
+		"s": "śŝşšșṡṣṥṧṩ",
+		"t": "ţťțṫṭṯṱẗ",
+		"u": "ùúûüũūŭůűųưǔǖǘǚǜȕȗṳṵṷṹṻụủứừửữự",
+		"v": "ṽṿ",
+		"w": "ŵẁẃẅẇẉẘ",
+		"x": "ẋẍ",
+		"y": "ýÿŷȳẏẙỳỵỷỹ",
+		"z": "źżžẑẓẕ",
+	}
+	m := map[rune]string{
+		'ß': "ss", 'æ': "ae", 'œ': "oe", 'ø': "o", 'đ': "d", 'ł': "l", 'ħ': "h", 'ŧ': "t", 'ı': "i",
+	}
+	for base, letters := range groups {
+		for _, r := range letters {
+			m[r] = base
+		}
+	}
+	return m
+}()
@@ -0,0 +1,105 @@
+package topic
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Options controls how topics are normalized.
+type Options struct {
+	// StripDiacritics removes accents so "Élection" matches "election".
+	StripDiacritics bool
+	// Turkic applies Turkish/Azerbaijani casing: "I" folds to "ı" and
+	// "İ" folds to "i".
+	Turkic bool
+}
+
+// Key returns the normalized comparison key for topic under opts. Two
+// topics match exactly when their keys are equal, so keys are suitable as
+// map keys for grouping. Surrounding whitespace is trimmed.
+func Key(topic string, opts Options) string {
+	var b strings.Builder
+	b.Grow(len(topic))
+	for _, r := range strings.TrimSpace(topic) {
+		r = fold(r, opts.Turkic)
+		if opts.StripDiacritics {
+			if unicode.Is(unicode.Mn, r) {
+				continue
+			}
+			if base, ok := latinBase[r]; ok {
+				b.WriteString(base)
+				continue
+			}
+		} else if r == 'ß' {
+			// Full case folding expands ß so it matches "SS"/"ss".
+			b.WriteString("ss")
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Equal reports whether a and b are the same topic under opts.
+func Equal(a, b string, opts Options) bool {
+	return Key(a, opts) == Key(b, opts)
+}
+
+// fold maps r to a canonical lowercase member of its case-folding orbit.
+func fold(r rune, turkic bool) rune {
+	if turkic {
+		switch r {
+		case 'I':
+			return 'ı'
+		case 'İ':
+			return 'i'
+		}
+	}
+	switch r {
+	case 'ς':
+		return 'σ'
+	case 'ſ':
+		return 's'
+	case 'ẞ':
+		return 'ß'
+	}
+	return unicode.ToLower(r)
+}
+
+// Matcher resolves topic variants to a registered canonical spelling.
+// It is safe for concurrent use after construction.
+type Matcher struct {
+	key   func(string) string
+	canon map[string]string
+}
+
+// NewMatcher returns a folded Matcher for the given canonical topics: a
+// topic matches when its Key under opts equals a canonical topic's. When
+// two canonical topics share a key, the first one wins.
+func NewMatcher(opts Options, canonical ...string) *Matcher {
+	return newMatcher(func(t string) string { return Key(t, opts) }, canonical)
+}
+
+// NewStrictMatcher returns a Matcher that matches only the exact spelling
+// of each canonical topic, with no trimming, case folding, or diacritic
+// stripping, for deployments that treat "#Vote" and "#vote" as distinct.
+func NewStrictMatcher(canonical ...string) *Matcher {
+	return newMatcher(func(t string) string { return t }, canonical)
+}
+
+func newMatcher(key func(string) string, canonical []string) *Matcher {
+	m := &Matcher{key: key, canon: make(map[string]string, len(canonical))}
+	for _, c := range canonical {
+		k := key(c)
+		if _, ok := m.canon[k]; !ok {
+			m.canon[k] = c
+		}
+	}
+	return m
+}
+
+// Match returns the canonical topic matching t, if any.
+func (m *Matcher) Match(t string) (string, bool) {
+	c, ok := m.canon[m.key(t)]
+	return c, ok
+}
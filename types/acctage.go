@@ -0,0 +1,62 @@
+package types
+
+import "time"
+
+// AcctAgeBucketFor returns the acct_age_bucket for an account created at
+// createdAt, as observed at now. Boundaries are defined once here so all
+// collectors agree:
+//
+//	0-7d   fewer than 8 whole days old (days 0 through 7)
+//	8-30d  8 through 30 whole days old
+//	1-6m   31 days old up to, but excluding, 6 calendar months
+//	6-24m  6 calendar months up to, but excluding, 24 calendar months
+//	24m+   24 calendar months or older
+//
+// Calendar months follow time.AddDate in UTC. A createdAt after now is
+// treated as age zero.
+func AcctAgeBucketFor(createdAt, now time.Time) AcctAge {
+	createdAt, now = createdAt.UTC(), now.UTC()
+	if now.Before(createdAt) {
+		return AcctAge_0_7d
+	}
+	days := int(now.Sub(createdAt) / (24 * time.Hour))
+	switch {
+	case days <= 7:
+		return AcctAge_0_7d
+	case days <= 30:
+		return AcctAge_8_30d
+	case now.Before(createdAt.AddDate(0, 6, 0)):
+		return AcctAge_1_6m
+	case now.Before(createdAt.AddDate(0, 24, 0)):
+		return AcctAge_6_24m
+	default:
+		return AcctAge_24mPlus
+	}
+}
+
+// Rank returns a's position in ascending age order (0 for 0-7d), or -1 if a
+// is not a schema-defined bucket.
+func (a AcctAge) Rank() int {
+	for i, v := range AcctAgeValues() {
+		if v == a {
+			return i
+		}
+	}
+	return -1
+}
+
+// CompareAcctAge returns -1, 0 or +1 as a is younger than, the same as, or
+// older than b. Undefined buckets sort before all defined ones.
+func CompareAcctAge(a, b AcctAge) int {
+	ra, rb := a.Rank(), b.Rank()
+	switch {
+	case ra < rb:
+		return -1
+	case ra > rb:
+		return 1
+	}
+	return 0
+}
+
+// Older reports whether bucket a covers older accounts than bucket b.
+func Older(a, b AcctAge) bool { return CompareAcctAge(a, b) > 0 }
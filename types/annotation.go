@@ -0,0 +1,104 @@
+package types
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// AnnotationKind classifies an Annotation.
+type AnnotationKind string
+
+const (
+	AnnotationElection     AnnotationKind = "election"
+	AnnotationHoliday      AnnotationKind = "holiday"
+	AnnotationBlackout     AnnotationKind = "blackout"
+	AnnotationOutage       AnnotationKind = "platform_outage"
+	AnnotationPolicyChange AnnotationKind = "policy_change"
+	AnnotationOther        AnnotationKind = "other"
+)
+
+// AnnotationKindValues returns the defined annotation kinds.
+func AnnotationKindValues() []AnnotationKind {
+	return []AnnotationKind{AnnotationElection, AnnotationHoliday, AnnotationBlackout,
+		AnnotationOutage, AnnotationPolicyChange, AnnotationOther}
+}
+
+// Valid reports whether k is a defined annotation kind.
+func (k AnnotationKind) Valid() bool { return contains(AnnotationKindValues(), k) }
+
+// Annotation marks a time range of a Series with context analysts need when
+// reading it (election day, platform outage, policy change). End is
+// exclusive; a zero End marks a single instant at Start.
+type Annotation struct {
+	Start time.Time      `json:"start" bson:"start"`                   // UTC, inclusive
+	End   time.Time      `json:"end,omitempty" bson:"end,omitempty"`   // UTC, exclusive; zero for an instant
+	Kind  AnnotationKind `json:"kind" bson:"kind"`                     // required
+	Note  string         `json:"note,omitempty" bson:"note,omitempty"` // free-text description
+}
+
+// Overlaps reports whether a and b share any instant or are directly
+// adjacent (a.End == b.Start).
+func (a Annotation) Overlaps(b Annotation) bool {
+	return !a.end().Before(b.Start) && !b.end().Before(a.Start)
+}
+
+// MarshalJSON omits end for an instant: encoding/json's omitempty does
+// not apply to time.Time, which would write a zero End as
+// "0001-01-01T00:00:00Z".
+func (a Annotation) MarshalJSON() ([]byte, error) {
+	v := struct {
+		Start time.Time      `json:"start"`
+		End   *time.Time     `json:"end,omitempty"`
+		Kind  AnnotationKind `json:"kind"`
+		Note  string         `json:"note,omitempty"`
+	}{Start: a.Start, Kind: a.Kind, Note: a.Note}
+	if !a.End.IsZero() {
+		v.End = &a.End
+	}
+	return json.Marshal(v)
+}
+
+func (a Annotation) end() time.Time {
+	if a.End.IsZero() {
+		return a.Start
+	}
+	return a.End
+}
+
+// MergeAnnotations returns the union of a and b sorted by Start. Annotations
+// with the same Kind and Note that overlap or touch are coalesced into one
+// spanning both ranges; all others are kept as-is. Inputs are not modified.
+func MergeAnnotations(a, b []Annotation) []Annotation {
+	all := make([]Annotation, 0, len(a)+len(b))
+	all = append(all, a...)
+	all = append(all, b...)
+	sort.SliceStable(all, func(i, j int) bool {
+		if !all[i].Start.Equal(all[j].Start) {
+			return all[i].Start.Before(all[j].Start)
+		}
+		if all[i].Kind != all[j].Kind {
+			return all[i].Kind < all[j].Kind
+		}
+		return all[i].Note < all[j].Note
+	})
+
+	out := all[:0]
+	for _, x := range all {
+		merged := false
+		for i := len(out) - 1; i >= 0; i-- {
+			y := &out[i]
+			if y.Kind == x.Kind && y.Note == x.Note && y.Overlaps(x) {
+				if x.end().After(y.end()) {
+					y.End = x.end()
+				}
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			out = append(out, x)
+		}
+	}
+	return out
+}
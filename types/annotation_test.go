@@ -0,0 +1,34 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestAnnotationJSON(t *testing.T) {
+	at := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		a    Annotation
+		want string
+	}{
+		{Annotation{Start: at, Kind: AnnotationOutage}, `{"start":"2025-03-01T12:00:00Z","kind":"platform_outage"}`},
+		{Annotation{Start: at, End: at.Add(time.Hour), Kind: AnnotationElection, Note: "primary"},
+			`{"start":"2025-03-01T12:00:00Z","end":"2025-03-01T13:00:00Z","kind":"election","note":"primary"}`},
+	} {
+		b, err := json.Marshal(tc.a)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != tc.want {
+			t.Errorf("got  %s\nwant %s", b, tc.want)
+		}
+		var back Annotation
+		if err := json.Unmarshal(b, &back); err != nil {
+			t.Fatal(err)
+		}
+		if back != tc.a {
+			t.Errorf("round trip = %+v, want %+v", back, tc.a)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package types
+
+import "math"
+
+// BasisPoints is a ratio expressed in hundredths of a percent (0–10000),
+// the integer wire form of Probability for pipelines that forbid floats.
+type BasisPoints int
+
+// BasisPointsScale is the number of basis points in a probability of 1.
+const BasisPointsScale = 10000
+
+// BasisPoints returns p rounded to the nearest basis point.
+func (p Probability) BasisPoints() BasisPoints {
+	return BasisPoints(math.Round(float64(p) * BasisPointsScale))
+}
+
+// Probability returns b as a Probability.
+func (b BasisPoints) Probability() Probability {
+	return Probability(float64(b) / BasisPointsScale)
+}
@@ -0,0 +1,274 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// BSON support. Struct types carry bson tags matching their JSON names, and
+// the types below implement the ValueMarshaler and ValueUnmarshaler
+// interfaces of go.mongodb.org/mongo-driver/v2/bson, whose methods use
+// plain byte type codes, so this package does not import the driver.
+//
+// BSON datetimes have millisecond precision; GeneratedAt values with finer
+// precision are truncated by the driver. Point timestamps, which fall on
+// interval boundaries, are unaffected.
+
+// BSON element type codes used here.
+const (
+	bsonDouble   byte = 0x01
+	bsonString   byte = 0x02
+	bsonDocument byte = 0x03
+	bsonArray    byte = 0x04
+	bsonBool     byte = 0x08
+	bsonNull     byte = 0x0A
+	bsonInt32    byte = 0x10
+	bsonInt64    byte = 0x12
+)
+
+var errBSON = errors.New("types: malformed BSON value")
+
+// MarshalBSONValue encodes i as a BSON string in canonical ISO 8601 form.
+func (i Interval) MarshalBSONValue() (byte, []byte, error) {
+	return bsonString, bsonStringBytes(string(i.Canonical())), nil
+}
+
+// UnmarshalBSONValue accepts "minute" and ISO 8601 durations, storing the
+// canonical form.
+func (i *Interval) UnmarshalBSONValue(typ byte, data []byte) error {
+	s, err := bsonStringValue(typ, data)
+	if err != nil {
+		return err
+	}
+	*i = Interval(s).Canonical()
+	return nil
+}
+
+// MarshalBSONValue encodes d as a BSON string holding an ISO 8601 duration,
+// as in JSON.
+func (d ISODuration) MarshalBSONValue() (byte, []byte, error) {
+	return bsonString, bsonStringBytes(FormatISODuration(time.Duration(d))), nil
+}
+
+// UnmarshalBSONValue decodes an ISO 8601 duration string.
+func (d *ISODuration) UnmarshalBSONValue(typ byte, data []byte) error {
+	s, err := bsonStringValue(typ, data)
+	if err != nil {
+		return err
+	}
+	v, err := ParseISODuration(s)
+	if err != nil {
+		return err
+	}
+	*d = ISODuration(v)
+	return nil
+}
+
+// MarshalBSONValue encodes e as an embedded document whose values are the
+// BSON equivalents of the raw JSON, so extensions stay queryable rather
+// than being stored as opaque bytes. Integers that fit in 64 bits become
+// int64; other numbers become doubles.
+func (e Extensions) MarshalBSONValue() (byte, []byte, error) {
+	if e == nil {
+		return bsonNull, nil, nil
+	}
+	m := make(map[string]any, len(e))
+	for k, raw := range e {
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.UseNumber()
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			return 0, nil, fmt.Errorf("types: extension %q: %w", k, err)
+		}
+		m[k] = v
+	}
+	return bsonAppendValue(nil, m)
+}
+
+// UnmarshalBSONValue decodes an embedded document written by
+// MarshalBSONValue back into raw JSON values.
+func (e *Extensions) UnmarshalBSONValue(typ byte, data []byte) error {
+	if typ == bsonNull {
+		*e = nil
+		return nil
+	}
+	if typ != bsonDocument {
+		return fmt.Errorf("types: extensions must be a BSON document, got type 0x%02x", typ)
+	}
+	v, _, err := bsonReadValue(typ, data)
+	if err != nil {
+		return err
+	}
+	out := Extensions{}
+	for k, x := range v.(map[string]any) {
+		raw, err := json.Marshal(x)
+		if err != nil {
+			return err
+		}
+		out[k] = raw
+	}
+	*e = out
+	return nil
+}
+
+func bsonStringBytes(s string) []byte {
+	b := binary.LittleEndian.AppendUint32(nil, uint32(len(s)+1))
+	b = append(b, s...)
+	return append(b, 0)
+}
+
+func bsonStringValue(typ byte, data []byte) (string, error) {
+	if typ != bsonString {
+		return "", fmt.Errorf("types: expected a BSON string, got type 0x%02x", typ)
+	}
+	v, _, err := bsonReadValue(typ, data)
+	if err != nil {
+		return "", err
+	}
+	return v.(string), nil
+}
+
+// bsonAppendValue encodes a value produced by json.Decoder with UseNumber.
+// Map keys are written in sorted order so output is deterministic.
+func bsonAppendValue(b []byte, v any) (byte, []byte, error) {
+	switch x := v.(type) {
+	case nil:
+		return bsonNull, b, nil
+	case bool:
+		if x {
+			return bsonBool, append(b, 1), nil
+		}
+		return bsonBool, append(b, 0), nil
+	case string:
+		return bsonString, append(b, bsonStringBytes(x)...), nil
+	case json.Number:
+		if n, err := strconv.ParseInt(string(x), 10, 64); err == nil {
+			return bsonInt64, binary.LittleEndian.AppendUint64(b, uint64(n)), nil
+		}
+		f, err := x.Float64()
+		if err != nil {
+			return 0, nil, err
+		}
+		return bsonDouble, binary.LittleEndian.AppendUint64(b, math.Float64bits(f)), nil
+	case []any:
+		m := make(map[string]any, len(x))
+		keys := make([]string, len(x))
+		for i, e := range x {
+			keys[i] = strconv.Itoa(i)
+			m[keys[i]] = e
+		}
+		b, err := bsonAppendDocument(b, keys, m)
+		return bsonArray, b, err
+	case map[string]any:
+		keys := make([]string, 0, len(x))
+		for k := range x {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		b, err := bsonAppendDocument(b, keys, x)
+		return bsonDocument, b, err
+	}
+	return 0, nil, fmt.Errorf("types: cannot encode %T as BSON", v)
+}
+
+func bsonAppendDocument(b []byte, keys []string, m map[string]any) ([]byte, error) {
+	start := len(b)
+	b = append(b, 0, 0, 0, 0)
+	for _, k := range keys {
+		typePos := len(b)
+		b = append(b, 0)
+		b = append(b, k...)
+		b = append(b, 0)
+		typ, nb, err := bsonAppendValue(b, m[k])
+		if err != nil {
+			return nil, err
+		}
+		b = nb
+		b[typePos] = typ
+	}
+	b = append(b, 0)
+	binary.LittleEndian.PutUint32(b[start:], uint32(len(b)-start))
+	return b, nil
+}
+
+// bsonReadValue decodes one value of type typ from the front of data into
+// the shapes encoding/json produces, returning the bytes consumed.
+func bsonReadValue(typ byte, data []byte) (any, int, error) {
+	switch typ {
+	case bsonNull:
+		return nil, 0, nil
+	case bsonBool:
+		if len(data) < 1 {
+			return nil, 0, errBSON
+		}
+		return data[0] == 1, 1, nil
+	case bsonInt32:
+		if len(data) < 4 {
+			return nil, 0, errBSON
+		}
+		return json.Number(strconv.Itoa(int(int32(binary.LittleEndian.Uint32(data))))), 4, nil
+	case bsonInt64:
+		if len(data) < 8 {
+			return nil, 0, errBSON
+		}
+		return json.Number(strconv.FormatInt(int64(binary.LittleEndian.Uint64(data)), 10)), 8, nil
+	case bsonDouble:
+		if len(data) < 8 {
+			return nil, 0, errBSON
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(data)), 8, nil
+	case bsonString:
+		if len(data) < 5 {
+			return nil, 0, errBSON
+		}
+		n := int(binary.LittleEndian.Uint32(data))
+		if n < 1 || 4+n > len(data) || data[3+n] != 0 {
+			return nil, 0, errBSON
+		}
+		return string(data[4 : 3+n]), 4 + n, nil
+	case bsonDocument, bsonArray:
+		if len(data) < 5 {
+			return nil, 0, errBSON
+		}
+		n := int(binary.LittleEndian.Uint32(data))
+		if n < 5 || n > len(data) || data[n-1] != 0 {
+			return nil, 0, errBSON
+		}
+		m := map[string]any{}
+		var arr []any
+		for p := 4; p < n-1; {
+			et := data[p]
+			end := bytes.IndexByte(data[p+1:n], 0)
+			if end < 0 {
+				return nil, 0, errBSON
+			}
+			key := string(data[p+1 : p+1+end])
+			p += 2 + end
+			v, used, err := bsonReadValue(et, data[p:n-1])
+			if err != nil {
+				return nil, 0, err
+			}
+			p += used
+			if typ == bsonArray {
+				arr = append(arr, v)
+			} else {
+				m[key] = v
+			}
+		}
+		if typ == bsonArray {
+			if arr == nil {
+				arr = []any{}
+			}
+			return arr, n, nil
+		}
+		return m, n, nil
+	}
+	return nil, 0, fmt.Errorf("types: unsupported BSON type 0x%02x", typ)
+}
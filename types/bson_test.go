@@ -0,0 +1,74 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBSONValueRoundTrip(t *testing.T) {
+	iv := Interval("PT1M")
+	typ, data, err := iv.MarshalBSONValue()
+	if err != nil || typ != bsonString {
+		t.Fatalf("Interval: type 0x%02x, err %v", typ, err)
+	}
+	var gotIv Interval
+	if err := gotIv.UnmarshalBSONValue(typ, data); err != nil || gotIv != IntervalMinute {
+		t.Errorf("Interval round trip = %q, %v; want minute", gotIv, err)
+	}
+
+	d := ISODuration(36 * time.Hour)
+	typ, data, err = d.MarshalBSONValue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotD ISODuration
+	if err := gotD.UnmarshalBSONValue(typ, data); err != nil || gotD != d {
+		t.Errorf("ISODuration round trip = %v, %v; want %v", gotD, err, d)
+	}
+
+	ext := Extensions{
+		"x-count":  json.RawMessage(`42`),
+		"x-ratio":  json.RawMessage(`0.25`),
+		"x-name":   json.RawMessage(`"ops"`),
+		"x-flags":  json.RawMessage(`[true,false,null]`),
+		"x-nested": json.RawMessage(`{"b":[],"a":{"c":-7}}`),
+	}
+	typ, data, err = ext.MarshalBSONValue()
+	if err != nil || typ != bsonDocument {
+		t.Fatalf("Extensions: type 0x%02x, err %v", typ, err)
+	}
+	var gotExt Extensions
+	if err := gotExt.UnmarshalBSONValue(typ, data); err != nil {
+		t.Fatal(err)
+	}
+	for k, want := range ext {
+		var w, g any
+		_ = json.Unmarshal(want, &w)
+		_ = json.Unmarshal(gotExt[k], &g)
+		if !reflect.DeepEqual(w, g) {
+			t.Errorf("extension %s = %s, want %s", k, gotExt[k], want)
+		}
+	}
+	if len(gotExt) != len(ext) {
+		t.Errorf("got %d extensions, want %d", len(gotExt), len(ext))
+	}
+}
+
+func TestBSONValueErrors(t *testing.T) {
+	var iv Interval
+	if err := iv.UnmarshalBSONValue(bsonInt64, make([]byte, 8)); err == nil {
+		t.Error("Interval accepted an int64")
+	}
+	var d ISODuration
+	if err := d.UnmarshalBSONValue(bsonString, []byte{3, 0, 0}); err == nil {
+		t.Error("ISODuration accepted a truncated string")
+	}
+	var ext Extensions
+	err := ext.UnmarshalBSONValue(bsonDocument, []byte{12, 0, 0, 0, 0x07, 'a', 0, 1, 2, 3, 4, 0})
+	if err == nil || !strings.Contains(err.Error(), "unsupported") {
+		t.Errorf("Extensions with ObjectId: err = %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package types
+
+import "time"
+
+// PublisherRef identifies a data publisher inside a SeriesBundle. When
+// Pseudonymous is true, ID is a keyed pseudonym (see package privacy) that
+// is stable across bundles produced with the same key but does not reveal
+// the platform name.
+type PublisherRef struct {
+	ID           string `json:"id" bson:"id"`                                         // raw publisher ID or pseudonym
+	Pseudonymous bool   `json:"pseudonymous,omitempty" bson:"pseudonymous,omitempty"` // true if ID is a pseudonym
+	Label        string `json:"label,omitempty" bson:"label,omitempty"`               // optional display name; omit for restricted publishers
+}
+
+// BundleEntry ties one series in a bundle to its publisher.
+type BundleEntry struct {
+	Topic     string `json:"topic" bson:"topic"`         // Series.Topic of the entry
+	Publisher string `json:"publisher" bson:"publisher"` // PublisherRef.ID
+}
+
+// BundleManifest describes the contents of a SeriesBundle.
+type BundleManifest struct {
+	GeneratedAt time.Time      `json:"generated_at" bson:"generated_at"` // UTC timestamp when the bundle was assembled
+	Publishers  []PublisherRef `json:"publishers" bson:"publishers"`     // distinct contributing publishers
+	Entries     []BundleEntry  `json:"entries" bson:"entries"`           // one per series, in Series order
+}
+
+// SeriesBundle aggregates series from one or more publishers.
+type SeriesBundle struct {
+	Manifest BundleManifest `json:"manifest" bson:"manifest"`
+	Series   []Series       `json:"series" bson:"series"`
+}
@@ -0,0 +1,47 @@
+package types
+
+import "time"
+
+// CompositeSeries holds one topic at several resolutions, e.g. minute,
+// hour, and day, computed together so an API can serve the resolution a
+// client asks for without re-aggregating on each request. Build one with
+// seriesops.Composite and check it with validate.ValidateCompositeSeries,
+// which requires every coarser level to agree with the finer ones.
+type CompositeSeries struct {
+	Topic       string    `json:"topic"`
+	GeneratedAt time.Time `json:"generated_at"`
+	// Levels are ordered finest first. Each has the composite's topic and
+	// a distinct fixed-length interval that is a multiple of the previous.
+	Levels []Series `json:"levels"`
+}
+
+// Level returns the level with interval iv, compared in canonical form,
+// or nil.
+func (c *CompositeSeries) Level(iv Interval) *Series {
+	if c == nil {
+		return nil
+	}
+	iv = iv.Canonical()
+	for i := range c.Levels {
+		if c.Levels[i].Interval.Canonical() == iv {
+			return &c.Levels[i]
+		}
+	}
+	return nil
+}
+
+// Resolve returns the coarsest level whose interval is at most d, or the
+// finest level if every interval is longer. It returns nil for a
+// composite without levels.
+func (c *CompositeSeries) Resolve(d time.Duration) *Series {
+	if c == nil || len(c.Levels) == 0 {
+		return nil
+	}
+	best := &c.Levels[0]
+	for i := range c.Levels {
+		if step := c.Levels[i].Interval.Duration(); step > 0 && step <= d {
+			best = &c.Levels[i]
+		}
+	}
+	return best
+}
@@ -0,0 +1,248 @@
+package types
+
+import (
+	"flag"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CollectorConfig is the configuration shared by services that aggregate
+// platform events into Series. Validate it with
+// validate.ValidateCollectorConfig.
+type CollectorConfig struct {
+	Interval     Interval      `json:"interval"`                 // aggregation interval, e.g. minute or PT1H
+	Topics       []string      `json:"topics"`                   // topics to aggregate
+	DedupHashAlg DedupHashAlg  `json:"dedup_hash_alg,omitempty"` // empty means sha256-trunc8
+	DedupSalt    string        `json:"-"`                        // hex-encoded dedup key; secret, never serialized or printed
+	SaltRotation ISODuration   `json:"salt_rotation"`            // how often DedupSalt is replaced, e.g. P1D
+	Privacy      PrivacyConfig `json:"privacy"`
+}
+
+// PublisherConfig is the configuration shared by services that publish
+// Series to a transparency endpoint. Validate it with
+// validate.ValidatePublisherConfig.
+type PublisherConfig struct {
+	PublisherID   string        `json:"publisher_id"`           // PublisherRef.ID to publish as
+	Tenant        string        `json:"tenant,omitempty"`       // optional tenant namespace
+	Jurisdiction  string        `json:"jurisdiction,omitempty"` // optional ISO 3166 code
+	Endpoint      string        `json:"endpoint"`               // absolute http(s) URL series are sent to
+	Interval      Interval      `json:"interval"`               // interval of published series
+	Topics        []string      `json:"topics"`                 // topics to publish
+	UpdateCadence ISODuration   `json:"update_cadence"`         // how often series are published
+	Privacy       PrivacyConfig `json:"privacy"`
+}
+
+// PrivacyConfig holds the disclosure-control settings applied before
+// series leave a service.
+type PrivacyConfig struct {
+	// MinVolume suppresses points with fewer events. Zero disables it.
+	MinVolume int `json:"min_volume,omitempty"`
+	// MaxOriginRisk is the re-identification risk (0–1) above which
+	// origin hints are coarsened to the country. Zero disables the check.
+	MaxOriginRisk float64 `json:"max_origin_risk,omitempty"`
+	// CountryOnlyOrigin coarsens every origin hint to the country.
+	CountryOnlyOrigin bool `json:"country_only_origin,omitempty"`
+}
+
+// Redacted returns a copy of c with DedupSalt masked, for logging.
+func (c CollectorConfig) Redacted() CollectorConfig {
+	if c.DedupSalt != "" {
+		c.DedupSalt = "REDACTED"
+	}
+	c.Topics = append([]string(nil), c.Topics...)
+	return c
+}
+
+// redactedConfig has CollectorConfig's fields without its methods, so
+// formatting it does not recurse into String or LogValue.
+type redactedConfig CollectorConfig
+
+// String formats c with %+v, with DedupSalt masked.
+func (c CollectorConfig) String() string { return fmt.Sprintf("%+v", redactedConfig(c.Redacted())) }
+
+// GoString is String for %#v.
+func (c CollectorConfig) GoString() string { return "types.CollectorConfig" + c.String() }
+
+// LogValue implements slog.LogValuer, logging c with DedupSalt masked.
+func (c CollectorConfig) LogValue() slog.Value { return slog.AnyValue(redactedConfig(c.Redacted())) }
+
+// RegisterFlags defines one flag per field of c on fs, named prefix plus
+// the field's JSON name with underscores as hyphens (e.g. "dedup-salt").
+// Current field values are the defaults; topics is comma-separated.
+func (c *CollectorConfig) RegisterFlags(fs *flag.FlagSet, prefix string) {
+	registerFlags(fs, prefix, c.vars())
+}
+
+// LoadEnv sets fields of c from environment variables named prefix plus
+// the upper-cased JSON name (e.g. "CT_DEDUP_SALT" for prefix "CT_").
+// Unset variables leave fields unchanged. lookup is usually os.LookupEnv.
+func (c *CollectorConfig) LoadEnv(prefix string, lookup func(string) (string, bool)) error {
+	return loadEnv(prefix, lookup, c.vars())
+}
+
+func (c *CollectorConfig) vars() []configVar {
+	return append([]configVar{
+		{"interval", "aggregation interval (minute or ISO 8601, e.g. PT1H)", (*intervalValue)(&c.Interval)},
+		{"topics", "comma-separated topics to aggregate", (*listValue)(&c.Topics)},
+		{"dedup_hash_alg", "dedup hash algorithm (empty means sha256-trunc8)", (*dedupAlgValue)(&c.DedupHashAlg)},
+		{"dedup_salt", "hex-encoded dedup key (secret)", (*stringValue)(&c.DedupSalt)},
+		{"salt_rotation", "dedup salt rotation period (ISO 8601, e.g. P1D)", (*durationValue)(&c.SaltRotation)},
+	}, c.Privacy.vars()...)
+}
+
+// RegisterFlags is like CollectorConfig.RegisterFlags.
+func (c *PublisherConfig) RegisterFlags(fs *flag.FlagSet, prefix string) {
+	registerFlags(fs, prefix, c.vars())
+}
+
+// LoadEnv is like CollectorConfig.LoadEnv.
+func (c *PublisherConfig) LoadEnv(prefix string, lookup func(string) (string, bool)) error {
+	return loadEnv(prefix, lookup, c.vars())
+}
+
+func (c *PublisherConfig) vars() []configVar {
+	return append([]configVar{
+		{"publisher_id", "publisher identifier", (*stringValue)(&c.PublisherID)},
+		{"tenant", "tenant namespace", (*stringValue)(&c.Tenant)},
+		{"jurisdiction", "ISO 3166 jurisdiction code", (*stringValue)(&c.Jurisdiction)},
+		{"endpoint", "http(s) URL series are published to", (*stringValue)(&c.Endpoint)},
+		{"interval", "interval of published series (ISO 8601)", (*intervalValue)(&c.Interval)},
+		{"topics", "comma-separated topics to publish", (*listValue)(&c.Topics)},
+		{"update_cadence", "publication cadence (ISO 8601, e.g. PT1H)", (*durationValue)(&c.UpdateCadence)},
+	}, c.Privacy.vars()...)
+}
+
+func (c *PrivacyConfig) vars() []configVar {
+	return []configVar{
+		{"min_volume", "suppress points with fewer events (0 disables)", (*intValue)(&c.MinVolume)},
+		{"max_origin_risk", "origin re-identification risk ceiling, 0-1 (0 disables)", (*floatValue)(&c.MaxOriginRisk)},
+		{"country_only_origin", "coarsen all origin hints to the country", (*boolValue)(&c.CountryOnlyOrigin)},
+	}
+}
+
+// configVar binds one config field to a flag and an environment variable.
+type configVar struct {
+	name  string // JSON name
+	usage string
+	value flag.Value
+}
+
+func registerFlags(fs *flag.FlagSet, prefix string, vs []configVar) {
+	for _, v := range vs {
+		fs.Var(v.value, prefix+strings.ReplaceAll(v.name, "_", "-"), v.usage)
+	}
+}
+
+func loadEnv(prefix string, lookup func(string) (string, bool), vs []configVar) error {
+	for _, v := range vs {
+		name := prefix + strings.ToUpper(v.name)
+		s, ok := lookup(name)
+		if !ok {
+			continue
+		}
+		if err := v.value.Set(s); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+type stringValue string
+
+func (v *stringValue) String() string     { return string(*v) }
+func (v *stringValue) Set(s string) error { *v = stringValue(s); return nil }
+
+type intValue int
+
+func (v *intValue) String() string { return strconv.Itoa(int(*v)) }
+func (v *intValue) Set(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return err
+	}
+	*v = intValue(n)
+	return nil
+}
+
+type floatValue float64
+
+func (v *floatValue) String() string { return strconv.FormatFloat(float64(*v), 'g', -1, 64) }
+func (v *floatValue) Set(s string) error {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+	*v = floatValue(f)
+	return nil
+}
+
+type boolValue bool
+
+func (v *boolValue) String() string   { return strconv.FormatBool(bool(*v)) }
+func (v *boolValue) IsBoolFlag() bool { return true }
+func (v *boolValue) Set(s string) error {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return err
+	}
+	*v = boolValue(b)
+	return nil
+}
+
+// listValue is a comma-separated list; empty elements are dropped.
+type listValue []string
+
+func (v *listValue) String() string { return strings.Join(*v, ",") }
+func (v *listValue) Set(s string) error {
+	*v = nil
+	for _, e := range strings.Split(s, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			*v = append(*v, e)
+		}
+	}
+	return nil
+}
+
+type intervalValue Interval
+
+func (v *intervalValue) String() string { return string(*v) }
+func (v *intervalValue) Set(s string) error {
+	if Interval(s) != IntervalMinute {
+		if _, err := ParseISODuration(s); err != nil {
+			return err
+		}
+	}
+	*v = intervalValue(Interval(s).Canonical())
+	return nil
+}
+
+type durationValue ISODuration
+
+func (v *durationValue) String() string {
+	if *v == 0 {
+		return ""
+	}
+	return FormatISODuration(time.Duration(*v))
+}
+func (v *durationValue) Set(s string) error {
+	d, err := ParseISODuration(s)
+	if err != nil {
+		return err
+	}
+	*v = durationValue(d)
+	return nil
+}
+
+type dedupAlgValue DedupHashAlg
+
+func (v *dedupAlgValue) String() string { return string(*v) }
+func (v *dedupAlgValue) Set(s string) error {
+	if !DedupHashAlg(s).Valid() {
+		return fmt.Errorf("unknown dedup hash algorithm %q", s)
+	}
+	*v = dedupAlgValue(s)
+	return nil
+}
@@ -0,0 +1,36 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestCollectorConfigHidesSalt(t *testing.T) {
+	const salt = "00112233445566778899aabbccddeeff"
+	c := CollectorConfig{Interval: IntervalMinute, DedupSalt: salt}
+
+	b, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	slog.New(slog.NewTextHandler(&buf, nil)).Info("config", "cfg", c)
+	for name, out := range map[string]string{
+		"json": string(b), "%v": fmt.Sprint(c), "%+v": fmt.Sprintf("%+v", &c), "%#v": fmt.Sprintf("%#v", c),
+		"slog": buf.String(),
+	} {
+		if strings.Contains(out, salt) {
+			t.Errorf("%s output contains the salt: %s", name, out)
+		}
+	}
+	if !strings.Contains(buf.String(), "REDACTED") {
+		t.Errorf("slog output = %s", buf.String())
+	}
+	if c.DedupSalt != salt {
+		t.Error("formatting modified the config")
+	}
+}
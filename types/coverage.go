@@ -0,0 +1,90 @@
+package types
+
+import "time"
+
+// Duration returns the length of one aggregation bucket, or 0 if i is
+// neither IntervalMinute nor a positive ISO 8601 duration.
+func (i Interval) Duration() time.Duration {
+	if i == IntervalMinute {
+		return time.Minute
+	}
+	d, err := ParseISODuration(string(i))
+	if err != nil || d <= 0 {
+		return 0
+	}
+	return d
+}
+
+// CoverageOptions tunes Series.CoverageWith.
+type CoverageOptions struct {
+	// Exclude lists annotation kinds whose ranges are removed from both the
+	// observed and expected bucket counts, e.g. declared blackouts.
+	Exclude []AnnotationKind
+}
+
+// Coverage reports how many interval buckets between the first and last
+// point (inclusive) have at least one point. ratio is have/expected, or 0
+// for a series without points or with an undefined interval.
+func (s *Series) Coverage() (have, expected int, ratio float64) {
+	return s.CoverageWith(CoverageOptions{})
+}
+
+// CoverageWith is Coverage with options.
+func (s *Series) CoverageWith(opts CoverageOptions) (have, expected int, ratio float64) {
+	if s == nil {
+		return 0, 0, 0
+	}
+	step := s.Interval.Duration()
+	if step <= 0 || len(s.Points) == 0 {
+		return 0, 0, 0
+	}
+
+	first, last := s.Points[0].TS, s.Points[0].TS
+	for _, p := range s.Points[1:] {
+		if p.TS.Before(first) {
+			first = p.TS
+		}
+		if p.TS.After(last) {
+			last = p.TS
+		}
+	}
+	first, last = first.UTC().Truncate(step), last.UTC().Truncate(step)
+
+	var excluded []Annotation
+	for _, a := range s.Annotations {
+		if contains(opts.Exclude, a.Kind) {
+			excluded = append(excluded, a)
+		}
+	}
+	skip := func(b time.Time) bool {
+		for _, a := range excluded {
+			start := a.Start.UTC().Truncate(step)
+			end := a.End
+			if end.IsZero() {
+				end = start.Add(step)
+			}
+			if !b.Before(start) && b.Before(end) {
+				return true
+			}
+		}
+		return false
+	}
+
+	seen := make(map[int64]bool, len(s.Points))
+	for _, p := range s.Points {
+		b := p.TS.UTC().Truncate(step)
+		if !skip(b) {
+			seen[b.Unix()] = true
+		}
+	}
+	for b := first; !b.After(last); b = b.Add(step) {
+		if !skip(b) {
+			expected++
+		}
+	}
+	have = len(seen)
+	if expected > 0 {
+		ratio = float64(have) / float64(expected)
+	}
+	return have, expected, ratio
+}
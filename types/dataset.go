@@ -0,0 +1,64 @@
+package types
+
+import "time"
+
+// DatasetDescriptor is one entry in a transparency portal's machine-readable
+// catalog of published series. Struct tags cover both JSON and YAML (for
+// gopkg.in/yaml.v3 and compatible encoders); this package does not import a
+// YAML library.
+type DatasetDescriptor struct {
+	ID            string       `json:"id" yaml:"id"`                                       // stable catalog identifier
+	Title         string       `json:"title" yaml:"title"`                                 // human-readable name
+	Description   string       `json:"description,omitempty" yaml:"description,omitempty"` // optional longer summary
+	Publisher     PublisherRef `json:"publisher" yaml:"publisher"`                         // who publishes the series
+	Topics        []string     `json:"topics" yaml:"topics"`                               // Series.Topic values covered
+	Coverage      TimeRange    `json:"coverage" yaml:"coverage"`                           // time span of available points
+	Interval      Interval     `json:"interval" yaml:"interval"`                           // aggregation interval of the series
+	UpdateCadence ISODuration  `json:"update_cadence" yaml:"update_cadence"`               // how often new data is published
+	License       License      `json:"license" yaml:"license"`                             // terms of reuse
+	Contact       Contact      `json:"contact" yaml:"contact"`                             // where to send questions and corrections
+	SchemaVersion string       `json:"schema_version" yaml:"schema_version"`               // SpecVersion the series conform to
+	URL           string       `json:"url,omitempty" yaml:"url,omitempty"`                 // optional landing page or download root
+}
+
+// TimeRange is a span of time. A nil End means the dataset is ongoing.
+type TimeRange struct {
+	Start time.Time  `json:"start" yaml:"start"`
+	End   *time.Time `json:"end,omitempty" yaml:"end,omitempty"`
+}
+
+// License identifies the terms a dataset is published under.
+type License struct {
+	ID   string `json:"id" yaml:"id"`                         // SPDX identifier, e.g. "CC-BY-4.0"
+	Name string `json:"name,omitempty" yaml:"name,omitempty"` // optional display name
+	URL  string `json:"url,omitempty" yaml:"url,omitempty"`   // optional license text
+}
+
+// Contact is a point of contact for a dataset. At least one of Email and
+// URL must be set.
+type Contact struct {
+	Name  string `json:"name,omitempty" yaml:"name,omitempty"`
+	Email string `json:"email,omitempty" yaml:"email,omitempty"`
+	URL   string `json:"url,omitempty" yaml:"url,omitempty"`
+}
+
+// MarshalYAML encodes d as an ISO 8601 duration string. It satisfies the
+// Marshaler interface of gopkg.in/yaml.v2 and v3.
+func (d ISODuration) MarshalYAML() (any, error) {
+	return FormatISODuration(time.Duration(d)), nil
+}
+
+// UnmarshalYAML decodes an ISO 8601 duration string. It satisfies the
+// function-based Unmarshaler interface of gopkg.in/yaml.v2 and v3.
+func (d *ISODuration) UnmarshalYAML(unmarshal func(any) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	v, err := ParseISODuration(s)
+	if err != nil {
+		return err
+	}
+	*d = ISODuration(v)
+	return nil
+}
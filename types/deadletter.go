@@ -0,0 +1,28 @@
+package types
+
+import "time"
+
+// DeadLetterStage is the ingestion step that rejected a payload.
+type DeadLetterStage string
+
+const (
+	StageDecode   DeadLetterStage = "decode"   // payload is not a well-formed document
+	StageValidate DeadLetterStage = "validate" // payload decoded but failed validation
+)
+
+// DeadLetter is what ingestion persists about a payload it rejected, so a
+// re-processing pipeline can retry it after a fix or rule change. Payload
+// holds the original bytes verbatim (base64 in JSON, since a payload that
+// failed to decode need not be valid JSON).
+type DeadLetter struct {
+	Key          string          `json:"key"`                     // hex SHA-256 of Payload; identifies repeats
+	SubmissionID SubmissionID    `json:"submission_id,omitempty"` // submission the payload first arrived in, if known
+	Source       string          `json:"source,omitempty"`        // where the payload came from, e.g. a publisher ID or queue name
+	Stage        DeadLetterStage `json:"stage"`                   // step that rejected it most recently
+	Payload      []byte          `json:"payload"`                 // original bytes
+	Codes        []string        `json:"codes,omitempty"`         // validate.ErrorCode values, deduplicated, in first-seen order
+	Errors       []string        `json:"errors"`                  // error messages from the most recent attempt
+	Attempts     int             `json:"attempts"`                // times the payload has failed
+	FirstSeen    time.Time       `json:"first_seen"`
+	LastSeen     time.Time       `json:"last_seen"`
+}
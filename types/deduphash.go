@@ -0,0 +1,51 @@
+package types
+
+import "regexp"
+
+// DedupHashAlg names the algorithm that produced a ProvenanceTag.DedupHash.
+type DedupHashAlg string
+
+const (
+	// DedupSHA256Trunc8 is SHA-256 over salt||content, truncated to 4 bytes
+	// (8 hex chars). It is the schema default when dedup_hash_alg is absent.
+	DedupSHA256Trunc8 DedupHashAlg = "sha256-trunc8"
+	// DedupSipHash24 is SipHash-2-4 keyed with a 16-byte key (16 hex chars).
+	DedupSipHash24 DedupHashAlg = "siphash-2-4"
+	// DedupXXHash64 is xxHash64 seeded with a uint64 (16 hex chars).
+	DedupXXHash64 DedupHashAlg = "xxhash64"
+)
+
+// DedupHashAlgValues returns the defined dedup hash algorithms.
+func DedupHashAlgValues() []DedupHashAlg {
+	return []DedupHashAlg{DedupSHA256Trunc8, DedupSipHash24, DedupXXHash64}
+}
+
+// Valid reports whether a is a defined algorithm. The empty value is valid
+// and means DedupSHA256Trunc8.
+func (a DedupHashAlg) Valid() bool { return a == "" || contains(DedupHashAlgValues(), a) }
+
+// HexLen returns the number of lowercase hex chars a hash of algorithm a
+// has, or 0 if a is not defined.
+func (a DedupHashAlg) HexLen() int {
+	switch a {
+	case "", DedupSHA256Trunc8:
+		return 8
+	case DedupSipHash24, DedupXXHash64:
+		return 16
+	}
+	return 0
+}
+
+// ReHex16 matches a 16-character lowercase hex string.
+var ReHex16 = regexp.MustCompile(`^[a-f0-9]{16}$`)
+
+// MatchesShape reports whether h has the shape required by algorithm a.
+func (a DedupHashAlg) MatchesShape(h HexHash8) bool {
+	switch a.HexLen() {
+	case 8:
+		return Hex8.MatchString(string(h))
+	case 16:
+		return Hex16.MatchString(string(h))
+	}
+	return false
+}
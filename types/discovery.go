@@ -0,0 +1,95 @@
+package types
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"time"
+)
+
+// DiscoveryPath is the well-known URI (RFC 8615) at which a publisher
+// serves its Discovery document.
+const DiscoveryPath = "/.well-known/civic-transparency"
+
+// Endpoint relations defined by the spec. Publishers may add others,
+// named by a URL they control.
+const (
+	RelSeries      = "series"      // published series, by topic
+	RelCatalog     = "catalog"     // DatasetDescriptor list
+	RelUploads     = "uploads"     // chunked upload protocol of package client
+	RelHeartbeat   = "heartbeat"   // live Heartbeat stream
+	RelRetractions = "retractions" // signed Retraction tombstones
+)
+
+// KeyAlgEd25519 is the only PublicKey algorithm defined so far.
+const KeyAlgEd25519 = "ed25519"
+
+// Discovery is a publisher's self-description, served at DiscoveryPath so
+// aggregators can find its endpoints, schema versions, topics, and
+// signing keys from its host name alone. Validate it with
+// validate.ValidateDiscovery; package client fetches and validates it in
+// one step.
+type Discovery struct {
+	Publisher PublisherRef `json:"publisher"`
+	Versions  []string     `json:"versions"`         // SpecVersions served, preferred first
+	Endpoints []Endpoint   `json:"endpoints"`        // at most one per Rel
+	Topics    []string     `json:"topics,omitempty"` // Series.Topic values published
+	Keys      []PublicKey  `json:"keys,omitempty"`   // keys that sign retractions and manifests
+	UpdatedAt time.Time    `json:"updated_at"`       // UTC time the document last changed
+}
+
+// Endpoint is one service of a publisher.
+type Endpoint struct {
+	Rel string `json:"rel"` // one of the Rel constants, or a URL for a custom relation
+	URL string `json:"url"` // absolute http(s) URL
+}
+
+// PublicKey is a publisher's signing key.
+type PublicKey struct {
+	ID       string     `json:"id"`                  // key identifier, unique in the document
+	Alg      string     `json:"alg"`                 // KeyAlgEd25519
+	Key      string     `json:"key"`                 // standard base64 of the raw public key
+	NotAfter *time.Time `json:"not_after,omitempty"` // optional end of validity, for rotation
+}
+
+// Ed25519 decodes an ed25519 key.
+func (k PublicKey) Ed25519() (ed25519.PublicKey, error) {
+	if k.Alg != KeyAlgEd25519 {
+		return nil, fmt.Errorf("types: key %q: algorithm %q is not %s", k.ID, k.Alg, KeyAlgEd25519)
+	}
+	b, err := base64.StdEncoding.DecodeString(k.Key)
+	if err != nil || len(b) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("types: key %q is not a base64 %d-byte ed25519 key", k.ID, ed25519.PublicKeySize)
+	}
+	return ed25519.PublicKey(b), nil
+}
+
+// Endpoint returns the URL of the endpoint with relation rel.
+func (d *Discovery) Endpoint(rel string) (string, bool) {
+	for _, e := range d.Endpoints {
+		if e.Rel == rel {
+			return e.URL, true
+		}
+	}
+	return "", false
+}
+
+// Supports reports whether the publisher serves schema version v.
+func (d *Discovery) Supports(v string) bool {
+	for _, s := range d.Versions {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Key returns the key with the given ID, unless it has expired by now.
+func (d *Discovery) Key(id string, now time.Time) (PublicKey, bool) {
+	for _, k := range d.Keys {
+		if k.ID == id && (k.NotAfter == nil || now.Before(*k.NotAfter)) {
+			return k, true
+		}
+	}
+	return PublicKey{}, false
+}
@@ -0,0 +1,59 @@
+package types
+
+// AcctAgeValues returns the schema's acct_age_bucket values in ascending age order.
+func AcctAgeValues() []AcctAge {
+	return []AcctAge{AcctAge_0_7d, AcctAge_8_30d, AcctAge_1_6m, AcctAge_6_24m, AcctAge_24mPlus}
+}
+
+// AcctTypeValues returns the schema's acct_type values.
+func AcctTypeValues() []AcctType {
+	return []AcctType{AcctTypePerson, AcctTypeOrg, AcctTypeMedia,
+		AcctTypePublicOfficial, AcctTypeUnverified, AcctTypeDeclaredAutomation}
+}
+
+// AutomationFlagValues returns the schema's automation_flag values.
+func AutomationFlagValues() []AutomationFlag {
+	return []AutomationFlag{AutomationManual, AutomationScheduled, AutomationAPICLIENT, AutomationDeclaredBot}
+}
+
+// PostKindValues returns the schema's post_kind values.
+func PostKindValues() []PostKind {
+	return []PostKind{PostKindOriginal, PostKindReshare, PostKindQuote, PostKindReply}
+}
+
+// ClientFamilyValues returns the schema's client_family values.
+func ClientFamilyValues() []ClientFamily {
+	return []ClientFamily{ClientWeb, ClientMobile, ClientThirdParty}
+}
+
+// MediaProvenanceValues returns the schema's media_provenance values.
+func MediaProvenanceValues() []MediaProvenance {
+	return []MediaProvenance{MediaProvC2PA, MediaProvHash, MediaProvNone}
+}
+
+// Valid reports whether a is a schema-defined acct_age_bucket.
+func (a AcctAge) Valid() bool { return contains(AcctAgeValues(), a) }
+
+// Valid reports whether a is a schema-defined acct_type.
+func (a AcctType) Valid() bool { return contains(AcctTypeValues(), a) }
+
+// Valid reports whether f is a schema-defined automation_flag.
+func (f AutomationFlag) Valid() bool { return contains(AutomationFlagValues(), f) }
+
+// Valid reports whether k is a schema-defined post_kind.
+func (k PostKind) Valid() bool { return contains(PostKindValues(), k) }
+
+// Valid reports whether c is a schema-defined client_family.
+func (c ClientFamily) Valid() bool { return contains(ClientFamilyValues(), c) }
+
+// Valid reports whether m is a schema-defined media_provenance.
+func (m MediaProvenance) Valid() bool { return contains(MediaProvenanceValues(), m) }
+
+func contains[T comparable](vs []T, v T) bool {
+	for _, x := range vs {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/bits"
+)
+
+// EnumSet is a set of values of one enumeration, stored as a bitmask in
+// schema declaration order. Sets are plain values: every method returns a
+// new set rather than mutating, so a set can be shared between goroutines
+// without locking. The zero value is the empty set.
+//
+// EnumSet marshals to and from a JSON array of enum values.
+type EnumSet[T Enum] uint64
+
+// Enum lists the enumerations an EnumSet can hold. The constraint makes an
+// EnumSet of any other type a compile error.
+type Enum interface {
+	AcctAge | AcctType | AutomationFlag | PostKind | ClientFamily | MediaProvenance |
+		AnnotationKind | RetractionReason | DedupHashAlg
+}
+
+// Enum sets for each ProvenanceTag enumeration.
+type (
+	AcctAgeSet         = EnumSet[AcctAge]
+	AcctTypeSet        = EnumSet[AcctType]
+	AutomationFlagSet  = EnumSet[AutomationFlag]
+	PostKindSet        = EnumSet[PostKind]
+	ClientFamilySet    = EnumSet[ClientFamily]
+	MediaProvenanceSet = EnumSet[MediaProvenance]
+)
+
+// enumValues returns the declared values of T. It has a case for every
+// member of Enum.
+func enumValues[T Enum]() []T {
+	var vs any
+	switch any(*new(T)).(type) {
+	case AcctAge:
+		vs = AcctAgeValues()
+	case AcctType:
+		vs = AcctTypeValues()
+	case AutomationFlag:
+		vs = AutomationFlagValues()
+	case PostKind:
+		vs = PostKindValues()
+	case ClientFamily:
+		vs = ClientFamilyValues()
+	case MediaProvenance:
+		vs = MediaProvenanceValues()
+	case AnnotationKind:
+		vs = AnnotationKindValues()
+	case RetractionReason:
+		vs = RetractionReasonValues()
+	case DedupHashAlg:
+		vs = DedupHashAlgValues()
+	}
+	return vs.([]T)
+}
+
+func enumBit[T Enum](v T) (EnumSet[T], bool) {
+	for i, x := range enumValues[T]() {
+		if x == v {
+			return EnumSet[T](1) << i, true
+		}
+	}
+	return 0, false
+}
+
+// NewEnumSet returns the set of the given values. Undefined values are ignored.
+func NewEnumSet[T Enum](vs ...T) EnumSet[T] {
+	return EnumSet[T](0).Add(vs...)
+}
+
+// AllOf returns the set of every declared value of T.
+func AllOf[T Enum]() EnumSet[T] {
+	return EnumSet[T](1)<<len(enumValues[T]()) - 1
+}
+
+// Has reports whether v is in s.
+func (s EnumSet[T]) Has(v T) bool {
+	b, ok := enumBit(v)
+	return ok && s&b != 0
+}
+
+// Add returns s with vs added. Undefined values are ignored.
+func (s EnumSet[T]) Add(vs ...T) EnumSet[T] {
+	for _, v := range vs {
+		if b, ok := enumBit(v); ok {
+			s |= b
+		}
+	}
+	return s
+}
+
+// Remove returns s without vs.
+func (s EnumSet[T]) Remove(vs ...T) EnumSet[T] {
+	for _, v := range vs {
+		if b, ok := enumBit(v); ok {
+			s &^= b
+		}
+	}
+	return s
+}
+
+// Union returns the values in s or o.
+func (s EnumSet[T]) Union(o EnumSet[T]) EnumSet[T] { return s | o }
+
+// Intersect returns the values in both s and o.
+func (s EnumSet[T]) Intersect(o EnumSet[T]) EnumSet[T] { return s & o }
+
+// Len returns the number of values in s.
+func (s EnumSet[T]) Len() int { return bits.OnesCount64(uint64(s)) }
+
+// IsEmpty reports whether s has no values.
+func (s EnumSet[T]) IsEmpty() bool { return s == 0 }
+
+// Values returns the members of s in declaration order.
+func (s EnumSet[T]) Values() []T {
+	var out []T
+	for i, v := range enumValues[T]() {
+		if s&(1<<i) != 0 {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// MarshalJSON encodes s as a JSON array of values.
+func (s EnumSet[T]) MarshalJSON() ([]byte, error) {
+	vs := s.Values()
+	if vs == nil {
+		vs = []T{}
+	}
+	return json.Marshal(vs)
+}
+
+// UnmarshalJSON decodes a JSON array of values, rejecting undefined ones.
+func (s *EnumSet[T]) UnmarshalJSON(b []byte) error {
+	var vs []T
+	if err := json.Unmarshal(b, &vs); err != nil {
+		return err
+	}
+	var out EnumSet[T]
+	for _, v := range vs {
+		bit, ok := enumBit(v)
+		if !ok {
+			return fmt.Errorf("types: undefined %T value %q", v, string(v))
+		}
+		out |= bit
+	}
+	*s = out
+	return nil
+}
@@ -0,0 +1,23 @@
+package types
+
+import "testing"
+
+// TestEnumValuesCoversEnum fails if a member of Enum has no case in
+// enumValues.
+func TestEnumValuesCoversEnum(t *testing.T) {
+	for name, n := range map[string]int{
+		"AcctAge":          AllOf[AcctAge]().Len(),
+		"AcctType":         AllOf[AcctType]().Len(),
+		"AutomationFlag":   AllOf[AutomationFlag]().Len(),
+		"PostKind":         AllOf[PostKind]().Len(),
+		"ClientFamily":     AllOf[ClientFamily]().Len(),
+		"MediaProvenance":  AllOf[MediaProvenance]().Len(),
+		"AnnotationKind":   AllOf[AnnotationKind]().Len(),
+		"RetractionReason": AllOf[RetractionReason]().Len(),
+		"DedupHashAlg":     AllOf[DedupHashAlg]().Len(),
+	} {
+		if n == 0 {
+			t.Errorf("AllOf[%s] is empty", name)
+		}
+	}
+}
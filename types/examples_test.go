@@ -16,6 +16,10 @@ package types_test
 import (
     "encoding/json"
     "fmt"
+    "log/slog"
+    "os"
+    "time"
+
     "github.com/civic-interconnect/civic-transparency-go-types/types"
 )
 
@@ -31,5 +35,128 @@ func ExampleProvenanceTag() {
     }
     b, _ := json.Marshal(tag)
     fmt.Println(string(b))
-    // Output: {"acct_age_bucket":"1-6m","acct_type":"person", ...}
+    // Output: {"acct_age_bucket":"1-6m","acct_type":"person","automation_flag":"manual","post_kind":"original","client_family":"web","media_provenance":"none","dedup_hash":"deadbeef"}
+}
+
+func ExampleMergeAnnotations() {
+    day := func(d int) time.Time { return time.Date(2024, 11, d, 0, 0, 0, 0, time.UTC) }
+    ours := []types.Annotation{{Start: day(5), End: day(6), Kind: types.AnnotationElection, Note: "general"}}
+    theirs := []types.Annotation{
+        {Start: day(6), End: day(7), Kind: types.AnnotationElection, Note: "general"},
+        {Start: day(5), End: day(5).Add(3 * time.Hour), Kind: types.AnnotationOutage},
+    }
+    for _, a := range types.MergeAnnotations(ours, theirs) {
+        fmt.Println(a.Kind, a.Start.Day(), a.End.Day())
+    }
+    // Output:
+    // election 5 7
+    // platform_outage 5 5
+}
+
+func ExampleAcctAgeBucketFor() {
+    now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+    for _, d := range []int{0, 7, 8, 30, 31, 200, 1000} {
+        fmt.Println(d, types.AcctAgeBucketFor(now.AddDate(0, 0, -d), now))
+    }
+    fmt.Println(types.Older(types.AcctAge_24mPlus, types.AcctAge_1_6m))
+    // Output:
+    // 0 0-7d
+    // 7 0-7d
+    // 8 8-30d
+    // 30 8-30d
+    // 31 1-6m
+    // 200 6-24m
+    // 1000 24m+
+    // true
+}
+
+func ExampleSeries_Coverage() {
+    t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+    s := types.Series{
+        Interval: types.IntervalMinute,
+        Points:   []types.Point{{TS: t0}, {TS: t0.Add(time.Minute)}, {TS: t0.Add(9 * time.Minute)}},
+        Annotations: []types.Annotation{
+            {Start: t0.Add(2 * time.Minute), End: t0.Add(8 * time.Minute), Kind: types.AnnotationBlackout},
+        },
+    }
+    fmt.Println(s.Coverage())
+    fmt.Println(s.CoverageWith(types.CoverageOptions{Exclude: []types.AnnotationKind{types.AnnotationBlackout}}))
+    // Output:
+    // 3 10 0.3
+    // 3 4 0.75
+}
+
+func ExampleEnumSet() {
+    s := types.NewEnumSet(types.AcctTypeMedia, types.AcctTypeOrg)
+    s = s.Union(types.NewEnumSet(types.AcctTypePerson))
+    fmt.Println(s.Has(types.AcctTypeOrg), s.Has(types.AcctTypeUnverified), s.Len())
+    b, _ := json.Marshal(s)
+    fmt.Println(string(b))
+    var back types.AcctTypeSet
+    fmt.Println(json.Unmarshal([]byte(`["org","robot"]`), &back))
+    // Output:
+    // true false 3
+    // ["person","org","media"]
+    // types: undefined types.AcctType value "robot"
+}
+
+func ExampleInterval_Canonical() {
+    var s types.Series
+    _ = json.Unmarshal([]byte(`{"interval":"PT1M"}`), &s)
+    fmt.Println(s.Interval, s.Interval.Duration(), s.Interval.ISO())
+    fmt.Println(types.Interval("PT60M").Canonical())
+
+    d, _ := types.ParseISODuration("P1DT12H")
+    fmt.Println(d, types.FormatISODuration(90*time.Second))
+    _, err := types.ParseISODuration("PT1S1M")
+    fmt.Println(err)
+    // Output:
+    // minute 1m0s PT1M
+    // PT1H
+    // 36h0m0s PT1M30S
+    // types: invalid ISO 8601 duration "PT1S1M"
+}
+
+func ExampleSeries_LogValue() {
+    noTime := func(groups []string, a slog.Attr) slog.Attr {
+        if a.Key == slog.TimeKey && len(groups) == 0 {
+            return slog.Attr{}
+        }
+        return a
+    }
+    log := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{ReplaceAttr: noTime}))
+
+    t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+    s := types.Series{Topic: "#vote", GeneratedAt: t0.Add(time.Hour), Interval: types.IntervalMinute}
+    for i := 0; i < 60; i++ {
+        s.Points = append(s.Points, types.Point{TS: t0.Add(time.Duration(i) * time.Minute), Volume: i})
+    }
+    tag := types.ProvenanceTag{
+        AcctAgeBucket:   types.AcctAge_1_6m,
+        AcctType:        types.AcctTypePerson,
+        AutomationFlag:  types.AutomationManual,
+        PostKind:        types.PostKindReshare,
+        ClientFamily:    types.ClientMobile,
+        MediaProvenance: types.MediaProvNone,
+        DedupHash:       "deadbeef",
+        OriginHint:      "US-CA",
+    }
+    log.Info("published", "series", s)
+    log.Info("rejected", "tag", tag)
+    // Output:
+    // level=INFO msg=published series.topic=#vote series.interval=minute series.generated_at=2025-01-01T01:00:00.000Z series.points=60 series.from=2025-01-01T00:00:00.000Z series.to=2025-01-01T00:59:00.000Z
+    // level=INFO msg=rejected tag.acct_age_bucket=1-6m tag.acct_type=person tag.automation_flag=manual tag.post_kind=reshare tag.client_family=mobile tag.media_provenance=none tag.dedup_hash=dead… tag.origin_country=US
+}
+
+func ExampleTransition() {
+    if _, err := types.Transition(types.SeriesFinalized, types.SeriesProvisional, ""); err != nil {
+        fmt.Println(err)
+    }
+    tr, _ := types.Transition(types.SeriesFinalized, types.SeriesRevised, "late deletions applied")
+    fmt.Printf("%s -> %s: %s\n", tr.From, tr.To, tr.Reason)
+    fmt.Println(types.SeriesProvisional.Final(), types.SeriesRevised.Final(), types.SeriesRevised.Next())
+    // Output:
+    // types: invalid series state transition: finalized to provisional
+    // finalized -> revised: late deletions applied
+    // false true [revised retracted]
 }
@@ -0,0 +1,120 @@
+package types
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+)
+
+// ExperimentalOptions describes an experimental enum value registered for
+// a pilot.
+type ExperimentalOptions struct {
+	// Flag is the feature flag that must be on (validate.Options.Features)
+	// for validation to accept the value. Empty means "<field>.<value>",
+	// e.g. "acct_type.state_media".
+	Flag string
+	// Description says what the value means and who is piloting it.
+	Description string
+}
+
+// ExperimentalValue is a registered experimental enum value.
+type ExperimentalValue struct {
+	Field       string `json:"field"` // schema field name, e.g. "acct_type"
+	Value       string `json:"value"`
+	Flag        string `json:"flag"`
+	Description string `json:"description,omitempty"`
+}
+
+var experimental struct {
+	sync.RWMutex
+	m map[string]map[string]ExperimentalValue // field → value → registration
+}
+
+var reEnumValue = regexp.MustCompile(`^[a-z0-9][a-z0-9_+-]*$`)
+
+// RegisterAcctType registers an experimental acct_type value, e.g.
+// "state_media", so deployments can pilot a new category without forking
+// the schema types. Registration is meant for init functions: it panics
+// if v is malformed, is already a schema value, or is already registered.
+//
+// A registered value is not Valid, is not part of AcctTypeValues or of
+// any EnumSet, and is rejected by validation unless its flag is enabled
+// in validate.Options.Features. acct_age_bucket, whose buckets are
+// ordered, cannot be extended.
+func RegisterAcctType(v AcctType, opts ExperimentalOptions) {
+	registerExperimental("acct_type", string(v), v.Valid(), opts)
+}
+
+// RegisterAutomationFlag is like RegisterAcctType for automation_flag.
+func RegisterAutomationFlag(v AutomationFlag, opts ExperimentalOptions) {
+	registerExperimental("automation_flag", string(v), v.Valid(), opts)
+}
+
+// RegisterPostKind is like RegisterAcctType for post_kind.
+func RegisterPostKind(v PostKind, opts ExperimentalOptions) {
+	registerExperimental("post_kind", string(v), v.Valid(), opts)
+}
+
+// RegisterClientFamily is like RegisterAcctType for client_family.
+func RegisterClientFamily(v ClientFamily, opts ExperimentalOptions) {
+	registerExperimental("client_family", string(v), v.Valid(), opts)
+}
+
+// RegisterMediaProvenance is like RegisterAcctType for media_provenance.
+func RegisterMediaProvenance(v MediaProvenance, opts ExperimentalOptions) {
+	registerExperimental("media_provenance", string(v), v.Valid(), opts)
+}
+
+func registerExperimental(field, v string, schema bool, opts ExperimentalOptions) {
+	switch {
+	case !reEnumValue.MatchString(v):
+		panic(fmt.Sprintf("types: experimental %s %q must be lowercase letters, digits, and _+-", field, v))
+	case schema:
+		panic(fmt.Sprintf("types: %s %q is already a schema value", field, v))
+	}
+	if opts.Flag == "" {
+		opts.Flag = field + "." + v
+	}
+	experimental.Lock()
+	defer experimental.Unlock()
+	if experimental.m == nil {
+		experimental.m = map[string]map[string]ExperimentalValue{}
+	}
+	if _, dup := experimental.m[field][v]; dup {
+		panic(fmt.Sprintf("types: experimental %s %q registered twice", field, v))
+	}
+	if experimental.m[field] == nil {
+		experimental.m[field] = map[string]ExperimentalValue{}
+	}
+	experimental.m[field][v] = ExperimentalValue{Field: field, Value: v, Flag: opts.Flag, Description: opts.Description}
+}
+
+// Experimental returns the registration of an experimental value of
+// field (a schema field name such as "post_kind").
+func Experimental(field, v string) (ExperimentalValue, bool) {
+	experimental.RLock()
+	defer experimental.RUnlock()
+	e, ok := experimental.m[field][v]
+	return e, ok
+}
+
+// ExperimentalValues returns every registered experimental value, sorted
+// by field and value, e.g. for publishing alongside the error catalog.
+func ExperimentalValues() []ExperimentalValue {
+	experimental.RLock()
+	defer experimental.RUnlock()
+	var out []ExperimentalValue
+	for _, vs := range experimental.m {
+		for _, e := range vs {
+			out = append(out, e)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Field != out[j].Field {
+			return out[i].Field < out[j].Field
+		}
+		return out[i].Value < out[j].Value
+	})
+	return out
+}
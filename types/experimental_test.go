@@ -0,0 +1,43 @@
+package types
+
+import "testing"
+
+func TestRegisterExperimental(t *testing.T) {
+	RegisterPostKind("test_thread", ExperimentalOptions{Description: "thread roll-ups"})
+	RegisterClientFamily("test_kiosk", ExperimentalOptions{Flag: "kiosk-pilot"})
+
+	e, ok := Experimental("post_kind", "test_thread")
+	if !ok || e.Flag != "post_kind.test_thread" || e.Description != "thread roll-ups" {
+		t.Errorf("Experimental(post_kind, test_thread) = %+v, %v", e, ok)
+	}
+	if PostKind("test_thread").Valid() {
+		t.Error("experimental value reported as a schema value")
+	}
+	if _, ok := Experimental("client_family", "test_thread"); ok {
+		t.Error("registration leaked into another field")
+	}
+	var found int
+	for _, e := range ExperimentalValues() {
+		if e.Value == "test_thread" || (e.Value == "test_kiosk" && e.Flag == "kiosk-pilot") {
+			found++
+		}
+	}
+	if found != 2 {
+		t.Errorf("ExperimentalValues missing registrations: %+v", ExperimentalValues())
+	}
+
+	for name, register := range map[string]func(){
+		"schema value": func() { RegisterAcctType(AcctTypeOrg, ExperimentalOptions{}) },
+		"duplicate":    func() { RegisterPostKind("test_thread", ExperimentalOptions{}) },
+		"malformed":    func() { RegisterAutomationFlag("Test Bot", ExperimentalOptions{}) },
+	} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s: no panic", name)
+				}
+			}()
+			register()
+		}()
+	}
+}
@@ -0,0 +1,78 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+)
+
+// ExtensionPrefix begins every extension key.
+const ExtensionPrefix = "x-"
+
+// extensionKey matches x-<platform>-<name> with lowercase alphanumeric
+// segments, e.g. "x-mastodon-instance-count".
+var extensionKey = regexp.MustCompile(`^x-[a-z0-9]+(-[a-z0-9]+)+$`)
+
+// Extensions carries platform-specific disclosures that the schema does not
+// define. Keys are namespaced x-<platform>-<name>; values are kept as raw
+// JSON so they round-trip unchanged through packages that do not know them.
+//
+// Because Extensions is a map, ProvenanceTag has not been comparable since
+// spec 0.4.0: it can no longer be compared with == or used as a map key.
+// Compare tags with ProvenanceTag.Equal instead.
+type Extensions map[string]json.RawMessage
+
+// Equal reports whether e and f have the same keys with byte-identical
+// values. Nil and empty are equal.
+func (e Extensions) Equal(f Extensions) bool {
+	if len(e) != len(f) {
+		return false
+	}
+	for k, v := range e {
+		w, ok := f[k]
+		if !ok || !bytes.Equal(v, w) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidExtensionKey reports whether key has the x-<platform>-<name> form.
+func ValidExtensionKey(key string) bool { return extensionKey.MatchString(key) }
+
+// ExtensionPlatform returns the <platform> segment of a valid key, or "".
+func ExtensionPlatform(key string) string {
+	if !ValidExtensionKey(key) {
+		return ""
+	}
+	rest := key[len(ExtensionPrefix):]
+	for i := 0; i < len(rest); i++ {
+		if rest[i] == '-' {
+			return rest[:i]
+		}
+	}
+	return ""
+}
+
+// Get decodes the value stored under key into v. It reports false if key
+// is absent.
+func (e Extensions) Get(key string, v any) (bool, error) {
+	raw, ok := e[key]
+	if !ok {
+		return false, nil
+	}
+	return true, json.Unmarshal(raw, v)
+}
+
+// Set encodes v and stores it under key, allocating *e if needed.
+func (e *Extensions) Set(key string, v any) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if *e == nil {
+		*e = Extensions{}
+	}
+	(*e)[key] = raw
+	return nil
+}
@@ -0,0 +1,266 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormatOptions tunes Format.
+type FormatOptions struct {
+	// Decimals is the number of fraction digits written for ratios and
+	// other floats. Zero selects 3.
+	Decimals int
+	// NoMixes leaves the breakdown maps off point lines, for a compact view
+	// of volumes and signals.
+	NoMixes bool
+}
+
+func (o FormatOptions) decimals() int {
+	if o.Decimals <= 0 {
+		return 3
+	}
+	return o.Decimals
+}
+
+// Format renders v as plain text for reading and diffing rather than for
+// machines: a Series as its header fields followed by one line per point,
+// a ProvenanceTag (or a slice of them) as one line per tag. Columns have
+// widths fixed by the schema and opts, not by the data, so changing one
+// value changes one line of output. Map keys are sorted, times are UTC
+// RFC 3339, and floats use opts.Decimals fixed digits, so equal values
+// always print identically. Series, Point, ProvenanceTag, and
+// CompositeSeries are supported, as values, pointers, or slices; any other
+// value is written as indented JSON. The output ends with a newline.
+func Format(v any, opts FormatOptions) string {
+	f := formatter{opts: opts}
+	switch v := v.(type) {
+	case Series:
+		f.series(&v)
+	case *Series:
+		f.series(v)
+	case []Series:
+		for i := range v {
+			if i > 0 {
+				f.b.WriteByte('\n')
+			}
+			f.series(&v[i])
+		}
+	case CompositeSeries:
+		f.composite(&v)
+	case *CompositeSeries:
+		f.composite(v)
+	case Point:
+		f.points([]Point{v})
+	case *Point:
+		f.points([]Point{*v})
+	case []Point:
+		f.points(v)
+	case ProvenanceTag:
+		f.tags([]ProvenanceTag{v})
+	case *ProvenanceTag:
+		f.tags([]ProvenanceTag{*v})
+	case []ProvenanceTag:
+		f.tags(v)
+	default:
+		b, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Sprintf("%+v\n", v)
+		}
+		return string(b) + "\n"
+	}
+	return f.b.String()
+}
+
+type formatter struct {
+	b    strings.Builder
+	opts FormatOptions
+}
+
+func (f *formatter) field(name, value string) {
+	if value != "" {
+		fmt.Fprintf(&f.b, "%-14s %s\n", name, value)
+	}
+}
+
+func (f *formatter) float(x float64) string {
+	return strconv.FormatFloat(x, 'f', f.opts.decimals(), 64)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func (f *formatter) series(s *Series) {
+	f.field("topic", s.Topic)
+	f.field("generated_at", formatTime(s.GeneratedAt))
+	f.field("interval", string(s.Interval))
+	f.field("tenant", s.Tenant)
+	f.field("jurisdiction", s.Jurisdiction)
+	if m := s.Methodology; m != nil {
+		var kv []string
+		add := func(k, v string, set bool) {
+			if set {
+				kv = append(kv, k+"="+v)
+			}
+		}
+		add("sampling_rate", f.float(float64(m.SamplingRate)), m.SamplingRate != 0)
+		add("suppression_threshold", strconv.Itoa(m.SuppressionThreshold), m.SuppressionThreshold != 0)
+		add("volume_rounding", strconv.Itoa(m.VolumeRounding), m.VolumeRounding != 0)
+		add("noise_epsilon", f.float(m.NoiseEpsilon), m.NoiseEpsilon != 0)
+		add("collection_lag", FormatISODuration(time.Duration(m.CollectionLag)), m.CollectionLag != 0)
+		f.field("methodology", strings.Join(kv, " "))
+		for _, c := range m.Caveats {
+			f.field("caveat", c)
+		}
+	}
+	if r := s.Retraction; r != nil {
+		f.field("retraction", strings.TrimSpace(fmt.Sprintf("%s effective %s %s", r.Reason, formatTime(r.EffectiveAt), r.Note)))
+	}
+	for _, a := range s.Annotations {
+		span := formatTime(a.Start)
+		if !a.End.IsZero() {
+			span += ".." + formatTime(a.End)
+		}
+		f.field("annotation", strings.TrimSpace(fmt.Sprintf("%s %s %s", span, a.Kind, a.Note)))
+	}
+	f.extensions(s.Extensions)
+	f.points(s.Points)
+}
+
+func (f *formatter) composite(c *CompositeSeries) {
+	f.field("topic", c.Topic)
+	f.field("generated_at", formatTime(c.GeneratedAt))
+	for i := range c.Levels {
+		fmt.Fprintf(&f.b, "\n[level %s]\n", c.Levels[i].Interval)
+		f.series(&c.Levels[i])
+	}
+}
+
+func (f *formatter) extensions(ext Extensions) {
+	keys := make([]string, 0, len(ext))
+	for k := range ext {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		f.field("extension", k+" "+string(ext[k]))
+	}
+}
+
+// Point columns: the widths fit any valid value, so they never depend on
+// the data. Volumes beyond the width push only their own line out.
+const (
+	tsWidth     = len("2006-01-02T15:04:05Z")
+	volumeWidth = 9
+	countWidth  = 6
+)
+
+func (f *formatter) points(ps []Point) {
+	rw := max(f.opts.decimals()+2, len("synchrony"))
+	fmt.Fprintf(&f.b, "%-*s %*s %*s %*s %*s %*s %*s\n", tsWidth, "ts", volumeWidth, "volume",
+		rw, "reshare", rw, "recycled", rw, "burst", rw, "synchrony", countWidth, "dups")
+	for _, p := range ps {
+		fmt.Fprintf(&f.b, "%-*s %*d %*s %*s %*s %*s %*d", tsWidth, formatTime(p.TS), volumeWidth, p.Volume,
+			rw, f.float(float64(p.ReshareRatio)), rw, f.float(float64(p.RecycledContentRate)),
+			rw, f.float(float64(p.CoordinationSignals.BurstScore)), rw, f.float(float64(p.CoordinationSignals.SynchronyIndex)),
+			countWidth, p.CoordinationSignals.DuplicationClusters)
+		if p.Backfilled {
+			f.b.WriteString(" backfilled")
+		}
+		if !f.opts.NoMixes {
+			f.mix("age", p.AcctAgeMix)
+			f.mix("automation", p.AutomationMix)
+			f.mix("client", p.ClientMix)
+			f.mix("acct_type", stringKeys(p.AcctTypeShares))
+			f.mix("post_kind", stringKeys(p.PostKindMix))
+		}
+		f.b.WriteByte('\n')
+	}
+}
+
+func (f *formatter) mix(name string, m map[string]Probability) {
+	if len(m) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fmt.Fprintf(&f.b, " %s{", name)
+	for i, k := range keys {
+		if i > 0 {
+			f.b.WriteByte(' ')
+		}
+		fmt.Fprintf(&f.b, "%s=%s", k, f.float(float64(m[k])))
+	}
+	f.b.WriteByte('}')
+}
+
+func stringKeys[K ~string](m map[K]Probability) map[string]Probability {
+	if len(m) == 0 {
+		return nil
+	}
+	out := make(map[string]Probability, len(m))
+	for k, v := range m {
+		out[string(k)] = v
+	}
+	return out
+}
+
+// widest returns the length of the longest value, so tag columns are as
+// wide as the schema requires.
+func widest[T ~string](values []T) int {
+	n := 0
+	for _, v := range values {
+		n = max(n, len(v))
+	}
+	return n
+}
+
+func (f *formatter) tags(ts []ProvenanceTag) {
+	cols := []struct {
+		name  string
+		width int
+		get   func(*ProvenanceTag) string
+	}{
+		{"acct_age", widest(AcctAgeValues()), func(t *ProvenanceTag) string { return string(t.AcctAgeBucket) }},
+		{"acct_type", widest(AcctTypeValues()), func(t *ProvenanceTag) string { return string(t.AcctType) }},
+		{"automation", widest(AutomationFlagValues()), func(t *ProvenanceTag) string { return string(t.AutomationFlag) }},
+		{"post_kind", widest(PostKindValues()), func(t *ProvenanceTag) string { return string(t.PostKind) }},
+		{"client", widest(ClientFamilyValues()), func(t *ProvenanceTag) string { return string(t.ClientFamily) }},
+		{"media", widest(MediaProvenanceValues()), func(t *ProvenanceTag) string { return string(t.MediaProvenance) }},
+		{"dedup_hash", 16, func(t *ProvenanceTag) string { return string(t.DedupHash) }},
+		{"alg", widest(DedupHashAlgValues()), func(t *ProvenanceTag) string { return string(t.DedupHashAlg) }},
+		{"origin", len("US-CA-XXX"), func(t *ProvenanceTag) string { return t.OriginHint }},
+	}
+	line := func(cell func(i int) string) string {
+		var b strings.Builder
+		for i, c := range cols {
+			fmt.Fprintf(&b, "%-*s ", max(c.width, len(c.name)), cell(i))
+		}
+		return strings.TrimRight(b.String(), " ")
+	}
+	f.b.WriteString(line(func(i int) string { return cols[i].name }))
+	f.b.WriteByte('\n')
+	for i := range ts {
+		t := &ts[i]
+		f.b.WriteString(line(func(i int) string { return cols[i].get(t) }))
+		keys := make([]string, 0, len(t.Extensions))
+		for k := range t.Extensions {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Fprintf(&f.b, " %s=%s", k, t.Extensions[k])
+		}
+		f.b.WriteByte('\n')
+	}
+}
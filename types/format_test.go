@@ -0,0 +1,68 @@
+package types
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFormat(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := Series{
+		Topic: "#vote", GeneratedAt: t0.Add(5 * time.Minute), Interval: IntervalMinute,
+		Methodology: &Methodology{SuppressionThreshold: 10, CollectionLag: ISODuration(2 * time.Minute)},
+		Annotations: []Annotation{{Start: t0, Kind: AnnotationElection, Note: "polls open"}},
+		Extensions:  Extensions{"x-example-region": json.RawMessage(`"emea"`)},
+		Points: []Point{
+			{TS: t0, Volume: 1200, ReshareRatio: 0.375, AutomationMix: map[string]Probability{"scheduled": 0.1, "manual": 0.9}},
+			{TS: t0.Add(time.Minute), Volume: 80, CoordinationSignals: CoordinationSignals{BurstScore: 0.5, DuplicationClusters: 2}, Backfilled: true},
+		},
+	}
+	want := `topic          #vote
+generated_at   2025-01-01T12:05:00Z
+interval       minute
+methodology    suppression_threshold=10 collection_lag=PT2M
+annotation     2025-01-01T12:00:00Z election polls open
+extension      x-example-region "emea"
+ts                      volume   reshare  recycled     burst synchrony   dups
+2025-01-01T12:00:00Z      1200     0.375     0.000     0.000     0.000      0 automation{manual=0.900 scheduled=0.100}
+2025-01-01T12:01:00Z        80     0.000     0.000     0.500     0.000      2 backfilled
+`
+	got := Format(&s, FormatOptions{})
+	if got != want {
+		t.Fatalf("Format =\n%s\nwant\n%s", got, want)
+	}
+
+	// A change to one point changes only its line.
+	s.Points[1].Volume = 123456
+	lines, changed := strings.Split(Format(s, FormatOptions{}), "\n"), 0
+	for i, l := range strings.Split(want, "\n") {
+		if lines[i] != l {
+			changed++
+		}
+	}
+	if changed != 1 {
+		t.Errorf("changing one volume changed %d lines", changed)
+	}
+}
+
+func TestFormatTags(t *testing.T) {
+	tags := []ProvenanceTag{
+		{AcctAgeBucket: AcctAge_1_6m, AcctType: AcctTypePerson, AutomationFlag: AutomationManual, PostKind: PostKindOriginal,
+			ClientFamily: ClientWeb, MediaProvenance: MediaProvNone, DedupHash: "0a1b2c3d", OriginHint: "US-CA"},
+		{AcctAgeBucket: AcctAge_24mPlus, AcctType: AcctTypeDeclaredAutomation, AutomationFlag: AutomationDeclaredBot, PostKind: PostKindReshare,
+			ClientFamily: ClientThirdParty, MediaProvenance: MediaProvC2PA, DedupHash: "0a1b2c3d4e5f6071", DedupHashAlg: DedupXXHash64},
+	}
+	got := strings.Split(Format(tags, FormatOptions{}), "\n")
+	if len(got) != 4 || got[3] != "" {
+		t.Fatalf("Format = %q", got)
+	}
+	// Schema-width columns line up regardless of the values.
+	for _, col := range []string{"post_kind", "media", "dedup_hash"} {
+		at := strings.Index(got[0], col)
+		if at < 0 || got[1][at-1] != ' ' || got[2][at-1] != ' ' || got[1][at] == ' ' {
+			t.Errorf("column %s misaligned:\n%s", col, strings.Join(got, "\n"))
+		}
+	}
+}
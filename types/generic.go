@@ -0,0 +1,46 @@
+package types
+
+import "time"
+
+// PointLike is implemented by per-interval point types that can live in a
+// SeriesOf. Extensions (e.g., ad-transparency points) implement it to reuse
+// the windowing, merge, and common validation helpers.
+type PointLike interface {
+	PointTime() time.Time
+}
+
+// PointTime returns p.TS, making Point a PointLike.
+func (p Point) PointTime() time.Time { return p.TS }
+
+// SeriesOf is a Series whose points are of a caller-defined type. Its JSON
+// shape matches Series apart from the point objects themselves.
+type SeriesOf[P PointLike] struct {
+	Topic        string       `json:"topic"`
+	GeneratedAt  time.Time    `json:"generated_at"`
+	Interval     Interval     `json:"interval"`
+	Points       []P          `json:"points"`
+	Annotations  []Annotation `json:"annotations,omitempty"`
+	Retraction   *Retraction  `json:"retraction,omitempty"`
+	Extensions   Extensions   `json:"extensions,omitempty"`
+	Tenant       string       `json:"tenant,omitempty"`
+	Jurisdiction string       `json:"jurisdiction,omitempty"`
+	Methodology  *Methodology `json:"methodology,omitempty"`
+}
+
+// Generic returns s as a SeriesOf[Point] carrying every field of s. Points,
+// annotations, extensions, and the retraction and methodology are shared,
+// not copied.
+func (s *Series) Generic() *SeriesOf[Point] {
+	return &SeriesOf[Point]{
+		Topic:        s.Topic,
+		GeneratedAt:  s.GeneratedAt,
+		Interval:     s.Interval,
+		Points:       s.Points,
+		Annotations:  s.Annotations,
+		Retraction:   s.Retraction,
+		Extensions:   s.Extensions,
+		Tenant:       s.Tenant,
+		Jurisdiction: s.Jurisdiction,
+		Methodology:  s.Methodology,
+	}
+}
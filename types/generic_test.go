@@ -0,0 +1,32 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestGenericCarriesAllFields(t *testing.T) {
+	at := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	s := &Series{
+		Topic: "#vote", GeneratedAt: at, Interval: IntervalMinute,
+		Points:       []Point{{TS: at, Volume: 3}},
+		Annotations:  []Annotation{{Start: at, Kind: AnnotationOutage}},
+		Retraction:   &Retraction{Topic: "#vote", Reason: RetractionDataError, EffectiveAt: at},
+		Extensions:   Extensions{"x-example-region": json.RawMessage(`"emea"`)},
+		Tenant:       "us.fec",
+		Jurisdiction: "US",
+		Methodology:  &Methodology{SuppressionThreshold: 10},
+	}
+	want, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := json.Marshal(s.Generic())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("Generic JSON differs:\ngot  %s\nwant %s", got, want)
+	}
+}
@@ -0,0 +1,43 @@
+package types
+
+import "time"
+
+// HealthStatus is an endpoint's overall self-assessment.
+type HealthStatus string
+
+const (
+	HealthOK       HealthStatus = "ok"
+	HealthDegraded HealthStatus = "degraded" // publishing, but late or with elevated rejections
+	HealthDown     HealthStatus = "down"     // not publishing
+)
+
+// HealthStatusValues returns the defined health statuses.
+func HealthStatusValues() []HealthStatus {
+	return []HealthStatus{HealthOK, HealthDegraded, HealthDown}
+}
+
+// Valid reports whether s is a defined health status.
+func (s HealthStatus) Valid() bool { return contains(HealthStatusValues(), s) }
+
+// HealthReport is the self-test payload a transparency endpoint serves so
+// monitors can check the publisher ecosystem without reading series.
+// Validate it with validate.ValidateHealthReport; fetch and check it with
+// health.Check.
+type HealthReport struct {
+	SpecVersion string        `json:"spec_version"`      // schema version published, e.g. "0.3.0"
+	GeneratedAt time.Time     `json:"generated_at"`      // UTC time the report was produced
+	Status      HealthStatus  `json:"status"`            // overall self-assessment
+	Window      ISODuration   `json:"window"`            // period the validation counts cover, e.g. PT1H
+	Topics      []TopicHealth `json:"topics"`            // one entry per published topic
+	Message     string        `json:"message,omitempty"` // operator note, e.g. a maintenance notice
+}
+
+// TopicHealth reports publishing and validation activity for one topic
+// over the report's Window.
+type TopicHealth struct {
+	Topic         string      `json:"topic"`
+	LastPublished time.Time   `json:"last_published"` // GeneratedAt of the newest series published; zero if none
+	Submitted     int         `json:"submitted"`      // payloads received (≥0)
+	Rejected      int         `json:"rejected"`       // payloads that failed validation (0 ≤ rejected ≤ submitted)
+	FailureRate   Probability `json:"failure_rate"`   // rejected/submitted; 0 when nothing was submitted
+}
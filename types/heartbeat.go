@@ -0,0 +1,37 @@
+package types
+
+import "time"
+
+// Heartbeat frequency rules. A live publisher sends a Heartbeat at least
+// every Interval it announces, which must be between HeartbeatMinInterval
+// and HeartbeatMaxInterval, whether or not it has data to send. A
+// subscriber that has received nothing for HeartbeatMissedLimit intervals
+// treats the publisher as down.
+const (
+	HeartbeatMinInterval = 5 * time.Second
+	HeartbeatMaxInterval = time.Minute
+	HeartbeatMissedLimit = 3
+)
+
+// Heartbeat is the keepalive message of a live series stream. It lets a
+// subscriber tell a quiet topic (heartbeats arrive, no points) from a
+// publisher that is down (heartbeats stop) or falling behind (Lag grows).
+// Validate it with validate.ValidateHeartbeat.
+type Heartbeat struct {
+	Publisher     string      `json:"publisher"`      // PublisherRef.ID of the sender
+	SentAt        time.Time   `json:"sent_at"`        // UTC send time
+	LastFinalized time.Time   `json:"last_finalized"` // ts of the newest bucket that will not change again; zero if none yet
+	Lag           ISODuration `json:"lag"`            // current delay between a bucket ending and its finalization
+	Interval      ISODuration `json:"interval"`       // longest time until the next heartbeat
+}
+
+// Expires returns the time after which a subscriber that has heard
+// nothing since h treats its publisher as down: SentAt plus
+// HeartbeatMissedLimit intervals.
+func (h *Heartbeat) Expires() time.Time {
+	return h.SentAt.Add(HeartbeatMissedLimit * time.Duration(h.Interval))
+}
+
+// Down reports whether, with h the newest heartbeat received, the
+// publisher should be treated as down at now.
+func (h *Heartbeat) Down(now time.Time) bool { return now.After(h.Expires()) }
@@ -0,0 +1,236 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseISODuration parses an ISO 8601 duration limited to fixed-length
+// units: weeks, days, hours, minutes, and (fractional) seconds, e.g. "PT1M",
+// "PT1H30M", "P1D", "P2W", "PT0.5S". Years and months are rejected because
+// their length varies. A day is 24 hours. Each designator may appear at
+// most once and in the order W, D, T, H, M, S. Numbers are plain decimal
+// digits: signs, exponents, NaN, Inf, and values beyond time.Duration's
+// range are rejected.
+func ParseISODuration(s string) (time.Duration, error) {
+	orig := s
+	bad := func() (time.Duration, error) {
+		return 0, fmt.Errorf("types: invalid ISO 8601 duration %q", orig)
+	}
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	if !strings.HasPrefix(s, "P") || len(s) < 3 {
+		return bad()
+	}
+	s = s[1:]
+
+	var d time.Duration
+	next := 0 // index into isoUnits of the first designator still allowed
+	inTime, seen := false, false
+	for len(s) > 0 {
+		if s[0] == 'T' {
+			if inTime || len(s) == 1 {
+				return bad()
+			}
+			inTime, next = true, isoTimeStart
+			s = s[1:]
+			continue
+		}
+		i := strings.IndexAny(s, "WDHMS")
+		if i <= 0 {
+			return bad()
+		}
+		num, unit := s[:i], s[i]
+		s = s[i+1:]
+		whole, frac, hasFrac := strings.Cut(num, ".")
+		if hasFrac && (unit != 'S' || len(s) > 0 || frac == "") {
+			return bad() // only the trailing seconds may be fractional
+		}
+		j := next
+		for j < len(isoUnits) && isoUnits[j].designator != unit {
+			j++
+		}
+		// A date unit after T, a time unit before T, or a unit that is
+		// repeated or out of order never matches from next onwards.
+		if j == len(isoUnits) || (j >= isoTimeStart) != inTime {
+			return bad()
+		}
+		v, ok := isoValue(whole, frac, isoUnits[j].size)
+		if !ok || v > math.MaxInt64-d {
+			return bad()
+		}
+		d += v
+		next, seen = j+1, true
+	}
+	if !seen {
+		return bad()
+	}
+	if neg {
+		d = -d
+	}
+	return d, nil
+}
+
+// isoValue returns whole.frac units of size, where whole and frac are
+// plain decimal digits; frac is truncated to nanoseconds. It reports false
+// for any other character or a result beyond time.Duration's range.
+func isoValue(whole, frac string, size time.Duration) (time.Duration, bool) {
+	if whole == "" {
+		return 0, false
+	}
+	var v time.Duration
+	for _, c := range []byte(whole) {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		if v > (math.MaxInt64-time.Duration(c-'0'))/10 {
+			return 0, false
+		}
+		v = v*10 + time.Duration(c-'0')
+	}
+	if v > math.MaxInt64/size {
+		return 0, false
+	}
+	v *= size
+	scale := size / 10
+	for _, c := range []byte(frac) {
+		if c < '0' || c > '9' {
+			return 0, false
+		}
+		v += time.Duration(c-'0') * scale
+		scale /= 10
+	}
+	return v, v >= 0 // the fraction can only overflow by wrapping negative
+}
+
+// isoUnits lists the accepted designators in the order ISO 8601 requires
+// them; the time units start at isoTimeStart.
+var isoUnits = [...]struct {
+	designator byte
+	size       time.Duration
+}{
+	{'W', 7 * 24 * time.Hour},
+	{'D', 24 * time.Hour},
+	{'H', time.Hour},
+	{'M', time.Minute},
+	{'S', time.Second},
+}
+
+const isoTimeStart = 2
+
+// FormatISODuration formats d as an ISO 8601 duration using days, hours,
+// minutes, and seconds, e.g. time.Minute -> "PT1M", 36h -> "P1DT12H".
+// Zero formats as "PT0S".
+func FormatISODuration(d time.Duration) string {
+	if d == 0 {
+		return "PT0S"
+	}
+	var b strings.Builder
+	u := uint64(d) // magnitude; uint64 so that math.MinInt64 negates safely
+	if d < 0 {
+		b.WriteByte('-')
+		u = -u
+	}
+	const day, hour, minute, second = uint64(24 * time.Hour), uint64(time.Hour), uint64(time.Minute), uint64(time.Second)
+	b.WriteByte('P')
+	if days := u / day; days > 0 {
+		b.WriteString(strconv.FormatUint(days, 10) + "D")
+		u %= day
+	}
+	if u > 0 {
+		b.WriteByte('T')
+		if h := u / hour; h > 0 {
+			b.WriteString(strconv.FormatUint(h, 10) + "H")
+			u %= hour
+		}
+		if m := u / minute; m > 0 {
+			b.WriteString(strconv.FormatUint(m, 10) + "M")
+			u %= minute
+		}
+		if u > 0 {
+			b.WriteString(strconv.FormatUint(u/second, 10))
+			if ns := u % second; ns > 0 {
+				b.WriteString(strings.TrimRight(fmt.Sprintf(".%09d", ns), "0"))
+			}
+			b.WriteByte('S')
+		}
+	}
+	return b.String()
+}
+
+// ISODuration is a time.Duration that encodes to JSON as an ISO 8601
+// duration string. Use it for retention, window, and similar fields.
+type ISODuration time.Duration
+
+// MarshalJSON encodes d as an ISO 8601 duration string.
+func (d ISODuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(FormatISODuration(time.Duration(d)))
+}
+
+// UnmarshalJSON decodes an ISO 8601 duration string.
+func (d *ISODuration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	v, err := ParseISODuration(s)
+	if err != nil {
+		return err
+	}
+	*d = ISODuration(v)
+	return nil
+}
+
+// Since spec 0.3.0 intervals other than one minute are ISO 8601 durations;
+// one minute keeps its original encoding "minute", and "PT1M" decodes to it.
+const (
+	IntervalHour Interval = "PT1H"
+	IntervalDay  Interval = "P1D"
+)
+
+// IntervalOf returns the canonical Interval for d: IntervalMinute for one
+// minute, otherwise its ISO 8601 form.
+func IntervalOf(d time.Duration) Interval {
+	if d == time.Minute {
+		return IntervalMinute
+	}
+	return Interval(FormatISODuration(d))
+}
+
+// Canonical returns i in canonical form, so equal intervals compare equal:
+// "PT1M" and "PT60S" become IntervalMinute and other durations their
+// shortest ISO 8601 form. Unparseable values are returned unchanged.
+func (i Interval) Canonical() Interval {
+	if i == IntervalMinute {
+		return i
+	}
+	if d, err := ParseISODuration(string(i)); err == nil && d > 0 {
+		return IntervalOf(d)
+	}
+	return i
+}
+
+// ISO returns i as an ISO 8601 duration, e.g. "PT1M" for IntervalMinute,
+// for consumers that expect durations throughout. Unparseable values are
+// returned unchanged.
+func (i Interval) ISO() string {
+	if d := i.Duration(); d > 0 {
+		return FormatISODuration(d)
+	}
+	return string(i)
+}
+
+// UnmarshalJSON accepts "minute" and ISO 8601 durations, storing the
+// canonical form.
+func (i *Interval) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	*i = Interval(s).Canonical()
+	return nil
+}
@@ -0,0 +1,71 @@
+package types
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseISODuration(t *testing.T) {
+	for in, want := range map[string]time.Duration{
+		"PT1M":                    time.Minute,
+		"P1DT12H":                 36 * time.Hour,
+		"P1W1D":                   8 * 24 * time.Hour,
+		"PT1H30M5S":               time.Hour + 30*time.Minute + 5*time.Second,
+		"PT0.5S":                  500 * time.Millisecond,
+		"-PT2M":                   -2 * time.Minute,
+		"PT1.0000000019S":         time.Second + 1,
+		"PT9223372036.854775807S": math.MaxInt64,
+	} {
+		if got, err := ParseISODuration(in); err != nil || got != want {
+			t.Errorf("ParseISODuration(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{
+		"", "P", "PT", "P1DT", "1M", "P1Y", "P1M", "PT1D", "P1H",
+		"PT1M1M", "PT1S1M", "P1D1W", "PT1H1H30M", "P1DT1HT1M", "PT0.5M", "PT-1M",
+		"PNaND", "PInfD", "P99999999999W", "PT+1M", "PT1e3S", "PT1.S", "PT.5S", "PT9223372036.854775808S",
+		"P15251W", "PT2562047H47M16.854775808S",
+	} {
+		if d, err := ParseISODuration(in); err == nil {
+			t.Errorf("ParseISODuration(%q) = %v, want error", in, d)
+		}
+	}
+}
+
+func TestIntervalCanonical(t *testing.T) {
+	for in, want := range map[Interval]Interval{
+		"minute": IntervalMinute,
+		"PT1M":   IntervalMinute,
+		"PT60S":  IntervalMinute,
+		"PT60M":  IntervalHour,
+		"P1D":    IntervalDay,
+		"bogus":  "bogus",
+	} {
+		if got := in.Canonical(); got != want {
+			t.Errorf("%q.Canonical() = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestFormatISODuration(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		0:                                   "PT0S",
+		time.Minute:                         "PT1M",
+		36 * time.Hour:                      "P1DT12H",
+		90*time.Second + 5*time.Millisecond: "PT1M30.005S",
+		-time.Nanosecond:                    "-PT0.000000001S",
+		math.MaxInt64:                       "P106751DT23H47M16.854775807S",
+		math.MinInt64:                       "-P106751DT23H47M16.854775808S",
+	} {
+		if got := FormatISODuration(d); got != want {
+			t.Errorf("FormatISODuration(%d) = %q, want %q", d, got, want)
+		}
+		if d == math.MinInt64 {
+			continue // one beyond what a positive magnitude can parse
+		}
+		if back, err := ParseISODuration(want); err != nil || back != d {
+			t.Errorf("ParseISODuration(%q) = %v, %v; want %v", want, back, err, d)
+		}
+	}
+}
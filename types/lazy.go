@@ -0,0 +1,172 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// LazySeries holds the JSON encoding of a Series and decodes it on demand:
+// Header decodes every field but the points, TimeRange decodes only point
+// timestamps, and Points decodes the rest. Routing and lookup services that
+// only need the topic and time span never allocate the points.
+//
+// Each part is decoded at most once and cached. A LazySeries is not safe
+// for concurrent use.
+type LazySeries struct {
+	raw    []byte
+	header *Series         // Points always nil
+	points json.RawMessage // set with header
+	span   *lazySpan
+	full   []Point
+}
+
+type lazySpan struct {
+	from, to time.Time
+	n        int
+}
+
+// NewLazySeries wraps raw, which is not copied and must not be modified
+// while l is in use. Nothing is decoded until a method needs it.
+func NewLazySeries(raw []byte) *LazySeries {
+	return &LazySeries{raw: raw}
+}
+
+// UnmarshalJSON copies b for later decoding, so a LazySeries can be a field
+// or slice element of a larger document.
+func (l *LazySeries) UnmarshalJSON(b []byte) error {
+	*l = LazySeries{raw: append([]byte(nil), b...)}
+	return nil
+}
+
+// MarshalJSON returns the wrapped bytes unchanged.
+func (l LazySeries) MarshalJSON() ([]byte, error) {
+	if l.raw == nil {
+		return []byte("null"), nil
+	}
+	return l.raw, nil
+}
+
+// Raw returns the wrapped bytes.
+func (l *LazySeries) Raw() []byte { return l.raw }
+
+// seriesFields has Series' fields without its methods, so lazyHeader can
+// shadow Points.
+type seriesFields Series
+
+type lazyHeader struct {
+	seriesFields
+	Points json.RawMessage `json:"points"`
+}
+
+// Header returns the series with Points nil. The points array is skipped
+// over, not decoded.
+func (l *LazySeries) Header() (*Series, error) {
+	if l.raw == nil {
+		return nil, errLazyNil
+	}
+	if l.header == nil {
+		var h lazyHeader
+		if err := json.Unmarshal(l.raw, &h); err != nil {
+			return nil, err
+		}
+		s := Series(h.seriesFields)
+		l.header, l.points = &s, h.Points
+	}
+	return l.header, nil
+}
+
+// Topic returns the series topic.
+func (l *LazySeries) Topic() (string, error) {
+	h, err := l.Header()
+	if err != nil {
+		return "", err
+	}
+	return h.Topic, nil
+}
+
+// TimeRange returns the earliest and latest point ts and the number of
+// points, decoding nothing else from each point. Both times are zero for a
+// series without points.
+func (l *LazySeries) TimeRange() (from, to time.Time, n int, err error) {
+	if l.span == nil {
+		if l.full != nil {
+			l.span = spanOf(len(l.full), func(i int) time.Time { return l.full[i].TS })
+		} else {
+			raw, err := l.rawPoints()
+			if err != nil {
+				return time.Time{}, time.Time{}, 0, err
+			}
+			var ts []struct {
+				TS time.Time `json:"ts"`
+			}
+			if len(raw) > 0 {
+				if err := json.Unmarshal(raw, &ts); err != nil {
+					return time.Time{}, time.Time{}, 0, err
+				}
+			}
+			l.span = spanOf(len(ts), func(i int) time.Time { return ts[i].TS })
+		}
+	}
+	return l.span.from, l.span.to, l.span.n, nil
+}
+
+func spanOf(n int, ts func(int) time.Time) *lazySpan {
+	sp := &lazySpan{n: n}
+	for i := 0; i < n; i++ {
+		t := ts(i)
+		if i == 0 || t.Before(sp.from) {
+			sp.from = t
+		}
+		if i == 0 || t.After(sp.to) {
+			sp.to = t
+		}
+	}
+	return sp
+}
+
+// Points decodes and returns the points. The slice is cached; callers must
+// not modify it.
+func (l *LazySeries) Points() ([]Point, error) {
+	if l.full == nil {
+		raw, err := l.rawPoints()
+		if err != nil {
+			return nil, err
+		}
+		pts := []Point{}
+		if len(raw) > 0 {
+			if err := json.Unmarshal(raw, &pts); err != nil {
+				return nil, err
+			}
+		}
+		l.full = pts
+	}
+	return l.full, nil
+}
+
+// Series decodes the whole series into a new value the caller owns.
+func (l *LazySeries) Series() (*Series, error) {
+	h, err := l.Header()
+	if err != nil {
+		return nil, err
+	}
+	pts, err := l.Points()
+	if err != nil {
+		return nil, err
+	}
+	s := *h
+	s.Points = append([]Point(nil), pts...)
+	return &s, nil
+}
+
+var errLazyNil = errors.New("types: LazySeries holds no data")
+
+func (l *LazySeries) rawPoints() (json.RawMessage, error) {
+	if _, err := l.Header(); err != nil {
+		return nil, err
+	}
+	if string(l.points) == "null" {
+		return nil, nil
+	}
+	return l.points, nil
+}
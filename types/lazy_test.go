@@ -0,0 +1,101 @@
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func lazyFixture(n int) *Series {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &Series{Topic: "#vote", GeneratedAt: t0.Add(time.Duration(n) * time.Minute), Interval: "minute", Tenant: "us.fec"}
+	for i := 0; i < n; i++ {
+		s.Points = append(s.Points, Point{
+			TS:         t0.Add(time.Duration(n-1-i) * time.Minute), // descending, to exercise min/max
+			Volume:     i,
+			AcctAgeMix: map[string]Probability{"0-7d": 0.5, "24m+": 0.5},
+		})
+	}
+	return s
+}
+
+func TestLazySeries(t *testing.T) {
+	want := lazyFixture(5)
+	raw, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := NewLazySeries(raw)
+
+	h, err := l.Header()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Topic != "#vote" || h.Interval != IntervalMinute || h.Tenant != "us.fec" || h.Points != nil {
+		t.Errorf("Header = %+v", h)
+	}
+	from, to, n, err := l.TimeRange()
+	if err != nil || n != 5 || !from.Equal(want.Points[4].TS) || !to.Equal(want.Points[0].TS) {
+		t.Errorf("TimeRange = %v, %v, %d, %v", from, to, n, err)
+	}
+	if l.full != nil {
+		t.Error("TimeRange decoded full points")
+	}
+
+	got, err := l.Series()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want.Interval = IntervalMinute
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Series = %+v\nwant %+v", got, want)
+	}
+
+	var wrapped struct{ Series LazySeries }
+	if err := json.Unmarshal([]byte(`{"Series":`+string(raw)+`}`), &wrapped); err != nil {
+		t.Fatal(err)
+	}
+	if topic, _ := wrapped.Series.Topic(); topic != "#vote" {
+		t.Errorf("embedded Topic = %q", topic)
+	}
+	if out, _ := json.Marshal(wrapped.Series); string(out) != string(raw) {
+		t.Error("MarshalJSON did not return the wrapped bytes")
+	}
+}
+
+func TestLazySeriesErrors(t *testing.T) {
+	if _, err := (&LazySeries{}).Header(); err == nil {
+		t.Error("empty LazySeries decoded")
+	}
+	l := NewLazySeries([]byte(`{"topic":"x","points":[{"ts":"nope"}]}`))
+	// Points are skipped, not checked, until something needs them.
+	if topic, err := l.Topic(); err != nil || topic != "x" {
+		t.Errorf("Topic = %q, %v", topic, err)
+	}
+	if _, _, _, err := l.TimeRange(); err == nil {
+		t.Error("TimeRange accepted a bad ts")
+	}
+	if _, _, _, err := NewLazySeries([]byte(`{"topic":"x","points":null}`)).TimeRange(); err != nil {
+		t.Errorf("null points: %v", err)
+	}
+}
+
+func BenchmarkLazySeriesTopic(b *testing.B) {
+	raw, _ := json.Marshal(lazyFixture(10000))
+	b.Run("lazy", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := NewLazySeries(raw).Topic(); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("full", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			var s Series
+			if err := json.Unmarshal(raw, &s); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SeriesState is where a published series is in its lifecycle. Publishers
+// and mirrors use it instead of each inferring from timestamps whether a
+// series can still change.
+type SeriesState string
+
+const (
+	SeriesCollecting  SeriesState = "collecting"  // points for recent buckets are still arriving
+	SeriesProvisional SeriesState = "provisional" // collection ended; late data within the collection lag may still change it
+	SeriesFinalized   SeriesState = "finalized"   // will not change except by revision or retraction
+	SeriesRevised     SeriesState = "revised"     // a finalized series republished with corrections
+	SeriesRetracted   SeriesState = "retracted"   // withdrawn (see Retraction); terminal
+)
+
+// SeriesStateValues returns the lifecycle states in order.
+func SeriesStateValues() []SeriesState {
+	return []SeriesState{SeriesCollecting, SeriesProvisional, SeriesFinalized, SeriesRevised, SeriesRetracted}
+}
+
+// Valid reports whether s is a defined lifecycle state.
+func (s SeriesState) Valid() bool { return contains(SeriesStateValues(), s) }
+
+// seriesTransitions lists the states each state may move to.
+var seriesTransitions = map[SeriesState][]SeriesState{
+	SeriesCollecting:  {SeriesProvisional, SeriesFinalized, SeriesRetracted},
+	SeriesProvisional: {SeriesFinalized, SeriesRetracted},
+	SeriesFinalized:   {SeriesRevised, SeriesRetracted},
+	SeriesRevised:     {SeriesRevised, SeriesRetracted},
+}
+
+// Next returns the states s may move to, in SeriesStateValues order. It is
+// empty for retracted and for undefined states.
+func (s SeriesState) Next() []SeriesState {
+	return append([]SeriesState(nil), seriesTransitions[s]...)
+}
+
+// CanTransition reports whether s may move to next.
+func (s SeriesState) CanTransition(next SeriesState) bool {
+	return contains(seriesTransitions[s], next)
+}
+
+// Final reports whether collection of a series in state s has ended:
+// finalized, revised, or retracted. Mirrors compare only final series.
+// Final is not immutable: finalized and revised series can still be
+// revised or retracted, so caches must bound how long they reuse one and
+// revalidate it, and a revision is announced by a new transition rather
+// than by points changing in place.
+func (s SeriesState) Final() bool {
+	return s == SeriesFinalized || s == SeriesRevised || s == SeriesRetracted
+}
+
+// Terminal reports whether s has no further transitions.
+func (s SeriesState) Terminal() bool { return s == SeriesRetracted }
+
+// ErrTransition is returned by Transition for a move the lifecycle does
+// not allow.
+var ErrTransition = errors.New("types: invalid series state transition")
+
+// StateTransition records one lifecycle move of a series.
+type StateTransition struct {
+	From   SeriesState `json:"from"`
+	To     SeriesState `json:"to"`
+	Reason string      `json:"reason,omitempty"` // why; a RetractionReason when To is retracted
+}
+
+// Transition checks that a series may move from one state to another and
+// returns the record of the move. Revising requires a reason describing
+// the correction, and retracting requires a RetractionReason value; other
+// moves take an optional reason. Errors wrap ErrTransition.
+func Transition(from, to SeriesState, reason string) (StateTransition, error) {
+	t := StateTransition{From: from, To: to, Reason: reason}
+	switch {
+	case !from.Valid():
+		return t, fmt.Errorf("%w: unknown state %q", ErrTransition, from)
+	case !to.Valid():
+		return t, fmt.Errorf("%w: unknown state %q", ErrTransition, to)
+	case !from.CanTransition(to):
+		return t, fmt.Errorf("%w: %s to %s", ErrTransition, from, to)
+	case to == SeriesRevised && reason == "":
+		return t, fmt.Errorf("%w: %s to %s requires a reason", ErrTransition, from, to)
+	case to == SeriesRetracted && !RetractionReason(reason).Valid():
+		return t, fmt.Errorf("%w: %s to %s requires a retraction reason, got %q", ErrTransition, from, to, reason)
+	}
+	return t, nil
+}
+
+// StateOf infers the lifecycle state of s from its contents, for series
+// whose publisher does not track state explicitly. A retracted series is
+// retracted. Otherwise, with end the end of the last point's bucket and
+// lag the methodology's collection lag, s is collecting if it was
+// generated before end+lag or has no points, provisional if generated
+// before the end of that point's UTC day plus lag, and finalized after.
+// A revision cannot be inferred: callers that revise series must record
+// the state themselves (see Transition).
+func StateOf(s *Series) SeriesState {
+	if s.Retraction != nil {
+		return SeriesRetracted
+	}
+	if len(s.Points) == 0 {
+		return SeriesCollecting
+	}
+	var last time.Time
+	for _, p := range s.Points {
+		if p.TS.After(last) {
+			last = p.TS
+		}
+	}
+	var lag time.Duration
+	if m := s.Methodology; m != nil {
+		lag = time.Duration(m.CollectionLag)
+	}
+	day := last.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+	switch {
+	case s.GeneratedAt.Before(last.Add(s.Interval.Duration()).Add(lag)):
+		return SeriesCollecting
+	case s.GeneratedAt.Before(day.Add(lag)):
+		return SeriesProvisional
+	}
+	return SeriesFinalized
+}
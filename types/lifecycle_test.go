@@ -0,0 +1,96 @@
+package types
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTransition(t *testing.T) {
+	for _, c := range []struct {
+		from, to SeriesState
+		reason   string
+		ok       bool
+	}{
+		{SeriesCollecting, SeriesProvisional, "", true},
+		{SeriesCollecting, SeriesFinalized, "", true},
+		{SeriesProvisional, SeriesFinalized, "collection lag elapsed", true},
+		{SeriesFinalized, SeriesRevised, "late deletions applied", true},
+		{SeriesRevised, SeriesRevised, "second correction", true},
+		{SeriesRevised, SeriesRetracted, string(RetractionPrivacy), true},
+		{SeriesCollecting, SeriesRetracted, string(RetractionLegal), true},
+
+		{SeriesProvisional, SeriesCollecting, "", false},
+		{SeriesFinalized, SeriesProvisional, "", false},
+		{SeriesCollecting, SeriesRevised, "fix", false},
+		{SeriesCollecting, SeriesCollecting, "", false},
+		{SeriesRetracted, SeriesRevised, "undo", false},
+		{SeriesFinalized, SeriesRevised, "", false},
+		{SeriesFinalized, SeriesRetracted, "oops", false},
+		{"final", SeriesRevised, "fix", false},
+		{SeriesFinalized, "", "", false},
+	} {
+		tr, err := Transition(c.from, c.to, c.reason)
+		if (err == nil) != c.ok {
+			t.Errorf("Transition(%q, %q, %q) error = %v, want ok=%v", c.from, c.to, c.reason, err, c.ok)
+			continue
+		}
+		if err != nil && !errors.Is(err, ErrTransition) {
+			t.Errorf("Transition(%q, %q) error %v does not wrap ErrTransition", c.from, c.to, err)
+		}
+		if tr != (StateTransition{c.from, c.to, c.reason}) {
+			t.Errorf("Transition(%q, %q) = %+v", c.from, c.to, tr)
+		}
+	}
+}
+
+func TestSeriesStateProperties(t *testing.T) {
+	for _, s := range SeriesStateValues() {
+		if s.Terminal() != (len(s.Next()) == 0) {
+			t.Errorf("%s: Terminal() = %v with Next() = %v", s, s.Terminal(), s.Next())
+		}
+		for _, n := range s.Next() {
+			if !n.Valid() {
+				t.Errorf("%s: undefined successor %q", s, n)
+			}
+			// Nothing moves from settled back to unsettled.
+			if s.Final() && !n.Final() {
+				t.Errorf("%s (final) may move to %s (not final)", s, n)
+			}
+		}
+	}
+	if SeriesProvisional.Final() || !SeriesRevised.Final() {
+		t.Error("Final: provisional must not be final, revised must be")
+	}
+	if SeriesState("final").Valid() || len(SeriesState("final").Next()) != 0 {
+		t.Error("undefined state reported valid or with successors")
+	}
+}
+
+func TestStateOf(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	s := &Series{Topic: "#vote", Interval: IntervalMinute, Points: []Point{{TS: t0}, {TS: t0.Add(time.Minute)}}}
+	for _, c := range []struct {
+		generated time.Time
+		lag       time.Duration
+		want      SeriesState
+	}{
+		{t0.Add(90 * time.Second), 0, SeriesCollecting},
+		{t0.Add(2 * time.Minute), 0, SeriesProvisional},
+		{t0.Add(2 * time.Minute), time.Hour, SeriesCollecting},
+		{t0.Add(14 * time.Hour), 0, SeriesFinalized},
+		{t0.Add(14 * time.Hour), time.Hour, SeriesProvisional},
+	} {
+		s.GeneratedAt, s.Methodology = c.generated, &Methodology{CollectionLag: ISODuration(c.lag)}
+		if got := StateOf(s); got != c.want {
+			t.Errorf("generated %s lag %s: StateOf = %s, want %s", c.generated.Format(time.Kitchen), c.lag, got, c.want)
+		}
+	}
+	s.Retraction = &Retraction{Topic: "#vote"}
+	if got := StateOf(s); got != SeriesRetracted {
+		t.Errorf("retracted: StateOf = %s", got)
+	}
+	if got := StateOf(&Series{}); got != SeriesCollecting {
+		t.Errorf("empty: StateOf = %s", got)
+	}
+}
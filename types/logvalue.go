@@ -0,0 +1,57 @@
+package types
+
+import (
+	"log/slog"
+	"strings"
+)
+
+// LogValue implements slog.LogValuer with a summary of s: topic, interval,
+// time range, and point count, never the points themselves.
+func (s Series) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("topic", s.Topic),
+		slog.String("interval", string(s.Interval)),
+		slog.Time("generated_at", s.GeneratedAt),
+		slog.Int("points", len(s.Points)),
+	}
+	if n := len(s.Points); n > 0 {
+		attrs = append(attrs, slog.Time("from", s.Points[0].TS), slog.Time("to", s.Points[n-1].TS))
+	}
+	if s.Tenant != "" {
+		attrs = append(attrs, slog.String("tenant", s.Tenant))
+	}
+	if s.Retraction != nil {
+		attrs = append(attrs, slog.Bool("retracted", true))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+// LogValue implements slog.LogValuer. It logs the enumerated fields, the
+// first four characters of the dedup hash, and the country of the origin
+// hint, so a log line cannot be joined back to the full tag.
+func (t ProvenanceTag) LogValue() slog.Value {
+	attrs := []slog.Attr{
+		slog.String("acct_age_bucket", string(t.AcctAgeBucket)),
+		slog.String("acct_type", string(t.AcctType)),
+		slog.String("automation_flag", string(t.AutomationFlag)),
+		slog.String("post_kind", string(t.PostKind)),
+		slog.String("client_family", string(t.ClientFamily)),
+		slog.String("media_provenance", string(t.MediaProvenance)),
+		slog.String("dedup_hash", truncateHash(string(t.DedupHash))),
+	}
+	if t.OriginHint != "" {
+		country, _, _ := strings.Cut(t.OriginHint, "-")
+		attrs = append(attrs, slog.String("origin_country", country))
+	}
+	if n := len(t.Extensions); n > 0 {
+		attrs = append(attrs, slog.Int("extensions", n))
+	}
+	return slog.GroupValue(attrs...)
+}
+
+func truncateHash(h string) string {
+	if len(h) <= 4 {
+		return h
+	}
+	return h[:4] + "…"
+}
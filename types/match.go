@@ -0,0 +1,68 @@
+package types
+
+// Matcher reports whether a string has a particular shape. *regexp.Regexp
+// satisfies it, so the Re* patterns and the handwritten matchers below are
+// interchangeable.
+type Matcher interface {
+	MatchString(s string) bool
+}
+
+// MatcherFunc adapts a predicate to Matcher.
+type MatcherFunc func(s string) bool
+
+// MatchString returns f(s).
+func (f MatcherFunc) MatchString(s string) bool { return f(s) }
+
+// Shape is a handwritten Matcher for one of the fixed shapes below. Its
+// values are constants, so unlike a package-level Matcher variable they
+// cannot be reassigned while validators read them.
+type Shape uint8
+
+// Handwritten equivalents of ReHex8, ReHex16, and ReISO3166. They are an
+// order of magnitude faster than the regexps (see the benchmarks), which
+// matters in per-tag validation loops. Validators in this module use these.
+const (
+	Hex8 Shape = iota + 1
+	Hex16
+	ISO3166
+)
+
+// MatchString reports whether s has shape m. The zero Shape matches
+// nothing.
+func (m Shape) MatchString(s string) bool {
+	switch m {
+	case Hex8:
+		return len(s) == 8 && lowerHex(s)
+	case Hex16:
+		return len(s) == 16 && lowerHex(s)
+	case ISO3166:
+		return iso3166(s)
+	}
+	return false
+}
+
+func lowerHex(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !('0' <= c && c <= '9' || 'a' <= c && c <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// iso3166 matches ^[A-Z]{2}(-[A-Z0-9]{1,3})?$.
+func iso3166(s string) bool {
+	switch n := len(s); {
+	case n == 2:
+	case n >= 4 && n <= 6 && s[2] == '-':
+		for i := 3; i < n; i++ {
+			if c := s[i]; !('A' <= c && c <= 'Z' || '0' <= c && c <= '9') {
+				return false
+			}
+		}
+	default:
+		return false
+	}
+	return 'A' <= s[0] && s[0] <= 'Z' && 'A' <= s[1] && s[1] <= 'Z'
+}
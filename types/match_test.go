@@ -0,0 +1,64 @@
+package types
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMatchersAgreeWithRegexps(t *testing.T) {
+	cases := []string{
+		"", "deadbeef", "DEADBEEF", "deadbee", "deadbeef0", "deadbeeg", "0123456789abcdef", "0123456789abcdeF",
+		"US", "us", "U", "USA", "US-", "US-CA", "US-C", "US-CAL", "US-CALI", "US_CA", "U1-CA", "US-ca", "GB-ENG",
+		"dé", "ÉÉ", "US-C\x00",
+	}
+	rng := rand.New(rand.NewSource(1))
+	const alphabet = "09afgzAFZ-_é"
+	for i := 0; i < 20000; i++ {
+		b := make([]byte, rng.Intn(18))
+		for j := range b {
+			b[j] = alphabet[rng.Intn(len(alphabet))]
+		}
+		cases = append(cases, string(b))
+	}
+	for _, s := range cases {
+		if got, want := Hex8.MatchString(s), ReHex8.MatchString(s); got != want {
+			t.Errorf("Hex8(%q) = %v, regexp %v", s, got, want)
+		}
+		if got, want := Hex16.MatchString(s), ReHex16.MatchString(s); got != want {
+			t.Errorf("Hex16(%q) = %v, regexp %v", s, got, want)
+		}
+		if got, want := ISO3166.MatchString(s), ReISO3166.MatchString(s); got != want {
+			t.Errorf("ISO3166(%q) = %v, regexp %v", s, got, want)
+		}
+	}
+}
+
+var matchSink bool
+
+func BenchmarkHex8(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		m    Matcher
+	}{{"regexp", ReHex8}, {"handwritten", Hex8}} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				matchSink = bc.m.MatchString("deadbeef")
+			}
+		})
+	}
+}
+
+func BenchmarkISO3166(b *testing.B) {
+	for _, bc := range []struct {
+		name string
+		m    Matcher
+	}{{"regexp", ReISO3166}, {"handwritten", ISO3166}} {
+		b.Run(bc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				matchSink = bc.m.MatchString("US-CA")
+			}
+		})
+	}
+}
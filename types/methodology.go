@@ -0,0 +1,28 @@
+package types
+
+// Methodology states how a series was produced, so consumers can interpret
+// its numbers without a separate methodology document. Every field is
+// optional; an omitted field means the publisher did not state it, not
+// that the corresponding technique was absent.
+type Methodology struct {
+	// SamplingRate is the fraction of posts the collector observed
+	// (0 < rate ≤ 1). Volumes count observed posts; divide by the rate to
+	// estimate the population.
+	SamplingRate Probability `json:"sampling_rate,omitempty" bson:"sampling_rate,omitempty"`
+	// SuppressionThreshold is the smallest nonzero volume published: points
+	// below it were suppressed to zero. Validation enforces it as the
+	// series' suppression floor.
+	SuppressionThreshold int `json:"suppression_threshold,omitempty" bson:"suppression_threshold,omitempty"`
+	// VolumeRounding is the multiple volumes were rounded to (see
+	// privacy.RoundVolumes).
+	VolumeRounding int `json:"volume_rounding,omitempty" bson:"volume_rounding,omitempty"`
+	// NoiseEpsilon is the differential-privacy budget of noise added to
+	// each point, if any; smaller means noisier.
+	NoiseEpsilon float64 `json:"noise_epsilon,omitempty" bson:"noise_epsilon,omitempty"`
+	// CollectionLag is how long after a bucket ends its data is complete;
+	// buckets younger than that may still grow in a later series.
+	CollectionLag ISODuration `json:"collection_lag,omitempty" bson:"collection_lag,omitempty"`
+	// Caveats are plain-language notes on anything else that affects
+	// interpretation, e.g. a known collection gap.
+	Caveats []string `json:"caveats,omitempty" bson:"caveats,omitempty"`
+}
@@ -0,0 +1,63 @@
+package types
+
+import "time"
+
+// The record types below were first generated from series.schema.json at
+// spec 0.2.1 (see series.go and provenance.go). They have been maintained
+// by hand since, as the spec grew to SpecVersion; schemahistory records
+// which version added each field.
+
+// CoordinationSignals captures per-interval coordination indicators.
+type CoordinationSignals struct {
+	BurstScore          Probability `json:"burst_score" bson:"burst_score"`                   // 0-1 burstiness indicator
+	SynchronyIndex      Probability `json:"synchrony_index" bson:"synchrony_index"`           // 0-1 temporal synchrony indicator
+	DuplicationClusters int         `json:"duplication_clusters" bson:"duplication_clusters"` // count of duplicate/near-duplicate clusters (≥0)
+}
+
+// Point represents metrics for a single UTC minute boundary.
+type Point struct {
+	TS                  time.Time                `json:"ts" bson:"ts"`                                                 // UTC minute boundary
+	Volume              int                      `json:"volume" bson:"volume"`                                         // total posts in this interval (≥0)
+	ReshareRatio        Probability              `json:"reshare_ratio" bson:"reshare_ratio"`                           // fraction of posts that are reshares (0-1)
+	RecycledContentRate Probability              `json:"recycled_content_rate" bson:"recycled_content_rate"`           // fraction of posts recycling prior content (0-1)
+	AcctAgeMix          map[string]Probability   `json:"acct_age_mix" bson:"acct_age_mix"`                             // distribution over account-age buckets (values ≈1.0)
+	AutomationMix       map[string]Probability   `json:"automation_mix" bson:"automation_mix"`                         // distribution over automation flags (values ≈1.0)
+	ClientMix           map[string]Probability   `json:"client_mix" bson:"client_mix"`                                 // distribution over client families (values ≈1.0)
+	AcctTypeShares      map[AcctType]Probability `json:"acct_type_shares,omitempty" bson:"acct_type_shares,omitempty"` // distribution over account types (values ≈1.0)
+	PostKindMix         map[PostKind]Probability `json:"post_kind_mix,omitempty" bson:"post_kind_mix,omitempty"`       // distribution over post kinds (values ≈1.0; reshare ≈ reshare_ratio)
+	CoordinationSignals CoordinationSignals      `json:"coordination_signals" bson:"coordination_signals"`             // per-interval coordination indicators
+	Backfilled          bool                     `json:"backfilled,omitempty" bson:"backfilled,omitempty"`             // true if supplied retroactively rather than live
+}
+
+// Series describes a full time series of Points for a specific topic.
+type Series struct {
+	Topic        string       `json:"topic" bson:"topic"`                                   // Topic key (e.g., hashtag)
+	GeneratedAt  time.Time    `json:"generated_at" bson:"generated_at"`                     // UTC timestamp when this series was generated
+	Interval     Interval     `json:"interval" bson:"interval"`                             // Aggregation interval
+	Points       []Point      `json:"points" bson:"points"`                                 // Collection of per-interval metrics
+	Annotations  []Annotation `json:"annotations,omitempty" bson:"annotations,omitempty"`   // Context markers (elections, outages, policy changes)
+	Retraction   *Retraction  `json:"retraction,omitempty" bson:"retraction,omitempty"`     // Set when the series has been withdrawn (tombstone)
+	Extensions   Extensions   `json:"extensions,omitempty" bson:"extensions,omitempty"`     // Platform disclosures keyed x-<platform>-<name>
+	Tenant       string       `json:"tenant,omitempty" bson:"tenant,omitempty"`             // Hosting namespace (e.g., "us.fec"); see ValidTenant
+	Jurisdiction string       `json:"jurisdiction,omitempty" bson:"jurisdiction,omitempty"` // ISO-3166 code of the governing authority (e.g., "US" or "CA-ON")
+	Methodology  *Methodology `json:"methodology,omitempty" bson:"methodology,omitempty"`   // How the numbers were produced (sampling, suppression, noise, lag)
+}
+
+// HexHash8 is a lowercase hex string (privacy-preserving daily-salted hash).
+// It is 8 chars for the default sha256-trunc8 algorithm; other algorithms
+// use the length given by DedupHashAlg.HexLen.
+type HexHash8 string
+
+// ProvenanceTag represents the metadata about a transparency event.
+type ProvenanceTag struct {
+	AcctAgeBucket   AcctAge         `json:"acct_age_bucket" bson:"acct_age_bucket"`                   // required
+	AcctType        AcctType        `json:"acct_type" bson:"acct_type"`                               // required
+	AutomationFlag  AutomationFlag  `json:"automation_flag" bson:"automation_flag"`                   // required
+	PostKind        PostKind        `json:"post_kind" bson:"post_kind"`                               // required
+	ClientFamily    ClientFamily    `json:"client_family" bson:"client_family"`                       // required
+	MediaProvenance MediaProvenance `json:"media_provenance" bson:"media_provenance"`                 // required
+	DedupHash       HexHash8        `json:"dedup_hash" bson:"dedup_hash"`                             // required (8 hex chars)
+	OriginHint      string          `json:"origin_hint,omitempty" bson:"origin_hint,omitempty"`       // optional ISO-3166 (e.g., "US" or "US-CA")
+	DedupHashAlg    DedupHashAlg    `json:"dedup_hash_alg,omitempty" bson:"dedup_hash_alg,omitempty"` // optional; empty means sha256-trunc8
+	Extensions      Extensions      `json:"extensions,omitempty" bson:"extensions,omitempty"`         // optional platform disclosures keyed x-<platform>-<name>
+}
@@ -66,19 +66,3 @@ const (
 	MediaProvHash MediaProvenance = "hash_only"
 	MediaProvNone MediaProvenance = "none"
 )
-
-// HexHash8 is a fixed 8-char lowercase hex string (privacy-preserving daily-salted hash).
-// Use ValidateHexHash8 to check validity.
-type HexHash8 string
-
-// ProvenanceTag represents the metadata about a transparency event.
-type ProvenanceTag struct {
-	AcctAgeBucket  AcctAge         `json:"acct_age_bucket"`  // required
-	AcctType       AcctType        `json:"acct_type"`        // required
-	AutomationFlag AutomationFlag  `json:"automation_flag"`  // required
-	PostKind       PostKind        `json:"post_kind"`        // required
-	ClientFamily   ClientFamily    `json:"client_family"`    // required
-	MediaProvenance MediaProvenance `json:"media_provenance"` // required
-	DedupHash      HexHash8        `json:"dedup_hash"`       // required (8 hex chars)
-	OriginHint     string          `json:"origin_hint,omitempty"` // optional ISO-3166 (e.g., "US" or "US-CA")
-}
@@ -0,0 +1,38 @@
+package types
+
+import "time"
+
+// QuarantineState is where a QuarantineRecord is in review.
+type QuarantineState string
+
+const (
+	QuarantinePending  QuarantineState = "pending"  // held, awaiting review
+	QuarantineReleased QuarantineState = "released" // reviewed and accepted for publication
+	QuarantineRejected QuarantineState = "rejected" // reviewed and discarded
+)
+
+// QuarantineStateValues returns the defined quarantine states.
+func QuarantineStateValues() []QuarantineState {
+	return []QuarantineState{QuarantinePending, QuarantineReleased, QuarantineRejected}
+}
+
+// Valid reports whether s is a defined quarantine state.
+func (s QuarantineState) Valid() bool { return contains(QuarantineStateValues(), s) }
+
+// QuarantineRecord holds a payload that passed validation but failed a
+// plausibility check, so a reviewer decides whether it is published
+// instead of it being dropped or silently accepted. Invalid payloads go to
+// a DeadLetter instead. A record starts pending and moves once, to
+// released or rejected.
+type QuarantineRecord struct {
+	Key           string          `json:"key"`                     // hex SHA-256 of Payload; identifies repeats
+	SubmissionID  SubmissionID    `json:"submission_id,omitempty"` // submission the payload arrived in, if known
+	Source        string          `json:"source,omitempty"`        // where the payload came from, e.g. a publisher ID
+	Payload       []byte          `json:"payload"`                 // original bytes
+	Reasons       []string        `json:"reasons"`                 // plausibility findings that caused the hold
+	State         QuarantineState `json:"state"`
+	QuarantinedAt time.Time       `json:"quarantined_at"`
+	DecidedAt     *time.Time      `json:"decided_at,omitempty"` // nil while pending
+	Reviewer      string          `json:"reviewer,omitempty"`   // who released or rejected it
+	Note          string          `json:"note,omitempty"`       // reviewer's rationale
+}
@@ -0,0 +1,10 @@
+package types
+
+// PublisherQuota is the ingestion allowance of one publisher: a token
+// bucket refilled at RatePerSecond up to Burst tokens, where each request
+// costs one token unless the endpoint says otherwise.
+type PublisherQuota struct {
+	PublisherID   string  `json:"publisher_id"`    // PublisherRef.ID
+	RatePerSecond float64 `json:"rate_per_second"` // sustained refill rate (>0)
+	Burst         int     `json:"burst"`           // bucket capacity (≥1)
+}
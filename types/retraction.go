@@ -0,0 +1,84 @@
+package types
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"time"
+)
+
+// RetractionReason classifies why a series was withdrawn.
+type RetractionReason string
+
+const (
+	RetractionLegal      RetractionReason = "legal"
+	RetractionDataError  RetractionReason = "data_error"
+	RetractionPrivacy    RetractionReason = "privacy"
+	RetractionSuperseded RetractionReason = "superseded"
+	RetractionOther      RetractionReason = "other"
+)
+
+// RetractionReasonValues returns the defined retraction reasons.
+func RetractionReasonValues() []RetractionReason {
+	return []RetractionReason{RetractionLegal, RetractionDataError, RetractionPrivacy,
+		RetractionSuperseded, RetractionOther}
+}
+
+// Valid reports whether r is a defined retraction reason.
+func (r RetractionReason) Valid() bool { return contains(RetractionReasonValues(), r) }
+
+// Retraction withdraws a previously published series. A series is
+// identified by its Topic and, optionally, the GeneratedAt of one published
+// version; a zero GeneratedAt retracts every version of the topic.
+type Retraction struct {
+	Topic       string           `json:"topic" bson:"topic"`                                   // Series.Topic being withdrawn
+	GeneratedAt time.Time        `json:"generated_at,omitempty" bson:"generated_at,omitempty"` // specific version; zero for all versions
+	Reason      RetractionReason `json:"reason" bson:"reason"`                                 // required
+	Note        string           `json:"note,omitempty" bson:"note,omitempty"`                 // free-text explanation
+	EffectiveAt time.Time        `json:"effective_at" bson:"effective_at"`                     // UTC time the withdrawal takes effect
+	Signature   string           `json:"signature,omitempty" bson:"signature,omitempty"`       // base64 Ed25519 signature over SigningBytes
+}
+
+// Matches reports whether r applies to s.
+func (r *Retraction) Matches(s *Series) bool {
+	if r == nil || s == nil || s.Topic != r.Topic {
+		return false
+	}
+	return r.GeneratedAt.IsZero() || r.GeneratedAt.Equal(s.GeneratedAt)
+}
+
+// SigningBytes returns the canonical payload covered by Signature: a domain
+// tag followed by the signed fields, each prefixed with its length as a
+// 4-byte big-endian integer so no field's content can shift another field's
+// boundary. Times are RFC 3339 UTC, or empty when zero.
+func (r *Retraction) SigningBytes() []byte {
+	ts := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+	var b []byte
+	for _, f := range []string{
+		"civic-transparency/retraction/v2",
+		r.Topic, ts(r.GeneratedAt), string(r.Reason), r.Note, ts(r.EffectiveAt),
+	} {
+		b = binary.BigEndian.AppendUint32(b, uint32(len(f)))
+		b = append(b, f...)
+	}
+	return b
+}
+
+// Sign sets Signature using key.
+func (r *Retraction) Sign(key ed25519.PrivateKey) {
+	r.Signature = base64.StdEncoding.EncodeToString(ed25519.Sign(key, r.SigningBytes()))
+}
+
+// Verify reports whether Signature is a valid signature by pub.
+func (r *Retraction) Verify(pub ed25519.PublicKey) bool {
+	sig, err := base64.StdEncoding.DecodeString(r.Signature)
+	if err != nil {
+		return false
+	}
+	return ed25519.Verify(pub, r.SigningBytes(), sig)
+}
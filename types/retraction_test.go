@@ -0,0 +1,44 @@
+package types
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"testing"
+	"time"
+)
+
+func TestRetractionSignVerify(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(bytes.NewReader(make([]byte, 64)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	r := Retraction{Topic: "#vote", Reason: RetractionDataError, Note: "x", EffectiveAt: at}
+	r.Sign(priv)
+	if !r.Verify(pub) {
+		t.Fatal("valid signature rejected")
+	}
+	otherPub, _, _ := ed25519.GenerateKey(bytes.NewReader(bytes.Repeat([]byte{1}, 64)))
+	if r.Verify(otherPub) {
+		t.Error("signature verified with the wrong key")
+	}
+
+	for name, edit := range map[string]func(*Retraction){
+		"topic":        func(r *Retraction) { r.Topic = "#ballot" },
+		"generated_at": func(r *Retraction) { r.GeneratedAt = at },
+		"reason":       func(r *Retraction) { r.Reason = RetractionOther },
+		"note":         func(r *Retraction) { r.Note = "y" },
+		"effective_at": func(r *Retraction) { r.EffectiveAt = at.Add(time.Hour) },
+		// Moving the effective time into the note must not keep the bytes.
+		"note/effective_at": func(r *Retraction) {
+			r.Note, r.EffectiveAt = "x\n"+at.Format(time.RFC3339Nano), time.Time{}
+		},
+		"signature": func(r *Retraction) { r.Signature = "!" + r.Signature[1:] },
+	} {
+		c := r
+		edit(&c)
+		if c.Verify(pub) {
+			t.Errorf("tampered %s still verifies", name)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package types
+
+// CoverageSLO is a coverage commitment from a transparency agreement, e.g.
+// "≥99% of minutes reported within 15 minutes over 30 days". A bucket
+// counts as reported on time when a series containing it was generated no
+// later than Deadline after the bucket ends. Evaluate it with
+// slo.Evaluate.
+type CoverageSLO struct {
+	TargetPercent float64          `json:"target_percent"`       // required share of on-time buckets, 0 < t ≤ 100
+	Window        ISODuration      `json:"window"`               // evaluation window, e.g. P30D; zero means all history
+	Deadline      ISODuration      `json:"deadline,omitempty"`   // reporting allowance after bucket end, e.g. PT15M; zero means no limit
+	Exclusions    []AnnotationKind `json:"exclusions,omitempty"` // annotation kinds whose ranges are not counted
+}
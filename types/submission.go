@@ -0,0 +1,37 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// SubmissionKey derives a stable idempotency key for submitting t, as part
+// of the submission id, within the aggregation window that contains window.
+// The window is truncated to the UTC minute boundary, so retries of the same
+// submission in the same minute yield the same key regardless of the
+// caller's clock zone or sub-minute jitter, while distinct submissions of
+// identical tags (two reshares of one post in one minute) do not collide.
+// A nil t is keyed as the zero tag. The key is 32 lowercase hex characters.
+func SubmissionKey(id SubmissionID, t *ProvenanceTag, window time.Time) string {
+	if t == nil {
+		t = &ProvenanceTag{}
+	}
+	h := sha256.New()
+	for _, f := range []string{
+		string(id),
+		string(t.AcctAgeBucket),
+		string(t.AcctType),
+		string(t.AutomationFlag),
+		string(t.PostKind),
+		string(t.ClientFamily),
+		string(t.MediaProvenance),
+		string(t.DedupHash),
+		t.OriginHint,
+		window.UTC().Truncate(time.Minute).Format(time.RFC3339),
+	} {
+		h.Write([]byte(f))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
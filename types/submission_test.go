@@ -0,0 +1,30 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSubmissionKey(t *testing.T) {
+	const a, b SubmissionID = "01JGFJJZ000000000000000000", "01JGFJJZ000000000000000001"
+	tag := &ProvenanceTag{PostKind: PostKindReshare, DedupHash: "deadbeef"}
+	t0 := time.Date(2025, 1, 1, 12, 0, 5, 0, time.UTC)
+
+	k := SubmissionKey(a, tag, t0)
+	if len(k) != 32 {
+		t.Fatalf("key %q: want 32 hex chars", k)
+	}
+	east := time.FixedZone("UTC+2", 2*60*60)
+	if got := SubmissionKey(a, tag, t0.Add(50*time.Second).In(east)); got != k {
+		t.Errorf("retry in the same minute and another zone = %q, want %q", got, k)
+	}
+	if SubmissionKey(b, tag, t0) == k {
+		t.Error("identical tags in different submissions share a key")
+	}
+	if SubmissionKey(a, tag, t0.Add(time.Minute)) == k {
+		t.Error("different windows share a key")
+	}
+	if got, want := SubmissionKey(a, nil, t0), SubmissionKey(a, &ProvenanceTag{}, t0); got != want {
+		t.Errorf("nil tag = %q, want the zero tag's key %q", got, want)
+	}
+}
@@ -0,0 +1,214 @@
+package types
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SubmissionID identifies one submission (a webhook delivery, a dead
+// letter, an audit log entry) across the ecosystem. It is a ULID in its
+// canonical form: 26 upper-case Crockford base32 characters encoding a
+// 48-bit millisecond timestamp followed by 80 random bits, so IDs sort by
+// creation time. Use ParseSubmissionID to accept lower-case or UUID-form
+// input.
+type SubmissionID string
+
+// crockford is the ULID alphabet (no I, L, O, U).
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+var crockfordIndex = func() (t [256]byte) {
+	for i := range t {
+		t[i] = 0xFF
+	}
+	for i := 0; i < len(crockford); i++ {
+		t[crockford[i]] = byte(i)
+		t[strings.ToLower(crockford[i : i+1])[0]] = byte(i)
+	}
+	return t
+}()
+
+// ErrSubmissionID is returned for strings that are not a ULID or UUID.
+var ErrSubmissionID = errors.New("types: invalid submission id")
+
+// NewSubmissionID returns the ID for t with 80 bits read from entropy, or
+// from crypto/rand if entropy is nil. IDs made in the same millisecond are
+// not ordered; use SubmissionIDGenerator when order matters.
+func NewSubmissionID(t time.Time, entropy io.Reader) (SubmissionID, error) {
+	var b [16]byte
+	if err := putULIDTime(&b, t); err != nil {
+		return "", err
+	}
+	if entropy == nil {
+		entropy = rand.Reader
+	}
+	if _, err := io.ReadFull(entropy, b[6:]); err != nil {
+		return "", err
+	}
+	return encodeULID(b), nil
+}
+
+// ParseSubmissionID parses a ULID in either case, or a UUID in its
+// hyphenated hex form, and returns the canonical ULID. UUIDs map bit for
+// bit, so existing UUIDv7 identifiers keep their time order.
+func ParseSubmissionID(s string) (SubmissionID, error) {
+	b, err := decodeSubmissionID(s)
+	if err != nil {
+		return "", err
+	}
+	return encodeULID(b), nil
+}
+
+// Valid reports whether id is in canonical form.
+func (id SubmissionID) Valid() bool {
+	if len(id) != 26 || id[0] > '7' {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if c := id[i]; crockfordIndex[c] == 0xFF || (c >= 'a' && c <= 'z') {
+			return false
+		}
+	}
+	return true
+}
+
+// Time returns the millisecond timestamp embedded in id, or the zero time
+// if id is not valid.
+func (id SubmissionID) Time() time.Time {
+	b, err := id.Bytes()
+	if err != nil {
+		return time.Time{}
+	}
+	ms := int64(b[0])<<40 | int64(b[1])<<32 | int64(binary.BigEndian.Uint32(b[2:6]))
+	return time.UnixMilli(ms).UTC()
+}
+
+// Bytes returns the 16-byte binary form of id.
+func (id SubmissionID) Bytes() ([16]byte, error) {
+	if !id.Valid() {
+		return [16]byte{}, fmt.Errorf("%w: %q", ErrSubmissionID, string(id))
+	}
+	return decodeSubmissionID(string(id))
+}
+
+// UUID returns id in hyphenated hex UUID form, for stores with a native
+// UUID column. It returns "" if id is not valid.
+func (id SubmissionID) UUID() string {
+	b, err := id.Bytes()
+	if err != nil {
+		return ""
+	}
+	h := hex.EncodeToString(b[:])
+	return h[:8] + "-" + h[8:12] + "-" + h[12:16] + "-" + h[16:20] + "-" + h[20:]
+}
+
+// SubmissionIDGenerator makes strictly increasing IDs: within one
+// millisecond, each ID is the previous one plus one rather than fresh
+// random bits, as in the ULID monotonic mode. The zero value uses
+// time.Now and crypto/rand. It is safe for concurrent use.
+type SubmissionIDGenerator struct {
+	// Now and Entropy override the clock and randomness source (tests).
+	Now     func() time.Time
+	Entropy io.Reader
+
+	mu   sync.Mutex
+	last [16]byte
+	ms   int64
+	used bool
+}
+
+// ErrSubmissionIDOverflow is returned when more IDs are requested in one
+// millisecond than the random bits can count past the first.
+var ErrSubmissionIDOverflow = errors.New("types: submission id overflow within one millisecond")
+
+// Next returns the next ID. If the clock goes backwards, IDs continue from
+// the last timestamp so order is preserved.
+func (g *SubmissionIDGenerator) Next() (SubmissionID, error) {
+	now := time.Now
+	if g.Now != nil {
+		now = g.Now
+	}
+	t := now()
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.used && t.UnixMilli() <= g.ms {
+		b := g.last
+		for i := 15; i >= 6; i-- {
+			b[i]++
+			if b[i] != 0 {
+				g.last = b
+				return encodeULID(b), nil
+			}
+		}
+		return "", ErrSubmissionIDOverflow
+	}
+	id, err := NewSubmissionID(t, g.Entropy)
+	if err != nil {
+		return "", err
+	}
+	g.last, _ = decodeSubmissionID(string(id))
+	g.ms, g.used = t.UnixMilli(), true
+	return id, nil
+}
+
+func putULIDTime(b *[16]byte, t time.Time) error {
+	ms := t.UnixMilli()
+	if ms < 0 || ms >= 1<<48 {
+		return fmt.Errorf("%w: time %v out of range", ErrSubmissionID, t)
+	}
+	b[0], b[1] = byte(ms>>40), byte(ms>>32)
+	binary.BigEndian.PutUint32(b[2:6], uint32(ms))
+	return nil
+}
+
+func encodeULID(b [16]byte) SubmissionID {
+	// 128 bits in 26 characters: the first carries the top 3 bits.
+	var out [26]byte
+	hi := binary.BigEndian.Uint64(b[:8])
+	lo := binary.BigEndian.Uint64(b[8:])
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[lo&31]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return SubmissionID(out[:])
+}
+
+func decodeSubmissionID(s string) ([16]byte, error) {
+	var b [16]byte
+	switch len(s) {
+	case 26:
+		if crockfordIndex[s[0]] > 7 {
+			return b, fmt.Errorf("%w: %q", ErrSubmissionID, s)
+		}
+		var hi, lo uint64
+		for i := 0; i < 26; i++ {
+			v := crockfordIndex[s[i]]
+			if v == 0xFF {
+				return b, fmt.Errorf("%w: %q", ErrSubmissionID, s)
+			}
+			hi = hi<<5 | lo>>59
+			lo = lo<<5 | uint64(v)
+		}
+		binary.BigEndian.PutUint64(b[:8], hi)
+		binary.BigEndian.PutUint64(b[8:], lo)
+		return b, nil
+	case 36:
+		if s[8] != '-' || s[13] != '-' || s[18] != '-' || s[23] != '-' {
+			return b, fmt.Errorf("%w: %q", ErrSubmissionID, s)
+		}
+		h := s[:8] + s[9:13] + s[14:18] + s[19:23] + s[24:]
+		if _, err := hex.Decode(b[:], []byte(h)); err != nil {
+			return b, fmt.Errorf("%w: %q", ErrSubmissionID, s)
+		}
+		return b, nil
+	}
+	return b, fmt.Errorf("%w: %q", ErrSubmissionID, s)
+}
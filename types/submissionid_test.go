@@ -0,0 +1,89 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSubmissionIDRoundTrip(t *testing.T) {
+	at := time.Date(2025, 3, 1, 12, 0, 0, 123e6, time.UTC)
+	id, err := NewSubmissionID(at, bytes.NewReader(bytes.Repeat([]byte{0xAB}, 10)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !id.Valid() || len(id) != 26 {
+		t.Fatalf("id %q not valid", id)
+	}
+	if !id.Time().Equal(at) {
+		t.Errorf("Time = %v, want %v", id.Time(), at)
+	}
+	u := id.UUID()
+	for _, s := range []string{string(id), u, string(bytes.ToLower([]byte(id)))} {
+		got, err := ParseSubmissionID(s)
+		if err != nil || got != id {
+			t.Errorf("ParseSubmissionID(%q) = %q, %v; want %q", s, got, err, id)
+		}
+	}
+}
+
+func TestSubmissionIDKnownValue(t *testing.T) {
+	// Reference vector from the ULID specification's binary layout.
+	id, err := ParseSubmissionID("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := id.Time().UnixMilli(); got != 1469922850259 {
+		t.Errorf("ms = %d, want 1469922850259", got)
+	}
+	if got := id.UUID(); got != "01563e3a-b5d3-d676-4c61-efb99302bd5b" {
+		t.Errorf("UUID = %s", got)
+	}
+}
+
+func TestSubmissionIDInvalid(t *testing.T) {
+	for _, s := range []string{"", "01ARZ3NDEKTSV4RRFFQ69G5FA", "81ARZ3NDEKTSV4RRFFQ69G5FAV", "01ARZ3NDEKTSV4RRFFQ69G5FAU",
+		"01563e3a-b5d3-d676-4c61-efb99302bd5", "01563e3ab5d3-d676-4c61-efb99302bd5bx", "zz563e3a-b5d3-d676-4c61-efb99302bd5b"} {
+		if _, err := ParseSubmissionID(s); !errors.Is(err, ErrSubmissionID) {
+			t.Errorf("ParseSubmissionID(%q) = %v, want ErrSubmissionID", s, err)
+		}
+	}
+	if SubmissionID("01arz3ndektsv4rrffq69g5fav").Valid() {
+		t.Error("lower-case id is canonical")
+	}
+}
+
+func TestSubmissionIDGeneratorMonotonic(t *testing.T) {
+	at := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	clock := []time.Time{at, at, at.Add(-time.Second), at.Add(time.Millisecond)}
+	entropy := append(bytes.Repeat([]byte{0}, 9), 0xFF) // second ID carries
+	entropy = append(entropy, bytes.Repeat([]byte{0x11}, 10)...)
+	g := SubmissionIDGenerator{
+		Now:     func() time.Time { t := clock[0]; clock = clock[1:]; return t },
+		Entropy: bytes.NewReader(entropy),
+	}
+	var prev SubmissionID
+	for i := 0; i < 4; i++ {
+		id, err := g.Next()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id <= prev {
+			t.Errorf("Next #%d = %s, not after %s", i, id, prev)
+		}
+		prev = id
+	}
+	if !prev.Time().Equal(at.Add(time.Millisecond)) {
+		t.Errorf("last Time = %v", prev.Time())
+	}
+
+	g = SubmissionIDGenerator{
+		Now:     func() time.Time { return at },
+		Entropy: bytes.NewReader(bytes.Repeat([]byte{0xFF}, 10)),
+	}
+	g.Next()
+	if _, err := g.Next(); !errors.Is(err, ErrSubmissionIDOverflow) {
+		t.Errorf("Next after max = %v, want ErrSubmissionIDOverflow", err)
+	}
+}
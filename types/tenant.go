@@ -0,0 +1,11 @@
+package types
+
+import "regexp"
+
+// reTenant matches dot-separated lowercase labels, e.g. "us.fec" or
+// "ca.elections-ontario".
+var reTenant = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*(\.[a-z0-9]+(-[a-z0-9]+)*)*$`)
+
+// ValidTenant reports whether s is a well-formed tenant namespace: one or
+// more dot-separated labels of lowercase letters, digits, and inner hyphens.
+func ValidTenant(s string) bool { return reTenant.MatchString(s) }
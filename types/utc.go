@@ -0,0 +1,68 @@
+package types
+
+import (
+	"fmt"
+	"time"
+)
+
+// Timestamps in this schema are UTC. JSON decoding keeps whatever offset a
+// publisher wrote, and times that denote the same instant in different
+// zones are unequal under ==, hash differently once re-encoded, and split
+// merge keys. NormalizeUTC removes the offsets; validators reject them.
+
+// IsUTC reports whether t has a zero offset from UTC.
+func IsUTC(t time.Time) bool {
+	_, off := t.Zone()
+	return off == 0
+}
+
+// NormalizeUTC converts every timestamp in s to UTC in place: generated_at,
+// point ts, annotation bounds, and the retraction's times. The instants are
+// unchanged. It is safe to call on a nil s.
+func (s *Series) NormalizeUTC() {
+	if s == nil {
+		return
+	}
+	s.eachTime(func(_ string, t *time.Time) bool {
+		*t = t.UTC()
+		return true
+	})
+}
+
+// NonUTCField returns the JSON path of the first timestamp in s with a
+// non-zero UTC offset, in document order, or "" if there is none.
+func (s *Series) NonUTCField() string {
+	var field string
+	if s != nil {
+		s.eachTime(func(f string, t *time.Time) bool {
+			if IsUTC(*t) {
+				return true
+			}
+			field = f
+			return false
+		})
+	}
+	return field
+}
+
+// eachTime calls fn with the path and address of each timestamp in s until
+// fn returns false.
+func (s *Series) eachTime(fn func(field string, t *time.Time) bool) {
+	if !fn("generated_at", &s.GeneratedAt) {
+		return
+	}
+	for i := range s.Points {
+		if !fn(fmt.Sprintf("points[%d].ts", i), &s.Points[i].TS) {
+			return
+		}
+	}
+	for i := range s.Annotations {
+		a := &s.Annotations[i]
+		if !fn(fmt.Sprintf("annotations[%d].start", i), &a.Start) || !fn(fmt.Sprintf("annotations[%d].end", i), &a.End) {
+			return
+		}
+	}
+	if r := s.Retraction; r != nil {
+		_ = fn("retraction.generated_at", &r.GeneratedAt) && fn("retraction.effective_at", &r.EffectiveAt)
+	}
+}
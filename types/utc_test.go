@@ -0,0 +1,34 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNormalizeUTC(t *testing.T) {
+	cest := time.FixedZone("CEST", 2*3600)
+	at := time.Date(2025, 6, 1, 14, 0, 0, 0, cest)
+	s := &Series{
+		GeneratedAt: at.UTC(),
+		Points:      []Point{{TS: at.UTC()}, {TS: at.Add(time.Minute)}},
+		Annotations: []Annotation{{Start: at}},
+	}
+	if f := s.NonUTCField(); f != "points[1].ts" {
+		t.Fatalf("NonUTCField = %q", f)
+	}
+	s.NormalizeUTC()
+	if f := s.NonUTCField(); f != "" {
+		t.Fatalf("after NormalizeUTC, NonUTCField = %q", f)
+	}
+	if s.Points[1].TS != time.Date(2025, 6, 1, 12, 1, 0, 0, time.UTC) || s.Annotations[0].Start != at.UTC() {
+		t.Errorf("times = %v, %v", s.Points[1].TS, s.Annotations[0].Start)
+	}
+	if !s.Annotations[0].End.IsZero() {
+		t.Error("zero End became non-zero")
+	}
+	var nilSeries *Series
+	nilSeries.NormalizeUTC()
+	if nilSeries.NonUTCField() != "" {
+		t.Error("nil series reported a field")
+	}
+}
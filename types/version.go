@@ -0,0 +1,10 @@
+package types
+
+// SpecVersion is the Civic Transparency schema version these types
+// implement. 0.3.0 extends 0.2.1 with point backfill markers, series
+// annotations, and retraction tombstones; 0.4.0 adds per-point account-type
+// and post-kind breakdowns, series tenant, jurisdiction, methodology, and
+// extensions, and dedup hash algorithms. See package negotiate for
+// converting to older versions and package schemahistory for the full
+// changelog.
+const SpecVersion = "0.4.0"
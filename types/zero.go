@@ -0,0 +1,58 @@
+package types
+
+// Zero-value semantics: the zero value of every type in this package is a
+// well-formed Go value that is safe to read, marshal, and pass to
+// validators, but it is never a valid schema document. The IsZero methods
+// report whether a value is entirely unset, which lets decoders and
+// encoders distinguish "absent" from "present but invalid". Each is safe to
+// call on a nil pointer receiver where the receiver is a pointer.
+
+// IsZero reports whether t is nil or has no fields set.
+func (t *ProvenanceTag) IsZero() bool {
+	return t == nil || (t.AcctAgeBucket == "" && t.AcctType == "" && t.AutomationFlag == "" &&
+		t.PostKind == "" && t.ClientFamily == "" && t.MediaProvenance == "" && t.DedupHash == "" &&
+		t.OriginHint == "" && t.DedupHashAlg == "" && len(t.Extensions) == 0)
+}
+
+// Equal reports whether t and u have the same fields, comparing
+// Extensions with Extensions.Equal. It replaces ==, which ProvenanceTag no
+// longer supports.
+func (t ProvenanceTag) Equal(u ProvenanceTag) bool {
+	return t.AcctAgeBucket == u.AcctAgeBucket && t.AcctType == u.AcctType &&
+		t.AutomationFlag == u.AutomationFlag && t.PostKind == u.PostKind &&
+		t.ClientFamily == u.ClientFamily && t.MediaProvenance == u.MediaProvenance &&
+		t.DedupHash == u.DedupHash && t.OriginHint == u.OriginHint &&
+		t.DedupHashAlg == u.DedupHashAlg && t.Extensions.Equal(u.Extensions)
+}
+
+// IsZero reports whether c reports no signals.
+func (c CoordinationSignals) IsZero() bool {
+	return c == CoordinationSignals{}
+}
+
+// IsZero reports whether p has no fields set. Empty and nil breakdown maps
+// are both treated as unset.
+func (p *Point) IsZero() bool {
+	return p == nil || (p.TS.IsZero() && p.Volume == 0 && p.ReshareRatio == 0 &&
+		p.RecycledContentRate == 0 && len(p.AcctAgeMix) == 0 && len(p.AutomationMix) == 0 &&
+		len(p.ClientMix) == 0 && len(p.AcctTypeShares) == 0 && len(p.PostKindMix) == 0 &&
+		p.CoordinationSignals.IsZero() && !p.Backfilled)
+}
+
+// IsZero reports whether s is nil or has no fields set.
+func (s *Series) IsZero() bool {
+	return s == nil || (s.Topic == "" && s.GeneratedAt.IsZero() && s.Interval == "" &&
+		len(s.Points) == 0 && len(s.Annotations) == 0 && s.Retraction == nil &&
+		len(s.Extensions) == 0 && s.Tenant == "" && s.Jurisdiction == "" &&
+		s.Methodology == nil)
+}
+
+// IsZero reports whether a has no fields set.
+func (a Annotation) IsZero() bool {
+	return a.Start.IsZero() && a.End.IsZero() && a.Kind == "" && a.Note == ""
+}
+
+// IsZero reports whether r is nil or has no fields set.
+func (r *Retraction) IsZero() bool {
+	return r == nil || *r == Retraction{}
+}
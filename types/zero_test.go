@@ -0,0 +1,32 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestProvenanceTagEqual(t *testing.T) {
+	tag := ProvenanceTag{
+		AcctAgeBucket: AcctAge_0_7d, AcctType: AcctTypePerson, AutomationFlag: AutomationManual,
+		PostKind: PostKindOriginal, ClientFamily: ClientWeb, MediaProvenance: MediaProvNone, DedupHash: "deadbeef",
+		Extensions: Extensions{"x-example-tier": json.RawMessage(`1`)},
+	}
+	same := tag
+	same.Extensions = Extensions{"x-example-tier": json.RawMessage(`1`)}
+	if !tag.Equal(same) {
+		t.Error("equal tags with separate extension maps compare unequal")
+	}
+	other := same
+	other.Extensions = Extensions{"x-example-tier": json.RawMessage(`2`)}
+	if tag.Equal(other) {
+		t.Error("different extension values compare equal")
+	}
+	other = same
+	other.OriginHint = "US"
+	if tag.Equal(other) {
+		t.Error("different origin hints compare equal")
+	}
+	if !(ProvenanceTag{Extensions: Extensions{}}).Equal(ProvenanceTag{}) {
+		t.Error("empty and nil extensions compare unequal")
+	}
+}
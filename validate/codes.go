@@ -0,0 +1,147 @@
+package validate
+
+import (
+	"errors"
+	"sort"
+)
+
+// ErrorCode is a stable identifier for a validation rule. Codes never change
+// meaning between releases, unlike messages, so clients can key remediation
+// UX on them. New codes may be added; removed rules keep their codes
+// reserved.
+type ErrorCode string
+
+const (
+	CodeNilInput                 ErrorCode = "ERR_NIL_INPUT"
+	CodeRequired                 ErrorCode = "ERR_REQUIRED"
+	CodeDuplicate                ErrorCode = "ERR_DUPLICATE"
+	CodeAcctAgeInvalid           ErrorCode = "ERR_ACCT_AGE_INVALID"
+	CodeAcctTypeInvalid          ErrorCode = "ERR_ACCT_TYPE_INVALID"
+	CodeAutomationFlagInvalid    ErrorCode = "ERR_AUTOMATION_FLAG_INVALID"
+	CodePostKindInvalid          ErrorCode = "ERR_POST_KIND_INVALID"
+	CodeClientFamilyInvalid      ErrorCode = "ERR_CLIENT_FAMILY_INVALID"
+	CodeMediaProvenanceInvalid   ErrorCode = "ERR_MEDIA_PROVENANCE_INVALID"
+	CodeDedupHashAlgInvalid      ErrorCode = "ERR_DEDUP_HASH_ALG_INVALID"
+	CodeDedupHashShape           ErrorCode = "ERR_DEDUP_HASH_SHAPE"
+	CodeOriginHintFormat         ErrorCode = "ERR_ORIGIN_HINT_FORMAT"
+	CodeOriginHintRisk           ErrorCode = "ERR_ORIGIN_HINT_RISK"
+	CodeTenantFormat             ErrorCode = "ERR_TENANT_FORMAT"
+	CodeTenantUnknown            ErrorCode = "ERR_TENANT_UNKNOWN"
+	CodeJurisdictionFormat       ErrorCode = "ERR_JURISDICTION_FORMAT"
+	CodeJurisdictionNotPermitted ErrorCode = "ERR_JURISDICTION_NOT_PERMITTED"
+	CodeIntervalUnsupported      ErrorCode = "ERR_INTERVAL_UNSUPPORTED"
+	CodePointsEmpty              ErrorCode = "ERR_POINTS_EMPTY"
+	CodeCountNegative            ErrorCode = "ERR_COUNT_NEGATIVE"
+	CodeRatioRange               ErrorCode = "ERR_RATIO_RANGE"
+	CodeDuplicationExceedsVolume ErrorCode = "ERR_DUPLICATION_EXCEEDS_VOLUME"
+	CodeSharesSum                ErrorCode = "ERR_SHARES_SUM"
+	CodeBackfillTooRecent        ErrorCode = "ERR_BACKFILL_TOO_RECENT"
+	CodeAnnotationKindInvalid    ErrorCode = "ERR_ANNOTATION_KIND_INVALID"
+	CodeTimeRange                ErrorCode = "ERR_TIME_RANGE"
+	CodeRetractionReasonInvalid  ErrorCode = "ERR_RETRACTION_REASON_INVALID"
+	CodeBundleMismatch           ErrorCode = "ERR_BUNDLE_MISMATCH"
+	CodeQuotaRange               ErrorCode = "ERR_QUOTA_RANGE"
+	CodeDurationInvalid          ErrorCode = "ERR_DURATION_INVALID"
+	CodeLicenseInvalid           ErrorCode = "ERR_LICENSE_INVALID"
+	CodeContactInvalid           ErrorCode = "ERR_CONTACT_INVALID"
+	CodeURLInvalid               ErrorCode = "ERR_URL_INVALID"
+	CodeVersionFormat            ErrorCode = "ERR_VERSION_FORMAT"
+	CodeExtensionKey             ErrorCode = "ERR_EXTENSION_KEY"
+	CodeExtensionInvalid         ErrorCode = "ERR_EXTENSION_INVALID"
+	CodeExtensionUnregistered    ErrorCode = "ERR_EXTENSION_UNREGISTERED"
+	CodeSaltInvalid              ErrorCode = "ERR_SALT_INVALID"
+	CodeReshareInconsistent      ErrorCode = "ERR_RESHARE_INCONSISTENT"
+	CodeSubmissionIDFormat       ErrorCode = "ERR_SUBMISSION_ID_FORMAT"
+	CodeHealthStatusInvalid      ErrorCode = "ERR_HEALTH_STATUS_INVALID"
+	CodeHealthCountsInconsistent ErrorCode = "ERR_HEALTH_COUNTS_INCONSISTENT"
+	CodeVolumeBelowFloor         ErrorCode = "ERR_VOLUME_BELOW_FLOOR"
+	CodeTimeNotUTC               ErrorCode = "ERR_TIME_NOT_UTC"
+	CodeCompositeInconsistent    ErrorCode = "ERR_COMPOSITE_INCONSISTENT"
+	CodeHeartbeatInterval        ErrorCode = "ERR_HEARTBEAT_INTERVAL"
+	CodeMethodologyInvalid       ErrorCode = "ERR_METHODOLOGY_INVALID"
+	CodeKeyInvalid               ErrorCode = "ERR_KEY_INVALID"
+)
+
+// CodeInfo documents one ErrorCode in the catalog.
+type CodeInfo struct {
+	Code    ErrorCode `json:"code"`
+	Summary string    `json:"summary"`
+}
+
+var catalog = map[ErrorCode]string{
+	CodeNilInput:                 "A nil document was passed to a validator.",
+	CodeRequired:                 "A required field is missing or empty.",
+	CodeDuplicate:                "A value that must be unique repeats an earlier one.",
+	CodeAcctAgeInvalid:           "acct_age_bucket is not a schema value.",
+	CodeAcctTypeInvalid:          "An acct_type (field or acct_type_shares key) is not a schema value.",
+	CodeAutomationFlagInvalid:    "automation_flag is not a schema value.",
+	CodePostKindInvalid:          "A post_kind (field or post_kind_mix key) is not a schema value.",
+	CodeClientFamilyInvalid:      "client_family is not a schema value.",
+	CodeMediaProvenanceInvalid:   "media_provenance is not a schema value.",
+	CodeDedupHashAlgInvalid:      "dedup_hash_alg is not a supported algorithm.",
+	CodeDedupHashShape:           "dedup_hash has the wrong length or is not lowercase hex for its algorithm.",
+	CodeOriginHintFormat:         "origin_hint is not an ISO 3166 country or subdivision code.",
+	CodeOriginHintRisk:           "origin_hint names a subdivision on a tag whose fields together risk re-identification; publish the country only.",
+	CodeTenantFormat:             "tenant is not dot-separated lowercase labels.",
+	CodeTenantUnknown:            "tenant is not registered in this deployment.",
+	CodeJurisdictionFormat:       "jurisdiction is not an ISO 3166 code.",
+	CodeJurisdictionNotPermitted: "jurisdiction is not one the tenant may publish under.",
+	CodeIntervalUnsupported:      "The aggregation interval is not supported for this document.",
+	CodePointsEmpty:              "A series has no points and is not a retraction.",
+	CodeCountNegative:            "A count (volume, duplication_clusters) is negative.",
+	CodeRatioRange:               "A ratio, share, or coordination score is outside 0–1.",
+	CodeDuplicationExceedsVolume: "duplication_clusters exceeds the point's volume.",
+	CodeSharesSum:                "A breakdown's shares do not sum to 1 within tolerance.",
+	CodeBackfillTooRecent:        "A point marked backfilled is too close to, or after, generated_at.",
+	CodeAnnotationKindInvalid:    "An annotation kind is not a defined value.",
+	CodeTimeRange:                "A range ends before it starts.",
+	CodeRetractionReasonInvalid:  "A retraction reason is not a defined value.",
+	CodeBundleMismatch:           "A bundle manifest does not describe its series.",
+	CodeQuotaRange:               "A publisher quota rate or burst is out of range.",
+	CodeDurationInvalid:          "A duration is not a positive ISO 8601 duration.",
+	CodeLicenseInvalid:           "A license is not identified by an SPDX identifier.",
+	CodeContactInvalid:           "A contact has neither a usable email nor a URL.",
+	CodeURLInvalid:               "A URL is not an absolute http(s) URL.",
+	CodeVersionFormat:            "A version is not MAJOR.MINOR.PATCH.",
+	CodeExtensionKey:             "An extension key is not x-<platform>-<name>.",
+	CodeExtensionInvalid:         "An extension value fails its registered check.",
+	CodeExtensionUnregistered:    "An extension key is not registered and the registry is strict.",
+	CodeSaltInvalid:              "A configured dedup salt is not hex or has the wrong length for its algorithm.",
+	CodeReshareInconsistent:      "reshare_ratio disagrees with the reshare share of post_kind_mix beyond tolerance.",
+	CodeSubmissionIDFormat:       "A submission ID is not a canonical ULID (26 upper-case Crockford base32 characters).",
+	CodeHealthStatusInvalid:      "A health report status is not ok, degraded, or down.",
+	CodeHealthCountsInconsistent: "A health report's rejected count exceeds submitted, or failure_rate disagrees with the counts.",
+	CodeVolumeBelowFloor:         "A point's volume is below the suppression floor for its topic and should have been suppressed.",
+	CodeTimeNotUTC:               "A timestamp has a non-zero UTC offset; publish times in UTC (\"Z\").",
+	CodeCompositeInconsistent:    "A composite series level disagrees with the finer level below it (topic, interval, alignment, or summed volume).",
+	CodeHeartbeatInterval:        "A heartbeat announces an interval outside the allowed keepalive frequency (PT5S to PT1M).",
+	CodeMethodologyInvalid:       "A series methodology value is out of range (e.g., a negative or non-finite noise_epsilon).",
+	CodeKeyInvalid:               "A published public key has an unsupported algorithm or does not decode to a key of the right size.",
+}
+
+// Catalog returns every ErrorCode with its summary, sorted by code. Its
+// JSON encoding is the machine-readable catalog published for clients.
+func Catalog() []CodeInfo {
+	out := make([]CodeInfo, 0, len(catalog))
+	for c, s := range catalog {
+		out = append(out, CodeInfo{Code: c, Summary: s})
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Code < out[j].Code })
+	return out
+}
+
+// Summary returns the catalog summary for c, or "" for unknown codes.
+func (c ErrorCode) Summary() string { return catalog[c] }
+
+// CodeOf returns the code of the first *FieldError in err's tree, or
+// CodeNilInput for ErrNilInput. It returns "" if err carries no code.
+func CodeOf(err error) ErrorCode {
+	if errors.Is(err, ErrNilInput) {
+		return CodeNilInput
+	}
+	var fe *FieldError
+	if errors.As(err, &fe) {
+		return fe.Code
+	}
+	return ""
+}
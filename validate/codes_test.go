@@ -0,0 +1,49 @@
+package validate
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestCatalogCoversCodes(t *testing.T) {
+	src, err := os.ReadFile("codes.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	decl := regexp.MustCompile(`(?m)^\s+Code\w+\s+ErrorCode = "(ERR_[A-Z0-9_]+)"$`)
+	ms := decl.FindAllSubmatch(src, -1)
+	if len(ms) == 0 || len(ms) != len(Catalog()) {
+		t.Fatalf("%d declared codes, %d catalog entries", len(ms), len(Catalog()))
+	}
+	for _, m := range ms {
+		if ErrorCode(m[1]).Summary() == "" {
+			t.Errorf("%s has no catalog summary", m[1])
+		}
+	}
+}
+
+func TestCodeOf(t *testing.T) {
+	if c := CodeOf(ValidateSeries(nil)); c != CodeNilInput {
+		t.Fatalf("CodeOf(nil series) = %q", c)
+	}
+	err := ValidateSeries(&types.Series{Topic: "#vote", Interval: types.IntervalMinute,
+		Points: []types.Point{{Volume: -1, ReshareRatio: 2}}})
+	var me *MultiError
+	if !errors.As(err, &me) {
+		t.Fatal(err)
+	}
+	var got []ErrorCode
+	for _, e := range me.Errors() {
+		got = append(got, CodeOf(e))
+	}
+	// generated_at and points[0].ts are unset.
+	want := []ErrorCode{CodeRequired, CodeRequired, CodeCountNegative, CodeRatioRange}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("codes = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,94 @@
+package validate
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// ValidateCompositeSeries validates a CompositeSeries: its header, the
+// points of every level, the ordering of levels, and consistency between
+// adjacent levels. Each coarser point must be aligned to its interval and
+// its volume must equal the summed volume of the finer points in its
+// bucket; a finer bucket with volume and no coarser point is also an
+// inconsistency. Levels other than the finest may use any fixed-length
+// interval, so the minute-only rule of ValidateSeries is not applied.
+func ValidateCompositeSeries(c *types.CompositeSeries, opts Options) error {
+	if c == nil {
+		return ErrNilInput
+	}
+	opts = opts.ForTopic(c.Topic)
+	var me MultiError
+
+	if c.Topic == "" {
+		me.Append(fieldErr(CodeRequired, "topic", "topic must be non-empty"))
+	}
+	if c.GeneratedAt.IsZero() {
+		me.Append(fieldErr(CodeRequired, "generated_at", "generated_at must be set"))
+	}
+	if len(c.Levels) == 0 {
+		me.Append(fieldErr(CodeRequired, "levels", "levels must contain at least one series"))
+	}
+	var prev time.Duration
+	for i := range c.Levels {
+		l := &c.Levels[i]
+		path := fmt.Sprintf("levels[%d]", i)
+		if l.Topic != c.Topic {
+			me.Append(fieldErr(CodeCompositeInconsistent, path+".topic", path+".topic must match the composite topic"))
+		}
+		step := l.Interval.Duration()
+		switch {
+		case step == 0:
+			me.Append(fieldErr(CodeIntervalUnsupported, path+".interval", fmt.Sprintf("%s.interval %q is not a fixed-length interval", path, l.Interval)))
+		case i > 0 && prev > 0 && (step <= prev || step%prev != 0):
+			me.Append(fieldErr(CodeCompositeInconsistent, path+".interval", fmt.Sprintf("%s.interval %s must be a larger multiple of %s", path, l.Interval, c.Levels[i-1].Interval)))
+			step = 0 // skip the sum check against a misordered level
+		}
+		var lme MultiError
+		for j, p := range l.Points {
+			validatePoint(&lme, j, p, l.GeneratedAt, opts)
+		}
+		appendPrefixed(&me, path, lme.NilOrError())
+		if i > 0 && step > 0 && prev > 0 {
+			validateLevelSums(&me, i, &c.Levels[i-1], l, step)
+		}
+		prev = l.Interval.Duration()
+	}
+
+	return opts.finish(me.NilOrError())
+}
+
+// validateLevelSums checks coarse, levels[i], against fine, levels[i-1].
+func validateLevelSums(me *MultiError, i int, fine, coarse *types.Series, step time.Duration) {
+	sums := map[time.Time]int{}
+	for _, p := range fine.Points {
+		sums[p.TS.UTC().Truncate(step)] += p.Volume
+	}
+	for j, p := range coarse.Points {
+		path := fmt.Sprintf("levels[%d].points[%d]", i, j)
+		k := p.TS.UTC()
+		if k.Truncate(step) != k {
+			me.Append(fieldErr(CodeCompositeInconsistent, path+".ts", fmt.Sprintf("%s.ts must be aligned to %s", path, coarse.Interval)))
+			continue
+		}
+		if want := sums[k]; p.Volume != want {
+			me.Append(fieldErr(CodeCompositeInconsistent, path+".volume",
+				fmt.Sprintf("%s.volume %d does not match %d summed from levels[%d]", path, p.Volume, want, i-1)))
+		}
+		delete(sums, k)
+	}
+	missing := make([]time.Time, 0, len(sums))
+	for k, v := range sums {
+		if v != 0 {
+			missing = append(missing, k)
+		}
+	}
+	sort.Slice(missing, func(a, b int) bool { return missing[a].Before(missing[b]) })
+	for _, k := range missing {
+		path := fmt.Sprintf("levels[%d].points", i)
+		me.Append(fieldErr(CodeCompositeInconsistent, path,
+			fmt.Sprintf("%s has no point for %s, where levels[%d] sums to volume %d", path, k.Format(time.RFC3339), i-1, sums[k])))
+	}
+}
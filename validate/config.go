@@ -0,0 +1,125 @@
+package validate
+
+import (
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// MinSaltRotation is the shortest dedup salt rotation period accepted by
+// ValidateCollectorConfig; shorter periods split identical content across
+// salts within one aggregation window.
+const MinSaltRotation = time.Hour
+
+// ValidateCollectorConfig validates a collector's configuration. It never
+// includes the salt value in messages.
+func ValidateCollectorConfig(c *types.CollectorConfig) error {
+	if c == nil {
+		return ErrNilInput
+	}
+	var me MultiError
+
+	validateConfigInterval(&me, c.Interval)
+	validateConfigTopics(&me, c.Topics)
+	if !c.DedupHashAlg.Valid() {
+		me.Append(fieldErr(CodeDedupHashAlgInvalid, "dedup_hash_alg", fmt.Sprintf("dedup_hash_alg %q is not supported", c.DedupHashAlg)))
+	}
+	if c.DedupSalt == "" {
+		me.Append(fieldErr(CodeRequired, "dedup_salt", "dedup_salt must be non-empty"))
+	} else if key, err := hex.DecodeString(c.DedupSalt); err != nil {
+		me.Append(fieldErr(CodeSaltInvalid, "dedup_salt", "dedup_salt must be hex-encoded"))
+	} else if msg := saltLenProblem(c.DedupHashAlg, len(key)); msg != "" {
+		me.Append(fieldErr(CodeSaltInvalid, "dedup_salt", msg))
+	}
+	if c.SaltRotation <= 0 {
+		me.Append(fieldErr(CodeDurationInvalid, "salt_rotation", "salt_rotation must be a positive ISO 8601 duration"))
+	} else if time.Duration(c.SaltRotation) < MinSaltRotation {
+		me.Append(fieldErr(CodeDurationInvalid, "salt_rotation", fmt.Sprintf("salt_rotation must be at least %s", types.FormatISODuration(MinSaltRotation))))
+	}
+	validatePrivacyConfig(&me, &c.Privacy)
+
+	return me.NilOrError()
+}
+
+// ValidatePublisherConfig validates a publisher's configuration.
+func ValidatePublisherConfig(c *types.PublisherConfig) error {
+	if c == nil {
+		return ErrNilInput
+	}
+	var me MultiError
+
+	if c.PublisherID == "" {
+		me.Append(fieldErr(CodeRequired, "publisher_id", "publisher_id must be non-empty"))
+	}
+	if c.Tenant != "" && !types.ValidTenant(c.Tenant) {
+		me.Append(fieldErr(CodeTenantFormat, "tenant", "tenant must be dot-separated lowercase labels (e.g., \"us.fec\")"))
+	}
+	if c.Jurisdiction != "" && !types.ISO3166.MatchString(c.Jurisdiction) {
+		me.Append(fieldErr(CodeJurisdictionFormat, "jurisdiction", "jurisdiction must be ISO-3166 (e.g., \"US\" or \"CA-ON\")"))
+	}
+	if !absoluteHTTP(c.Endpoint) {
+		me.Append(fieldErr(CodeURLInvalid, "endpoint", "endpoint must be an absolute http(s) URL"))
+	}
+	validateConfigInterval(&me, c.Interval)
+	validateConfigTopics(&me, c.Topics)
+	if c.UpdateCadence <= 0 {
+		me.Append(fieldErr(CodeDurationInvalid, "update_cadence", "update_cadence must be a positive ISO 8601 duration"))
+	}
+	validatePrivacyConfig(&me, &c.Privacy)
+
+	return me.NilOrError()
+}
+
+func validateConfigInterval(me *MultiError, iv types.Interval) {
+	if iv.Duration() == 0 {
+		me.Append(fieldErr(CodeIntervalUnsupported, "interval", fmt.Sprintf("interval %q is not supported", iv)))
+	}
+}
+
+func validateConfigTopics(me *MultiError, topics []string) {
+	if len(topics) == 0 {
+		me.Append(fieldErr(CodeRequired, "topics", "topics must list at least one topic"))
+	}
+	seen := make(map[string]bool, len(topics))
+	for i, t := range topics {
+		path := fmt.Sprintf("topics[%d]", i)
+		switch {
+		case t == "":
+			me.Append(fieldErr(CodeRequired, path, path+" must be non-empty"))
+		case seen[t]:
+			me.Append(fieldErr(CodeDuplicate, path, fmt.Sprintf("%s duplicates topic %q", path, t)))
+		}
+		seen[t] = true
+	}
+}
+
+func validatePrivacyConfig(me *MultiError, p *types.PrivacyConfig) {
+	if p.MinVolume < 0 {
+		me.Append(fieldErr(CodeCountNegative, "privacy.min_volume", "privacy.min_volume must be >= 0"))
+	}
+	if p.MaxOriginRisk < 0 || p.MaxOriginRisk > 1 {
+		me.Append(fieldErr(CodeRatioRange, "privacy.max_origin_risk", "privacy.max_origin_risk must be in [0,1]"))
+	}
+}
+
+// saltLenProblem mirrors the key requirements of dedupe.Hash, except that
+// sha256-trunc8 salts must be at least 16 bytes to resist guessing.
+func saltLenProblem(alg types.DedupHashAlg, n int) string {
+	switch alg {
+	case "", types.DedupSHA256Trunc8:
+		if n < 16 {
+			return fmt.Sprintf("dedup_salt must be at least 16 bytes for %s, got %d", types.DedupSHA256Trunc8, n)
+		}
+	case types.DedupSipHash24:
+		if n != 16 {
+			return fmt.Sprintf("dedup_salt must be 16 bytes for %s, got %d", alg, n)
+		}
+	case types.DedupXXHash64:
+		if n != 8 {
+			return fmt.Sprintf("dedup_salt must be 8 bytes for %s, got %d", alg, n)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,93 @@
+package validate
+
+import "github.com/civic-interconnect/civic-transparency-go-types/types"
+
+// ConformanceLevel grades a payload against the spec.
+type ConformanceLevel string
+
+const (
+	// ConformanceFull means the payload is valid and carries every optional
+	// feature the spec recommends.
+	ConformanceFull ConformanceLevel = "full"
+	// ConformanceMinimal means the payload is valid but omits some optional
+	// features; Conformance.Missing lists them.
+	ConformanceMinimal ConformanceLevel = "minimal"
+	// ConformanceNone means the payload failed validation.
+	ConformanceNone ConformanceLevel = "non_conformant"
+)
+
+// Optional features reported in Conformance.Missing.
+const (
+	FeatureAcctAgeMix          = "acct_age_mix"
+	FeatureAutomationMix       = "automation_mix"
+	FeatureClientMix           = "client_mix"
+	FeatureAcctTypeShares      = "acct_type_shares"
+	FeaturePostKindMix         = "post_kind_mix"
+	FeatureCoordinationSignals = "coordination_signals"
+	FeatureMethodology         = "methodology"
+)
+
+// optionalFeatures lists the optional features in the order Missing reports
+// them, each with the test for whether a series carries it.
+var optionalFeatures = []struct {
+	name    string
+	present func(s *types.Series) bool
+}{
+	{FeatureAcctAgeMix, everyPoint(func(p *types.Point) bool { return len(p.AcctAgeMix) > 0 })},
+	{FeatureAutomationMix, everyPoint(func(p *types.Point) bool { return len(p.AutomationMix) > 0 })},
+	{FeatureClientMix, everyPoint(func(p *types.Point) bool { return len(p.ClientMix) > 0 })},
+	{FeatureAcctTypeShares, everyPoint(func(p *types.Point) bool { return len(p.AcctTypeShares) > 0 })},
+	{FeaturePostKindMix, everyPoint(func(p *types.Point) bool { return len(p.PostKindMix) > 0 })},
+	{FeatureCoordinationSignals, func(s *types.Series) bool {
+		for i := range s.Points {
+			if s.Points[i].CoordinationSignals != (types.CoordinationSignals{}) {
+				return true
+			}
+		}
+		return false
+	}},
+	{FeatureMethodology, func(s *types.Series) bool { return s.Methodology != nil }},
+}
+
+// everyPoint reports whether has holds for every point of a series.
+func everyPoint(has func(p *types.Point) bool) func(s *types.Series) bool {
+	return func(s *types.Series) bool {
+		for i := range s.Points {
+			if !has(&s.Points[i]) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Conformance is the graded outcome of SeriesConformance.
+type Conformance struct {
+	Level   ConformanceLevel `json:"level"`
+	Missing []string         `json:"missing,omitempty"` // optional features absent from at least one point, or from the series
+}
+
+// SeriesConformance validates s with opts and grades it. The returned error
+// is the validation error, if any, in which case Level is ConformanceNone.
+//
+// A breakdown counts as present only if every point carries it. Coordination
+// signals count as present if any point reports a non-zero signal, since an
+// all-zero series is indistinguishable from one that never computed them.
+// Methodology counts as present if the series declares one.
+func SeriesConformance(s *types.Series, opts Options) (Conformance, error) {
+	if err := ValidateSeriesWith(s, opts); err != nil {
+		return Conformance{Level: ConformanceNone}, err
+	}
+
+	var c Conformance
+	for _, f := range optionalFeatures {
+		if !f.present(s) {
+			c.Missing = append(c.Missing, f.name)
+		}
+	}
+	c.Level = ConformanceFull
+	if len(c.Missing) > 0 {
+		c.Level = ConformanceMinimal
+	}
+	return c, nil
+}
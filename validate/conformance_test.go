@@ -0,0 +1,64 @@
+package validate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// TestConformanceCoversFields fails when Point or Series gains a field that
+// is neither required, deliberately not graded, nor an optional feature, so
+// new optional fields are not silently left out of SeriesConformance.
+func TestConformanceCoversFields(t *testing.T) {
+	classified := map[string]bool{
+		// Required.
+		"ts": true, "volume": true, "reshare_ratio": true, "recycled_content_rate": true,
+		"topic": true, "generated_at": true, "interval": true, "points": true,
+		// Optional but not a feature a publisher is expected to carry.
+		"backfilled": true, "annotations": true, "retraction": true, "extensions": true,
+		"tenant": true, "jurisdiction": true,
+	}
+	features := make(map[string]bool)
+	for _, f := range optionalFeatures {
+		features[f.name] = true
+	}
+	fields := make(map[string]bool)
+	for _, v := range []any{types.Point{}, types.Series{}} {
+		rt := reflect.TypeOf(v)
+		for i := 0; i < rt.NumField(); i++ {
+			name, _, _ := strings.Cut(rt.Field(i).Tag.Get("json"), ",")
+			fields[name] = true
+			if !classified[name] && !features[name] {
+				t.Errorf("%s.%s is not an optional feature in conformance.go", rt.Name(), name)
+			}
+		}
+	}
+	for name := range features {
+		if !fields[name] {
+			t.Errorf("optional feature %q is not a Point or Series field", name)
+		}
+	}
+}
+
+func TestConformanceFull(t *testing.T) {
+	s := types.Series{
+		Topic: "#vote", GeneratedAt: time.Date(2025, 1, 1, 0, 5, 0, 0, time.UTC), Interval: types.IntervalMinute,
+		Points: []types.Point{{
+			TS: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Volume: 4, ReshareRatio: 0.25,
+			AcctAgeMix:          map[string]types.Probability{"24m+": 1},
+			AutomationMix:       map[string]types.Probability{"manual": 1},
+			ClientMix:           map[string]types.Probability{"web": 1},
+			AcctTypeShares:      map[types.AcctType]types.Probability{types.AcctTypePerson: 1},
+			PostKindMix:         map[types.PostKind]types.Probability{types.PostKindOriginal: 0.75, types.PostKindReshare: 0.25},
+			CoordinationSignals: types.CoordinationSignals{BurstScore: 0.1},
+		}},
+		Methodology: &types.Methodology{SamplingRate: 1},
+	}
+	c, err := SeriesConformance(&s, DefaultOptions())
+	if err != nil || c.Level != ConformanceFull || len(c.Missing) != 0 {
+		t.Fatalf("SeriesConformance = %+v, %v", c, err)
+	}
+}
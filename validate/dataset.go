@@ -0,0 +1,89 @@
+package validate
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"regexp"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+var (
+	reSPDX   = regexp.MustCompile(`^[A-Za-z0-9.+-]+$`)
+	reSemver = regexp.MustCompile(`^[0-9]+\.[0-9]+\.[0-9]+$`)
+)
+
+// ValidateDatasetDescriptor validates a catalog entry.
+func ValidateDatasetDescriptor(d *types.DatasetDescriptor) error {
+	if d == nil {
+		return ErrNilInput
+	}
+	var me MultiError
+
+	if d.ID == "" {
+		me.Append(fieldErr(CodeRequired, "id", "id must be non-empty"))
+	}
+	if d.Title == "" {
+		me.Append(fieldErr(CodeRequired, "title", "title must be non-empty"))
+	}
+	if d.Publisher.ID == "" {
+		me.Append(fieldErr(CodeRequired, "publisher.id", "publisher.id must be non-empty"))
+	}
+	if len(d.Topics) == 0 {
+		me.Append(fieldErr(CodeRequired, "topics", "topics must list at least one topic"))
+	}
+	seen := make(map[string]bool, len(d.Topics))
+	for i, t := range d.Topics {
+		path := fmt.Sprintf("topics[%d]", i)
+		switch {
+		case t == "":
+			me.Append(fieldErr(CodeRequired, path, path+" must be non-empty"))
+		case seen[t]:
+			me.Append(fieldErr(CodeDuplicate, path, fmt.Sprintf("%s duplicates topic %q", path, t)))
+		}
+		seen[t] = true
+	}
+	if d.Coverage.Start.IsZero() {
+		me.Append(fieldErr(CodeRequired, "coverage.start", "coverage.start must be set"))
+	}
+	if e := d.Coverage.End; e != nil && !e.After(d.Coverage.Start) {
+		me.Append(fieldErr(CodeTimeRange, "coverage.end", "coverage.end must be after coverage.start"))
+	}
+	if d.Interval.Duration() == 0 {
+		me.Append(fieldErr(CodeDurationInvalid, "interval", "interval must be a positive ISO 8601 duration"))
+	}
+	if d.UpdateCadence <= 0 {
+		me.Append(fieldErr(CodeDurationInvalid, "update_cadence", "update_cadence must be a positive ISO 8601 duration"))
+	}
+	if !reSPDX.MatchString(d.License.ID) {
+		me.Append(fieldErr(CodeLicenseInvalid, "license.id", "license.id must be an SPDX identifier"))
+	}
+	if d.License.URL != "" && !absoluteHTTP(d.License.URL) {
+		me.Append(fieldErr(CodeURLInvalid, "license.url", "license.url must be an absolute http(s) URL"))
+	}
+	switch {
+	case d.Contact.Email == "" && d.Contact.URL == "":
+		me.Append(fieldErr(CodeContactInvalid, "contact", "contact must have an email or url"))
+	case d.Contact.Email != "":
+		if a, err := mail.ParseAddress(d.Contact.Email); err != nil || a.Address != d.Contact.Email {
+			me.Append(fieldErr(CodeContactInvalid, "contact.email", "contact.email must be a bare email address"))
+		}
+	}
+	if d.Contact.URL != "" && !absoluteHTTP(d.Contact.URL) {
+		me.Append(fieldErr(CodeURLInvalid, "contact.url", "contact.url must be an absolute http(s) URL"))
+	}
+	if !reSemver.MatchString(d.SchemaVersion) {
+		me.Append(fieldErr(CodeVersionFormat, "schema_version", "schema_version must be MAJOR.MINOR.PATCH"))
+	}
+	if d.URL != "" && !absoluteHTTP(d.URL) {
+		me.Append(fieldErr(CodeURLInvalid, "url", "url must be an absolute http(s) URL"))
+	}
+
+	return me.NilOrError()
+}
+
+func absoluteHTTP(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "https" || u.Scheme == "http") && u.Host != ""
+}
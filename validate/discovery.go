@@ -0,0 +1,106 @@
+package validate
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// ValidateDiscovery validates a publisher's well-known discovery document.
+// Every endpoint URL must be absolute http(s) and each relation must
+// appear once; a relation that is not one of the types.Rel constants
+// must itself be an absolute URL. Keys must decode for their algorithm
+// and have unique IDs.
+func ValidateDiscovery(d *types.Discovery) error {
+	if d == nil {
+		return ErrNilInput
+	}
+	var me MultiError
+
+	if d.Publisher.ID == "" {
+		me.Append(fieldErr(CodeRequired, "publisher.id", "publisher.id must be non-empty"))
+	}
+	if len(d.Versions) == 0 {
+		me.Append(fieldErr(CodeRequired, "versions", "versions must list at least one schema version"))
+	}
+	seen := map[string]bool{}
+	for i, v := range d.Versions {
+		path := fmt.Sprintf("versions[%d]", i)
+		switch {
+		case !reSemver.MatchString(v):
+			me.Append(fieldErr(CodeVersionFormat, path, path+" must be MAJOR.MINOR.PATCH"))
+		case seen[v]:
+			me.Append(fieldErr(CodeDuplicate, path, fmt.Sprintf("%s duplicates version %q", path, v)))
+		}
+		seen[v] = true
+	}
+	if len(d.Endpoints) == 0 {
+		me.Append(fieldErr(CodeRequired, "endpoints", "endpoints must list at least one endpoint"))
+	}
+	seen = map[string]bool{}
+	for i, e := range d.Endpoints {
+		path := fmt.Sprintf("endpoints[%d]", i)
+		switch {
+		case e.Rel == "":
+			me.Append(fieldErr(CodeRequired, path+".rel", path+".rel must be non-empty"))
+		case seen[e.Rel]:
+			me.Append(fieldErr(CodeDuplicate, path+".rel", fmt.Sprintf("%s.rel duplicates relation %q", path, e.Rel)))
+		case !knownRel(e.Rel) && !absoluteURL(e.Rel):
+			me.Append(fieldErr(CodeURLInvalid, path+".rel", path+".rel must be a defined relation or an absolute URL"))
+		}
+		seen[e.Rel] = true
+		if !absoluteHTTP(e.URL) {
+			me.Append(fieldErr(CodeURLInvalid, path+".url", path+".url must be an absolute http(s) URL"))
+		}
+	}
+	seen = map[string]bool{}
+	for i, t := range d.Topics {
+		path := fmt.Sprintf("topics[%d]", i)
+		switch {
+		case t == "":
+			me.Append(fieldErr(CodeRequired, path, path+" must be non-empty"))
+		case seen[t]:
+			me.Append(fieldErr(CodeDuplicate, path, fmt.Sprintf("%s duplicates topic %q", path, t)))
+		}
+		seen[t] = true
+	}
+	seen = map[string]bool{}
+	for i, k := range d.Keys {
+		path := fmt.Sprintf("keys[%d]", i)
+		switch {
+		case k.ID == "":
+			me.Append(fieldErr(CodeRequired, path+".id", path+".id must be non-empty"))
+		case seen[k.ID]:
+			me.Append(fieldErr(CodeDuplicate, path+".id", fmt.Sprintf("%s.id duplicates key %q", path, k.ID)))
+		}
+		seen[k.ID] = true
+		if _, err := k.Ed25519(); err != nil {
+			me.Append(fieldErr(CodeKeyInvalid, path, fmt.Sprintf("%s must be a base64 ed25519 public key", path)))
+		}
+		if k.NotAfter != nil && !types.IsUTC(*k.NotAfter) {
+			me.Append(fieldErr(CodeTimeNotUTC, path+".not_after", path+".not_after must be in UTC"))
+		}
+	}
+	switch {
+	case d.UpdatedAt.IsZero():
+		me.Append(fieldErr(CodeRequired, "updated_at", "updated_at must be set"))
+	case !types.IsUTC(d.UpdatedAt):
+		me.Append(fieldErr(CodeTimeNotUTC, "updated_at", "updated_at must be in UTC"))
+	}
+
+	return me.NilOrError()
+}
+
+func knownRel(rel string) bool {
+	switch rel {
+	case types.RelSeries, types.RelCatalog, types.RelUploads, types.RelHeartbeat, types.RelRetractions:
+		return true
+	}
+	return false
+}
+
+func absoluteURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && u.IsAbs() && u.Host != ""
+}
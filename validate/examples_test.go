@@ -0,0 +1,370 @@
+package validate_test
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/privacy"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+	"github.com/civic-interconnect/civic-transparency-go-types/validate"
+)
+
+func ExampleToProblem() {
+	tag := types.ProvenanceTag{
+		AcctAgeBucket:   types.AcctAge_1_6m,
+		AcctType:        types.AcctTypePerson,
+		AutomationFlag:  types.AutomationManual,
+		PostKind:        types.PostKindOriginal,
+		ClientFamily:    types.ClientWeb,
+		MediaProvenance: types.MediaProvNone,
+		DedupHash:       "DEADBEEF",
+	}
+	b, _ := json.MarshalIndent(validate.ToProblem(validate.ValidateProvenanceTag(&tag)), "", "  ")
+	fmt.Println(string(b))
+	// Output:
+	// {
+	//   "type": "urn:civic-transparency:problem:validation",
+	//   "title": "Validation failed",
+	//   "status": 422,
+	//   "detail": "1 field failed validation",
+	//   "errors": [
+	//     {
+	//       "field": "dedup_hash",
+	//       "code": "ERR_DEDUP_HASH_SHAPE",
+	//       "pointer": "/dedup_hash",
+	//       "detail": "dedup_hash must be 8 lowercase hex chars"
+	//     }
+	//   ]
+	// }
+}
+
+func ExampleValidateSeriesWith() {
+	s := types.Series{
+		Topic:       "#vote",
+		GeneratedAt: time.Date(2025, 1, 1, 0, 5, 0, 0, time.UTC),
+		Interval:    types.IntervalMinute,
+		Points: []types.Point{{
+			TS:         time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			Volume:     3,
+			AcctAgeMix: map[string]types.Probability{"0-7d": 0.33, "24m+": 0.66},
+		}},
+	}
+	fmt.Println(validate.ValidateSeries(&s))
+	fmt.Println(validate.ValidateSeriesWith(&s, validate.Options{SumEpsilon: 0.02}))
+	// Output:
+	// points[0].acct_age_mix must sum to 1 (±0.001), got 0.99
+	// <nil>
+}
+
+func ExampleSeriesConformance() {
+	s := types.Series{
+		Topic:       "#vote",
+		GeneratedAt: time.Date(2025, 1, 1, 0, 5, 0, 0, time.UTC),
+		Interval:    types.IntervalMinute,
+		Points: []types.Point{{
+			TS:                  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			Volume:              3,
+			AcctAgeMix:          map[string]types.Probability{"24m+": 1},
+			CoordinationSignals: types.CoordinationSignals{BurstScore: 0.4},
+		}},
+	}
+	c, err := validate.SeriesConformance(&s, validate.DefaultOptions())
+	fmt.Println(c.Level, c.Missing, err)
+	// Output: minimal [automation_mix client_mix acct_type_shares post_kind_mix methodology] <nil>
+}
+
+func ExampleCheckProvenanceTag() {
+	tag := types.ProvenanceTag{
+		AcctAgeBucket:   types.AcctAge_1_6m,
+		AcctType:        types.AcctTypePerson,
+		AutomationFlag:  types.AutomationManual,
+		PostKind:        types.PostKindOriginal,
+		ClientFamily:    types.ClientWeb,
+		MediaProvenance: types.MediaProvNone,
+		DedupHash:       "deadbeef",
+	}
+	ws, err := validate.CheckProvenanceTag(&tag)
+	for _, w := range ws {
+		fmt.Println(w)
+	}
+	fmt.Println(err)
+	// Output:
+	// origin_hint is empty; geographic breakdowns will omit this tag
+	// dedup_hash_alg is unset; assuming sha256-trunc8
+	// <nil>
+}
+
+func ExampleExtensionRegistry() {
+	reg := &validate.ExtensionRegistry{Strict: true}
+	reg.Register("x-mastodon-instance-count", func(raw json.RawMessage) error {
+		var n int
+		if err := json.Unmarshal(raw, &n); err != nil || n < 0 {
+			return fmt.Errorf("must be a non-negative integer")
+		}
+		return nil
+	})
+
+	var s types.Series
+	_ = json.Unmarshal([]byte(`{
+		"topic": "#vote", "generated_at": "2025-01-01T00:05:00Z", "interval": "PT1M",
+		"points": [{"ts": "2025-01-01T00:00:00Z", "volume": 3}],
+		"extensions": {"x-mastodon-instance-count": -2, "x-bsky-labels": ["spam"], "platform_note": "hi"}
+	}`), &s)
+	out, _ := json.Marshal(s.Extensions)
+	fmt.Println(string(out))
+
+	me := validate.ValidateSeriesWith(&s, validate.Options{Extensions: reg}).(*validate.MultiError)
+	for _, err := range me.Errors() {
+		fmt.Println(err)
+	}
+	// Output:
+	// {"platform_note":"hi","x-bsky-labels":["spam"],"x-mastodon-instance-count":-2}
+	// extension key "platform_note" must be x-<platform>-<name>
+	// extension "x-bsky-labels" is not registered
+	// extensions.x-mastodon-instance-count: must be a non-negative integer
+}
+
+func ExampleValidateDatasetDescriptor() {
+	var d types.DatasetDescriptor
+	_ = json.Unmarshal([]byte(`{
+		"id": "ec-2025-national",
+		"title": "National election hashtags, per minute",
+		"publisher": {"id": "pub_0f3a"},
+		"topics": ["#vote", "#ballot"],
+		"coverage": {"start": "2025-01-01T00:00:00Z"},
+		"interval": "PT1M",
+		"update_cadence": "PT1H",
+		"license": {"id": "CC-BY-4.0"},
+		"contact": {"email": "Data Desk <data@example.org>"},
+		"schema_version": "0.3.0"
+	}`), &d)
+	fmt.Println(time.Duration(d.UpdateCadence), d.Coverage.End == nil)
+	fmt.Println(validate.ValidateDatasetDescriptor(&d))
+	// Output:
+	// 1h0m0s true
+	// contact.email must be a bare email address
+}
+
+func ExampleOriginRiskPolicy() {
+	tag := types.ProvenanceTag{
+		AcctAgeBucket:   types.AcctAge_0_7d,
+		AcctType:        types.AcctTypePerson,
+		AutomationFlag:  types.AutomationManual,
+		PostKind:        types.PostKindOriginal,
+		ClientFamily:    types.ClientThirdParty,
+		MediaProvenance: types.MediaProvNone,
+		DedupHash:       "deadbeef",
+		OriginHint:      "US-WY",
+	}
+	policy := &validate.OriginRiskPolicy{Scorer: privacy.DefaultRiskScorer(), Max: privacy.DefaultMaxSubdivisionRisk}
+	fmt.Println(validate.ValidateProvenanceTagWith(&tag, validate.Options{OriginRisk: policy}))
+
+	privacy.CoarsenOriginHint(&tag, policy.Scorer, policy.Max)
+	fmt.Println(tag.OriginHint, validate.ValidateProvenanceTagWith(&tag, validate.Options{OriginRisk: policy}))
+	// Output:
+	// origin_hint subdivision not permitted at re-identification risk 0.70 (max 0.50); use country only
+	// US <nil>
+}
+
+func ExampleTenantRegistry() {
+	var reg validate.TenantRegistry
+	reg.Register("us.fec", validate.TenantPolicy{Jurisdictions: []string{"US"}})
+	reg.Register("ca.elections-ontario", validate.TenantPolicy{
+		Jurisdictions: []string{"CA-ON"},
+		Options:       validate.Options{SumEpsilon: 0.02},
+	})
+
+	s := types.Series{
+		Topic:        "#vote",
+		GeneratedAt:  time.Date(2025, 1, 1, 0, 5, 0, 0, time.UTC),
+		Interval:     types.IntervalMinute,
+		Tenant:       "ca.elections-ontario",
+		Jurisdiction: "CA-ON",
+		Points: []types.Point{{
+			TS:         time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			Volume:     3,
+			AcctAgeMix: map[string]types.Probability{"0-7d": 0.33, "24m+": 0.66},
+		}},
+	}
+	fmt.Println(reg.ValidateSeries(&s))
+
+	s.Tenant = "us.fec"
+	fmt.Println(reg.ValidateSeries(&s))
+	// Output:
+	// <nil>
+	// points[0].acct_age_mix must sum to 1 (±0.001), got 0.99; jurisdiction "CA-ON" is not permitted for tenant "us.fec"
+}
+
+func ExampleValidateCollectorConfig() {
+	var cfg types.CollectorConfig
+	fs := flag.NewFlagSet("collector", flag.ContinueOnError)
+	cfg.RegisterFlags(fs, "")
+	_ = fs.Parse([]string{"-interval", "PT1M", "-topics", "#vote, #ballot", "-max-origin-risk", "0.5"})
+
+	env := map[string]string{"CT_DEDUP_SALT": "00112233", "CT_SALT_ROTATION": "P1D"}
+	_ = cfg.LoadEnv("CT_", func(k string) (string, bool) { v, ok := env[k]; return v, ok })
+
+	fmt.Println(cfg)
+	fmt.Println(validate.ValidateCollectorConfig(&cfg))
+	// Output:
+	// {Interval:minute Topics:[#vote #ballot] DedupHashAlg: DedupSalt:REDACTED SaltRotation:86400000000000 Privacy:{MinVolume:0 MaxOriginRisk:0.5 CountryOnlyOrigin:false}}
+	// dedup_salt must be at least 16 bytes for sha256-trunc8, got 4
+}
+
+func ExampleValidateSeries_postKindMix() {
+	s := types.Series{
+		Topic:       "#vote",
+		GeneratedAt: time.Date(2025, 1, 1, 0, 5, 0, 0, time.UTC),
+		Interval:    types.IntervalMinute,
+		Points: []types.Point{{
+			TS:           time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			Volume:       40,
+			ReshareRatio: 0.5,
+			PostKindMix: map[types.PostKind]types.Probability{
+				types.PostKindOriginal: 0.7, types.PostKindReshare: 0.3,
+			},
+		}},
+	}
+	err := validate.ValidateSeries(&s)
+	fmt.Println(validate.CodeOf(err), err)
+	// Output: ERR_RESHARE_INCONSISTENT points[0].reshare_ratio must match post_kind_mix.reshare (±0.01), got 0.5 vs 0.3
+}
+
+func ExampleSeriesValidator_Checkpoint() {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	header := &types.Series{Topic: "#vote", GeneratedAt: t0.Add(time.Hour), Interval: types.IntervalMinute}
+
+	v := validate.NewSeriesValidator(header, validate.Options{})
+	v.Add(types.Point{TS: t0, Volume: 4})
+	v.Add(types.Point{TS: t0.Add(time.Minute), Volume: -1})
+	saved := v.Checkpoint()
+
+	// After a restart, continue from the saved state.
+	v = validate.NewSeriesValidator(nil, validate.Options{})
+	if err := v.Resume(saved); err != nil {
+		panic(err)
+	}
+	v.Add(types.Point{TS: t0.Add(2 * time.Minute), Volume: 7})
+	fmt.Println(v.Len(), v.Close())
+	// Output:
+	// 3 points[1].volume must be ≥0
+}
+
+func ExampleOptions_WithTopicOverrides() {
+	opts := validate.DefaultOptions().WithTopicOverrides(map[string]validate.Options{
+		"#election": {MinVolume: 10},
+	})
+	mk := func(topic string) *types.Series {
+		return &types.Series{
+			Topic:       topic,
+			GeneratedAt: time.Date(2025, 1, 1, 0, 5, 0, 0, time.UTC),
+			Interval:    types.IntervalMinute,
+			Points:      []types.Point{{TS: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Volume: 4}},
+		}
+	}
+	fmt.Println(validate.ValidateSeriesWith(mk("#weather"), opts))
+	fmt.Println(validate.ValidateSeriesWith(mk("#election"), opts))
+	// Output:
+	// <nil>
+	// points[0].volume must be 0 or at least 10 (suppression floor)
+}
+
+func ExampleValidateHeartbeat() {
+	t0 := time.Date(2025, 1, 1, 12, 0, 30, 0, time.UTC)
+	hb := types.Heartbeat{
+		Publisher:     "p1",
+		SentAt:        t0,
+		LastFinalized: t0.Truncate(time.Minute).Add(-time.Minute),
+		Lag:           types.ISODuration(30 * time.Second),
+		Interval:      types.ISODuration(2 * time.Minute),
+	}
+	fmt.Println(validate.ValidateHeartbeat(&hb, validate.DefaultOptions()))
+
+	hb.Interval = types.ISODuration(15 * time.Second)
+	fmt.Println(validate.ValidateHeartbeat(&hb, validate.DefaultOptions()))
+	fmt.Println(hb.Down(t0.Add(45*time.Second)), hb.Down(t0.Add(46*time.Second)))
+	// Output:
+	// interval must be between PT5S and PT1M
+	// <nil>
+	// false true
+}
+
+func ExampleOptions_features() {
+	// Normally in an init function of the deployment.
+	types.RegisterAcctType("state_media", types.ExperimentalOptions{
+		Description: "state-affiliated media accounts (pilot)",
+	})
+
+	tag := types.ProvenanceTag{
+		AcctAgeBucket:   types.AcctAge_24mPlus,
+		AcctType:        "state_media",
+		AutomationFlag:  types.AutomationManual,
+		PostKind:        types.PostKindOriginal,
+		ClientFamily:    types.ClientWeb,
+		MediaProvenance: types.MediaProvNone,
+		DedupHash:       "deadbeef",
+	}
+	fmt.Println(validate.ValidateProvenanceTagWith(&tag, validate.DefaultOptions()))
+	opts := validate.Options{Features: map[string]bool{"acct_type.state_media": true}}
+	fmt.Println(validate.ValidateProvenanceTagWith(&tag, opts))
+	// Output:
+	// acct_type "state_media" is experimental; enable feature acct_type.state_media
+	// <nil>
+}
+
+func ExampleExplain() {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := types.Series{
+		Topic:       "#vote",
+		GeneratedAt: t0.Add(5 * time.Minute),
+		Interval:    types.IntervalMinute,
+		Points: []types.Point{
+			{TS: t0, Volume: 12, ClientMix: map[string]types.Probability{"web": 0.5, "mobile": 0.5}},
+			{TS: t0.Add(time.Minute), Volume: 0},
+		},
+	}
+	e := validate.Explain(&s)
+	fmt.Println("valid:", e.Valid)
+	for _, r := range e.Rules {
+		if r.Field == "points[*].client_mix" || r.Field == "points[*].acct_age_mix" {
+			fmt.Printf("%s %s: %s (%d checked; %s)\n", r.Outcome, r.Field, r.Rule, r.Checked, r.Reason)
+		}
+	}
+	// Output:
+	// valid: true
+	// skipped points[*].acct_age_mix: shares are 0–1 (0 checked; optional field absent from every point)
+	// passed points[*].client_mix: shares are 0–1 (1 checked; shares sum to 1 (±0.001))
+}
+
+func ExampleValidateDiscovery() {
+	d := types.Discovery{
+		Publisher: types.PublisherRef{ID: "p1"},
+		Versions:  []string{"0.3.0", "0.2"},
+		Endpoints: []types.Endpoint{
+			{Rel: types.RelSeries, URL: "https://data.example.org/v1/series"},
+			{Rel: "feeds", URL: "https://data.example.org/v1/feeds"},
+		},
+		Keys:      []types.PublicKey{{ID: "2025-01", Alg: types.KeyAlgEd25519, Key: "11qYAYKxCrfVS/7TyWQHOg7hcvPapiMlrwIaaPcHURo="}},
+		UpdatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	fmt.Println(validate.ValidateDiscovery(&d))
+	// Output:
+	// versions[1] must be MAJOR.MINOR.PATCH; endpoints[1].rel must be a defined relation or an absolute URL
+}
+
+func ExampleValidateSeries_methodology() {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := types.Series{
+		Topic:       "#vote",
+		GeneratedAt: t0.Add(5 * time.Minute),
+		Interval:    types.IntervalMinute,
+		Points:      []types.Point{{TS: t0, Volume: 7}},
+		Methodology: &types.Methodology{SuppressionThreshold: 10, NoiseEpsilon: -1},
+	}
+	// The declared threshold is enforced as the suppression floor.
+	fmt.Println(validate.ValidateSeries(&s))
+	// Output:
+	// methodology.noise_epsilon must be a finite number ≥0; points[0].volume must be 0 or at least 10 (suppression floor)
+}
@@ -0,0 +1,276 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// RuleOutcome is what happened to one rule in an Explanation.
+type RuleOutcome string
+
+const (
+	RulePassed  RuleOutcome = "passed"
+	RuleFailed  RuleOutcome = "failed"
+	RuleSkipped RuleOutcome = "skipped"
+)
+
+// RuleResult reports one validation rule.
+type RuleResult struct {
+	Rule    string      `json:"rule"`  // what the rule requires
+	Field   string      `json:"field"` // field path; points[*] stands for every point
+	Codes   []ErrorCode `json:"codes"` // codes the rule reports
+	Outcome RuleOutcome `json:"outcome"`
+	Checked int         `json:"checked,omitempty"` // values checked, e.g. points carrying a breakdown
+	Failed  int         `json:"failed,omitempty"`  // errors reported
+	Reason  string      `json:"reason,omitempty"`  // why skipped, or the parameters it ran with
+}
+
+// Explanation is the audit trail of validating one Series: every rule
+// ValidateSeriesWith applies, whether it passed, failed, or was skipped,
+// and why. Valid agrees with ValidateSeriesWith under the same Options.
+type Explanation struct {
+	Valid  bool         `json:"valid"`
+	Policy string       `json:"policy"` // which options applied, e.g. a topic override
+	Rules  []RuleResult `json:"rules"`  // in validation order
+}
+
+// String lists the rules one per line, for certification reports.
+func (e Explanation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "valid=%t policy=%s\n", e.Valid, e.Policy)
+	for _, r := range e.Rules {
+		fmt.Fprintf(&b, "%-7s %-40s %s", r.Outcome, r.Field, r.Rule)
+		switch {
+		case r.Failed > 0:
+			fmt.Fprintf(&b, " (%d of %d failed)", r.Failed, r.Checked)
+		case r.Checked > 1:
+			fmt.Fprintf(&b, " (%d checked)", r.Checked)
+		}
+		if r.Reason != "" {
+			b.WriteString(": " + r.Reason)
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Explain is ExplainWith using DefaultOptions.
+func Explain(s *types.Series) Explanation {
+	return ExplainWith(s, DefaultOptions())
+}
+
+// ExplainWith validates s with opts and explains the result: for each
+// rule, how many values it checked and how many failed, or why it did not
+// run — an absent optional field, an option that disables it, or a
+// precondition such as a series without points. It neither calls
+// opts.Observe nor attaches opts.Source; use ValidateSeriesWith for the
+// errors themselves.
+func ExplainWith(s *types.Series, opts Options) Explanation {
+	if s == nil {
+		return Explanation{Policy: "none", Rules: []RuleResult{{
+			Rule: "document is present", Field: "", Codes: []ErrorCode{CodeNilInput}, Outcome: RuleFailed, Failed: 1,
+		}}}
+	}
+	eff := opts.ForSeries(s)
+	var me MultiError
+	h := headerOf(s)
+	validateSeriesHead(&me, h, len(s.Points) > 0, false)
+	for i, p := range s.Points {
+		validatePoint(&me, i, p, s.GeneratedAt, eff)
+	}
+	validateSeriesTail(&me, h, eff)
+
+	rules := seriesRules(s, eff)
+	for _, err := range me.Errors() {
+		var field string
+		code := CodeOf(err)
+		if fe, ok := err.(*FieldError); ok {
+			field = reIndex.ReplaceAllString(fe.Field, "[*]")
+		}
+		if !attribute(rules, field, code) {
+			// A rule this table does not know yet; report it rather than
+			// explaining a failure away.
+			rules = append(rules, &rule{RuleResult: RuleResult{Rule: err.Error(), Field: field, Codes: []ErrorCode{code}, Checked: 1, Failed: 1}})
+		}
+	}
+	e := Explanation{Valid: len(me.Errors()) == 0, Policy: policy(s, opts, eff), Rules: make([]RuleResult, len(rules))}
+	for i, r := range rules {
+		switch {
+		case r.Reason != "" && r.Checked == 0:
+			r.Outcome = RuleSkipped
+		case r.Failed > 0:
+			r.Outcome = RuleFailed
+		default:
+			r.Outcome = RulePassed
+		}
+		e.Rules[i] = r.RuleResult
+	}
+	return e
+}
+
+var reIndex = regexp.MustCompile(`\[\d+\]`)
+
+type rule struct{ RuleResult }
+
+// attribute counts an error against the rule with the longest field
+// prefix that reports code, and reports whether there was one.
+func attribute(rules []*rule, field string, code ErrorCode) bool {
+	var best *rule
+	for _, r := range rules {
+		if field != r.Field && !strings.HasPrefix(field, r.Field+".") && !strings.HasPrefix(field, r.Field+"[") {
+			continue
+		}
+		if !hasCode(r.Codes, code) {
+			continue
+		}
+		if best == nil || len(r.Field) > len(best.Field) {
+			best = r
+		}
+	}
+	if best == nil {
+		return false
+	}
+	best.Failed++
+	return true
+}
+
+func hasCode(codes []ErrorCode, c ErrorCode) bool {
+	for _, x := range codes {
+		if x == c {
+			return true
+		}
+	}
+	return false
+}
+
+func policy(s *types.Series, opts, eff Options) string {
+	p := "default options"
+	if _, ok := opts.TopicOverrides[s.Topic]; ok {
+		p = fmt.Sprintf("topic override for %q", s.Topic)
+	}
+	if m := s.Methodology; m != nil && m.SuppressionThreshold > opts.ForTopic(s.Topic).MinVolume {
+		p += fmt.Sprintf("; suppression floor %d from methodology.suppression_threshold", eff.MinVolume)
+	}
+	return p
+}
+
+// seriesRules lists the rules of ValidateSeriesWith in order, with Checked
+// counts and skip reasons filled in for the options eff in effect for s.
+func seriesRules(s *types.Series, eff Options) []*rule {
+	var rules []*rule
+	add := func(field, desc string, checked int, skip string, codes ...ErrorCode) *rule {
+		r := &rule{RuleResult: RuleResult{Rule: desc, Field: field, Codes: codes, Checked: checked}}
+		if checked == 0 {
+			r.Reason = skip
+		}
+		rules = append(rules, r)
+		return r
+	}
+	one := func(present bool) int {
+		if present {
+			return 1
+		}
+		return 0
+	}
+	n := len(s.Points)
+	noPoints := "series has no points"
+	count := func(has func(p *types.Point) bool) int {
+		c := 0
+		for i := range s.Points {
+			if has(&s.Points[i]) {
+				c++
+			}
+		}
+		return c
+	}
+
+	add("topic", "is non-empty", 1, "", CodeRequired)
+	add("generated_at", "is set and in UTC", 1, "", CodeRequired, CodeTimeNotUTC)
+	add("tenant", "is dot-separated lowercase labels", one(s.Tenant != ""), "optional field absent", CodeTenantFormat)
+	add("jurisdiction", "is an ISO 3166 code", one(s.Jurisdiction != ""), "optional field absent", CodeJurisdictionFormat)
+	add("methodology", "values are in range", one(s.Methodology != nil), "optional field absent",
+		CodeRatioRange, CodeCountNegative, CodeMethodologyInvalid, CodeDurationInvalid, CodeRequired)
+	add("interval", `is "minute"`, 1, "", CodeIntervalUnsupported)
+	r := add("points", "has at least one point unless retracted", 1, "", CodePointsEmpty)
+	if s.Retraction != nil {
+		r.Reason = "a retraction may have no points"
+	}
+	add("retraction", "is a valid retraction", one(s.Retraction != nil), "optional field absent",
+		CodeRequired, CodeRetractionReasonInvalid)
+
+	add("points[*].ts", "is set and in UTC", n, noPoints, CodeRequired, CodeTimeNotUTC)
+	add("points[*].volume", "is ≥0", n, noPoints, CodeCountNegative)
+	add("points[*].reshare_ratio", "is 0–1", n, noPoints, CodeRatioRange)
+	add("points[*].recycled_content_rate", "is 0–1", n, noPoints, CodeRatioRange)
+	add("points[*].coordination_signals.burst_score", "is 0–1", n, noPoints, CodeRatioRange)
+	add("points[*].coordination_signals.synchrony_index", "is 0–1", n, noPoints, CodeRatioRange)
+	add("points[*].coordination_signals.duplication_clusters", "is ≥0 and at most volume", n, noPoints,
+		CodeCountNegative, CodeDuplicationExceedsVolume)
+
+	sums := fmt.Sprintf("shares sum to 1 (±%g)", eff.sumEpsilon())
+	if eff.sumEpsilon() < 0 {
+		sums = "sum check disabled by Options.SumEpsilon"
+	}
+	breakdown := func(field, desc string, has func(p *types.Point) bool, codes ...ErrorCode) {
+		r := add("points[*]."+field, desc, count(has), "optional field absent from every point",
+			append([]ErrorCode{CodeRatioRange, CodeSharesSum}, codes...)...)
+		if r.Checked > 0 {
+			r.Reason = sums
+		}
+	}
+	breakdown("acct_age_mix", "shares are 0–1", func(p *types.Point) bool { return len(p.AcctAgeMix) > 0 })
+	breakdown("automation_mix", "shares are 0–1", func(p *types.Point) bool { return len(p.AutomationMix) > 0 })
+	breakdown("client_mix", "shares are 0–1", func(p *types.Point) bool { return len(p.ClientMix) > 0 })
+	breakdown("acct_type_shares", "keys are accepted acct_types and shares are 0–1",
+		func(p *types.Point) bool { return len(p.AcctTypeShares) > 0 }, CodeAcctTypeInvalid)
+	breakdown("post_kind_mix", "keys are accepted post_kinds and shares are 0–1",
+		func(p *types.Point) bool { return len(p.PostKindMix) > 0 }, CodePostKindInvalid)
+	withMix := count(func(p *types.Point) bool { return len(p.PostKindMix) > 0 })
+	switch tol := eff.reshareTolerance(); {
+	case tol < 0:
+		add("points[*].reshare_ratio", "matches post_kind_mix.reshare", 0, "disabled by Options.ReshareTolerance", CodeReshareInconsistent)
+	default:
+		r := add("points[*].reshare_ratio", "matches post_kind_mix.reshare", withMix, "post_kind_mix absent from every point", CodeReshareInconsistent)
+		if withMix > 0 {
+			r.Reason = fmt.Sprintf("tolerance ±%g", tol)
+		}
+	}
+	switch {
+	case eff.MinVolume <= 0:
+		add("points[*].volume", "is 0 or at least the suppression floor", 0, "no floor set by Options.MinVolume or methodology", CodeVolumeBelowFloor)
+	default:
+		r := add("points[*].volume", "is 0 or at least the suppression floor", n, noPoints, CodeVolumeBelowFloor)
+		if n > 0 {
+			r.Reason = fmt.Sprintf("floor %d", eff.MinVolume)
+		}
+	}
+	backfilled := count(func(p *types.Point) bool { return p.Backfilled })
+	switch {
+	case s.GeneratedAt.IsZero():
+		add("points[*].backfilled", "backfilled points precede generated_at", 0, "generated_at is not set", CodeBackfillTooRecent)
+	default:
+		r := add("points[*].backfilled", "backfilled points precede generated_at", backfilled, "no point is backfilled", CodeBackfillTooRecent)
+		if backfilled > 0 {
+			r.Reason = backfillRule(eff.BackfillMinAge)
+		}
+	}
+
+	add("annotations", "have a defined kind, a start, and an end not before it", len(s.Annotations), "optional field absent",
+		CodeAnnotationKindInvalid, CodeRequired, CodeTimeRange)
+	add("extensions", "keys are x-<platform>-<name>", len(s.Extensions), "optional field absent", CodeExtensionKey)
+	switch {
+	case len(s.Extensions) == 0:
+		add("extensions", "values pass registered checks", 0, "optional field absent", CodeExtensionInvalid, CodeExtensionUnregistered)
+	case eff.Extensions == nil:
+		add("extensions", "values pass registered checks", 0, "no registry in Options.Extensions", CodeExtensionInvalid, CodeExtensionUnregistered)
+	default:
+		r := add("extensions", "values pass registered checks", len(s.Extensions), "", CodeExtensionInvalid, CodeExtensionUnregistered)
+		if eff.Extensions.Strict {
+			r.Reason = "strict registry"
+		}
+	}
+	return rules
+}
@@ -0,0 +1,94 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// TestExplainAttributesEveryError checks that Explain agrees with
+// ValidateSeries on the conformance vectors and that each error lands on a
+// rule from its table rather than the fallback.
+func TestExplainAttributesEveryError(t *testing.T) {
+	files, _ := filepath.Glob("../testdata/vectors/series/*.json")
+	if len(files) == 0 {
+		t.Skip("no vectors")
+	}
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var s types.Series
+		if err := json.Unmarshal(b, &s); err != nil {
+			t.Fatal(err)
+		}
+		e := Explain(&s)
+		var me MultiError
+		me.Append(ValidateSeries(&s))
+		if e.Valid != (len(me.Errors()) == 0) {
+			t.Errorf("%s: Explain valid=%t, ValidateSeries %v", f, e.Valid, ValidateSeries(&s))
+		}
+		failed := 0
+		for _, r := range e.Rules {
+			failed += r.Failed
+			if r.Failed > 0 && r.Checked == 0 {
+				t.Errorf("%s: %s %s failed without being checked", f, r.Field, r.Rule)
+			}
+		}
+		if failed != len(me.Errors()) {
+			t.Errorf("%s: %d failures attributed, %d errors", f, failed, len(me.Errors()))
+		}
+		if n := len(seriesRules(&s, DefaultOptions())); len(e.Rules) != n {
+			t.Errorf("%s: unattributed errors:\n%s", f, e)
+		}
+	}
+}
+
+func TestExplainSkips(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &types.Series{
+		Topic: "#vote", GeneratedAt: t0.Add(time.Hour), Interval: types.IntervalMinute,
+		Points: []types.Point{
+			{TS: t0, Volume: 3, PostKindMix: map[types.PostKind]types.Probability{types.PostKindOriginal: 1}},
+			{TS: t0.Add(time.Minute), Volume: 20, Backfilled: true},
+		},
+	}
+	opts := DefaultOptions().WithTopicOverrides(map[string]Options{"#vote": {MinVolume: 10}})
+	e := ExplainWith(s, opts)
+	if e.Valid || e.Policy != `topic override for "#vote"` {
+		t.Errorf("valid=%t policy=%s", e.Valid, e.Policy)
+	}
+	want := map[string]string{
+		"tenant is dot-separated lowercase labels":                                "skipped optional field absent",
+		"points[*].post_kind_mix keys are accepted post_kinds and shares are 0–1": "passed 1",
+		"points[*].acct_age_mix shares are 0–1":                                   "skipped optional field absent from every point",
+		"points[*].volume is 0 or at least the suppression floor":                 "failed 2",
+		"points[*].backfilled backfilled points precede generated_at":             "passed 1",
+		"extensions values pass registered checks":                                "skipped optional field absent",
+	}
+	for _, r := range e.Rules {
+		key := strings.TrimSpace(r.Field + " " + r.Rule)
+		w, ok := want[key]
+		if !ok {
+			continue
+		}
+		delete(want, key)
+		got := fmt.Sprintf("%s %d", r.Outcome, r.Checked)
+		if r.Outcome == RuleSkipped {
+			got = fmt.Sprintf("%s %s", r.Outcome, r.Reason)
+		}
+		if got != w {
+			t.Errorf("%s: %s, want %s", key, got, w)
+		}
+	}
+	for k := range want {
+		t.Errorf("rule %q missing", k)
+	}
+}
@@ -0,0 +1,83 @@
+package validate
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// ExtensionCheck validates the raw value of one extension.
+type ExtensionCheck func(raw json.RawMessage) error
+
+// ExtensionRegistry maps extension keys to checks. The zero value is an
+// empty registry ready for use; it is safe for concurrent use.
+type ExtensionRegistry struct {
+	// Strict rejects extension keys that have no registered check.
+	Strict bool
+
+	mu     sync.RWMutex
+	checks map[string]ExtensionCheck
+}
+
+// Register installs check for key, replacing any previous check. It
+// panics if key is not of the form x-<platform>-<name>.
+func (r *ExtensionRegistry) Register(key string, check ExtensionCheck) {
+	if !types.ValidExtensionKey(key) {
+		panic(fmt.Sprintf("validate: invalid extension key %q", key))
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.checks == nil {
+		r.checks = map[string]ExtensionCheck{}
+	}
+	r.checks[key] = check
+}
+
+// Lookup returns the check registered for key.
+func (r *ExtensionRegistry) Lookup(key string) (ExtensionCheck, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, ok := r.checks[key]
+	return c, ok
+}
+
+// validateExtensionKeys checks that every key is namespaced. It runs for
+// every payload, registry or not.
+func validateExtensionKeys(me *MultiError, ext types.Extensions) {
+	for _, k := range sortedKeys(ext) {
+		if !types.ValidExtensionKey(k) {
+			me.Append(fieldErr(CodeExtensionKey, "extensions."+k, fmt.Sprintf("extension key %q must be x-<platform>-<name>", k)))
+		}
+	}
+}
+
+// validateExtensionValues runs registered checks and, for a strict
+// registry, rejects unknown keys. A nil registry checks nothing.
+func validateExtensionValues(me *MultiError, ext types.Extensions, reg *ExtensionRegistry) {
+	if reg == nil {
+		return
+	}
+	for _, k := range sortedKeys(ext) {
+		check, ok := reg.Lookup(k)
+		switch {
+		case ok && check != nil:
+			if err := check(ext[k]); err != nil {
+				me.Append(fieldErr(CodeExtensionInvalid, "extensions."+k, fmt.Sprintf("extensions.%s: %v", k, err)))
+			}
+		case !ok && reg.Strict && types.ValidExtensionKey(k):
+			me.Append(fieldErr(CodeExtensionUnregistered, "extensions."+k, fmt.Sprintf("extension %q is not registered", k)))
+		}
+	}
+}
+
+func sortedKeys(ext types.Extensions) []string {
+	keys := make([]string, 0, len(ext))
+	for k := range ext {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,71 @@
+package validate
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// ValidateHealthReport validates an endpoint's health report. failure_rate
+// must match rejected/submitted within opts' sum epsilon, which absorbs
+// publishers rounding the rate.
+func ValidateHealthReport(r *types.HealthReport, opts Options) error {
+	if r == nil {
+		return ErrNilInput
+	}
+	var me MultiError
+
+	if !reSemver.MatchString(r.SpecVersion) {
+		me.Append(fieldErr(CodeVersionFormat, "spec_version", "spec_version must be MAJOR.MINOR.PATCH"))
+	}
+	if r.GeneratedAt.IsZero() {
+		me.Append(fieldErr(CodeRequired, "generated_at", "generated_at must be set"))
+	}
+	if !r.Status.Valid() {
+		me.Append(fieldErr(CodeHealthStatusInvalid, "status", fmt.Sprintf("status %q is not ok, degraded, or down", r.Status)))
+	}
+	if r.Window <= 0 {
+		me.Append(fieldErr(CodeDurationInvalid, "window", "window must be a positive ISO 8601 duration"))
+	}
+	seen := make(map[string]bool, len(r.Topics))
+	for i, t := range r.Topics {
+		path := fmt.Sprintf("topics[%d]", i)
+		switch {
+		case t.Topic == "":
+			me.Append(fieldErr(CodeRequired, path+".topic", path+".topic must be non-empty"))
+		case seen[t.Topic]:
+			me.Append(fieldErr(CodeDuplicate, path+".topic", fmt.Sprintf("%s.topic %q is listed more than once", path, t.Topic)))
+		}
+		seen[t.Topic] = true
+		if !t.LastPublished.IsZero() && !r.GeneratedAt.IsZero() && t.LastPublished.After(r.GeneratedAt) {
+			me.Append(fieldErr(CodeTimeRange, path+".last_published", path+".last_published must not be after generated_at"))
+		}
+		if t.Submitted < 0 {
+			me.Append(fieldErr(CodeCountNegative, path+".submitted", path+".submitted must be ≥0"))
+		}
+		if t.Rejected < 0 {
+			me.Append(fieldErr(CodeCountNegative, path+".rejected", path+".rejected must be ≥0"))
+		}
+		if t.FailureRate < 0 || t.FailureRate > 1 {
+			me.Append(fieldErr(CodeRatioRange, path+".failure_rate", path+".failure_rate must be 0–1"))
+		}
+		if t.Submitted < 0 || t.Rejected < 0 {
+			continue
+		}
+		if t.Rejected > t.Submitted {
+			me.Append(fieldErr(CodeHealthCountsInconsistent, path+".rejected", path+".rejected must not exceed submitted"))
+			continue
+		}
+		var want float64
+		if t.Submitted > 0 {
+			want = float64(t.Rejected) / float64(t.Submitted)
+		}
+		if eps := opts.sumEpsilon(); eps >= 0 && math.Abs(float64(t.FailureRate)-want) > eps {
+			me.Append(fieldErr(CodeHealthCountsInconsistent, path+".failure_rate",
+				fmt.Sprintf("%s.failure_rate must be rejected/submitted (±%g), got %g want %g", path, eps, float64(t.FailureRate), want)))
+		}
+	}
+
+	return opts.finish(me.NilOrError())
+}
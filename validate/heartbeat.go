@@ -0,0 +1,44 @@
+package validate
+
+import (
+	"fmt"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// ValidateHeartbeat validates a live stream keepalive. interval must lie
+// within types.HeartbeatMinInterval and types.HeartbeatMaxInterval, and
+// last_finalized must not be after sent_at.
+func ValidateHeartbeat(h *types.Heartbeat, opts Options) error {
+	if h == nil {
+		return ErrNilInput
+	}
+	var me MultiError
+
+	if h.Publisher == "" {
+		me.Append(fieldErr(CodeRequired, "publisher", "publisher must be non-empty"))
+	}
+	switch {
+	case h.SentAt.IsZero():
+		me.Append(fieldErr(CodeRequired, "sent_at", "sent_at must be set"))
+	case !types.IsUTC(h.SentAt):
+		me.Append(fieldErr(CodeTimeNotUTC, "sent_at", "sent_at must be in UTC"))
+	}
+	if !h.LastFinalized.IsZero() {
+		if !types.IsUTC(h.LastFinalized) {
+			me.Append(fieldErr(CodeTimeNotUTC, "last_finalized", "last_finalized must be in UTC"))
+		}
+		if !h.SentAt.IsZero() && h.LastFinalized.After(h.SentAt) {
+			me.Append(fieldErr(CodeTimeRange, "last_finalized", "last_finalized must not be after sent_at"))
+		}
+	}
+	if h.Lag < 0 {
+		me.Append(fieldErr(CodeDurationInvalid, "lag", "lag must be ≥0"))
+	}
+	if iv := h.Interval; iv < types.ISODuration(types.HeartbeatMinInterval) || iv > types.ISODuration(types.HeartbeatMaxInterval) {
+		me.Append(fieldErr(CodeHeartbeatInterval, "interval", fmt.Sprintf("interval must be between %s and %s",
+			types.FormatISODuration(types.HeartbeatMinInterval), types.FormatISODuration(types.HeartbeatMaxInterval))))
+	}
+
+	return opts.finish(me.NilOrError())
+}
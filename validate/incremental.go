@@ -0,0 +1,123 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// checkpointVersion is bumped whenever the checkpoint layout changes.
+const checkpointVersion = 1
+
+// ErrCheckpoint is returned by Resume for checkpoints it cannot restore.
+var ErrCheckpoint = errors.New("validate: invalid checkpoint")
+
+// SeriesValidator validates a Series one point at a time, for ingestion
+// jobs that never hold the whole series in memory. Feeding every point of s
+// to NewSeriesValidator(s, opts) and calling Close reports the same errors
+// as ValidateSeriesWith(s, opts).
+//
+// Its state can be saved with Checkpoint and restored with Resume, so a job
+// restarting after a crash continues from the last checkpointed point
+// instead of revalidating from the beginning. It is not safe for
+// concurrent use.
+type SeriesValidator struct {
+	opts   Options
+	header types.Series // series-level fields; Points is always nil
+	n      int          // points validated so far
+	errs   []*FieldError
+}
+
+// NewSeriesValidator returns a validator for a series with the header
+// fields of s; any Points in s are ignored. A nil s is allowed when the
+// state will be restored with Resume.
+func NewSeriesValidator(s *types.Series, opts Options) *SeriesValidator {
+	v := &SeriesValidator{opts: opts}
+	if s != nil {
+		v.header = *s
+		v.header.Points = nil
+	}
+	return v
+}
+
+// Add validates the next point and returns its errors, if any. They are
+// also retained for Close.
+func (v *SeriesValidator) Add(p types.Point) error {
+	var me MultiError
+	validatePoint(&me, v.n, p, v.header.GeneratedAt, v.opts.ForSeries(&v.header))
+	v.n++
+	for _, err := range me.Errors() {
+		v.errs = append(v.errs, err.(*FieldError))
+	}
+	return AttachSource(me.NilOrError(), v.opts.Source)
+}
+
+// Len reports how many points have been validated.
+func (v *SeriesValidator) Len() int { return v.n }
+
+// Close returns every error for the series: header fields, all points seen,
+// and annotations and extensions. The validator may be used again after
+// Close; it does not reset.
+func (v *SeriesValidator) Close() error {
+	var me MultiError
+	h := headerOf(&v.header)
+	validateSeriesHead(&me, h, v.n > 0, false)
+	for _, err := range v.errs {
+		me.Append(err)
+	}
+	validateSeriesTail(&me, h, v.opts.ForSeries(&v.header))
+	return v.opts.finish(me.NilOrError())
+}
+
+// checkpoint is the serialized form of a SeriesValidator. Options are not
+// included, since registries and scorers cannot be serialized.
+type checkpoint struct {
+	Version int            `json:"version"`
+	Header  types.Series   `json:"header"`
+	Points  int            `json:"points"`
+	Errors  []checkpointFE `json:"errors,omitempty"`
+}
+
+type checkpointFE struct {
+	Field string    `json:"field"`
+	Code  ErrorCode `json:"code"`
+	Msg   string    `json:"msg"`
+}
+
+// Checkpoint returns v's state as JSON.
+func (v *SeriesValidator) Checkpoint() []byte {
+	cp := checkpoint{Version: checkpointVersion, Header: v.header, Points: v.n}
+	for _, e := range v.errs {
+		cp.Errors = append(cp.Errors, checkpointFE{e.Field, e.Code, e.Msg})
+	}
+	b, err := json.Marshal(cp)
+	if err != nil {
+		// Only unencodable extension values can fail, and the header was
+		// decoded from JSON in any realistic pipeline.
+		panic(fmt.Sprintf("validate: checkpoint: %v", err))
+	}
+	return b
+}
+
+// Resume replaces v's header and progress with a state saved by
+// Checkpoint. v's Options are kept and should match those in effect when
+// the checkpoint was taken.
+func (v *SeriesValidator) Resume(b []byte) error {
+	var cp checkpoint
+	if err := json.Unmarshal(b, &cp); err != nil {
+		return fmt.Errorf("%w: %v", ErrCheckpoint, err)
+	}
+	if cp.Version != checkpointVersion {
+		return fmt.Errorf("%w: version %d, want %d", ErrCheckpoint, cp.Version, checkpointVersion)
+	}
+	if cp.Points < 0 || len(cp.Header.Points) > 0 {
+		return fmt.Errorf("%w: malformed progress", ErrCheckpoint)
+	}
+	v.header, v.n, v.errs = cp.Header, cp.Points, nil
+	for _, e := range cp.Errors {
+		v.errs = append(v.errs, &FieldError{Field: e.Field, Code: e.Code, Msg: e.Msg})
+	}
+	return nil
+}
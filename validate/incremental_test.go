@@ -0,0 +1,69 @@
+package validate
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestSeriesValidatorMatchesValidateSeries(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &types.Series{
+		Topic:       "#vote",
+		GeneratedAt: t0.Add(10 * time.Minute),
+		Interval:    types.IntervalMinute,
+		Tenant:      "Bad Tenant",
+		Annotations: []types.Annotation{{Kind: "bogus"}},
+	}
+	for i := 0; i < 6; i++ {
+		p := types.Point{TS: t0.Add(time.Duration(i) * time.Minute), Volume: 10}
+		switch i {
+		case 1:
+			p.Volume = -1
+		case 3:
+			p.AcctAgeMix = map[string]types.Probability{"0-7d": 0.5}
+		case 5:
+			p.Backfilled = true
+		}
+		s.Points = append(s.Points, p)
+	}
+	want := ValidateSeries(s).Error()
+
+	v := NewSeriesValidator(s, DefaultOptions())
+	for _, p := range s.Points[:3] {
+		v.Add(p)
+	}
+	cp := v.Checkpoint()
+
+	r := NewSeriesValidator(nil, DefaultOptions())
+	if err := r.Resume(cp); err != nil {
+		t.Fatal(err)
+	}
+	if r.Len() != 3 {
+		t.Fatalf("resumed Len = %d, want 3", r.Len())
+	}
+	for _, p := range s.Points[3:] {
+		r.Add(p)
+	}
+	if got := r.Close(); got == nil || got.Error() != want {
+		t.Errorf("resumed Close =\n%v\nwant\n%s", got, want)
+	}
+}
+
+func TestSeriesValidatorEmpty(t *testing.T) {
+	v := NewSeriesValidator(&types.Series{Topic: "#t", GeneratedAt: time.Now(), Interval: types.IntervalMinute}, Options{})
+	var fe *FieldError
+	if err := v.Close(); !errors.As(err, &fe) || fe.Code != CodePointsEmpty {
+		t.Errorf("Close with no points = %v, want %s", err, CodePointsEmpty)
+	}
+}
+
+func TestResumeRejectsBadCheckpoints(t *testing.T) {
+	for _, b := range []string{``, `{"version":99}`, `{"version":1,"points":-1}`} {
+		if err := NewSeriesValidator(nil, Options{}).Resume([]byte(b)); !errors.Is(err, ErrCheckpoint) {
+			t.Errorf("Resume(%q) = %v, want ErrCheckpoint", b, err)
+		}
+	}
+}
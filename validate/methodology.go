@@ -0,0 +1,47 @@
+package validate
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// validateMethodology checks the ranges of a series' declared methodology.
+func validateMethodology(me *MultiError, m *types.Methodology) {
+	if m == nil {
+		return
+	}
+	if m.SamplingRate < 0 || m.SamplingRate > 1 {
+		me.Append(fieldErr(CodeRatioRange, "methodology.sampling_rate", "methodology.sampling_rate must be 0–1"))
+	}
+	if m.SuppressionThreshold < 0 {
+		me.Append(fieldErr(CodeCountNegative, "methodology.suppression_threshold", "methodology.suppression_threshold must be ≥0"))
+	}
+	if m.VolumeRounding < 0 {
+		me.Append(fieldErr(CodeCountNegative, "methodology.volume_rounding", "methodology.volume_rounding must be ≥0"))
+	}
+	if m.NoiseEpsilon < 0 || math.IsNaN(m.NoiseEpsilon) || math.IsInf(m.NoiseEpsilon, 0) {
+		me.Append(fieldErr(CodeMethodologyInvalid, "methodology.noise_epsilon", "methodology.noise_epsilon must be a finite number ≥0"))
+	}
+	if m.CollectionLag < 0 {
+		me.Append(fieldErr(CodeDurationInvalid, "methodology.collection_lag", "methodology.collection_lag must be ≥0"))
+	}
+	for i, c := range m.Caveats {
+		if c == "" {
+			path := fmt.Sprintf("methodology.caveats[%d]", i)
+			me.Append(fieldErr(CodeRequired, path, path+" must be non-empty"))
+		}
+	}
+}
+
+// ForSeries returns the options ValidateSeriesWith applies to s: its
+// topic's, with the suppression floor raised to the threshold s declares in
+// its methodology, so a series cannot claim suppression it did not apply.
+func (o Options) ForSeries(s *types.Series) Options {
+	o = o.ForTopic(s.Topic)
+	if m := s.Methodology; m != nil && m.SuppressionThreshold > o.MinVolume {
+		o.MinVolume = m.SuppressionThreshold
+	}
+	return o
+}
@@ -0,0 +1,42 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestNilInputs(t *testing.T) {
+	_, checkTag := CheckProvenanceTag(nil)
+	_, checkSeries := CheckSeries(nil, DefaultOptions())
+	_, conformance := SeriesConformance(nil, DefaultOptions())
+	for name, err := range map[string]error{
+		"ValidateProvenanceTag":  ValidateProvenanceTag(nil),
+		"ValidateSeries":         ValidateSeries(nil),
+		"ValidateSeriesBundle":   ValidateSeriesBundle(nil),
+		"ValidateRetraction":     ValidateRetraction(nil),
+		"ValidatePublisherQuota": ValidatePublisherQuota(nil),
+		"ValidateHeartbeat":      ValidateHeartbeat(nil, DefaultOptions()),
+		"ValidateDiscovery":      ValidateDiscovery(nil),
+		"CheckProvenanceTag":     checkTag,
+		"CheckSeries":            checkSeries,
+		"SeriesConformance":      conformance,
+	} {
+		if !errors.Is(err, ErrNilInput) {
+			t.Errorf("%s(nil) = %v, want ErrNilInput", name, err)
+		}
+	}
+}
+
+func TestToProblemNilMultiError(t *testing.T) {
+	var me *MultiError
+	if p := ToProblem(me); p != nil {
+		t.Errorf("ToProblem(nil *MultiError) = %+v, want nil", p)
+	}
+	if p := ToProblem(fmt.Errorf("bundle: %w", me)); p == nil || len(p.Errors) != 1 {
+		t.Errorf("ToProblem(wrapped nil *MultiError) = %+v, want one entry", p)
+	}
+	if b, err := me.MarshalJSON(); err != nil || string(b) != "null" {
+		t.Errorf("MarshalJSON = %s, %v", b, err)
+	}
+}
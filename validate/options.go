@@ -0,0 +1,190 @@
+package validate
+
+import (
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/privacy"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// Options tunes rules that have no single correct value across deployments.
+// The zero value is valid; DefaultOptions documents the package defaults.
+type Options struct {
+	// BackfillMinAge is how far a backfilled point's ts must precede the
+	// series generated_at. Zero only requires it not to be in the future.
+	BackfillMinAge time.Duration
+
+	// SumEpsilon is the tolerance when checking that a breakdown's fractions
+	// sum to 1. Zero selects DefaultSumEpsilon; negative disables the check.
+	SumEpsilon float64
+
+	// ReshareTolerance is the largest allowed difference between a point's
+	// reshare_ratio and the reshare share of its post_kind_mix. Zero
+	// selects DefaultReshareTolerance; negative disables the check.
+	ReshareTolerance float64
+
+	// Extensions, if set, validates extension values against registered
+	// checks. Extension keys are always required to be x-<platform>-<name>.
+	Extensions *ExtensionRegistry
+
+	// OriginRisk, if set, rejects subdivision-level origin hints on tags
+	// whose combined fields score above the policy maximum.
+	OriginRisk *OriginRiskPolicy
+
+	// MinVolume, if positive, is the suppression floor: points with a
+	// volume between 1 and MinVolume-1 must have been suppressed (dropped
+	// or zeroed) before publishing. A series declaring a higher
+	// methodology.suppression_threshold is held to that instead.
+	MinVolume int
+
+	// TopicOverrides adjusts these options for series of the listed
+	// topics, e.g. stricter privacy floors on politically sensitive
+	// topics. The set fields of an override replace those of the enclosing
+	// Options; unset fields keep the enclosing values, so an override never
+	// drops a deployment-wide registry, risk policy, or feature flag. Set
+	// it with WithTopicOverrides.
+	TopicOverrides map[string]Options
+
+	// Features enables experimental enum values: a value registered with
+	// types.RegisterAcctType and friends is accepted when its flag is
+	// true here. Unregistered values are rejected regardless.
+	Features map[string]bool
+
+	// Source is attached to every FieldError the validator returns (see
+	// WithContext to take it from a context).
+	Source Source
+
+	// Observe, if set, is called once per validated document with Source
+	// and the result (nil if valid), e.g. to count rejections per
+	// publisher.
+	Observe func(src Source, err error)
+}
+
+// WithTopicOverrides returns a copy of o that validates series of each
+// topic in overrides with o adjusted by that topic's Options (see
+// ForTopic). Overrides do not nest: TopicOverrides inside an override are
+// ignored.
+func (o Options) WithTopicOverrides(overrides map[string]Options) Options {
+	m := make(map[string]Options, len(overrides))
+	for topic, ov := range overrides {
+		ov.TopicOverrides = nil
+		m[topic] = ov
+	}
+	o.TopicOverrides = m
+	return o
+}
+
+// ForTopic returns the options that apply to series of topic: o with the
+// non-zero fields of topic's override, if there is one, replacing o's.
+// Features are merged key by key, the override winning. Source and
+// Observe describe the payload rather than policy, so they are always o's.
+// Use ForTopic to validate provenance tags, which carry no topic, under
+// their topic's policy.
+func (o Options) ForTopic(topic string) Options {
+	ov, ok := o.TopicOverrides[topic]
+	if !ok {
+		return o
+	}
+	out := o.merge(ov)
+	out.TopicOverrides = nil
+	return out
+}
+
+// merge returns o with the non-zero policy fields of ov replacing o's and
+// Features merged key by key, ov winning. TopicOverrides, Source, and
+// Observe are always o's.
+func (o Options) merge(ov Options) Options {
+	out := o
+	if ov.BackfillMinAge != 0 {
+		out.BackfillMinAge = ov.BackfillMinAge
+	}
+	if ov.SumEpsilon != 0 {
+		out.SumEpsilon = ov.SumEpsilon
+	}
+	if ov.ReshareTolerance != 0 {
+		out.ReshareTolerance = ov.ReshareTolerance
+	}
+	if ov.Extensions != nil {
+		out.Extensions = ov.Extensions
+	}
+	if ov.OriginRisk != nil {
+		out.OriginRisk = ov.OriginRisk
+	}
+	if ov.MinVolume != 0 {
+		out.MinVolume = ov.MinVolume
+	}
+	if len(ov.Features) > 0 {
+		out.Features = make(map[string]bool, len(o.Features)+len(ov.Features))
+		for k, v := range o.Features {
+			out.Features[k] = v
+		}
+		for k, v := range ov.Features {
+			out.Features[k] = v
+		}
+	}
+	return out
+}
+
+// EnumAccepted reports whether validation under o accepts v for the enum
+// field (a schema field name such as "acct_type"): v is a schema value, or
+// an experimental value whose feature flag o.Features enables.
+func (o Options) EnumAccepted(field, v string) bool {
+	var schema bool
+	switch field {
+	case "acct_age_bucket":
+		schema = types.AcctAge(v).Valid()
+	case "acct_type":
+		schema = types.AcctType(v).Valid()
+	case "automation_flag":
+		schema = types.AutomationFlag(v).Valid()
+	case "post_kind":
+		schema = types.PostKind(v).Valid()
+	case "client_family":
+		schema = types.ClientFamily(v).Valid()
+	case "media_provenance":
+		schema = types.MediaProvenance(v).Valid()
+	}
+	if schema {
+		return true
+	}
+	e, ok := types.Experimental(field, v)
+	return ok && o.Features[e.Flag]
+}
+
+// OriginRiskPolicy is a re-identification guardrail for origin_hint. Use
+// privacy.CoarsenOriginHint with the same scorer and maximum to repair tags
+// before publishing rather than rejecting them.
+type OriginRiskPolicy struct {
+	// Scorer rates a tag's re-identification risk. Nil uses
+	// privacy.DefaultRiskScorer.
+	Scorer privacy.RiskScorer
+	// Max is the highest score at which a subdivision is still allowed.
+	Max float64
+}
+
+// DefaultSumEpsilon absorbs rounding in published breakdowns (e.g., shares
+// rounded to 3 decimals across a handful of buckets).
+const DefaultSumEpsilon = 1e-3
+
+func (o Options) sumEpsilon() float64 {
+	if o.SumEpsilon == 0 {
+		return DefaultSumEpsilon
+	}
+	return o.SumEpsilon
+}
+
+// DefaultReshareTolerance allows for reshare_ratio and post_kind_mix being
+// rounded independently by the publisher.
+const DefaultReshareTolerance = 0.01
+
+func (o Options) reshareTolerance() float64 {
+	if o.ReshareTolerance == 0 {
+		return DefaultReshareTolerance
+	}
+	return o.ReshareTolerance
+}
+
+// DefaultOptions returns the options used by ValidateSeries.
+func DefaultOptions() Options {
+	return Options{}
+}
@@ -0,0 +1,92 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ProblemContentType is the RFC 7807 media type for Problem bodies.
+const ProblemContentType = "application/problem+json"
+
+// ProblemTypeValidation identifies validation failures in Problem.Type.
+const ProblemTypeValidation = "urn:civic-transparency:problem:validation"
+
+// Problem is an RFC 7807 problem details body with a validation extension
+// member, errors, listing one entry per failed field.
+type Problem struct {
+	Type     string         `json:"type"`
+	Title    string         `json:"title"`
+	Status   int            `json:"status,omitempty"`
+	Detail   string         `json:"detail,omitempty"`
+	Instance string         `json:"instance,omitempty"`
+	Errors   []FieldProblem `json:"errors,omitempty"`
+	// Source echoes the payload metadata the errors carry, if any.
+	Source *Source `json:"source,omitempty"`
+}
+
+// FieldProblem describes one failed field. Pointer is the RFC 6901 JSON
+// Pointer of the field within the validated document, when known.
+type FieldProblem struct {
+	Field   string    `json:"field,omitempty"`
+	Code    ErrorCode `json:"code,omitempty"`
+	Pointer string    `json:"pointer,omitempty"`
+	Detail  string    `json:"detail"`
+}
+
+// ToProblem converts an error returned by this package into a Problem with
+// status 422. It returns nil for a nil error, including a nil *MultiError.
+// Errors that are not a *MultiError become a single entry.
+func ToProblem(err error) *Problem {
+	if me, ok := err.(*MultiError); err == nil || ok && me == nil {
+		return nil
+	}
+	p := &Problem{
+		Type:   ProblemTypeValidation,
+		Title:  "Validation failed",
+		Status: http.StatusUnprocessableEntity,
+	}
+	var me *MultiError
+	if errors.As(err, &me) && me != nil {
+		for _, e := range me.errs {
+			p.Errors = append(p.Errors, fieldProblem(e))
+		}
+	} else {
+		p.Errors = append(p.Errors, fieldProblem(err))
+	}
+	if src, ok := SourceOf(err); ok {
+		p.Source = &src
+	}
+	if n := len(p.Errors); n == 1 {
+		p.Detail = "1 field failed validation"
+	} else {
+		p.Detail = strconv.Itoa(n) + " fields failed validation"
+	}
+	return p
+}
+
+// MarshalJSON encodes m as an RFC 7807 problem details object.
+func (m *MultiError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ToProblem(m))
+}
+
+func fieldProblem(err error) FieldProblem {
+	var fe *FieldError
+	if errors.As(err, &fe) {
+		return FieldProblem{Field: fe.Field, Code: fe.Code, Pointer: jsonPointer(fe.Field), Detail: fe.Msg}
+	}
+	return FieldProblem{Code: CodeOf(err), Detail: err.Error()}
+}
+
+// jsonPointer converts "points[3].coordination_signals.burst_score" into
+// "/points/3/coordination_signals/burst_score".
+func jsonPointer(field string) string {
+	if field == "" {
+		return ""
+	}
+	r := strings.NewReplacer("[", ".", "]", "", "~", "~0", "/", "~1")
+	parts := strings.Split(r.Replace(field), ".")
+	return "/" + strings.Join(parts, "/")
+}
@@ -0,0 +1,116 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// Source describes where a validated payload came from, so errors,
+// metrics, and audit entries can be traced back to it without threading
+// the metadata through every call by hand.
+type Source struct {
+	Publisher  string    `json:"publisher,omitempty"`   // publisher or tenant ID
+	Batch      string    `json:"batch,omitempty"`       // upstream batch or submission ID
+	ReceivedAt time.Time `json:"received_at,omitempty"` // when the payload arrived
+}
+
+// IsZero reports whether s carries no metadata.
+func (s Source) IsZero() bool { return s == Source{} }
+
+type sourceKey struct{}
+
+// WithSource returns a copy of ctx carrying src, for the *Context
+// validators and for handlers further down a request.
+func WithSource(ctx context.Context, src Source) context.Context {
+	return context.WithValue(ctx, sourceKey{}, src)
+}
+
+// SourceFromContext returns the Source stored by WithSource.
+func SourceFromContext(ctx context.Context) (Source, bool) {
+	src, ok := ctx.Value(sourceKey{}).(Source)
+	return src, ok
+}
+
+// WithContext returns a copy of o whose Source is taken from ctx, unless
+// o already has one.
+func (o Options) WithContext(ctx context.Context) Options {
+	if src, ok := SourceFromContext(ctx); ok && o.Source.IsZero() {
+		o.Source = src
+	}
+	return o
+}
+
+// ValidateSeriesContext is ValidateSeriesWith with the Source in ctx.
+func ValidateSeriesContext(ctx context.Context, s *types.Series, opts Options) error {
+	return ValidateSeriesWith(s, opts.WithContext(ctx))
+}
+
+// ValidateProvenanceTagContext is ValidateProvenanceTagWith with the
+// Source in ctx.
+func ValidateProvenanceTagContext(ctx context.Context, t *types.ProvenanceTag, opts Options) error {
+	return ValidateProvenanceTagWith(t, opts.WithContext(ctx))
+}
+
+// AttachSource sets src on every *FieldError in err, including those of
+// nested and wrapped errors, and returns err. It is a no-op for a zero src.
+func AttachSource(err error, src Source) error {
+	if err == nil || src.IsZero() {
+		return err
+	}
+	walkFieldErrors(err, func(fe *FieldError) {
+		s := src
+		fe.Source = &s
+	})
+	return err
+}
+
+// SourceOf returns the Source attached to the first *FieldError in err.
+func SourceOf(err error) (Source, bool) {
+	var src *Source
+	if err != nil {
+		walkFieldErrors(err, func(fe *FieldError) {
+			if src == nil && fe.Source != nil {
+				src = fe.Source
+			}
+		})
+	}
+	if src == nil {
+		return Source{}, false
+	}
+	return *src, true
+}
+
+func walkFieldErrors(err error, fn func(*FieldError)) {
+	switch e := err.(type) {
+	case *FieldError:
+		fn(e)
+	case *MultiError:
+		if e == nil {
+			return
+		}
+		for _, c := range e.errs {
+			walkFieldErrors(c, fn)
+		}
+	case interface{ Unwrap() []error }:
+		for _, c := range e.Unwrap() {
+			walkFieldErrors(c, fn)
+		}
+	default:
+		if c := errors.Unwrap(err); c != nil {
+			walkFieldErrors(c, fn)
+		}
+	}
+}
+
+// finish attaches opts.Source to err and reports the result to
+// opts.Observe. Every *With validator returns through it.
+func (o Options) finish(err error) error {
+	err = AttachSource(err, o.Source)
+	if o.Observe != nil {
+		o.Observe(o.Source, err)
+	}
+	return err
+}
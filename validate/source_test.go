@@ -0,0 +1,47 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+func TestSourceFromContext(t *testing.T) {
+	src := Source{Publisher: "p1", Batch: "b-42", ReceivedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	ctx := WithSource(context.Background(), src)
+	var observed []Source
+	opts := Options{
+		Observe: func(s Source, err error) { observed = append(observed, s) },
+	}.WithTopicOverrides(map[string]Options{"#strict": {MinVolume: 10}})
+
+	err := ValidateSeriesContext(ctx, &types.Series{Topic: "#strict", Interval: types.IntervalMinute}, opts)
+	var me *MultiError
+	if !errors.As(err, &me) {
+		t.Fatalf("err = %v", err)
+	}
+	for _, e := range me.Errors() {
+		if fe := e.(*FieldError); fe.Source == nil || *fe.Source != src {
+			t.Errorf("%s: Source = %v", fe.Field, fe.Source)
+		}
+	}
+	if len(observed) != 1 || observed[0] != src {
+		t.Errorf("observed = %v", observed)
+	}
+	if p := ToProblem(fmt.Errorf("series[0]: %w", err)); p.Source == nil || *p.Source != src {
+		t.Errorf("problem source = %v", p.Source)
+	}
+
+	explicit := Source{Publisher: "p2"}
+	opts.Source = explicit
+	err = ValidateProvenanceTagContext(ctx, &types.ProvenanceTag{}, opts)
+	if got, ok := SourceOf(err); !ok || got != explicit {
+		t.Errorf("SourceOf = %v, %v; want explicit Options.Source to win", got, ok)
+	}
+	if _, ok := SourceOf(ValidateSeries(&types.Series{})); ok {
+		t.Error("source attached without one configured")
+	}
+}
@@ -0,0 +1,114 @@
+package validate
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// TenantPolicy is the validation policy of one tenant.
+type TenantPolicy struct {
+	// Jurisdictions lists the jurisdiction codes the tenant may publish
+	// under. Empty allows any, including none.
+	Jurisdictions []string
+	// Options adjusts the registry's Options for the tenant's series: its
+	// non-zero fields replace the registry's and its topic overrides, if
+	// any, replace the registry's, so the zero value inherits every rule.
+	Options Options
+}
+
+// TenantRegistry maps tenant namespaces to policies for deployments that
+// host series for several authorities. The zero value is an empty registry
+// ready for use; it is safe for concurrent use.
+type TenantRegistry struct {
+	// Default, if set, is used for series without a tenant. Otherwise such
+	// series are rejected.
+	Default *TenantPolicy
+	// Options are the deployment-wide rules every tenant starts from.
+	Options Options
+
+	mu       sync.RWMutex
+	policies map[string]TenantPolicy
+}
+
+// Register installs p for tenant, replacing any previous policy. It panics
+// if tenant is not a valid namespace (see types.ValidTenant).
+func (r *TenantRegistry) Register(tenant string, p TenantPolicy) {
+	if !types.ValidTenant(tenant) {
+		panic(fmt.Sprintf("validate: invalid tenant %q", tenant))
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.policies == nil {
+		r.policies = map[string]TenantPolicy{}
+	}
+	r.policies[tenant] = p
+}
+
+// Lookup returns the policy for tenant. The empty tenant resolves to
+// Default.
+func (r *TenantRegistry) Lookup(tenant string) (TenantPolicy, bool) {
+	if tenant == "" {
+		if r.Default == nil {
+			return TenantPolicy{}, false
+		}
+		return *r.Default, true
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	p, ok := r.policies[tenant]
+	return p, ok
+}
+
+// ValidateSeries validates s under its tenant's policy: the tenant must be
+// registered, the jurisdiction must be one the tenant allows, and s must
+// pass ValidateSeriesWith using the registry's Options adjusted by the
+// tenant's (see OptionsFor).
+func (r *TenantRegistry) ValidateSeries(s *types.Series) error {
+	if s == nil {
+		return ErrNilInput
+	}
+	p, ok := r.Lookup(s.Tenant)
+	if !ok {
+		if s.Tenant == "" {
+			return fieldErr(CodeRequired, "tenant", "tenant must be set")
+		}
+		return fieldErr(CodeTenantUnknown, "tenant", fmt.Sprintf("tenant %q is not registered", s.Tenant))
+	}
+	var me MultiError
+	if err := ValidateSeriesWith(s, r.optionsFor(p)); err != nil {
+		me.Append(err)
+	}
+	if len(p.Jurisdictions) > 0 && !containsString(p.Jurisdictions, s.Jurisdiction) {
+		me.Append(fieldErr(CodeJurisdictionNotPermitted, "jurisdiction", fmt.Sprintf("jurisdiction %q is not permitted for tenant %q", s.Jurisdiction, s.Tenant)))
+	}
+	return me.NilOrError()
+}
+
+// OptionsFor returns the options tenant's series are validated with, and
+// whether the tenant resolves to a policy (see Lookup).
+func (r *TenantRegistry) OptionsFor(tenant string) (Options, bool) {
+	p, ok := r.Lookup(tenant)
+	if !ok {
+		return Options{}, false
+	}
+	return r.optionsFor(p), true
+}
+
+func (r *TenantRegistry) optionsFor(p TenantPolicy) Options {
+	out := r.Options.merge(p.Options)
+	if p.Options.TopicOverrides != nil {
+		out.TopicOverrides = p.Options.TopicOverrides
+	}
+	return out
+}
+
+func containsString(vs []string, s string) bool {
+	for _, v := range vs {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -3,11 +3,19 @@ package validate
 import (
 	"errors"
 	"fmt"
+	"math"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/civic-interconnect/civic-transparency-go-types/privacy"
 	"github.com/civic-interconnect/civic-transparency-go-types/types"
 )
 
+// ErrNilInput is returned by validators given a nil pointer, so ingestion
+// workers fed malformed upstream batches fail the item instead of panicking.
+var ErrNilInput = errors.New("validate: nil input")
+
 // MultiError is a tiny, allocation-light aggregator.
 // Safe for concurrent use as long as each goroutine uses its own instance
 type MultiError struct{ errs []error }
@@ -23,13 +31,19 @@ func MustSeries(s *types.Series) {
 	}
 }
 
+// Append adds err, if non-nil. The errors of a *MultiError are added
+// individually, so nested validators produce one flat list.
 func (m *MultiError) Append(err error) {
+	if nested, ok := err.(*MultiError); ok {
+		m.errs = append(m.errs, nested.errs...)
+		return
+	}
 	if err != nil {
 		m.errs = append(m.errs, err)
 	}
 }
 func (m *MultiError) Error() string {
-	if len(m.errs) == 0 {
+	if m == nil || len(m.errs) == 0 {
 		return ""
 	}
 	var b strings.Builder
@@ -44,108 +58,456 @@ func (m *MultiError) Error() string {
 
 // Unwrap lets callers use errors.Is/As; Go 1.20+ errors.Join is efficient.
 func (m *MultiError) Unwrap() error {
-	if len(m.errs) == 0 {
+	if m == nil || len(m.errs) == 0 {
 		return nil
 	}
 	return errors.Join(m.errs...)
 }
 
+// Errors returns the collected errors in the order they were appended.
+func (m *MultiError) Errors() []error {
+	if m == nil {
+		return nil
+	}
+	return append([]error(nil), m.errs...)
+}
+
 // NilOrError returns nil if empty, otherwise m.
 func (m *MultiError) NilOrError() error {
-	if len(m.errs) == 0 {
+	if m == nil || len(m.errs) == 0 {
 		return nil
 	}
 	return m
 }
 
 // ValidateProvenanceTag validates a single ProvenanceTag instance.
+// Experimental enum values are rejected; see ValidateProvenanceTagWith.
 func ValidateProvenanceTag(t *types.ProvenanceTag) error {
+	if t == nil {
+		return ErrNilInput
+	}
 	var me MultiError
+	validateTag(&me, t, Options{})
+	return me.NilOrError()
+}
 
-	switch t.AcctAgeBucket {
-	case types.AcctAge_0_7d, types.AcctAge_8_30d, types.AcctAge_1_6m, types.AcctAge_6_24m, types.AcctAge_24mPlus:
-	default:
-		me.Append(errors.New("invalid acct_age_bucket"))
+// validateTag checks t's fields, accepting experimental enum values
+// enabled in opts.Features.
+func validateTag(me *MultiError, t *types.ProvenanceTag, opts Options) {
+	for _, f := range [...]struct {
+		code  ErrorCode
+		field string
+		value string
+	}{
+		{CodeAcctAgeInvalid, "acct_age_bucket", string(t.AcctAgeBucket)},
+		{CodeAcctTypeInvalid, "acct_type", string(t.AcctType)},
+		{CodeAutomationFlagInvalid, "automation_flag", string(t.AutomationFlag)},
+		{CodePostKindInvalid, "post_kind", string(t.PostKind)},
+		{CodeClientFamilyInvalid, "client_family", string(t.ClientFamily)},
+		{CodeMediaProvenanceInvalid, "media_provenance", string(t.MediaProvenance)},
+	} {
+		if !opts.EnumAccepted(f.field, f.value) {
+			me.Append(fieldErr(f.code, f.field, enumMsg(f.field, f.value)))
+		}
 	}
-	switch t.AcctType {
-	case types.AcctTypePerson, types.AcctTypeOrg, types.AcctTypeMedia,
-		types.AcctTypePublicOfficial, types.AcctTypeUnverified, types.AcctTypeDeclaredAutomation:
-	default:
-		me.Append(errors.New("invalid acct_type"))
+
+	switch {
+	case !t.DedupHashAlg.Valid():
+		me.Append(fieldErr(CodeDedupHashAlgInvalid, "dedup_hash_alg", "invalid dedup_hash_alg"))
+	case !t.DedupHashAlg.MatchesShape(t.DedupHash):
+		me.Append(fieldErr(CodeDedupHashShape, "dedup_hash", fmt.Sprintf("dedup_hash must be %d lowercase hex chars", t.DedupHashAlg.HexLen())))
 	}
-	switch t.AutomationFlag {
-	case types.AutomationManual, types.AutomationScheduled, types.AutomationAPICLIENT, types.AutomationDeclaredBot:
-	default:
-		me.Append(errors.New("invalid automation_flag"))
+	if err := validateISO3166MaybeEmpty(t.OriginHint); err != nil {
+		me.Append(err)
 	}
-	switch t.PostKind {
-	case types.PostKindOriginal, types.PostKindReshare, types.PostKindQuote, types.PostKindReply:
-	default:
-		me.Append(errors.New("invalid post_kind"))
+	validateExtensionKeys(me, t.Extensions)
+}
+
+// enumMsg describes a rejected enum value, naming the feature flag when
+// the value is a registered experimental one.
+func enumMsg(field, v string) string {
+	if e, ok := types.Experimental(field, v); ok {
+		return fmt.Sprintf("%s %q is experimental; enable feature %s", field, v, e.Flag)
 	}
-	switch t.ClientFamily {
-	case types.ClientWeb, types.ClientMobile, types.ClientThirdParty:
-	default:
-		me.Append(errors.New("invalid client_family"))
+	return "invalid " + field
+}
+
+// ValidateProvenanceTagWith is ValidateProvenanceTag with extensions checked
+// against opts.Extensions, origin_hint checked against opts.OriginRisk, and
+// experimental enum values accepted per opts.Features.
+func ValidateProvenanceTagWith(t *types.ProvenanceTag, opts Options) error {
+	if t == nil {
+		return ErrNilInput
 	}
-	switch t.MediaProvenance {
-	case types.MediaProvC2PA, types.MediaProvHash, types.MediaProvNone:
-	default:
-		me.Append(errors.New("invalid media_provenance"))
+	var me MultiError
+	validateTag(&me, t, opts)
+	validateExtensionValues(&me, t.Extensions, opts.Extensions)
+	if r := opts.OriginRisk; r != nil && privacy.HasSubdivision(t.OriginHint) {
+		scorer := r.Scorer
+		if scorer == nil {
+			scorer = privacy.DefaultRiskScorer()
+		}
+		if score := scorer.Score(t); score > r.Max {
+			me.Append(fieldErr(CodeOriginHintRisk, "origin_hint", fmt.Sprintf(
+				"origin_hint subdivision not permitted at re-identification risk %.2f (max %.2f); use country only", score, r.Max)))
+		}
 	}
+	return opts.finish(me.NilOrError())
+}
 
-	if !types.ReHex8.MatchString(string(t.DedupHash)) {
-		me.Append(errors.New("dedup_hash must be 8 lowercase hex chars"))
+// ValidateSeries validates a Series instance and all nested Points.
+func ValidateSeries(s *types.Series) error {
+	return ValidateSeriesWith(s, DefaultOptions())
+}
+
+// ValidateSeriesWith is ValidateSeries with caller-supplied Options.
+func ValidateSeriesWith(s *types.Series, opts Options) error {
+	if s == nil {
+		return ErrNilInput
 	}
-	if err := validateISO3166MaybeEmpty(t.OriginHint); err != nil {
-		me.Append(err)
+	opts = opts.ForSeries(s)
+	h := headerOf(s)
+	var me MultiError
+	validateSeriesHead(&me, h, len(s.Points) > 0, false)
+	for i, p := range s.Points {
+		validatePoint(&me, i, p, s.GeneratedAt, opts)
+	}
+	validateSeriesTail(&me, h, opts)
+	return opts.finish(me.NilOrError())
+}
+
+// ValidateSeriesBundle validates the manifest of b and every contained Series.
+func ValidateSeriesBundle(b *types.SeriesBundle) error {
+	if b == nil {
+		return ErrNilInput
+	}
+	var me MultiError
+
+	if b.Manifest.GeneratedAt.IsZero() {
+		me.Append(fieldErr(CodeRequired, "manifest.generated_at", "manifest.generated_at must be set"))
+	}
+	pubs := make(map[string]bool, len(b.Manifest.Publishers))
+	for i, p := range b.Manifest.Publishers {
+		path := fmt.Sprintf("manifest.publishers[%d].id", i)
+		switch {
+		case p.ID == "":
+			me.Append(fieldErr(CodeRequired, path, path+" must be non-empty"))
+		case pubs[p.ID]:
+			me.Append(fieldErr(CodeDuplicate, path, path+" duplicates an earlier publisher"))
+		}
+		pubs[p.ID] = true
+	}
+	if len(b.Manifest.Entries) != len(b.Series) {
+		me.Append(fieldErr(CodeBundleMismatch, "manifest.entries", "manifest.entries must have one entry per series"))
+	}
+	for i, e := range b.Manifest.Entries {
+		path := fmt.Sprintf("manifest.entries[%d]", i)
+		if !pubs[e.Publisher] {
+			me.Append(fieldErr(CodeBundleMismatch, path+".publisher", path+".publisher must reference a manifest publisher"))
+		}
+		if i < len(b.Series) && b.Series[i].Topic != e.Topic {
+			me.Append(fieldErr(CodeBundleMismatch, path+".topic", path+".topic must match series topic"))
+		}
+	}
+	for i := range b.Series {
+		appendPrefixed(&me, fmt.Sprintf("series[%d]", i), ValidateSeries(&b.Series[i]))
 	}
 
 	return me.NilOrError()
 }
 
-// ValidateSeries validates a Series instance and all nested Points.
-func ValidateSeries(s *types.Series) error {
+// ValidateRetraction validates a Retraction message. It does not verify the
+// signature; use Retraction.Verify with the publisher's key.
+func ValidateRetraction(r *types.Retraction) error {
+	if r == nil {
+		return ErrNilInput
+	}
 	var me MultiError
 
-	if s.Topic == "" {
-		me.Append(errors.New("topic must be non-empty"))
+	if r.Topic == "" {
+		me.Append(fieldErr(CodeRequired, "topic", "topic must be non-empty"))
 	}
-	if s.GeneratedAt.IsZero() {
-		me.Append(errors.New("generated_at must be set"))
+	if !r.Reason.Valid() {
+		me.Append(fieldErr(CodeRetractionReasonInvalid, "reason", "invalid reason"))
 	}
-	if s.Interval != types.IntervalMinute {
-		me.Append(errors.New("interval must be \"minute\""))
-	}
-	if len(s.Points) == 0 {
-		me.Append(errors.New("series must contain at least one point"))
+	if r.EffectiveAt.IsZero() {
+		me.Append(fieldErr(CodeRequired, "effective_at", "effective_at must be set"))
 	}
 
+	return me.NilOrError()
+}
+
+// ValidateSeriesOf checks the fields SeriesOf shares with Series with the
+// same rules ValidateSeries applies, except that any positive interval is
+// accepted: topic, generated_at, interval, at least one point unless
+// retracted, point times that are set and in UTC (and, for SeriesOf,
+// unique), annotations,
+// retraction, extension keys, tenant, jurisdiction, and methodology. It then
+// calls check, if non-nil, for each point.
+// Errors returned by check are collected; wrap them in a *FieldError to
+// attach a field path.
+func ValidateSeriesOf[P types.PointLike](s *types.SeriesOf[P], check func(i int, p P) error) error {
+	if s == nil {
+		return ErrNilInput
+	}
+	h := header{
+		topic: s.Topic, generatedAt: s.GeneratedAt, interval: s.Interval,
+		tenant: s.Tenant, jurisdiction: s.Jurisdiction, methodology: s.Methodology,
+		retraction: s.Retraction, annotations: s.Annotations, extensions: s.Extensions,
+	}
+	var me MultiError
+	validateSeriesHead(&me, h, len(s.Points) > 0, true)
+	seen := make(map[time.Time]bool, len(s.Points))
 	for i, p := range s.Points {
-		if p.Volume < 0 {
-			me.Append(fmt.Errorf("points[%d].volume must be ≥0", i))
+		t := p.PointTime()
+		validatePointTime(&me, i, t)
+		if seen[t.UTC()] {
+			me.Append(pointErr(i, CodeDuplicate, "ts", "duplicates an earlier point"))
 		}
-		if p.ReshareRatio < 0 || p.ReshareRatio > 1 {
-			me.Append(fmt.Errorf("points[%d].reshare_ratio must be 0–1", i))
+		seen[t.UTC()] = true
+		if check != nil {
+			me.Append(check(i, p))
 		}
-		if p.RecycledContentRate < 0 || p.RecycledContentRate > 1 {
-			me.Append(fmt.Errorf("points[%d].recycled_content_rate must be 0–1", i))
-		}
-		if p.CoordinationSignals.BurstScore < 0 || p.CoordinationSignals.BurstScore > 1 {
-			me.Append(fmt.Errorf("points[%d].coordination_signals.burst_score must be 0–1", i))
+	}
+	validateSeriesTail(&me, h, Options{})
+	return me.NilOrError()
+}
+
+// ValidatePublisherQuota validates a PublisherQuota.
+func ValidatePublisherQuota(q *types.PublisherQuota) error {
+	if q == nil {
+		return ErrNilInput
+	}
+	var me MultiError
+
+	if q.PublisherID == "" {
+		me.Append(fieldErr(CodeRequired, "publisher_id", "publisher_id must be non-empty"))
+	}
+	if !(q.RatePerSecond > 0) {
+		me.Append(fieldErr(CodeQuotaRange, "rate_per_second", "rate_per_second must be >0"))
+	}
+	if q.Burst < 1 {
+		me.Append(fieldErr(CodeQuotaRange, "burst", "burst must be ≥1"))
+	}
+
+	return me.NilOrError()
+}
+
+// ValidateSubmissionID requires id to be a canonical ULID. Use
+// types.ParseSubmissionID first to accept lower-case or UUID-form input.
+func ValidateSubmissionID(id types.SubmissionID) error {
+	if id == "" {
+		return fieldErr(CodeRequired, "submission_id", "submission_id must be set")
+	}
+	if !id.Valid() {
+		return fieldErr(CodeSubmissionIDFormat, "submission_id", "submission_id must be a ULID (26 upper-case Crockford base32 characters)")
+	}
+	return nil
+}
+
+// --- helpers ---
+
+// header holds the series-level fields Series and SeriesOf share, so both
+// are validated by the same code.
+type header struct {
+	topic                string
+	generatedAt          time.Time
+	interval             types.Interval
+	tenant, jurisdiction string
+	methodology          *types.Methodology
+	retraction           *types.Retraction
+	annotations          []types.Annotation
+	extensions           types.Extensions
+}
+
+func headerOf(s *types.Series) header {
+	return header{
+		topic: s.Topic, generatedAt: s.GeneratedAt, interval: s.Interval,
+		tenant: s.Tenant, jurisdiction: s.Jurisdiction, methodology: s.Methodology,
+		retraction: s.Retraction, annotations: s.Annotations, extensions: s.Extensions,
+	}
+}
+
+// validateSeriesHead checks the series-level fields reported before any
+// point. Series must be per minute; anyInterval accepts any positive
+// interval, as SeriesOf does.
+func validateSeriesHead(me *MultiError, s header, hasPoints, anyInterval bool) {
+	if s.topic == "" {
+		me.Append(fieldErr(CodeRequired, "topic", "topic must be non-empty"))
+	}
+	switch {
+	case s.generatedAt.IsZero():
+		me.Append(fieldErr(CodeRequired, "generated_at", "generated_at must be set"))
+	case !types.IsUTC(s.generatedAt):
+		me.Append(fieldErr(CodeTimeNotUTC, "generated_at", "generated_at must be in UTC"))
+	}
+	if s.tenant != "" && !types.ValidTenant(s.tenant) {
+		me.Append(fieldErr(CodeTenantFormat, "tenant", "tenant must be dot-separated lowercase labels (e.g., \"us.fec\")"))
+	}
+	if s.jurisdiction != "" && !types.ISO3166.MatchString(s.jurisdiction) {
+		me.Append(fieldErr(CodeJurisdictionFormat, "jurisdiction", "jurisdiction must be ISO-3166 (e.g., \"US\" or \"CA-ON\")"))
+	}
+	validateMethodology(me, s.methodology)
+	switch d := s.interval.Duration(); {
+	case anyInterval && d <= 0:
+		me.Append(fieldErr(CodeIntervalUnsupported, "interval", fmt.Sprintf("interval %q is not supported", s.interval)))
+	case !anyInterval && d != time.Minute:
+		me.Append(fieldErr(CodeIntervalUnsupported, "interval", "interval must be \"minute\" (or \"PT1M\")"))
+	}
+	if !hasPoints && s.retraction == nil {
+		me.Append(fieldErr(CodePointsEmpty, "points", "series must contain at least one point"))
+	}
+	if s.retraction != nil {
+		appendPrefixed(me, "retraction", ValidateRetraction(s.retraction))
+	}
+}
+
+// validatePointTime checks that points[i].ts is set and in UTC, for Point
+// and every other PointLike.
+func validatePointTime(me *MultiError, i int, t time.Time) {
+	switch {
+	case t.IsZero():
+		me.Append(pointErr(i, CodeRequired, "ts", "must be set"))
+	case !types.IsUTC(t):
+		me.Append(pointErr(i, CodeTimeNotUTC, "ts", "must be in UTC"))
+	}
+}
+
+// ValidatePoint checks p against the rules ValidateSeriesWith applies to
+// each point, reporting fields relative to the point (e.g. "volume",
+// "acct_age_mix.0-7d"). generatedAt is the series' generated_at, for the
+// backfill rule; a zero generatedAt skips it.
+func ValidatePoint(p types.Point, generatedAt time.Time, opts Options) error {
+	var me MultiError
+	validatePoint(&me, -1, p, generatedAt, opts)
+	return me.NilOrError()
+}
+
+// validatePoint checks points[i] of a series generated at generatedAt.
+func validatePoint(me *MultiError, i int, p types.Point, generatedAt time.Time, opts Options) {
+	validatePointTime(me, i, p.TS)
+	if p.Volume < 0 {
+		me.Append(pointErr(i, CodeCountNegative, "volume", "must be ≥0"))
+	}
+	if p.ReshareRatio < 0 || p.ReshareRatio > 1 {
+		me.Append(pointErr(i, CodeRatioRange, "reshare_ratio", "must be 0–1"))
+	}
+	if p.RecycledContentRate < 0 || p.RecycledContentRate > 1 {
+		me.Append(pointErr(i, CodeRatioRange, "recycled_content_rate", "must be 0–1"))
+	}
+	if p.CoordinationSignals.BurstScore < 0 || p.CoordinationSignals.BurstScore > 1 {
+		me.Append(pointErr(i, CodeRatioRange, "coordination_signals.burst_score", "must be 0–1"))
+	}
+	if p.CoordinationSignals.SynchronyIndex < 0 || p.CoordinationSignals.SynchronyIndex > 1 {
+		me.Append(pointErr(i, CodeRatioRange, "coordination_signals.synchrony_index", "must be 0–1"))
+	}
+	if p.CoordinationSignals.DuplicationClusters < 0 {
+		me.Append(pointErr(i, CodeCountNegative, "coordination_signals.duplication_clusters", "must be ≥0"))
+	}
+	if p.CoordinationSignals.DuplicationClusters > p.Volume && p.Volume >= 0 {
+		me.Append(pointErr(i, CodeDuplicationExceedsVolume, "coordination_signals.duplication_clusters", "must not exceed volume"))
+	}
+	validateShares(me, i, "acct_age_mix", p.AcctAgeMix, opts)
+	validateShares(me, i, "automation_mix", p.AutomationMix, opts)
+	validateShares(me, i, "client_mix", p.ClientMix, opts)
+	validateAcctTypeShares(me, i, p.AcctTypeShares, opts)
+	validatePostKindMix(me, i, p, opts)
+	if opts.MinVolume > 0 && p.Volume > 0 && p.Volume < opts.MinVolume {
+		me.Append(pointErr(i, CodeVolumeBelowFloor, "volume", fmt.Sprintf("must be 0 or at least %d (suppression floor)", opts.MinVolume)))
+	}
+	if p.Backfilled && !generatedAt.IsZero() && p.TS.After(generatedAt.Add(-opts.BackfillMinAge)) {
+		me.Append(pointErr(i, CodeBackfillTooRecent, "backfilled", backfillRule(opts.BackfillMinAge)))
+	}
+}
+
+// validateSeriesTail checks the series-level fields reported after the
+// points.
+func validateSeriesTail(me *MultiError, s header, opts Options) {
+	for i, a := range s.annotations {
+		validateAnnotation(me, i, a)
+	}
+	validateExtensionKeys(me, s.extensions)
+	validateExtensionValues(me, s.extensions, opts.Extensions)
+}
+
+// validateShares checks a non-empty breakdown: every fraction is 0–1 and
+// the fractions sum to 1 within opts' epsilon. Empty breakdowns are allowed.
+func validateShares(me *MultiError, i int, field string, shares map[string]types.Probability, opts Options) {
+	if len(shares) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(shares))
+	for k := range shares {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var sum float64
+	for _, k := range keys {
+		v := shares[k]
+		if v < 0 || v > 1 {
+			me.Append(pointErr(i, CodeRatioRange, field+"."+k, "must be 0–1"))
 		}
-		if p.CoordinationSignals.SynchronyIndex < 0 || p.CoordinationSignals.SynchronyIndex > 1 {
-			me.Append(fmt.Errorf("points[%d].coordination_signals.synchrony_index must be 0–1", i))
+		sum += float64(v)
+	}
+	if eps := opts.sumEpsilon(); eps >= 0 && math.Abs(sum-1) > eps {
+		me.Append(pointErr(i, CodeSharesSum, field, fmt.Sprintf("must sum to 1 (±%g), got %g", eps, sum)))
+	}
+}
+
+// validatePostKindMix checks post_kind_mix like any other breakdown, requires
+// every key to be an accepted post_kind, and requires its reshare share to
+// agree with reshare_ratio. A mix without a reshare key means no reshares.
+func validatePostKindMix(me *MultiError, i int, p types.Point, opts Options) {
+	if len(p.PostKindMix) == 0 {
+		return
+	}
+	m := make(map[string]types.Probability, len(p.PostKindMix))
+	for k, v := range p.PostKindMix {
+		if !opts.EnumAccepted("post_kind", string(k)) {
+			me.Append(pointErr(i, CodePostKindInvalid, "post_kind_mix."+string(k), "unknown post_kind"))
 		}
-		if p.CoordinationSignals.DuplicationClusters < 0 {
-			me.Append(fmt.Errorf("points[%d].coordination_signals.duplication_clusters must be ≥0", i))
+		m[string(k)] = v
+	}
+	validateShares(me, i, "post_kind_mix", m, opts)
+	r := p.PostKindMix[types.PostKindReshare]
+	if tol := opts.reshareTolerance(); tol >= 0 && math.Abs(float64(p.ReshareRatio-r)) > tol {
+		me.Append(pointErr(i, CodeReshareInconsistent, "reshare_ratio",
+			fmt.Sprintf("must match post_kind_mix.reshare (±%g), got %g vs %g", tol, float64(p.ReshareRatio), float64(r))))
+	}
+}
+
+// validateAcctTypeShares checks acct_type_shares like any other breakdown
+// and additionally requires every key to be an accepted acct_type.
+func validateAcctTypeShares(me *MultiError, i int, shares map[types.AcctType]types.Probability, opts Options) {
+	m := make(map[string]types.Probability, len(shares))
+	for k, v := range shares {
+		if !opts.EnumAccepted("acct_type", string(k)) {
+			me.Append(pointErr(i, CodeAcctTypeInvalid, "acct_type_shares."+string(k), "unknown acct_type"))
 		}
+		m[string(k)] = v
 	}
+	validateShares(me, i, "acct_type_shares", m, opts)
+}
 
-	return me.NilOrError()
+func validateAnnotation(me *MultiError, i int, a types.Annotation) {
+	path := fmt.Sprintf("annotations[%d]", i)
+	if !a.Kind.Valid() {
+		me.Append(fieldErr(CodeAnnotationKindInvalid, path+".kind", path+".kind is invalid"))
+	}
+	if a.Start.IsZero() {
+		me.Append(fieldErr(CodeRequired, path+".start", path+".start must be set"))
+	}
+	if !a.End.IsZero() && a.End.Before(a.Start) {
+		me.Append(fieldErr(CodeTimeRange, path+".end", path+".end must not be before start"))
+	}
 }
 
-// --- helpers ---
+func backfillRule(minAge time.Duration) string {
+	if minAge <= 0 {
+		return "requires ts not after generated_at"
+	}
+	return fmt.Sprintf("requires ts at least %s before generated_at", minAge)
+}
 
 // validateISO3166MaybeEmpty accepts "" or a string that looks like ISO-3166
 // country or country-subdivision code (e.g., "US" or "US-CA").
@@ -153,8 +515,56 @@ func validateISO3166MaybeEmpty(code string) error {
 	if code == "" {
 		return nil
 	}
-	if !types.ReISO3166.MatchString(code) {
-		return fmt.Errorf("origin_hint/country must match ISO-3166 pattern (e.g., US or US-CA)")
+	if !types.ISO3166.MatchString(code) {
+		return fieldErr(CodeOriginHintFormat, "origin_hint", "origin_hint/country must match ISO-3166 pattern (e.g., US or US-CA)")
 	}
 	return nil
 }
+
+// FieldError is a validation failure tied to a single field. Field uses the
+// schema's JSON names with dotted/indexed paths (e.g., "points[3].volume").
+// Code identifies the rule and, unlike Msg, is stable across releases.
+type FieldError struct {
+	Field string
+	Code  ErrorCode
+	Msg   string
+	// Source is the payload's origin, when the validator was given one.
+	Source *Source
+}
+
+func (e *FieldError) Error() string { return e.Msg }
+
+func fieldErr(code ErrorCode, field, msg string) error {
+	return &FieldError{Field: field, Code: code, Msg: msg}
+}
+
+// appendPrefixed appends the errors of a nested document's validation,
+// each FieldError copied with prefix added to its Field and Msg, so codes
+// and pointers survive into the enclosing result.
+func appendPrefixed(me *MultiError, prefix string, err error) {
+	if err == nil {
+		return
+	}
+	errs := []error{err}
+	if nested, ok := err.(*MultiError); ok {
+		errs = nested.errs
+	}
+	for _, e := range errs {
+		fe, ok := e.(*FieldError)
+		if !ok {
+			me.Append(fmt.Errorf("%s: %w", prefix, e))
+			continue
+		}
+		me.Append(&FieldError{Field: prefix + "." + fe.Field, Code: fe.Code, Msg: prefix + "." + fe.Msg, Source: fe.Source})
+	}
+}
+
+// pointErr reports a failed rule on points[i].<field>, or on <field> alone
+// if i < 0.
+func pointErr(i int, code ErrorCode, field, rule string) error {
+	path := field
+	if i >= 0 {
+		path = fmt.Sprintf("points[%d].%s", i, field)
+	}
+	return &FieldError{Field: path, Code: code, Msg: path + " " + rule}
+}
@@ -0,0 +1,202 @@
+package validate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/privacy"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// TestNestedErrorsKeepCodes checks that errors of nested documents stay
+// individual FieldErrors with prefixed fields, so problems point at the
+// failing member.
+func TestNestedErrorsKeepCodes(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := types.Series{
+		Topic: "#vote", GeneratedAt: t0.Add(time.Hour), Interval: types.IntervalMinute,
+		Points: []types.Point{{TS: t0, Volume: -1, ReshareRatio: 2}},
+	}
+	b := &types.SeriesBundle{
+		Manifest: types.BundleManifest{
+			GeneratedAt: t0,
+			Publishers:  []types.PublisherRef{{ID: "p1"}},
+			Entries:     []types.BundleEntry{{Topic: "#vote", Publisher: "p1"}},
+		},
+		Series: []types.Series{s},
+	}
+	checkProblems(t, ValidateSeriesBundle(b), []FieldProblem{
+		{Field: "series[0].points[0].volume", Code: CodeCountNegative, Pointer: "/series/0/points/0/volume"},
+		{Field: "series[0].points[0].reshare_ratio", Code: CodeRatioRange, Pointer: "/series/0/points/0/reshare_ratio"},
+	})
+}
+
+func checkProblems(t *testing.T, err error, want []FieldProblem) {
+	t.Helper()
+	p := ToProblem(err)
+	if p == nil || len(p.Errors) != len(want) {
+		t.Fatalf("ToProblem = %+v, want %d errors", p, len(want))
+	}
+	for i, w := range want {
+		got := p.Errors[i]
+		if got.Field != w.Field || got.Code != w.Code || got.Pointer != w.Pointer {
+			t.Errorf("errors[%d] = %+v, want %+v", i, got, w)
+		}
+	}
+}
+
+func TestRetractionErrorsKeepCodes(t *testing.T) {
+	s := types.Series{
+		Topic: "#vote", GeneratedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Interval: types.IntervalMinute,
+		Retraction: &types.Retraction{Topic: "#vote", Reason: "oops"},
+	}
+	err := ValidateSeries(&s)
+	checkProblems(t, err, []FieldProblem{
+		{Field: "retraction.reason", Code: CodeRetractionReasonInvalid, Pointer: "/retraction/reason"},
+		{Field: "retraction.effective_at", Code: CodeRequired, Pointer: "/retraction/effective_at"},
+	})
+	if c := CodeOf(err); c != CodeRetractionReasonInvalid {
+		t.Errorf("CodeOf = %q, want %q", c, CodeRetractionReasonInvalid)
+	}
+
+	e := Explain(&s)
+	for _, r := range e.Rules {
+		if r.Field == "retraction" && r.Failed != 2 {
+			t.Errorf("Explain retraction rule failed %d, want 2", r.Failed)
+		}
+	}
+	if n := len(seriesRules(&s, DefaultOptions())); len(e.Rules) != n {
+		t.Errorf("unattributed errors:\n%s", e)
+	}
+}
+
+func TestForTopicMerges(t *testing.T) {
+	reg := &ExtensionRegistry{}
+	risk := &OriginRiskPolicy{Max: 0.5}
+	base := Options{
+		SumEpsilon: 0.01, MinVolume: 5, Extensions: reg, OriginRisk: risk,
+		Features: map[string]bool{"a": true, "b": true},
+	}.WithTopicOverrides(map[string]Options{
+		"#strict": {MinVolume: 10, Features: map[string]bool{"b": false}},
+	})
+	o := base.ForTopic("#strict")
+	if o.MinVolume != 10 || o.SumEpsilon != 0.01 || o.Extensions != reg || o.OriginRisk != risk {
+		t.Errorf("ForTopic dropped enclosing options: %+v", o)
+	}
+	if !o.Features["a"] || o.Features["b"] || !base.Features["b"] {
+		t.Errorf("Features = %v (base %v)", o.Features, base.Features)
+	}
+	if o.TopicOverrides != nil {
+		t.Error("ForTopic kept TopicOverrides")
+	}
+	if o := base.ForTopic("#other"); o.MinVolume != 5 {
+		t.Errorf("no override: MinVolume = %d", o.MinVolume)
+	}
+}
+
+// TestFeaturesSurviveTopicOverride checks that deployment-wide feature
+// flags still apply to series of a topic with an override.
+func TestFeaturesSurviveTopicOverride(t *testing.T) {
+	types.RegisterAcctType("test_pilot_media", types.ExperimentalOptions{})
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &types.Series{
+		Topic: "#vote", GeneratedAt: t0.Add(time.Hour), Interval: types.IntervalMinute,
+		Points: []types.Point{{TS: t0, Volume: 20, AcctTypeShares: map[types.AcctType]types.Probability{"test_pilot_media": 1}}},
+	}
+	opts := Options{Features: map[string]bool{"acct_type.test_pilot_media": true}}
+	if err := ValidateSeriesWith(s, opts); err != nil {
+		t.Fatalf("without override: %v", err)
+	}
+	opts = opts.WithTopicOverrides(map[string]Options{"#vote": {MinVolume: 10}})
+	if err := ValidateSeriesWith(s, opts); err != nil {
+		t.Errorf("with override: %v", err)
+	}
+}
+
+func TestOriginRiskNilScorer(t *testing.T) {
+	tag := types.ProvenanceTag{
+		AcctAgeBucket: types.AcctAge_0_7d, AcctType: types.AcctTypePerson, AutomationFlag: types.AutomationManual,
+		PostKind: types.PostKindOriginal, ClientFamily: types.ClientThirdParty, MediaProvenance: types.MediaProvNone,
+		DedupHash: "deadbeef", OriginHint: "US-WY",
+	}
+	opts := Options{OriginRisk: &OriginRiskPolicy{Max: privacy.DefaultMaxSubdivisionRisk}}
+	if err := ValidateProvenanceTagWith(&tag, opts); CodeOf(err) != CodeOriginHintRisk {
+		t.Errorf("nil Scorer: %v, want the default scorer's %s", err, CodeOriginHintRisk)
+	}
+	if !privacy.CoarsenOriginHint(&tag, nil, opts.OriginRisk.Max) || tag.OriginHint != "US" {
+		t.Errorf("CoarsenOriginHint with nil scorer: %q", tag.OriginHint)
+	}
+}
+
+// TestTenantInheritsRegistryOptions checks that a tenant policy adjusts
+// the registry's rules rather than replacing them.
+func TestTenantInheritsRegistryOptions(t *testing.T) {
+	t0 := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &types.Series{
+		Topic: "#vote", GeneratedAt: t0.Add(time.Hour), Interval: types.IntervalMinute,
+		Points: []types.Point{{TS: t0, Volume: 3}},
+	}
+	reg := TenantRegistry{Options: Options{MinVolume: 10}}
+	reg.Register("us.fec", TenantPolicy{})
+	reg.Register("us.ca.sos", TenantPolicy{Options: Options{SumEpsilon: 0.01}})
+	reg.Register("us.wy.sos", TenantPolicy{Options: Options{MinVolume: 2}})
+	for tenant, wantErr := range map[string]bool{"us.fec": true, "us.ca.sos": true, "us.wy.sos": false} {
+		s.Tenant = tenant
+		if err := reg.ValidateSeries(s); (err != nil) != wantErr {
+			t.Errorf("tenant %s: %v, want error %v", tenant, err, wantErr)
+		}
+	}
+	if o, ok := reg.OptionsFor("us.ca.sos"); !ok || o.MinVolume != 10 || o.SumEpsilon != 0.01 {
+		t.Errorf("OptionsFor = %+v, %v", o, ok)
+	}
+}
+
+// TestDeprecatedEnumWarning checks that a value the schema history marks
+// deprecated passes validation with a warning.
+func TestDeprecatedEnumWarning(t *testing.T) {
+	deprecatedValues["AcctType.unverified"] = "Use person."
+	defer delete(deprecatedValues, "AcctType.unverified")
+
+	tag := types.ProvenanceTag{
+		AcctAgeBucket: types.AcctAge_0_7d, AcctType: types.AcctTypeUnverified, AutomationFlag: types.AutomationManual,
+		PostKind: types.PostKindOriginal, ClientFamily: types.ClientWeb, MediaProvenance: types.MediaProvNone,
+		DedupHash: "deadbeef", DedupHashAlg: types.DedupSHA256Trunc8, OriginHint: "US",
+	}
+	ws, err := CheckProvenanceTag(&tag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Warning{"acct_type", `acct_type value "unverified" is deprecated: Use person.`}
+	if len(ws) != 1 || ws[0] != want {
+		t.Errorf("warnings = %+v, want [%+v]", ws, want)
+	}
+}
+
+func TestValidatePointRelativeFields(t *testing.T) {
+	p := types.Point{TS: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Volume: -1,
+		AcctAgeMix: map[string]types.Probability{"0-7d": 2}}
+	checkProblems(t, ValidatePoint(p, time.Time{}, Options{}), []FieldProblem{
+		{Field: "volume", Code: CodeCountNegative, Pointer: "/volume"},
+		{Field: "acct_age_mix.0-7d", Code: CodeRatioRange, Pointer: "/acct_age_mix/0-7d"},
+		{Field: "acct_age_mix", Code: CodeSharesSum, Pointer: "/acct_age_mix"},
+	})
+}
+
+// TestSeriesAndSeriesOfShareChecks checks that Series and SeriesOf report
+// the same header and point-time errors.
+func TestSeriesAndSeriesOfShareChecks(t *testing.T) {
+	est := time.FixedZone("EST", -5*60*60)
+	gen := time.Date(2025, 1, 1, 0, 5, 0, 0, est)
+	want := []FieldProblem{
+		{Field: "generated_at", Code: CodeTimeNotUTC, Pointer: "/generated_at"},
+		{Field: "points[0].ts", Code: CodeRequired, Pointer: "/points/0/ts"},
+		{Field: "points[1].ts", Code: CodeTimeNotUTC, Pointer: "/points/1/ts"},
+	}
+
+	s := &types.Series{Topic: "#vote", GeneratedAt: gen, Interval: types.IntervalMinute,
+		Points: []types.Point{{}, {TS: time.Date(2025, 1, 1, 0, 0, 0, 0, est)}}}
+	checkProblems(t, ValidateSeries(s), want)
+
+	g := &types.SeriesOf[types.Point]{Topic: s.Topic, GeneratedAt: s.GeneratedAt, Interval: s.Interval, Points: s.Points}
+	checkProblems(t, ValidateSeriesOf(g, nil), want)
+}
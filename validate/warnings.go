@@ -0,0 +1,136 @@
+package validate
+
+import (
+	"fmt"
+
+	"github.com/civic-interconnect/civic-transparency-go-types/schemahistory"
+	"github.com/civic-interconnect/civic-transparency-go-types/types"
+)
+
+// Warning is a non-fatal finding: the payload is acceptable, but operators
+// should look at it. Field uses the same paths as FieldError.
+type Warning struct {
+	Field string `json:"field,omitempty"`
+	Msg   string `json:"detail"`
+}
+
+func (w Warning) String() string { return w.Msg }
+
+// MonotoneMinPoints is the series length from which constant or strictly
+// monotone ratios are reported as suspicious.
+const MonotoneMinPoints = 10
+
+// CheckProvenanceTag validates t and returns non-fatal warnings alongside
+// the hard error from ValidateProvenanceTag. Enum values that the schema
+// history marks deprecated are warnings, not errors.
+func CheckProvenanceTag(t *types.ProvenanceTag) ([]Warning, error) {
+	err := ValidateProvenanceTag(t)
+	if t == nil {
+		return nil, err
+	}
+	var ws []Warning
+	if t.OriginHint == "" {
+		ws = append(ws, Warning{"origin_hint", "origin_hint is empty; geographic breakdowns will omit this tag"})
+	}
+	if t.DedupHashAlg == "" {
+		ws = append(ws, Warning{"dedup_hash_alg", "dedup_hash_alg is unset; assuming sha256-trunc8"})
+	}
+	ws = deprecated(ws, "acct_age_bucket", "AcctAge", string(t.AcctAgeBucket))
+	ws = deprecated(ws, "acct_type", "AcctType", string(t.AcctType))
+	ws = deprecated(ws, "automation_flag", "AutomationFlag", string(t.AutomationFlag))
+	ws = deprecated(ws, "post_kind", "PostKind", string(t.PostKind))
+	ws = deprecated(ws, "client_family", "ClientFamily", string(t.ClientFamily))
+	ws = deprecated(ws, "media_provenance", "MediaProvenance", string(t.MediaProvenance))
+	ws = deprecated(ws, "dedup_hash_alg", "DedupHashAlg", string(t.DedupHashAlg))
+	return ws, err
+}
+
+// CheckSeries validates s with opts and returns non-fatal warnings
+// alongside the hard error from ValidateSeriesWith, including deprecated
+// interval, annotation kind, and retraction reason values.
+func CheckSeries(s *types.Series, opts Options) ([]Warning, error) {
+	err := ValidateSeriesWith(s, opts)
+	if s == nil {
+		return nil, err
+	}
+	var ws []Warning
+	for _, r := range []struct {
+		field string
+		get   func(types.Point) types.Probability
+	}{
+		{"reshare_ratio", func(p types.Point) types.Probability { return p.ReshareRatio }},
+		{"recycled_content_rate", func(p types.Point) types.Probability { return p.RecycledContentRate }},
+	} {
+		if w, ok := monotone(s.Points, r.field, r.get); ok {
+			ws = append(ws, w)
+		}
+	}
+	if n := len(s.Points); n > 0 {
+		zero := 0
+		for _, p := range s.Points {
+			if p.Volume == 0 {
+				zero++
+			}
+		}
+		if zero == n {
+			ws = append(ws, Warning{"points", "every point has volume 0"})
+		}
+	}
+	ws = deprecated(ws, "interval", "Interval", string(s.Interval))
+	for i, a := range s.Annotations {
+		ws = deprecated(ws, fmt.Sprintf("annotations[%d].kind", i), "AnnotationKind", string(a.Kind))
+	}
+	if s.Retraction != nil {
+		ws = deprecated(ws, "retraction.reason", "RetractionReason", string(s.Retraction.Reason))
+	}
+	return ws, err
+}
+
+// deprecatedValues maps the "Enum.value" paths that the schema history
+// marks deprecated to the history note, which usually names a replacement.
+var deprecatedValues = func() map[string]string {
+	m := make(map[string]string)
+	for _, c := range schemahistory.All() {
+		if c.Kind == schemahistory.KindEnumValue && c.Change == schemahistory.Deprecated {
+			m[c.Path] = c.Note
+		}
+	}
+	return m
+}()
+
+// deprecated appends a warning to ws if value of the enum named enum is
+// deprecated.
+func deprecated(ws []Warning, field, enum, value string) []Warning {
+	note, ok := deprecatedValues[enum+"."+value]
+	if !ok || value == "" {
+		return ws
+	}
+	msg := fmt.Sprintf("%s value %q is deprecated", field, value)
+	if note != "" {
+		msg += ": " + note
+	}
+	return append(ws, Warning{field, msg})
+}
+
+// monotone flags ratios that never change or only ever move one way across
+// a long series; real activity is noisy, so both usually indicate
+// placeholder or synthetic values.
+func monotone(pts []types.Point, field string, get func(types.Point) types.Probability) (Warning, bool) {
+	if len(pts) < MonotoneMinPoints {
+		return Warning{}, false
+	}
+	same, up, down := true, true, true
+	for i := 1; i < len(pts); i++ {
+		a, b := get(pts[i-1]), get(pts[i])
+		same = same && a == b
+		up = up && b > a
+		down = down && b < a
+	}
+	switch {
+	case same:
+		return Warning{"points[*]." + field, fmt.Sprintf("%s is constant across %d points", field, len(pts))}, true
+	case up || down:
+		return Warning{"points[*]." + field, fmt.Sprintf("%s is strictly monotone across %d points", field, len(pts))}, true
+	}
+	return Warning{}, false
+}